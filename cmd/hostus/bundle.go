@@ -27,7 +27,7 @@ func newBundleCmd() *cobra.Command {
 		RunE:  runBundle,
 	}
 	cmd.Flags().String("db", "", "path to the source SQLite database to bundle from")
-	cmd.Flags().String("area", "", "comma-separated area identifier(s) to scope the bundle to, e.g. \"DE,AT,CH\" (empty = whole database)")
+	cmd.Flags().String("area", "", "comma-separated area identifier(s) to scope the bundle to, e.g. \"DE,AT,CH\" or the WGSRPD region \"11\" (empty = whole database)")
 	cmd.Flags().String("out", "", "output path for the bundle")
 	cmd.Flags().String("snapshot", "", "snapshot version recorded into the bundle's bundle_meta table")
 	cmd.Flags().Bool("force-include-restricted", false, "export even if a contributing source's redistribution is not \"allowed\" (records the offending source ids into bundle_meta.restricted_sources)")
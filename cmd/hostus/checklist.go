@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jobrunner/hostus/internal/app"
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// checklistCmdName is shared with tests so the "checklist" literal only
+// needs to be spelled once outside of _test.go files.
+const checklistCmdName = "checklist"
+
+// newChecklistCmd builds "hostus checklist --db hostus.sqlite --area GER
+// [--rank SPECIES] [--establishment native] [--entry-backbone wcvp]
+// [--format csv|json] [--out checklist.csv]": it writes the whole area
+// checklist GET /v1/checklist serves page by page, in one file.
+func newChecklistCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   checklistCmdName,
+		Short: "Export the accepted taxa occurring in an area, grouped by family",
+		RunE:  runChecklist,
+	}
+	cmd.Flags().String("db", "", "path to the SQLite database to read")
+	cmd.Flags().String("area", "", "area to list, as GET /v1/checklist takes it, e.g. \"GER\", \"11\" or \"euromed:Ge\"")
+	cmd.Flags().String("rank", "", "comma-separated ranks to list, e.g. \"SPECIES,SUBSPECIES\" (empty = every rank)")
+	cmd.Flags().String("establishment", "", "only taxa with this checklist verdict in the area: native, introduced or doubtful")
+	cmd.Flags().String("entry-backbone", "", "only concepts of this backbone, e.g. \"wcvp\"")
+	cmd.Flags().String("format", "csv", "output format: csv or json")
+	cmd.Flags().String("out", "", "output path (default: standard output)")
+	return cmd
+}
+
+// checklistJSON is the --format json document: GET /v1/checklist's
+// envelope for the whole checklist, so without limit and next_cursor.
+type checklistJSON struct {
+	Area     string                `json:"area"`
+	Families []checklistFamilyJSON `json:"families"`
+}
+
+type checklistFamilyJSON struct {
+	Family string               `json:"family"`
+	Taxa   []checklistEntryJSON `json:"taxa"`
+}
+
+type checklistEntryJSON struct {
+	ConceptID     string `json:"concept_id"`
+	Backbone      string `json:"backbone"`
+	Canonical     string `json:"canonical"`
+	Authorship    string `json:"authorship,omitempty"`
+	Rank          string `json:"rank"`
+	Establishment string `json:"establishment,omitempty"`
+	Derived       bool   `json:"derived"`
+}
+
+// runChecklist wires cmd's flags into internal/app.Checklist. CSV is
+// written page by page as it is read; JSON collects every page first, so a
+// family cut by a page boundary is still one group.
+func runChecklist(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	dbPath, err := flags.GetString("db")
+	if err != nil {
+		return err
+	}
+	if dbPath == "" {
+		return errors.New("checklist: --db is required")
+	}
+	area, err := flags.GetString("area")
+	if err != nil {
+		return err
+	}
+	if area == "" {
+		return errors.New("checklist: --area is required")
+	}
+	format, err := flags.GetString("format")
+	if err != nil {
+		return err
+	}
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("checklist: --format %q is not csv or json", format)
+	}
+
+	req := application.ChecklistRequest{Area: area, Limit: application.MaxChecklistLimit}
+	rank, err := flags.GetString("rank")
+	if err != nil {
+		return err
+	}
+	if rank != "" {
+		for _, tok := range strings.Split(rank, ",") {
+			r, err := domain.ParseRank(strings.TrimSpace(tok))
+			if err != nil {
+				return fmt.Errorf("checklist: --rank: %w", err)
+			}
+			req.Ranks = append(req.Ranks, r)
+		}
+	}
+	establishment, err := flags.GetString("establishment")
+	if err != nil {
+		return err
+	}
+	if req.Establishment, err = domain.ParseDistributionStatus(establishment); err != nil {
+		return fmt.Errorf("checklist: --establishment: %w", err)
+	}
+	if req.EntryBackbone, err = flags.GetString("entry-backbone"); err != nil {
+		return err
+	}
+
+	outPath, err := flags.GetString("out")
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+	var f *os.File
+	if outPath != "" {
+		if f, err = os.Create(outPath); err != nil {
+			return fmt.Errorf("checklist: creating %q: %w", outPath, err)
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+
+	if format == "csv" {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(application.ChecklistCSVColumns); err != nil {
+			return err
+		}
+		err := app.Checklist(cmd.Context(), dbPath, req, func(page application.ChecklistResult) error {
+			for _, e := range page.Entries {
+				if err := cw.Write(application.ChecklistCSVRecord(e)); err != nil {
+					return err
+				}
+			}
+			cw.Flush()
+			return cw.Error()
+		})
+		if err != nil {
+			return err
+		}
+		return closeChecklistOut(f)
+	}
+
+	all := application.ChecklistResult{Area: area}
+	err = app.Checklist(cmd.Context(), dbPath, req, func(page application.ChecklistResult) error {
+		all.Area = page.Area
+		all.Entries = append(all.Entries, page.Entries...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeChecklistJSON(w, all); err != nil {
+		return err
+	}
+	return closeChecklistOut(f)
+}
+
+// writeChecklistJSON renders res as one indented checklistJSON document.
+func writeChecklistJSON(w io.Writer, res application.ChecklistResult) error {
+	families := res.Families()
+	doc := checklistJSON{Area: res.Area, Families: make([]checklistFamilyJSON, len(families))}
+	for i, f := range families {
+		taxa := make([]checklistEntryJSON, len(f.Entries))
+		for j, e := range f.Entries {
+			taxa[j] = checklistEntryJSON{
+				ConceptID:     e.ConceptID,
+				Backbone:      e.BackboneID,
+				Canonical:     e.Canonical,
+				Authorship:    e.Authorship,
+				Rank:          string(e.Rank),
+				Establishment: string(e.Establishment),
+				Derived:       e.Derived,
+			}
+		}
+		doc.Families[i] = checklistFamilyJSON{Family: f.Family, Taxa: taxa}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// closeChecklistOut closes the --out file, if any, explicitly, so a failed
+// final write is reported instead of lost in the deferred Close.
+func closeChecklistOut(f *os.File) error {
+	if f == nil {
+		return nil
+	}
+	return f.Close()
+}
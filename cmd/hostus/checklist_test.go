@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestChecklistCommand_WritesTheAreaAsCSV drives "hostus checklist --db
+// <fixture> --area AUT" end to end: the header row, then the fixture's
+// three AUT concepts in family order, Asteraceae before Poaceae.
+func TestChecklistCommand_WritesTheAreaAsCSV(t *testing.T) {
+	dbPath := ingestFixtureDB(t)
+
+	cmd := newChecklistCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--db=" + dbPath, "--area=AUT"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("Execute: unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV output: %v", err)
+	}
+	var got []string
+	for _, rec := range records[1:] {
+		got = append(got, rec[0]+"/"+rec[3])
+	}
+	want := "Asteraceae/Jacobaea vulgaris,Poaceae/Corynephorus canescens,Poaceae/Festuca ovina"
+	if strings.Join(records[0], ",") != "family,concept_id,backbone,canonical,authorship,rank,establishment,derived" || strings.Join(got, ",") != want {
+		t.Errorf("CSV = %q, want the header and %s", records, want)
+	}
+}
+
+// TestChecklistCommand_WritesJSONToOut pins --format json --out: one
+// document, grouped by family, written to the file instead of stdout.
+func TestChecklistCommand_WritesJSONToOut(t *testing.T) {
+	dbPath := ingestFixtureDB(t)
+	outPath := filepath.Join(t.TempDir(), "aut.json")
+
+	cmd := newChecklistCmd()
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--db=" + dbPath, "--area=AUT", "--rank=SPECIES", "--format=json", "--out=" + outPath})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("Execute: unexpected error: %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want nothing with --out", stdout.String())
+	}
+
+	raw, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("reading --out file: %v", err)
+	}
+	var doc struct {
+		Area     string `json:"area"`
+		Families []struct {
+			Family string            `json:"family"`
+			Taxa   []json.RawMessage `json:"taxa"`
+		} `json:"families"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decoding --out file: %v (%s)", err, raw)
+	}
+	if doc.Area != "AUT" || len(doc.Families) != 2 || doc.Families[1].Family != "Poaceae" || len(doc.Families[1].Taxa) != 2 {
+		t.Errorf("document = %s, want AUT with Asteraceae and two Poaceae", raw)
+	}
+}
+
+func TestChecklistCommand_RejectsBadFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--area=AUT"},
+		{"--db=x.sqlite"},
+		{"--db=x.sqlite", "--area=AUT", "--format=xml"},
+		{"--db=x.sqlite", "--area=AUT", "--rank=tribe-ish"},
+		{"--db=x.sqlite", "--area=AUT", "--establishment=extinct"},
+	} {
+		cmd := newChecklistCmd()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetArgs(args)
+		if err := cmd.ExecuteContext(context.Background()); err == nil {
+			t.Errorf("Execute(%v): want an error, got nil", args)
+		}
+	}
+}
@@ -33,6 +33,7 @@ func newIngestCmd() *cobra.Command {
 	}
 	cmd.Flags().String("dataset", "", "path to the dataset.yaml manifest to ingest")
 	cmd.Flags().String("db", "", "path to the SQLite database to ingest into")
+	cmd.Flags().Bool("strict-parents", false, "fail the ingest when a backbone's parent chains are broken (cycles, dangling or synonym parents, rank inversions) instead of only reporting them")
 	return cmd
 }
 
@@ -56,14 +57,22 @@ func runIngest(cmd *cobra.Command, _ []string) error {
 		return errors.New("ingest: --db is required")
 	}
 
-	reports, err := app.Ingest(cmd.Context(), datasetPath, dbPath)
+	strictParents, err := cmd.Flags().GetBool("strict-parents")
+	if err != nil {
+		return err
+	}
+
+	reports, err := app.Ingest(cmd.Context(), datasetPath, dbPath, app.IngestOpts{StrictParentChains: strictParents})
 	if err != nil {
 		return err
 	}
 
 	printIngestReport(cmd.OutOrStdout(), reports.Backbone)
+	printAreaHierarchyReport(cmd.OutOrStdout(), reports.Areas)
 	printTraitReports(cmd.OutOrStdout(), reports.Traits)
 	printXrefReports(cmd.OutOrStdout(), reports.Xrefs)
+	printDistributionReports(cmd.OutOrStdout(), reports.Distributions)
+	printFrequencyReports(cmd.OutOrStdout(), reports.Frequencies)
 	printConceptSourceReports(cmd.OutOrStdout(), reports.ConceptSources)
 	printNameSpaceReports(cmd.OutOrStdout(), reports.NameSpaces)
 	// app.Ingest already (re)built distribution_effective as its final step
@@ -73,6 +82,14 @@ func runIngest(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// printAreaHierarchyReport renders the WGSRPD unit and alias counts the area
+// filter's roll-up rests on. Level 4 is printed even at 0 so an operator can tell the
+// checked-in levels 1-3 table from a pipeline-generated one.
+func printAreaHierarchyReport(w io.Writer, r application.AreaHierarchyReport) {
+	_, _ = fmt.Fprintf(w, "Areas: wgsrpd level1=%d level2=%d level3=%d level4=%d aliases=%d\n",
+		r.Level1, r.Level2, r.Level3, r.Level4, r.Aliases)
+}
+
 // printNameSpaceReports renders one line per ingested name space (SP9/UC4).
 // Its visibility posture matches the three report printers above: the
 // crosswalk from a flat name list onto hostus concepts is lossy by
@@ -109,6 +126,45 @@ func printNameSpaceReports(w io.Writer, reports []application.NameSpaceIngestRep
 	}
 }
 
+// printDistributionReports renders one line per ingested distribution source
+// with the same visibility posture as printNameSpaceReports: every row that
+// did not become a distribution row — unmatched, ambiguous, invalid, an
+// explicit absent verdict, reader-rejected — is counted, and the loss modes
+// are sampled.
+func printDistributionReports(w io.Writer, reports []application.DistributionIngestReport) {
+	if len(reports) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Distribution sources:")
+	for _, r := range reports {
+		_, _ = fmt.Fprintf(w, "  %s: rows=%d matched=%d (by xref=%d) unmatched=%d ambiguous=%d concepts=%d areas=%d\n",
+			r.Source, r.Rows, r.Matched, r.ByXref, r.Unmatched, r.Ambiguous, r.Concepts, r.Areas)
+		_, _ = fmt.Fprintf(w, "    dropped: invalid=%d absent=%d reader errors=%d\n", r.Invalid, r.Absent, r.ReaderErrors)
+		printSampleLine(w, "unmatched sample", r.UnmatchedSample)
+		printSampleLine(w, "ambiguous sample", r.AmbiguousSample)
+		printSampleLine(w, "invalid sample", r.InvalidSample)
+		printRedistributionNotice(w, r.Source, r.Redistribution)
+	}
+}
+
+// printFrequencyReports renders one line per ingested frequency source, with
+// the same visibility posture as printDistributionReports.
+func printFrequencyReports(w io.Writer, reports []application.FrequencyIngestReport) {
+	if len(reports) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Frequency sources:")
+	for _, r := range reports {
+		_, _ = fmt.Fprintf(w, "  %s: rows=%d matched=%d (by xref=%d) unmatched=%d ambiguous=%d concepts=%d\n",
+			r.Source, r.Rows, r.Matched, r.ByXref, r.Unmatched, r.Ambiguous, r.Concepts)
+		_, _ = fmt.Fprintf(w, "    dropped: invalid=%d reader errors=%d\n", r.Invalid, r.ReaderErrors)
+		printSampleLine(w, "unmatched sample", r.UnmatchedSample)
+		printSampleLine(w, "ambiguous sample", r.AmbiguousSample)
+		printSampleLine(w, "invalid sample", r.InvalidSample)
+		printRedistributionNotice(w, r.Source, r.Redistribution)
+	}
+}
+
 // printSampleLine renders one bounded loss sample, or nothing when the sample
 // is empty. Extracted so the four sample lines above cannot drift in format.
 func printSampleLine(w io.Writer, label string, sample []string) {
@@ -132,6 +188,7 @@ func printIngestReport(w io.Writer, report application.IngestReport) {
 			b.ID, b.Names, b.Concepts, b.Synonyms, b.Orphaned)
 		printOtherRanksNotice(w, b)
 		printNomStatusNotice(w, b)
+		printParentChainsNotice(w, b.ParentChains)
 		printRedistributionNotice(w, b.ID, b.Redistribution)
 	}
 }
@@ -174,6 +231,24 @@ func printNomStatusNotice(w io.Writer, b application.BackboneReport) {
 	_, _ = fmt.Fprintln(w, line)
 }
 
+// printParentChainsNotice prints one "parent chains:" line with the four
+// counts of the parent-chain check, plus a sample line per non-empty finding,
+// when the check found anything — an intact backbone stays silent, like
+// printOtherRanksLine's "other == 0" gate. The ingest itself went through
+// (broken links degrade to root concepts); --strict-parents turns this into
+// a failure instead.
+func printParentChainsNotice(w io.Writer, r application.ParentChainReport) {
+	if !r.Broken() {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "    parent chains: cycles=%d dangling=%d synonym parents=%d rank inversions=%d\n",
+		r.Cycles, r.DanglingParents, r.SynonymParents, r.RankInversions)
+	printSampleLine(w, "cycle sample", r.CycleSample)
+	printSampleLine(w, "dangling parent sample", r.DanglingParentSample)
+	printSampleLine(w, "synonym parent sample", r.SynonymParentSample)
+	printSampleLine(w, "rank inversion sample", r.RankInversionSample)
+}
+
 // printRedistributionNotice prints one "hinweis:" line for id if
 // redistribution is set and not "allowed" — see printIngestReport's doc
 // comment. A blank redistribution (should not happen once the manifest
@@ -260,9 +335,9 @@ func sortedKeys(m map[string]int) []string {
 // (SP5). Its visibility posture matches printTraitReports/printXrefReports,
 // and the four loss counters it prints are the whole point: a CDM ingest
 // legitimately writes fewer relations than it read, and the operator must be
-// able to see WHY — dropped misapplied-name rows, unresolvable ends,
-// unresolvable parents, reader-level bad rows — rather than being told a
-// number that quietly does not add up.
+// able to see WHY — misapplied-name rows (kept apart for matching, never in
+// concept_relation), unresolvable ends, unresolvable parents, reader-level bad
+// rows — rather than being told a number that quietly does not add up.
 func printConceptSourceReports(w io.Writer, reports []application.CDMIngestReport) {
 	if len(reports) == 0 {
 		return
@@ -274,8 +349,10 @@ func printConceptSourceReports(w io.Writer, reports []application.CDMIngestRepor
 		for _, rel := range sortedKeys(r.PerRelationType) {
 			_, _ = fmt.Fprintf(w, "    %s: %d\n", rel, r.PerRelationType[rel])
 		}
-		_, _ = fmt.Fprintf(w, "    dropped: misapplied/non-concept=%d unresolved ends=%d unresolved parents=%d reader errors=%d\n",
-			r.NonConcept, r.UnresolvedEnds, r.UnresolvedParents, r.ReaderErrors)
+		_, _ = fmt.Fprintf(w, "    not in concept_relation: misapplied/non-concept=%d (misapplications kept for matching=%d)\n",
+			r.NonConcept, r.MisapplicationsWritten)
+		_, _ = fmt.Fprintf(w, "    dropped: unresolved ends=%d unresolved parents=%d reader errors=%d\n",
+			r.UnresolvedEnds, r.UnresolvedParents, r.ReaderErrors)
 		_, _ = fmt.Fprintf(w, "    unknown concept-relation flag=%d, concepts without sec.=%d, empty status=%d\n",
 			r.UnknownFlag, r.ConceptsWithoutSec, r.EmptyStatus)
 		printOtherRanksLine(w, r.OtherRanks, r.OtherRankSample)
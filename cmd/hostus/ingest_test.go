@@ -130,6 +130,44 @@ func TestPrintIngestReport_NomStatusNotice(t *testing.T) {
 	}
 }
 
+func TestPrintIngestReport_ParentChainsNotice(t *testing.T) {
+	report := application.IngestReport{
+		Backbones: []application.BackboneReport{
+			{
+				ID:    "wcvp",
+				Names: 5,
+				ParentChains: application.ParentChainReport{
+					Cycles:              1,
+					CycleSample:         []string{"10"},
+					RankInversions:      2,
+					RankInversionSample: []string{"20", "21"},
+				},
+			},
+			{ID: "clean", Names: 1},
+		},
+	}
+
+	var out bytes.Buffer
+	printIngestReport(&out, report)
+
+	got := out.String()
+	for _, want := range []string{
+		"parent chains: cycles=1 dangling=0 synonym parents=0 rank inversions=2",
+		"cycle sample: 10",
+		"rank inversion sample: 20, 21",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report %q, want a %q line", got, want)
+		}
+	}
+	if strings.Contains(got, "dangling parent sample") {
+		t.Errorf("report %q, want no sample line for an empty finding", got)
+	}
+	if cleanSection := got[strings.Index(got, "clean:"):]; strings.Contains(cleanSection, "parent chains:") {
+		t.Errorf("report %q, want no \"parent chains:\" line for an intact backbone", cleanSection)
+	}
+}
+
 // TestIngestCommand_RestrictedVocabulary_PrintsRedistributionNotice drives
 // "hostus ingest" against a manifest whose eive trait vocabulary is pinned
 // redistribution: unknown (testdata/dataset-restricted.yaml) and asserts
@@ -30,7 +30,8 @@ func newMCPCmd() *cobra.Command {
 background and additionally serves a read-only Model Context Protocol
 server over stdio, exposing the running instance's buffered logs and
 spans (get_recent_logs, tail_errors, get_trace, list_spans) to an MCP
-client such as Claude Code.
+client such as Claude Code. With a database configured it also serves
+explain_match, which traces how POST /v1/match resolves a name.
 
 stdout is reserved for MCP JSON-RPC framing; all of this command's own
 diagnostic output goes to stderr instead.`,
@@ -75,7 +76,7 @@ func runMCP(cmd *cobra.Command, _ []string) error {
 	go func() { httpErrCh <- a.Serve(ctx) }()
 
 	stderrLog.Info("starting debug MCP over stdio", "http_address", cfg.Server.Address())
-	mcpServer := mcpAdapter.NewServer(a.Telemetry.Log, a.Telemetry.Memory)
+	mcpServer := mcpAdapter.NewServer(a.Telemetry.Log, a.Telemetry.Memory, mcpAdapter.WithRepository(a.Repo))
 	stdioErrCh := make(chan error, 1)
 	go func() { stdioErrCh <- mcpServer.ServeStdio(ctx) }()
 
@@ -48,6 +48,7 @@ Running hostus with no subcommand is equivalent to "hostus serve".`,
 	root.AddCommand(newIngestCmd())
 	root.AddCommand(newValidateCmd())
 	root.AddCommand(newBundleCmd())
+	root.AddCommand(newChecklistCmd())
 
 	return root
 }
@@ -0,0 +1,124 @@
+// Package distribution reads the canonical, pipe-delimited DISTRIBUTION CSV
+// a regional checklist is converted into (see pipelines/README.md,
+// "Canonical CSV contract (distributions)"): one row per (taxon, area) the
+// checklist records, in the checklist's own area scheme.
+//
+// Like the namelist/xref readers this stays string-typed — a thin, defensive
+// CSV decode. Whether a scheme is a valid scheme id and a status one of the
+// known verdicts is decided by the ingest (application.IngestDistributions),
+// which reports the rows it rejects; this reader only rejects rows that
+// carry no usable identity at all.
+package distribution
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Row is one row of the canonical distribution CSV. The concept is named
+// either by Authority/ExtID — an id hostus already holds as an xref, e.g.
+// authority "powo" — or, when those are empty, by the Taxon name. Status is
+// kept verbatim.
+type Row struct {
+	Taxon     string
+	Authority string
+	ExtID     string
+	Scheme    string
+	Code      string
+	Status    string
+}
+
+// Dataset is the parsed canonical distribution CSV. Errors collects
+// non-fatal, per-row problems (short row, no taxon and no id, half an id,
+// empty scheme or code): such rows are SKIPPED but never silently — the
+// count is surfaced on the ingest report.
+type Dataset struct {
+	Rows   []Row
+	Errors []error
+}
+
+var wantHeader = []string{"taxon", "authority", "ext_id", "scheme", "code", "status"}
+
+// Read parses the canonical distribution CSV at path.
+func Read(path string) (*Dataset, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("distribution: open %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	r := csv.NewReader(f)
+	r.Comma = '|'
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("distribution: read header of %s: %w", path, err)
+	}
+	idx := make(map[string]int, len(header))
+	for i, name := range header {
+		idx[name] = i
+	}
+	minFields := 0
+	for _, want := range wantHeader {
+		i, ok := idx[want]
+		if !ok {
+			return nil, fmt.Errorf("distribution: %s: missing expected column %q in header %v", path, want, header)
+		}
+		minFields = max(minFields, i+1)
+	}
+
+	var ds Dataset
+	line := 1 // header was line 1
+	for {
+		line++
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("distribution: %s line %d: %w", path, line, err)
+		}
+		row, rerr := rowFrom(rec, idx, minFields)
+		if rerr != nil {
+			ds.Errors = append(ds.Errors, fmt.Errorf("distribution: %s line %d: %w", path, line, rerr))
+			continue
+		}
+		ds.Rows = append(ds.Rows, row)
+	}
+	return &ds, nil
+}
+
+// rowFrom decodes one record. A row is rejected when it names no concept (no
+// taxon and no id), names half an id (an authority without an ext_id or the
+// reverse — the id join needs both, and silently falling back to the name
+// would hide a broken converter), or names no area.
+func rowFrom(rec []string, idx map[string]int, minFields int) (Row, error) {
+	if len(rec) < minFields {
+		return Row{}, fmt.Errorf("short row: %d fields, want at least %d", len(rec), minFields)
+	}
+	field := func(name string) string { return strings.TrimSpace(rec[idx[name]]) }
+	row := Row{
+		Taxon:     field("taxon"),
+		Authority: field("authority"),
+		ExtID:     field("ext_id"),
+		Scheme:    field("scheme"),
+		Code:      field("code"),
+		Status:    field("status"),
+	}
+	switch {
+	case (row.Authority == "") != (row.ExtID == ""):
+		return Row{}, fmt.Errorf("taxon %q: authority %q and ext_id %q must be given together", row.Taxon, row.Authority, row.ExtID)
+	case row.Taxon == "" && row.ExtID == "":
+		return Row{}, errors.New("empty taxon and no ext_id")
+	case row.Scheme == "" || row.Code == "":
+		return Row{}, fmt.Errorf("taxon %q: empty scheme or code", row.Taxon)
+	}
+	return row, nil
+}
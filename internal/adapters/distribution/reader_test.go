@@ -0,0 +1,75 @@
+package distribution_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/distribution"
+)
+
+// TestRead_RoundTripsEveryColumn pins that every column survives the decode
+// in the source's own spelling: the status is not normalized here (the
+// ingest does that), and an id-only row keeps its empty taxon.
+func TestRead_RoundTripsEveryColumn(t *testing.T) {
+	t.Parallel()
+
+	ds, err := distribution.Read(filepath.Join("testdata", "euromed-sample.csv"))
+	if err != nil {
+		t.Fatalf("Read: unexpected error: %v", err)
+	}
+	if len(ds.Errors) != 0 {
+		t.Fatalf("Read: unexpected row errors: %v", ds.Errors)
+	}
+	want := []distribution.Row{
+		{Taxon: "Festuca ovina", Scheme: "euromed", Code: "Ge", Status: "native"},
+		{Taxon: "Festuca ovina", Scheme: "euromed", Code: "Br", Status: "introduced"},
+		{Taxon: "Corynephorus canescens", Authority: "powo", ExtID: "396681-1", Scheme: "euromed", Code: "Ge", Status: "Native"},
+		{Authority: "powo", ExtID: "396681-1", Scheme: "euromed", Code: "Au", Status: "doubtful"},
+		{Taxon: "Jacobaea vulgaris subsp. dunensis", Scheme: "euromed", Code: "Ga"},
+	}
+	if len(ds.Rows) != len(want) {
+		t.Fatalf("Read: got %d rows, want %d (%+v)", len(ds.Rows), len(want), ds.Rows)
+	}
+	for i, w := range want {
+		if ds.Rows[i] != w {
+			t.Errorf("row %d = %+v, want %+v", i, ds.Rows[i], w)
+		}
+	}
+}
+
+// TestRead_BadRowsAreCollectedNotDropped pins the standing loss rule: an
+// unusable row is skipped, but lands in Errors with its line number.
+func TestRead_BadRowsAreCollectedNotDropped(t *testing.T) {
+	t.Parallel()
+
+	ds, err := distribution.Read(filepath.Join("testdata", "euromed-broken.csv"))
+	if err != nil {
+		t.Fatalf("Read: unexpected error: %v", err)
+	}
+	if got, want := len(ds.Rows), 2; got != want {
+		t.Fatalf("Read: got %d usable rows, want %d (%+v)", got, want, ds.Rows)
+	}
+	if got, want := len(ds.Errors), 4; got != want {
+		t.Fatalf("Read: got %d row errors, want %d (%v)", got, want, ds.Errors)
+	}
+	parts := make([]string, len(ds.Errors))
+	for i, e := range ds.Errors {
+		parts[i] = e.Error()
+	}
+	joined := strings.Join(parts, "\n")
+	for _, want := range []string{"empty taxon and no ext_id", "must be given together", "empty scheme or code", "short row", "line 6"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Read: errors %q do not mention %q", joined, want)
+		}
+	}
+}
+
+func TestRead_MissingColumnIsFatal(t *testing.T) {
+	t.Parallel()
+
+	_, err := distribution.Read(filepath.Join("testdata", "wrong-header.csv"))
+	if err == nil || !strings.Contains(err.Error(), `"authority"`) {
+		t.Fatalf("Read: err = %v, want a missing-column error naming authority", err)
+	}
+}
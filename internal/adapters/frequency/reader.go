@@ -0,0 +1,118 @@
+// Package frequency reads the canonical, pipe-delimited FREQUENCY CSV a
+// per-taxon occurrence count export is converted into (see
+// pipelines/README.md, "Canonical CSV contract (frequencies)"): one row per
+// taxon the source counted, e.g. a national relevé database's number of
+// relevés per taxon or a locally dumped GBIF occurrence count.
+//
+// Like the distribution reader this stays string-typed — a thin, defensive
+// CSV decode. Whether a count is a non-negative integer is decided by the
+// ingest (application.IngestFrequencies), which reports the rows it
+// rejects; this reader only rejects rows that carry no usable identity.
+package frequency
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Row is one row of the canonical frequency CSV. The concept is named either
+// by Authority/ExtID — an id hostus already holds as an xref — or, when
+// those are empty, by the Taxon name. Count is kept verbatim.
+type Row struct {
+	Taxon     string
+	Authority string
+	ExtID     string
+	Count     string
+}
+
+// Dataset is the parsed canonical frequency CSV. Errors collects non-fatal,
+// per-row problems (short row, no taxon and no id, half an id, empty count):
+// such rows are SKIPPED but never silently — the count is surfaced on the
+// ingest report.
+type Dataset struct {
+	Rows   []Row
+	Errors []error
+}
+
+var wantHeader = []string{"taxon", "authority", "ext_id", "count"}
+
+// Read parses the canonical frequency CSV at path.
+func Read(path string) (*Dataset, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("frequency: open %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	r := csv.NewReader(f)
+	r.Comma = '|'
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("frequency: read header of %s: %w", path, err)
+	}
+	idx := make(map[string]int, len(header))
+	for i, name := range header {
+		idx[name] = i
+	}
+	minFields := 0
+	for _, want := range wantHeader {
+		i, ok := idx[want]
+		if !ok {
+			return nil, fmt.Errorf("frequency: %s: missing expected column %q in header %v", path, want, header)
+		}
+		minFields = max(minFields, i+1)
+	}
+
+	var ds Dataset
+	line := 1 // header was line 1
+	for {
+		line++
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("frequency: %s line %d: %w", path, line, err)
+		}
+		row, rerr := rowFrom(rec, idx, minFields)
+		if rerr != nil {
+			ds.Errors = append(ds.Errors, fmt.Errorf("frequency: %s line %d: %w", path, line, rerr))
+			continue
+		}
+		ds.Rows = append(ds.Rows, row)
+	}
+	return &ds, nil
+}
+
+// rowFrom decodes one record. A row is rejected when it names no concept (no
+// taxon and no id), names half an id (the id join needs both, exactly as in
+// the distribution reader), or carries no count.
+func rowFrom(rec []string, idx map[string]int, minFields int) (Row, error) {
+	if len(rec) < minFields {
+		return Row{}, fmt.Errorf("short row: %d fields, want at least %d", len(rec), minFields)
+	}
+	field := func(name string) string { return strings.TrimSpace(rec[idx[name]]) }
+	row := Row{
+		Taxon:     field("taxon"),
+		Authority: field("authority"),
+		ExtID:     field("ext_id"),
+		Count:     field("count"),
+	}
+	switch {
+	case (row.Authority == "") != (row.ExtID == ""):
+		return Row{}, fmt.Errorf("taxon %q: authority %q and ext_id %q must be given together", row.Taxon, row.Authority, row.ExtID)
+	case row.Taxon == "" && row.ExtID == "":
+		return Row{}, errors.New("empty taxon and no ext_id")
+	case row.Count == "":
+		return Row{}, fmt.Errorf("taxon %q: empty count", row.Taxon)
+	}
+	return row, nil
+}
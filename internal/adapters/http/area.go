@@ -1,30 +1,48 @@
 package httpx
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
 	"github.com/jobrunner/hostus/internal/httperr"
 	"github.com/jobrunner/hostus/internal/ports/output"
 )
 
 // areaDTO is one distribution area on the wire: its code, human-readable name
-// (empty when the source carried none) and scheme.
+// (empty when the source carried none) and scheme, plus — for a WGSRPD area —
+// its level and the code of the unit one level up. Children is filled only
+// by GET /v1/areas, which nests each area under its parent.
 type areaDTO struct {
-	Code   string `json:"code"`
-	Name   string `json:"name,omitempty"`
-	Scheme string `json:"scheme"`
+	Code     string    `json:"code"`
+	Name     string    `json:"name,omitempty"`
+	Scheme   string    `json:"scheme"`
+	Level    int       `json:"level,omitempty"`
+	Parent   string    `json:"parent,omitempty"`
+	Children []areaDTO `json:"children,omitempty"`
+}
+
+// newAreaDTO maps one domain.Area onto the wire, without children.
+func newAreaDTO(a domain.Area) areaDTO {
+	return areaDTO{Code: a.Code, Name: a.Name, Scheme: a.Scheme, Level: a.Level(), Parent: a.Parent}
 }
 
 // areaListResponseDTO is the GET /v1/areas envelope: every distribution area
-// that carries data, each with its name where known. It lets a client offer a
-// "Germany (GER)" picker instead of a bare WGSRPD code — the codes are WGSRPD
-// (not ISO) and rarely remembered.
+// that carries data, each with its name where known, nested under the WGSRPD
+// regions and continents containing it. It lets a client offer a "Germany
+// (GER)" picker — or "Middle Europe" above it — instead of a bare WGSRPD code;
+// the codes are WGSRPD (not ISO) and rarely remembered.
 type areaListResponseDTO struct {
 	Areas []areaDTO `json:"areas"`
 }
 
-// handleAreas serves GET /v1/areas, listing the distribution areas with data
-// (Repository.Areas) in (scheme, code) order. No parameters; the only error
+// handleAreas serves GET /v1/areas: the distribution areas with data and
+// their WGSRPD ancestors (Repository.Areas) as a tree — the top level holds
+// the continents, plus every area without a parent in the result (a
+// database ingested before the hierarchy, a non-WGSRPD scheme). Siblings
+// keep the repository's (scheme, code) order. No parameters; the only error
 // path is a repository failure.
 func handleAreas(repo output.Repository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -33,10 +51,136 @@ func handleAreas(repo output.Repository) http.HandlerFunc {
 			httperr.InternalError(w)
 			return
 		}
+		writeJSON(w, areaListResponseDTO{Areas: areaTree(areas)})
+	}
+}
+
+// areaTree nests areas under their parents. An area whose Parent is not
+// itself in areas becomes a root rather than being dropped. Never nil, so an
+// empty index encodes as [].
+func areaTree(areas []domain.Area) []areaDTO {
+	index := make(map[string]int, len(areas)) // scheme + "|" + code
+	for i, a := range areas {
+		index[a.Scheme+"|"+a.Code] = i
+	}
+	children := make(map[int][]int, len(areas))
+	var roots []int
+	for i, a := range areas {
+		parent, ok := index[domain.WGSRPDParentScheme(a.Scheme)+"|"+a.Parent]
+		if a.Parent == "" || !ok {
+			roots = append(roots, i)
+			continue
+		}
+		children[parent] = append(children[parent], i)
+	}
+	var build func(ids []int) []areaDTO
+	build = func(ids []int) []areaDTO {
+		out := make([]areaDTO, len(ids))
+		for k, i := range ids {
+			out[k] = newAreaDTO(areas[i])
+			if kids := children[i]; len(kids) != 0 {
+				out[k].Children = build(kids)
+			}
+		}
+		return out
+	}
+	return build(roots)
+}
+
+// areaResolveResponseDTO is the GET /v1/areas/resolve envelope: the WGSRPD
+// areas containing the position, broadest level first. Empty (never null)
+// for a position in no area, such as open sea.
+type areaResolveResponseDTO struct {
+	Areas []areaDTO `json:"areas"`
+}
+
+// handleAreasResolve serves GET /v1/areas/resolve?lat=&lon=, placing a
+// position in the WGSRPD areas at every level (application.LocateAreas). Both
+// parameters are required; a missing or out-of-range one is 400
+// INVALID_QUERY, and a server built without the geometry asset answers 503
+// GEOMETRY_UNAVAILABLE.
+func handleAreasResolve(loc output.AreaLocator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		lat, lon, err := parsePosition(query.Get("lat"), query.Get("lon"))
+		if err == nil && lat == nil {
+			err = errPositionRequired
+		}
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+		areas, err := application.LocateAreas(loc, *lat, *lon)
+		if err != nil {
+			writePositionError(w, err)
+			return
+		}
 		dtos := make([]areaDTO, len(areas))
 		for i, a := range areas {
-			dtos[i] = areaDTO{Code: a.Code, Name: a.Name, Scheme: a.Scheme}
+			dtos[i] = newAreaDTO(a)
 		}
-		writeJSON(w, areaListResponseDTO{Areas: dtos})
+		writeJSON(w, areaResolveResponseDTO{Areas: dtos})
+	}
+}
+
+var (
+	errPositionRequired = errors.New("lat and lon query parameters are required")
+	errPositionHalf     = errors.New("lat and lon must be given together")
+	errAreaAndPosition  = errors.New("area and lat/lon are mutually exclusive")
+)
+
+// parsePosition reads a lat/lon pair given as strings (query parameters).
+// Both empty is (nil, nil, nil) — no position; exactly one empty, or either
+// not a number, is an error. The range is checked later, by domain.NewPoint.
+func parsePosition(latRaw, lonRaw string) (lat, lon *float64, err error) {
+	if latRaw == "" && lonRaw == "" {
+		return nil, nil, nil
+	}
+	if latRaw == "" || lonRaw == "" {
+		return nil, nil, errPositionHalf
+	}
+	la, err := strconv.ParseFloat(latRaw, 64)
+	if err != nil {
+		return nil, nil, errors.New("lat must be a number")
+	}
+	lo, err := strconv.ParseFloat(lonRaw, 64)
+	if err != nil {
+		return nil, nil, errors.New("lon must be a number")
+	}
+	return &la, &lo, nil
+}
+
+// areaFilter turns suggest's and match's area inputs into the one area code
+// their use cases take: area as given, or the level-3 area containing
+// lat/lon (application.AreaAtPosition). A position is the phone's answer to
+// "where was this recorded?" — it stands in for area=, so naming both is an
+// error rather than a precedence rule the caller has to know.
+func areaFilter(loc output.AreaLocator, area string, lat, lon *float64) (string, error) {
+	if lat == nil && lon == nil {
+		return area, nil
+	}
+	if lat == nil || lon == nil {
+		return "", errPositionHalf
+	}
+	if area != "" {
+		return "", errAreaAndPosition
+	}
+	return application.AreaAtPosition(loc, *lat, *lon)
+}
+
+// writePositionError renders a parsePosition/areaFilter/LocateAreas error:
+// missing geometries are the server's 503, everything else — a half or
+// conflicting position, an out-of-range coordinate, a position in no area —
+// is the client's 400.
+func writePositionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, application.ErrNoAreaLocator):
+		httperr.GeometryUnavailableError(w)
+	case errors.Is(err, domain.ErrInvalidCoordinate):
+		httperr.InvalidQueryError(w, "lat must be within [-90, 90] and lon within [-180, 180]")
+	case errors.Is(err, application.ErrNoAreaAtPosition):
+		httperr.InvalidQueryError(w, "lat/lon lies in no WGSRPD area")
+	default:
+		httperr.InvalidQueryError(w, err.Error())
 	}
 }
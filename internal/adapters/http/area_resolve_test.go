@@ -0,0 +1,183 @@
+package httpx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// stubLocator places every position north of the equator in Austria (AUT,
+// the WCVP fixture's distributed area) and everything else in open sea.
+type stubLocator struct{}
+
+func (stubLocator) Locate(p domain.Point) []domain.Area {
+	if p.Lat <= 0 {
+		return nil
+	}
+	return []domain.Area{
+		{Scheme: domain.AreaSchemeWGSRPDL1, Code: "1"},
+		{Scheme: domain.AreaSchemeWGSRPDL2, Code: "11"},
+		{Scheme: domain.AreaSchemeWGSRPDL3, Code: "AUT", Name: "Austria"},
+	}
+}
+
+func serveWithLocator(t *testing.T, repo output.Repository, loc output.AreaLocator, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httpx.NewRouter(httpx.Deps{Repo: repo, Locator: loc})
+	rr := httptest.NewRecorder()
+	rr.Body = new(bytes.Buffer)
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestHandleAreasResolve_ReturnsEveryLevel(t *testing.T) {
+	rr := serveWithLocator(t, stubAreaRepo{}, stubLocator{},
+		httptest.NewRequest(http.MethodGet, "/v1/areas/resolve?lat=47.8&lon=13.0", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	got := decodeJSON[areaListResponse](t, rr.Body)
+	if len(got.Areas) != 3 {
+		t.Fatalf("areas = %+v, want three levels", got.Areas)
+	}
+	if a := got.Areas[2]; a.Code != "AUT" || a.Name != "Austria" || a.Scheme != "wgsrpd_l3" {
+		t.Errorf("areas[2] = %+v, want {AUT, Austria, wgsrpd_l3}", a)
+	}
+	if a := got.Areas[0]; a.Code != "1" || a.Scheme != "wgsrpd_l1" {
+		t.Errorf("areas[0] = %+v, want level 1 first", a)
+	}
+}
+
+func TestHandleAreasResolve_OpenSea_ReturnsEmptyArray(t *testing.T) {
+	rr := serveWithLocator(t, stubAreaRepo{}, stubLocator{},
+		httptest.NewRequest(http.MethodGet, "/v1/areas/resolve?lat=-10&lon=-30", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	if body := rr.Body.String(); !strings.Contains(body, `"areas":[]`) {
+		t.Errorf("body = %s, want an empty JSON array, not null", body)
+	}
+}
+
+func TestHandleAreasResolve_BadPosition_Returns400(t *testing.T) {
+	for _, query := range []string{"", "lat=47", "lat=x&lon=1", "lat=95&lon=1", "lat=1&lon=181"} {
+		rr := serveWithLocator(t, stubAreaRepo{}, stubLocator{},
+			httptest.NewRequest(http.MethodGet, "/v1/areas/resolve?"+query, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%q: status = %d, want 400 (body: %s)", query, rr.Code, rr.Body.String())
+			continue
+		}
+		assertErrorCode(t, rr, "INVALID_QUERY")
+	}
+}
+
+// TestHandleAreasResolve_WithoutRepo: the endpoint needs only the locator,
+// so a server without an index still resolves positions.
+func TestHandleAreasResolve_WithoutRepo(t *testing.T) {
+	rr := serveWithLocator(t, nil, stubLocator{},
+		httptest.NewRequest(http.MethodGet, "/v1/areas/resolve?lat=47.8&lon=13.0", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+}
+
+// TestHandleAreasResolve_NoGeometries_Returns503 pins the degraded mode of a
+// build without the generated asset: the route exists, the server cannot
+// answer.
+func TestHandleAreasResolve_NoGeometries_Returns503(t *testing.T) {
+	rr := serveWithLocator(t, stubAreaRepo{}, nil,
+		httptest.NewRequest(http.MethodGet, "/v1/areas/resolve?lat=47&lon=13", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503 (body: %s)", rr.Code, rr.Body.String())
+	}
+	assertErrorCode(t, rr, "GEOMETRY_UNAVAILABLE")
+}
+
+// TestHandleSuggest_PositionStandsInForArea: lat/lon resolve to AUT, so the
+// fixture's Corynephorus canescens comes back in_area exactly as with area=AUT.
+func TestHandleSuggest_PositionStandsInForArea(t *testing.T) {
+	rr := serveWithLocator(t, seededRepo(t), stubLocator{},
+		httptest.NewRequest(http.MethodGet, "/v1/suggest?q=coryn&lat=47.8&lon=13.0", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	got := decodeJSON[suggestResponse](t, rr.Body)
+	coryn := findSuggestResult(got.Results, corynephorusConceptID)
+	if coryn == nil || !coryn.InArea {
+		t.Errorf("results = %+v, want Corynephorus canescens in_area", got.Results)
+	}
+}
+
+func TestHandleSuggest_PositionErrors(t *testing.T) {
+	repo := seededRepo(t)
+	cases := []struct {
+		query  string
+		loc    output.AreaLocator
+		status int
+		code   string
+	}{
+		{"q=coryn&lat=47.8", stubLocator{}, http.StatusBadRequest, "INVALID_QUERY"},
+		{"q=coryn&area=AUT&lat=47.8&lon=13", stubLocator{}, http.StatusBadRequest, "INVALID_QUERY"},
+		{"q=coryn&lat=-10&lon=-30", stubLocator{}, http.StatusBadRequest, "INVALID_QUERY"},
+		{"q=coryn&lat=47.8&lon=13", nil, http.StatusServiceUnavailable, "GEOMETRY_UNAVAILABLE"},
+	}
+	for _, c := range cases {
+		rr := serveWithLocator(t, repo, c.loc, httptest.NewRequest(http.MethodGet, "/v1/suggest?"+c.query, nil))
+		if rr.Code != c.status {
+			t.Errorf("%q: status = %d, want %d (body: %s)", c.query, rr.Code, c.status, rr.Body.String())
+			continue
+		}
+		assertErrorCode(t, rr, c.code)
+	}
+}
+
+func TestHandleMatch_PositionStandsInForArea(t *testing.T) {
+	body := `{"lat":47.8,"lon":13.0,"names":[{"id":"1","verbatim":"Corynephorus canescens"}]}`
+	rr := serveWithLocator(t, seededRepo(t), stubLocator{},
+		httptest.NewRequest(http.MethodPost, "/v1/match", strings.NewReader(body)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	var inArea bool
+	if err := json.Unmarshal(rawResults(t, rr)[0]["in_area"], &inArea); err != nil || !inArea {
+		t.Errorf("in_area = %v (%v), want true for a position in AUT", inArea, err)
+	}
+}
+
+// TestHandleMatch_StreamedPositionFromQuery: a CSV body has no room for
+// request-level fields, so lat/lon come from the query string like area does.
+func TestHandleMatch_StreamedPositionFromQuery(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/match?lat=47.8&lon=13",
+		strings.NewReader("id,verbatim\n1,Corynephorus canescens\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	req.Header.Set("Accept", "text/csv")
+	rr := serveWithLocator(t, seededRepo(t), stubLocator{}, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	header, _, _ := strings.Cut(rr.Body.String(), "\n")
+	if !strings.Contains(header, "in_area") {
+		t.Errorf("CSV header = %q, want the area columns", header)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/match?lat=47.8",
+		strings.NewReader("id,verbatim\n1,Corynephorus canescens\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	if rr := serveWithLocator(t, seededRepo(t), stubLocator{}, req); rr.Code != http.StatusBadRequest {
+		t.Errorf("lat without lon: status = %d, want 400 (body: %s)", rr.Code, rr.Body.String())
+	}
+}
+
+func assertErrorCode(t *testing.T, rr *httptest.ResponseRecorder, code string) {
+	t.Helper()
+	if got := decodeJSON[errorEnvelope](t, rr.Body); got.Error.Code != code {
+		t.Errorf("error.code = %q, want %q", got.Error.Code, code)
+	}
+}
@@ -58,6 +58,55 @@ func TestHandleAreas_ListsAreasWithNames(t *testing.T) {
 	}
 }
 
+// areaNode is one node of the GET /v1/areas tree.
+type areaNode struct {
+	Code     string     `json:"code"`
+	Scheme   string     `json:"scheme"`
+	Level    int        `json:"level"`
+	Parent   string     `json:"parent"`
+	Children []areaNode `json:"children"`
+}
+
+// TestHandleAreas_NestsAreasUnderTheirParents: Europe holds Middle Europe,
+// which holds Germany; an area whose parent is not in the list (AUT's region
+// is missing) and a non-WGSRPD area stay at the top.
+func TestHandleAreas_NestsAreasUnderTheirParents(t *testing.T) {
+	repo := stubAreaRepo{areas: []domain.Area{
+		{Scheme: "bayern", Code: "BY-1", Name: "Unterfranken"},
+		{Scheme: "wgsrpd_l1", Code: "1", Name: "Europe"},
+		{Scheme: "wgsrpd_l2", Code: "11", Name: "Middle Europe", Parent: "1"},
+		{Scheme: "wgsrpd_l3", Code: "AUT", Name: "Austria", Parent: "99"},
+		{Scheme: "wgsrpd_l3", Code: "GER", Name: "Germany", Parent: "11"},
+	}}
+	rr := httptest.NewRecorder()
+	httpx.NewRouter(httpx.Deps{Repo: repo}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/areas", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+
+	got := decodeJSON[struct {
+		Areas []areaNode `json:"areas"`
+	}](t, rr.Body)
+	var roots []string
+	for _, a := range got.Areas {
+		roots = append(roots, a.Code)
+	}
+	if strings.Join(roots, ",") != "BY-1,1,AUT" {
+		t.Fatalf("roots = %v, want [BY-1 1 AUT] (body: %s)", roots, rr.Body.String())
+	}
+	europe := got.Areas[1]
+	if europe.Level != 1 || len(europe.Children) != 1 || europe.Children[0].Code != "11" {
+		t.Fatalf("Europe = %+v, want level 1 holding Middle Europe", europe)
+	}
+	middle := europe.Children[0]
+	if middle.Level != 2 || middle.Parent != "1" || len(middle.Children) != 1 || middle.Children[0].Code != "GER" {
+		t.Errorf("Middle Europe = %+v, want level 2 under 1 holding GER", middle)
+	}
+	if got.Areas[0].Level != 0 {
+		t.Errorf("bayern area level = %d, want 0 (outside WGSRPD)", got.Areas[0].Level)
+	}
+}
+
 func TestHandleAreas_Empty_ReturnsEmptyArrayNotNull(t *testing.T) {
 	r := httpx.NewRouter(httpx.Deps{Repo: stubAreaRepo{areas: nil}})
 	rr := httptest.NewRecorder()
@@ -0,0 +1,147 @@
+package httpx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/httperr"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// nextCursorHeader carries a CSV checklist page's next_cursor, which has no
+// envelope to put it in. It is absent on the last page.
+const nextCursorHeader = "X-Next-Cursor"
+
+// checklistEntryDTO is one taxon of a GET /v1/checklist family group.
+type checklistEntryDTO struct {
+	ConceptID  string `json:"concept_id"`
+	Backbone   string `json:"backbone"`
+	Canonical  string `json:"canonical"`
+	Authorship string `json:"authorship,omitempty"`
+	Rank       string `json:"rank"`
+	// Establishment is omitted for presence without a verdict of the
+	// concept's own rather than sent as "".
+	Establishment string `json:"establishment,omitempty"`
+	Derived       bool   `json:"derived"`
+}
+
+// checklistFamilyDTO is one family's run of taxa on the page; family is ""
+// for the taxa whose family is unknown, always the last group.
+type checklistFamilyDTO struct {
+	Family string              `json:"family"`
+	Taxa   []checklistEntryDTO `json:"taxa"`
+}
+
+// checklistResponseDTO is the JSON GET /v1/checklist envelope.
+type checklistResponseDTO struct {
+	Area       string               `json:"area"`
+	Limit      int                  `json:"limit"`
+	Families   []checklistFamilyDTO `json:"families"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// handleChecklist serves GET /v1/checklist?area=&rank=&establishment=
+// &entry_backbone=&limit=&cursor=, one page of the accepted taxa occurring in
+// area grouped by family. The format follows Accept as POST /v1/match's
+// output does: text/csv writes the page as CSV with the next cursor in
+// X-Next-Cursor, anything else the JSON envelope. A missing area, an
+// unknown rank or establishment, a bad limit or cursor and an un-ingested
+// entry_backbone all report 400 INVALID_QUERY.
+func handleChecklist(repo output.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		ranks, err := parseSuggestRanks(query.Get("rank"))
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+		establishment, err := domain.ParseDistributionStatus(query.Get("establishment"))
+		if err != nil {
+			httperr.InvalidQueryError(w, fmt.Sprintf("unknown establishment %q", query.Get("establishment")))
+			return
+		}
+		limit, err := parseTreeInt(query.Get("limit"))
+		if err != nil {
+			httperr.InvalidQueryError(w, fmt.Sprintf("limit %q is not an integer", query.Get("limit")))
+			return
+		}
+
+		res, err := application.Checklist(r.Context(), repo, application.ChecklistRequest{
+			Area:          query.Get("area"),
+			Ranks:         ranks,
+			Establishment: establishment,
+			EntryBackbone: query.Get("entry_backbone"),
+			Cursor:        query.Get("cursor"),
+			Limit:         limit,
+		})
+		if err != nil {
+			writeChecklistError(w, err, query)
+			return
+		}
+
+		if matchOutputFormat(r.Header.Get("Accept")) == matchFormatCSV {
+			writeChecklistCSV(w, res)
+			return
+		}
+		families := res.Families()
+		dto := checklistResponseDTO{Area: res.Area, Limit: res.Limit, Families: make([]checklistFamilyDTO, len(families)), NextCursor: res.NextCursor}
+		for i, f := range families {
+			taxa := make([]checklistEntryDTO, len(f.Entries))
+			for j, e := range f.Entries {
+				taxa[j] = checklistEntryDTO{
+					ConceptID:     e.ConceptID,
+					Backbone:      e.BackboneID,
+					Canonical:     e.Canonical,
+					Authorship:    e.Authorship,
+					Rank:          string(e.Rank),
+					Establishment: string(e.Establishment),
+					Derived:       e.Derived,
+				}
+			}
+			dto.Families[i] = checklistFamilyDTO{Family: f.Family, Taxa: taxa}
+		}
+		writeJSON(w, dto)
+	}
+}
+
+// writeChecklistCSV writes res as CSV: the header row, then one row per
+// entry with its family in the first column instead of a grouping.
+func writeChecklistCSV(w http.ResponseWriter, res application.ChecklistResult) {
+	if res.NextCursor != "" {
+		w.Header().Set(nextCursorHeader, res.NextCursor)
+	}
+	w.Header().Set("Content-Type", mediaCSV+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	cw := csv.NewWriter(w)
+	_ = cw.Write(application.ChecklistCSVColumns)
+	for _, e := range res.Entries {
+		_ = cw.Write(application.ChecklistCSVRecord(e))
+	}
+	cw.Flush()
+}
+
+// writeChecklistError maps application.Checklist's named failures onto the
+// error contract, composing each 400 from the raw query value (see
+// writeSynonymsError for why). Anything unrecognized is a 500.
+func writeChecklistError(w http.ResponseWriter, err error, query url.Values) {
+	switch {
+	case errors.Is(err, application.ErrEmptyArea):
+		httperr.InvalidQueryError(w, "area query parameter is required")
+	case errors.Is(err, application.ErrInvalidLimit):
+		httperr.InvalidQueryError(w, fmt.Sprintf("limit %q is not in [0, %d]", query.Get("limit"), application.MaxChecklistLimit))
+	case errors.Is(err, application.ErrInvalidEstablishment):
+		httperr.InvalidQueryError(w, fmt.Sprintf("establishment %q is not native, introduced or doubtful", query.Get("establishment")))
+	case errors.Is(err, application.ErrInvalidCursor):
+		httperr.InvalidQueryError(w, "cursor "+strconv.Quote(query.Get("cursor"))+" was not issued by this endpoint")
+	case errors.Is(err, application.ErrUnknownBackbone):
+		httperr.InvalidQueryError(w, "unknown entry_backbone "+strconv.Quote(query.Get("entry_backbone")))
+	default:
+		httperr.InternalError(w)
+	}
+}
@@ -0,0 +1,150 @@
+package httpx_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+)
+
+type checklistResponse struct {
+	Area     string `json:"area"`
+	Limit    int    `json:"limit"`
+	Families []struct {
+		Family string `json:"family"`
+		Taxa   []struct {
+			ConceptID     string `json:"concept_id"`
+			Backbone      string `json:"backbone"`
+			Canonical     string `json:"canonical"`
+			Rank          string `json:"rank"`
+			Establishment string `json:"establishment"`
+			Derived       bool   `json:"derived"`
+		} `json:"taxa"`
+	} `json:"families"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// TestHandleChecklist_GroupsTheAreaByFamily reads the WCVP fixture's AUT
+// checklist: Jacobaea vulgaris under Asteraceae, then the two grasses
+// under Poaceae, each family from WCVP's own family column.
+func TestHandleChecklist_GroupsTheAreaByFamily(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/checklist?area=AUT&rank=SPECIES", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	got := decodeJSON[checklistResponse](t, rec.Body)
+	if got.Area != "AUT" || got.Limit != 500 || got.NextCursor != "" {
+		t.Errorf("envelope = {area %q, limit %d, next_cursor %q}, want {AUT, 500, none}", got.Area, got.Limit, got.NextCursor)
+	}
+	var groups []string
+	for _, f := range got.Families {
+		names := make([]string, len(f.Taxa))
+		for i, tx := range f.Taxa {
+			names[i] = tx.Canonical
+		}
+		groups = append(groups, f.Family+": "+strings.Join(names, ", "))
+	}
+	want := []string{"Asteraceae: Jacobaea vulgaris", "Poaceae: Corynephorus canescens, Festuca ovina"}
+	if strings.Join(groups, "; ") != strings.Join(want, "; ") {
+		t.Errorf("families = %q, want %q", groups, want)
+	}
+}
+
+// TestHandleChecklist_WCVPEstablishment filters the WCVP fixture by the
+// backbone's own verdict: all three species are native in AUT, while WCVP
+// records Jacobaea vulgaris and Corynephorus canescens as introduced in
+// British Columbia, so nothing there is native.
+func TestHandleChecklist_WCVPEstablishment(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	for _, tc := range []struct {
+		area, establishment string
+		want                []string
+	}{
+		{"AUT", "native", []string{"Jacobaea vulgaris", "Corynephorus canescens", "Festuca ovina"}},
+		{"BRC", "introduced", []string{"Jacobaea vulgaris", "Corynephorus canescens"}},
+		{"BRC", "native", nil},
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/checklist?area="+tc.area+"&establishment="+tc.establishment, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: status = %d, want 200 (body: %s)", tc.area, tc.establishment, rec.Code, rec.Body.String())
+		}
+		var got []string
+		for _, f := range decodeJSON[checklistResponse](t, rec.Body).Families {
+			for _, tx := range f.Taxa {
+				got = append(got, tx.Canonical)
+				if tx.Establishment != tc.establishment {
+					t.Errorf("%s %s: %s establishment = %q", tc.area, tc.establishment, tx.Canonical, tx.Establishment)
+				}
+			}
+		}
+		if strings.Join(got, ", ") != strings.Join(tc.want, ", ") {
+			t.Errorf("%s %s: taxa = %q, want %q", tc.area, tc.establishment, got, tc.want)
+		}
+	}
+}
+
+// TestHandleChecklist_CSVPagesByCursor walks the AUT checklist one row per
+// page as CSV, following X-Next-Cursor until it is absent.
+func TestHandleChecklist_CSVPagesByCursor(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	var ids []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		req := httptest.NewRequest(http.MethodGet, "/v1/checklist?area=AUT&limit=1&cursor="+url.QueryEscape(cursor), nil)
+		req.Header.Set("Accept", "text/csv")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("page %d: status = %d, want 200 (body: %s)", page, rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+			t.Fatalf("page %d: Content-Type = %q, want text/csv", page, ct)
+		}
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("page %d: reading CSV: %v", page, err)
+		}
+		if len(records) != 2 || records[0][0] != "family" {
+			t.Fatalf("page %d: records = %q, want the header and one row", page, records)
+		}
+		ids = append(ids, records[1][1])
+		if cursor = rec.Header().Get("X-Next-Cursor"); cursor == "" {
+			break
+		}
+	}
+	want := "wcvp:concept:3082777,wcvp:concept:405825,wcvp:concept:415853"
+	if strings.Join(ids, ",") != want {
+		t.Errorf("paged ids = %v, want %s", ids, want)
+	}
+}
+
+func TestHandleChecklist_Errors(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	for _, path := range []string{
+		"/v1/checklist",
+		"/v1/checklist?area=AUT&limit=5001",
+		"/v1/checklist?area=AUT&limit=all",
+		"/v1/checklist?area=AUT&establishment=extinct",
+		"/v1/checklist?area=AUT&establishment=absent",
+		"/v1/checklist?area=AUT&cursor=not-a-cursor",
+		"/v1/checklist?area=AUT&rank=tribe-ish",
+		"/v1/checklist?area=AUT&entry_backbone=nope",
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status = %d, want 400 (body: %s)", path, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/httperr"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// The `include` tokens GET /v1/concept/{id} and POST /v1/concepts accept,
+// one per request a species card would otherwise make on its own.
+const (
+	includeTraits         = "traits"
+	includeSynonyms       = "synonyms"
+	includeSpaces         = "spaces"
+	includeClassification = "classification"
+)
+
+// nameSpaceEntryDTO is one name-space spelling of a concept. status and
+// resolution follow domain.NameSpaceEntry: omitted when the space recorded
+// no status, and for an exact canonical match, respectively.
+type nameSpaceEntryDTO struct {
+	Space      string `json:"space"`
+	ExtID      string `json:"ext_id"`
+	Name       string `json:"name"`
+	Aggregate  bool   `json:"aggregate"`
+	Status     string `json:"status,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+}
+
+// nameSpaceEntriesDTO is the spaces part of a compound concept, every
+// ingested space's spellings ordered by (space, ext_id).
+type nameSpaceEntriesDTO struct {
+	ConceptID string              `json:"concept_id"`
+	Entries   []nameSpaceEntryDTO `json:"entries"`
+}
+
+// conceptIncludedDTO carries the parts `include` asked for, each in the
+// very shape its own endpoint answers with: traits as GET
+// /v1/concept/{id}/traits, synonyms as GET /v1/concept/{id}/synonyms
+// without parameters. A part not asked for is omitted; one asked for is
+// present even when empty.
+type conceptIncludedDTO struct {
+	Traits   *traitsResponseDTO   `json:"traits,omitempty"`
+	Synonyms *synonymsResponseDTO `json:"synonyms,omitempty"`
+	Spaces   *nameSpaceEntriesDTO `json:"spaces,omitempty"`
+}
+
+// conceptsRequestDTO is the POST /v1/concepts request body.
+type conceptsRequestDTO struct {
+	IDs []string `json:"ids"`
+}
+
+// conceptsResponseDTO is the POST /v1/concepts envelope: one concept per
+// known id in request order, a repeated id once, and the ids that resolved
+// to nothing. Both arrays are always present.
+type conceptsResponseDTO struct {
+	Concepts []conceptDTO `json:"concepts"`
+	NotFound []string     `json:"not_found"`
+}
+
+// handleConcepts serves POST /v1/concepts?include=: many compound concepts
+// in one request, read with one repository query per part rather than one
+// per concept. classification is read only when included, unlike on GET
+// /v1/concept/{id}. A malformed body, an id list that is empty or longer
+// than application.MaxBulkConcepts and an unknown include token report 400
+// INVALID_QUERY; unknown ids are listed in not_found, never a 404.
+func handleConcepts(repo output.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		include, err := parseConceptInclude(r.URL.Query().Get("include"))
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+		var body conceptsRequestDTO
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			httperr.InvalidQueryError(w, "malformed request body")
+			return
+		}
+
+		res, err := application.Concepts(r.Context(), repo, body.IDs, include)
+		if errors.Is(err, application.ErrInvalidConceptCount) {
+			httperr.InvalidQueryError(w, fmt.Sprintf("ids must list 1 to %d concept ids, got %d", application.MaxBulkConcepts, len(body.IDs)))
+			return
+		}
+		if err != nil {
+			httperr.InternalError(w)
+			return
+		}
+		concepts := make([]conceptDTO, len(res.Documents))
+		for i, doc := range res.Documents {
+			concepts[i] = conceptDocumentToDTO(doc, include)
+		}
+		writeJSON(w, conceptsResponseDTO{Concepts: concepts, NotFound: res.NotFound})
+	}
+}
+
+// conceptDocumentToDTO renders one compound concept: conceptToDTO's shape
+// plus its sec. reference and the included parts.
+func conceptDocumentToDTO(doc application.ConceptDocument, include output.ConceptInclude) conceptDTO {
+	dto := conceptToDTO(&doc.Concept, doc.Synonyms, doc.Xrefs, doc.Distribution, doc.Classification)
+	dto.Sec = optionalSecToDTO(doc.Sec)
+	for _, p := range doc.HybridParents {
+		dto.HybridParents = append(dto.HybridParents, hybridParentDTO{Canonical: p.Canonical, ConceptID: p.ConceptID})
+	}
+	if !include.Traits && !include.SynonymCandidates && !include.NameSpaceEntries {
+		return dto
+	}
+	dto.Included = &conceptIncludedDTO{}
+	if include.Traits {
+		traits := traitSetsToDTO(doc.Concept.ID, doc.Traits)
+		dto.Included.Traits = &traits
+	}
+	if doc.RankedSynonyms != nil {
+		synonyms := synonymsToDTO(*doc.RankedSynonyms)
+		dto.Included.Synonyms = &synonyms
+	}
+	if include.NameSpaceEntries {
+		entries := make([]nameSpaceEntryDTO, len(doc.NameSpaceEntries))
+		for i, e := range doc.NameSpaceEntries {
+			entries[i] = nameSpaceEntryDTO{Space: e.Space, ExtID: e.ExtID, Name: e.Name, Aggregate: e.Aggregate, Status: e.Status, Resolution: e.Resolution}
+		}
+		dto.Included.Spaces = &nameSpaceEntriesDTO{ConceptID: doc.Concept.ID, Entries: entries}
+	}
+	return dto
+}
+
+// parseConceptInclude splits the comma-separated `include` parameter into
+// the parts output.ConceptInclude selects. An empty param includes nothing;
+// an unrecognized token is reported by name, as parseTraitVocabs does.
+func parseConceptInclude(param string) (output.ConceptInclude, error) {
+	var include output.ConceptInclude
+	if param == "" {
+		return include, nil
+	}
+	for _, tok := range strings.Split(param, ",") {
+		switch trimmed := strings.TrimSpace(tok); trimmed {
+		case includeTraits:
+			include.Traits = true
+		case includeSynonyms:
+			include.SynonymCandidates = true
+		case includeSpaces:
+			include.NameSpaceEntries = true
+		case includeClassification:
+			include.Classification = true
+		default:
+			return output.ConceptInclude{}, fmt.Errorf("unknown include %q (supported: %s, %s, %s, %s)",
+				trimmed, includeTraits, includeSynonyms, includeSpaces, includeClassification)
+		}
+	}
+	return include, nil
+}
@@ -0,0 +1,145 @@
+package httpx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+)
+
+type compoundConceptResponse struct {
+	conceptResponse
+	Included *struct {
+		Traits   json.RawMessage `json:"traits"`
+		Synonyms json.RawMessage `json:"synonyms"`
+		Spaces   *struct {
+			ConceptID string            `json:"concept_id"`
+			Entries   []json.RawMessage `json:"entries"`
+		} `json:"spaces"`
+	} `json:"included"`
+}
+
+type conceptsResponse struct {
+	Concepts []compoundConceptResponse `json:"concepts"`
+	NotFound []string                  `json:"not_found"`
+}
+
+// compactJSON re-encodes raw without insignificant whitespace, so a part
+// embedded in a compound concept compares byte for byte with the body its
+// own endpoint writes.
+func compactJSON(t *testing.T, raw []byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		t.Fatalf("compacting %s: %v", raw, err)
+	}
+	return buf.String()
+}
+
+// TestHandleConcept_IncludesThePartsAsTheirEndpointsRenderThem reads
+// Corynephorus canescens with every part: traits and synonyms are the very
+// bodies GET .../traits and GET .../synonyms answer with, spaces is present
+// though the fixture ingests no name space, and classification is still
+// there, as it always was.
+func TestHandleConcept_IncludesThePartsAsTheirEndpointsRenderThem(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d, want 200 (body: %s)", path, rec.Code, rec.Body.String())
+		}
+		return rec
+	}
+
+	got := decodeJSON[compoundConceptResponse](t, get("/v1/concept/"+corynephorusConceptID+"?include=traits,synonyms,spaces").Body)
+	if got.Included == nil || got.Included.Spaces == nil {
+		t.Fatalf("included = %+v, want traits, synonyms and spaces", got.Included)
+	}
+	if want := compactJSON(t, get("/v1/concept/"+corynephorusConceptID+"/traits").Body.Bytes()); compactJSON(t, got.Included.Traits) != want {
+		t.Errorf("included.traits = %s, want %s", got.Included.Traits, want)
+	}
+	if want := compactJSON(t, get("/v1/concept/"+corynephorusConceptID+"/synonyms").Body.Bytes()); compactJSON(t, got.Included.Synonyms) != want {
+		t.Errorf("included.synonyms = %s, want %s", got.Included.Synonyms, want)
+	}
+	if got.Included.Spaces.ConceptID != corynephorusConceptID || got.Included.Spaces.Entries == nil || len(got.Included.Spaces.Entries) != 0 {
+		t.Errorf("included.spaces = %+v, want an empty entries array", got.Included.Spaces)
+	}
+	assertCorynephorusCanescensClassification(t, got.conceptResponse)
+
+	plain := get("/v1/concept/" + corynephorusConceptID)
+	if strings.Contains(plain.Body.String(), `"included"`) {
+		t.Errorf("GET without include = %s, want no included", plain.Body.String())
+	}
+}
+
+func TestHandleConcept_UnknownIncludeIsInvalidQuery(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/"+corynephorusConceptID+"?include=traits,vernacular", nil))
+	got := decodeJSON[errorEnvelope](t, rec.Body)
+	if rec.Code != http.StatusBadRequest || got.Error.Code != "INVALID_QUERY" || !strings.Contains(got.Error.Message, `"vernacular"`) {
+		t.Errorf("status = %d, error = %+v; want 400 INVALID_QUERY naming the token", rec.Code, got.Error)
+	}
+}
+
+// TestHandleConcepts_BulkInRequestOrder posts two known ids around an
+// unknown one and a repeat: the concepts come back in request order, once
+// each, the unknown id in not_found, and classification only on request.
+func TestHandleConcepts_BulkInRequestOrder(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+	post := func(query, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/concepts"+query, strings.NewReader(body)))
+		return rec
+	}
+
+	body := `{"ids": ["wcvp:concept:415853", "wcvp:concept:0", "` + corynephorusConceptID + `", "wcvp:concept:415853"]}`
+	rec := post("?include=synonyms", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	got := decodeJSON[conceptsResponse](t, rec.Body)
+	if len(got.Concepts) != 2 || got.Concepts[0].Canonical != "Festuca ovina" || got.Concepts[1].ConceptID != corynephorusConceptID {
+		t.Fatalf("concepts = %+v, want Festuca ovina, then Corynephorus canescens", got.Concepts)
+	}
+	if len(got.NotFound) != 1 || got.NotFound[0] != "wcvp:concept:0" {
+		t.Errorf("not_found = %v, want [wcvp:concept:0]", got.NotFound)
+	}
+	for _, c := range got.Concepts {
+		if c.Included == nil || c.Included.Synonyms == nil || c.Included.Traits != nil || c.Included.Spaces != nil {
+			t.Errorf("%s: included = %+v, want synonyms only", c.ConceptID, c.Included)
+		}
+		if c.Classification != nil {
+			t.Errorf("%s: classification = %+v, want none without include=classification", c.ConceptID, c.Classification)
+		}
+	}
+
+	got = decodeJSON[conceptsResponse](t, post("?include=classification", `{"ids": ["`+corynephorusConceptID+`"]}`).Body)
+	if len(got.Concepts) != 1 || got.Concepts[0].Included != nil || got.NotFound == nil {
+		t.Fatalf("include=classification: %+v, want one concept without included and an empty not_found", got)
+	}
+	assertCorynephorusCanescensClassification(t, got.Concepts[0].conceptResponse)
+}
+
+func TestHandleConcepts_RejectsBadRequests(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	for _, tc := range []struct{ name, query, body string }{
+		{"malformed body", "", `{"ids": `},
+		{"no ids", "", `{"ids": []}`},
+		{"too many ids", "", `{"ids": [` + strings.TrimSuffix(strings.Repeat(`"x",`, 501), ",") + `]}`},
+		{"unknown include", "?include=everything", `{"ids": ["` + corynephorusConceptID + `"]}`},
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/concepts"+tc.query, strings.NewReader(tc.body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400 (body: %s)", tc.name, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package httpx_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// TestHandleMatch_AreaAddsRangeFlags pins the wire shape of the range flags:
+// absent without an area, and with one always BOTH present — false included —
+// so a client never mistakes a missing outside_known_range for "fine".
+func TestHandleMatch_AreaAddsRangeFlags(t *testing.T) {
+	db := seededRepo(t)
+
+	rr := postMatch(t, db, `{"names":[{"id":"1","verbatim":"Corynephorus canescens"}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	plain := rawResults(t, rr)[0]
+	for _, field := range []string{"in_area", "outside_known_range"} {
+		if _, present := plain[field]; present {
+			t.Errorf("%s present without area", field)
+		}
+	}
+
+	rr = postMatch(t, db, `{"area":"AUT","names":[{"id":"1","verbatim":"Corynephorus canescens"}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	res := rawResults(t, rr)[0]
+	var inArea, outside bool
+	if err := json.Unmarshal(res["in_area"], &inArea); err != nil {
+		t.Fatalf("in_area: %v (result: %s)", err, res["in_area"])
+	}
+	if err := json.Unmarshal(res["outside_known_range"], &outside); err != nil {
+		t.Fatalf("outside_known_range: %v (result: %s)", err, res["outside_known_range"])
+	}
+	if !inArea || outside {
+		t.Errorf("in_area = %v, outside_known_range = %v; want true, false", inArea, outside)
+	}
+}
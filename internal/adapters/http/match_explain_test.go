@@ -0,0 +1,97 @@
+package httpx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+)
+
+// postMatchQuery is postMatch with a query string on the URL.
+func postMatchQuery(t *testing.T, db *sqlite.DB, query, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httpx.NewRouter(httpx.Deps{Repo: db})
+	rr := httptest.NewRecorder()
+	rr.Body = new(bytes.Buffer)
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/match?"+query, bytes.NewBufferString(body)))
+	return rr
+}
+
+// TestHandleMatch_ExplainIsOptIn pins that the plain response carries no
+// explain object at all — the shape every existing client parses is
+// unchanged.
+func TestHandleMatch_ExplainIsOptIn(t *testing.T) {
+	db := seededRepo(t)
+	rr := postMatch(t, db, `{"names":[{"id":"1","verbatim":"Corynephorus canescens"}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	if _, present := rawResults(t, rr)[0]["explain"]; present {
+		t.Error("explain present without ?explain=true")
+	}
+}
+
+// TestHandleMatch_ExplainTracesTheFilteredLookup renders a trace end to end:
+// the filter echo, the one exact lookup, the candidate the filter removed and
+// the decision the remaining one produced.
+func TestHandleMatch_ExplainTracesTheFilteredLookup(t *testing.T) {
+	db := seededRepo(t)
+	seedDuplicateCorynephorusInBackbone(t, db)
+
+	rr := postMatchQuery(t, db, "explain=true",
+		`{"entry_backbone":"wcvp","names":[{"id":"1","verbatim":"Corynephorus canescens (L.) P.Beauv."}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	var explain struct {
+		Canonical string `json:"canonical"`
+		Author    string `json:"author"`
+		Path      string `json:"path"`
+		Filter    struct {
+			EntryBackbone string `json:"entry_backbone"`
+		} `json:"filter"`
+		Lookups []struct {
+			Key        string `json:"key"`
+			Rule       string `json:"rule"`
+			Candidates []struct {
+				ConceptID string `json:"concept_id"`
+				Backbone  string `json:"backbone"`
+			} `json:"candidates"`
+			FilteredOut int    `json:"filtered_out"`
+			Decision    string `json:"decision"`
+		} `json:"lookups"`
+	}
+	if err := json.Unmarshal(rawResults(t, rr)[0]["explain"], &explain); err != nil {
+		t.Fatalf("decoding explain: %v", err)
+	}
+	if explain.Path != "species" || explain.Canonical != "Corynephorus canescens" || explain.Author != "(L.) P.Beauv." {
+		t.Errorf("parsed = (%q, %q, %q), want (species, Corynephorus canescens, (L.) P.Beauv.)", explain.Path, explain.Canonical, explain.Author)
+	}
+	if explain.Filter.EntryBackbone != "wcvp" {
+		t.Errorf("filter.entry_backbone = %q, want wcvp", explain.Filter.EntryBackbone)
+	}
+	if len(explain.Lookups) != 1 {
+		t.Fatalf("lookups = %+v, want exactly the one exact lookup", explain.Lookups)
+	}
+	l := explain.Lookups[0]
+	if l.Rule != "exact" || l.Key != "corynephorus canescens" || l.FilteredOut != 1 || l.Decision != "single_concept" {
+		t.Errorf("lookup = %+v, want exact on the folded key, one filtered out, single_concept", l)
+	}
+	if len(l.Candidates) != 1 || l.Candidates[0].ConceptID != corynephorusConceptID || l.Candidates[0].Backbone != "wcvp" {
+		t.Errorf("candidates = %+v, want only the WCVP concept", l.Candidates)
+	}
+}
+
+// TestHandleMatch_ExplainRejectsGarbage pins that a mistyped flag is a 400,
+// not a silent "false" that looks like an empty trace.
+func TestHandleMatch_ExplainRejectsGarbage(t *testing.T) {
+	db := seededRepo(t)
+	rr := postMatchQuery(t, db, "explain=ture", `{"names":[{"id":"1","verbatim":"Corynephorus canescens"}]}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rr.Code)
+	}
+}
@@ -0,0 +1,465 @@
+package httpx
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/httperr"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// matchFormat is a wire format POST /v1/match reads or writes.
+type matchFormat int
+
+const (
+	matchFormatJSON matchFormat = iota
+	matchFormatCSV
+	matchFormatNDJSON
+)
+
+// The media types a batch tool actually sends. "application/ndjson" is the
+// newer registration of the same format; both are read, x-ndjson is written.
+const (
+	mediaCSV          = "text/csv"
+	mediaNDJSON       = "application/x-ndjson"
+	mediaNDJSONLegacy = "application/ndjson"
+)
+
+// Defaults for the id_column / verbatim_column query parameters — the field
+// names of the JSON body, so one input file fits every format.
+const (
+	defaultIDColumn       = "id"
+	defaultVerbatimColumn = "verbatim"
+)
+
+// backboneVersionsHeader carries backbone_versions on a streamed response,
+// which has no envelope to put them in: "cdm=2025-10,wcvp=14", sorted by id.
+const backboneVersionsHeader = "X-Backbone-Versions"
+
+// matchErrorTrailer reports a failure that struck after the first row was
+// written, when the status is long sent. An NDJSON body additionally ends in
+// an error line; a CSV body has no room for one and simply ends early.
+const matchErrorTrailer = "X-Match-Error"
+
+// errMatchInput marks a malformed row or header in a streamed body: a 400
+// while nothing has been written, the trailer afterwards.
+var errMatchInput = errors.New("malformed match input")
+
+// matchInputFormat reads the request's Content-Type. Only CSV and NDJSON
+// switch format; anything else — including none and curl's form default — is
+// the JSON body every client has always sent, so no existing caller breaks.
+func matchInputFormat(contentType string) matchFormat {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return matchFormatJSON
+	}
+	return mediaFormat(mt)
+}
+
+// matchOutputFormat picks the first CSV, NDJSON or JSON range from Accept, in
+// the order the client listed them. No Accept, "*/*" or nothing hostus can
+// write is JSON — the unchanged response, never a 406.
+func matchOutputFormat(accept string) matchFormat {
+	for _, part := range strings.Split(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if f := mediaFormat(mt); f != matchFormatJSON || mt == "application/json" || mt == "*/*" {
+			return f
+		}
+	}
+	return matchFormatJSON
+}
+
+func mediaFormat(mt string) matchFormat {
+	switch strings.ToLower(mt) {
+	case mediaCSV:
+		return matchFormatCSV
+	case mediaNDJSON, mediaNDJSONLegacy:
+		return matchFormatNDJSON
+	}
+	return matchFormatJSON
+}
+
+// streamMatch serves every POST /v1/match that is not JSON in and JSON out.
+// The options come from the JSON body when there is one, otherwise from the
+// query string under the same names. Validation, the CSV header and the
+// backbone versions are all settled before the first row is resolved, so
+// each of them can still fail as a proper 4xx/5xx — lat/lon's area included.
+func streamMatch(w http.ResponseWriter, r *http.Request, repo output.Repository, loc output.AreaLocator, in, out matchFormat, explain bool) {
+	if explain && out == matchFormatCSV {
+		httperr.InvalidQueryError(w, "explain is not available as text/csv; request application/x-ndjson or application/json")
+		return
+	}
+
+	var (
+		opts   matchRequestDTO
+		source application.MatchSource
+		err    error
+	)
+	q := r.URL.Query()
+	switch in {
+	case matchFormatCSV:
+		opts, err = matchOptionsFromQuery(q.Get)
+		if err == nil {
+			source, err = csvMatchSource(r.Body, q.Get("id_column"), q.Get("verbatim_column"), q.Get("delimiter"))
+		}
+	case matchFormatNDJSON:
+		opts, err = matchOptionsFromQuery(q.Get)
+		source = ndjsonMatchSource(r.Body, q.Get("id_column"), q.Get("verbatim_column"))
+	default:
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			httperr.InvalidQueryError(w, "malformed request body")
+			return
+		}
+		source = sliceMatchSource(opts.Names)
+	}
+	if err != nil {
+		httperr.InvalidQueryError(w, err.Error())
+		return
+	}
+	if opts.PublishedBefore < 0 {
+		httperr.InvalidQueryError(w, errPublishedBefore.Error())
+		return
+	}
+	if opts.Area, err = areaFilter(loc, opts.Area, opts.Lat, opts.Lon); err != nil {
+		writePositionError(w, err)
+		return
+	}
+
+	versions, err := backboneVersionMap(r, repo)
+	if err != nil {
+		httperr.InternalError(w)
+		return
+	}
+
+	// HTTP/1.x servers stop reading the request body once the response has
+	// started; a streamed reply interleaves the two by design.
+	_ = http.NewResponseController(w).EnableFullDuplex()
+
+	sw := &matchStreamWriter{w: w, format: out, versions: versions, targetSpace: opts.TargetSpace != "", area: opts.Area != ""}
+	err = application.StreamMatches(r.Context(), repo, source, sw.emit, opts.TargetSpace,
+		application.MatchFilter{Backbone: opts.EntryBackbone, Sec: opts.EntrySec, Area: opts.Area, PublishedBefore: opts.PublishedBefore}, explain)
+	if err == nil {
+		err = sw.finish()
+	}
+	if err == nil {
+		return
+	}
+	if sw.started {
+		sw.fail(err)
+		return
+	}
+	if errors.Is(err, errMatchInput) {
+		httperr.InvalidQueryError(w, err.Error())
+		return
+	}
+	writeMatchError(w, err, opts)
+}
+
+// matchOptionsFromQuery reads the request-level fields of matchRequestDTO
+// from the query string, for bodies that carry only rows. Only lat/lon
+// (parsePosition) and published_before (parsePublishedBefore) can fail to
+// parse.
+func matchOptionsFromQuery(get func(string) string) (matchRequestDTO, error) {
+	lat, lon, err := parsePosition(get("lat"), get("lon"))
+	if err != nil {
+		return matchRequestDTO{}, err
+	}
+	publishedBefore, err := parsePublishedBefore(get("published_before"))
+	return matchRequestDTO{
+		TargetSpace:     get("target_space"),
+		EntryBackbone:   get("entry_backbone"),
+		EntrySec:        get("entry_sec"),
+		Area:            get("area"),
+		Lat:             lat,
+		Lon:             lon,
+		PublishedBefore: publishedBefore,
+	}, err
+}
+
+// backboneVersionMap is the backbone_versions object both response shapes
+// carry.
+func backboneVersionMap(r *http.Request, repo output.Repository) (map[string]string, error) {
+	versions, err := repo.BackboneVersions(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(versions))
+	for _, v := range versions {
+		out[v.ID] = v.Version
+	}
+	return out, nil
+}
+
+func sliceMatchSource(names []matchNameDTO) application.MatchSource {
+	i := 0
+	return func() (application.MatchRequest, error) {
+		if i >= len(names) {
+			return application.MatchRequest{}, io.EOF
+		}
+		n := names[i]
+		i++
+		return application.MatchRequest{ID: n.ID, Verbatim: n.Verbatim}, nil
+	}
+}
+
+// csvMatchSource reads a CSV body with a header row. The verbatim column is
+// required; without an id column each row's id is its 1-based data row
+// number, so results can still be joined back. The delimiter defaults to ','
+// — ';' is what a German spreadsheet exports — and must be one character.
+// Extra columns are ignored, a short row is a client error.
+func csvMatchSource(body io.Reader, idColumn, verbatimColumn, delimiter string) (application.MatchSource, error) {
+	if idColumn == "" {
+		idColumn = defaultIDColumn
+	}
+	if verbatimColumn == "" {
+		verbatimColumn = defaultVerbatimColumn
+	}
+	cr := csv.NewReader(body)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1 // checked per row below, with a clearer message
+	if delimiter != "" {
+		d, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) || d == '"' || d == '\n' || d == '\r' {
+			return nil, fmt.Errorf("%w: delimiter must be a single character", errMatchInput)
+		}
+		cr.Comma = d
+	}
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading the CSV header: %v", errMatchInput, err)
+	}
+	idIdx, verbatimIdx := -1, -1
+	for i, h := range header {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		if h == idColumn && idIdx < 0 {
+			idIdx = i
+		}
+		if h == verbatimColumn && verbatimIdx < 0 {
+			verbatimIdx = i
+		}
+	}
+	if verbatimIdx < 0 {
+		return nil, fmt.Errorf("%w: CSV header has no %q column", errMatchInput, verbatimColumn)
+	}
+
+	row := 0
+	return func() (application.MatchRequest, error) {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return application.MatchRequest{}, io.EOF
+		}
+		row++
+		if err != nil {
+			return application.MatchRequest{}, fmt.Errorf("%w: CSV row %d: %v", errMatchInput, row, err)
+		}
+		if verbatimIdx >= len(rec) || (idIdx >= 0 && idIdx >= len(rec)) {
+			return application.MatchRequest{}, fmt.Errorf("%w: CSV row %d has %d fields, fewer than the header", errMatchInput, row, len(rec))
+		}
+		req := application.MatchRequest{ID: strconv.Itoa(row), Verbatim: rec[verbatimIdx]}
+		if idIdx >= 0 {
+			req.ID = rec[idIdx]
+		}
+		return req, nil
+	}, nil
+}
+
+// ndjsonMatchSource reads one JSON object per line. The id may be a string or
+// a number (an ETL export's row key often is one) and defaults to the 1-based
+// line number like the CSV source's; the verbatim must be a string.
+func ndjsonMatchSource(body io.Reader, idField, verbatimField string) application.MatchSource {
+	if idField == "" {
+		idField = defaultIDColumn
+	}
+	if verbatimField == "" {
+		verbatimField = defaultVerbatimColumn
+	}
+	dec := json.NewDecoder(body)
+	dec.UseNumber()
+	row := 0
+	return func() (application.MatchRequest, error) {
+		var obj map[string]any
+		err := dec.Decode(&obj)
+		if err == io.EOF {
+			return application.MatchRequest{}, io.EOF
+		}
+		row++
+		if err != nil {
+			return application.MatchRequest{}, fmt.Errorf("%w: NDJSON line %d: %v", errMatchInput, row, err)
+		}
+		req := application.MatchRequest{ID: strconv.Itoa(row)}
+		switch v := obj[verbatimField].(type) {
+		case string:
+			req.Verbatim = v
+		case nil:
+		default:
+			return application.MatchRequest{}, fmt.Errorf("%w: NDJSON line %d: %q is not a string", errMatchInput, row, verbatimField)
+		}
+		switch v := obj[idField].(type) {
+		case string:
+			req.ID = v
+		case json.Number:
+			req.ID = v.String()
+		case nil:
+		default:
+			return application.MatchRequest{}, fmt.Errorf("%w: NDJSON line %d: %q is neither a string nor a number", errMatchInput, row, idField)
+		}
+		return req, nil
+	}
+}
+
+// matchStreamWriter renders results as they arrive. Nothing — status,
+// headers, the CSV header row — is written before the first result (or
+// finish, for an empty batch), so every error up to then is still an ordinary
+// error response. JSON output is the one exception to streaming: a client
+// that asked for one document gets the usual envelope, assembled at finish.
+type matchStreamWriter struct {
+	w           http.ResponseWriter
+	format      matchFormat
+	versions    map[string]string
+	targetSpace bool
+	area        bool
+
+	started bool
+	csv     *csv.Writer
+	results []matchResultDTO
+}
+
+func (s *matchStreamWriter) emit(res application.MatchResult) error {
+	dto := matchResultsToDTO([]application.MatchResult{res}, s.targetSpace)[0]
+	if s.format == matchFormatJSON {
+		s.results = append(s.results, dto)
+		return nil
+	}
+	s.start()
+	if s.format == matchFormatCSV {
+		if err := s.csv.Write(s.csvRow(dto)); err != nil {
+			return err
+		}
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return err
+		}
+	} else if err := json.NewEncoder(s.w).Encode(dto); err != nil {
+		return err
+	}
+	_ = http.NewResponseController(s.w).Flush()
+	return nil
+}
+
+func (s *matchStreamWriter) finish() error {
+	if s.format == matchFormatJSON {
+		results := s.results
+		if results == nil {
+			results = []matchResultDTO{}
+		}
+		writeJSON(s.w, matchResponseDTO{BackboneVersions: s.versions, Results: results})
+		return nil
+	}
+	s.start()
+	return nil
+}
+
+// start writes the status and headers, and the CSV header row, once.
+func (s *matchStreamWriter) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+	h := s.w.Header()
+	h.Set("Trailer", matchErrorTrailer)
+	h.Set(backboneVersionsHeader, formatBackboneVersions(s.versions))
+	if s.format == matchFormatCSV {
+		h.Set("Content-Type", mediaCSV+"; charset=utf-8")
+		s.w.WriteHeader(http.StatusOK)
+		s.csv = csv.NewWriter(s.w)
+		_ = s.csv.Write(s.csvColumns())
+		s.csv.Flush()
+		return
+	}
+	h.Set("Content-Type", mediaNDJSON)
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// fail reports an error that arrived after start. The message of a client
+// error is the client's to see; anything else stays as opaque as
+// httperr.InternalError.
+func (s *matchStreamWriter) fail(err error) {
+	detail := httperr.Detail{Code: httperr.Internal, Message: "Internal server error"}
+	if errors.Is(err, errMatchInput) {
+		detail = httperr.Detail{Code: httperr.InvalidQuery, Message: err.Error()}
+	}
+	if s.format == matchFormatNDJSON {
+		_ = json.NewEncoder(s.w).Encode(httperr.Response{Error: detail})
+	}
+	s.w.Header().Set(matchErrorTrailer, string(detail.Code)+": "+detail.Message)
+}
+
+// csvColumns mirrors matchResultDTO's field names; the optional groups appear
+// under the same conditions as their JSON fields. explain has no CSV form.
+func (s *matchStreamWriter) csvColumns() []string {
+	cols := []string{"id", "match_type", "confidence", "concept_id", "candidates", "requires_review", "note", "family", "genus"}
+	if s.targetSpace {
+		cols = append(cols, "target_space_name", "aggregate_policy", "esy_diagnostic_relevance")
+	}
+	if s.area {
+		cols = append(cols, "in_area", "outside_known_range")
+	}
+	return cols
+}
+
+// csvRow renders dto in csvColumns order. Candidates are joined with '|',
+// which no scientific name contains; an absent flag is an empty cell.
+func (s *matchStreamWriter) csvRow(dto matchResultDTO) []string {
+	row := []string{
+		dto.ID,
+		dto.MatchType,
+		strconv.FormatFloat(dto.Confidence, 'f', -1, 64),
+		dto.ConceptID,
+		strings.Join(dto.Candidates, "|"),
+		strconv.FormatBool(dto.RequiresReview),
+		dto.Note,
+		dto.Family,
+		dto.Genus,
+	}
+	if s.targetSpace {
+		row = append(row, dto.TargetSpaceName, dto.AggregatePolicy, dto.ESyDiagnosticRelevance)
+	}
+	if s.area {
+		row = append(row, optionalBool(dto.InArea), optionalBool(dto.OutsideKnownRange))
+	}
+	return row
+}
+
+func optionalBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func formatBackboneVersions(versions map[string]string) string {
+	ids := make([]string, 0, len(versions))
+	for id := range versions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	pairs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		pairs = append(pairs, id+"="+versions[id])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,189 @@
+package httpx_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+)
+
+// postMatchAs posts body to /v1/match?query with the given Content-Type and
+// Accept (either may be empty) through a real httptest server, so trailers
+// and streaming behave as they do on the wire.
+func postMatchAs(t *testing.T, db *sqlite.DB, query, contentType, accept, body string) *http.Response {
+	t.Helper()
+	srv := httptest.NewServer(httpx.NewRouter(httpx.Deps{Repo: db}))
+	t.Cleanup(srv.Close)
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/v1/match?"+query, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+// ndjsonLines decodes every line of an NDJSON body into a raw map.
+func ndjsonLines(t *testing.T, resp *http.Response) []map[string]json.RawMessage {
+	t.Helper()
+	var out []map[string]json.RawMessage
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			t.Fatalf("decoding NDJSON line %q: %v", sc.Text(), err)
+		}
+		out = append(out, m)
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// TestHandleMatch_CSVInCSVOut is the spreadsheet round trip: a
+// semicolon-separated export with its own column names, answered as CSV in
+// input order.
+func TestHandleMatch_CSVInCSVOut(t *testing.T) {
+	db := seededRepo(t)
+	body := "Nr;Art;Bemerkung\n7;Corynephorus canescens;Sand\n8;Nonexistus bogus;\n"
+
+	resp := postMatchAs(t, db, "id_column=Nr&verbatim_column=Art&delimiter=%3B", "text/csv", "text/csv", body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+	if v := resp.Header.Get("X-Backbone-Versions"); v != "wcvp=2026-06-15" {
+		t.Errorf("X-Backbone-Versions = %q, want wcvp=2026-06-15", v)
+	}
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV response: %v", err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != "id,match_type,confidence,concept_id,candidates,requires_review,note,family,genus" {
+		t.Fatalf("rows = %v, want the header and two results", rows)
+	}
+	if rows[1][0] != "7" || rows[1][1] != "exact" || rows[1][3] != corynephorusConceptID || rows[1][7] != "Poaceae" || rows[1][8] != "Corynephorus" {
+		t.Errorf("row 1 = %v, want id 7 exact on %s in Poaceae, Corynephorus", rows[1], corynephorusConceptID)
+	}
+	if rows[2][0] != "8" || rows[2][1] != "unresolvable" || rows[2][5] != "true" {
+		t.Errorf("row 2 = %v, want id 8 unresolvable for review", rows[2])
+	}
+}
+
+// TestHandleMatch_NDJSONInNDJSONOut pins one line per row, numeric ids
+// accepted, and the row number standing in for a missing id.
+func TestHandleMatch_NDJSONInNDJSONOut(t *testing.T) {
+	db := seededRepo(t)
+	body := `{"id":41,"verbatim":"Corynephorus canescens"}` + "\n" + `{"verbatim":"Nonexistus bogus"}` + "\n"
+
+	resp := postMatchAs(t, db, "", "application/x-ndjson", "application/x-ndjson", body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	lines := ndjsonLines(t, resp)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if string(lines[0]["id"]) != `"41"` || string(lines[0]["concept_id"]) != `"`+corynephorusConceptID+`"` {
+		t.Errorf("line 1 = %v, want id 41 on %s", lines[0], corynephorusConceptID)
+	}
+	if string(lines[1]["id"]) != `"2"` || string(lines[1]["match_type"]) != `"unresolvable"` {
+		t.Errorf("line 2 = %v, want id 2 (the row number), unresolvable", lines[1])
+	}
+	if got := resp.Trailer.Get("X-Match-Error"); got != "" {
+		t.Errorf("X-Match-Error = %q on a clean stream", got)
+	}
+}
+
+// TestHandleMatch_JSONInNDJSONOut pins that a JSON body can be streamed back,
+// with its options taken from the body as usual.
+func TestHandleMatch_JSONInNDJSONOut(t *testing.T) {
+	db := seededRepo(t)
+	body := `{"area":"DE","names":[{"id":"1","verbatim":"Corynephorus canescens"}]}`
+
+	resp := postMatchAs(t, db, "", "application/json", "application/x-ndjson", body)
+	lines := ndjsonLines(t, resp)
+	if len(lines) != 1 || lines[0]["in_area"] == nil || lines[0]["outside_known_range"] == nil {
+		t.Errorf("lines = %v, want one result carrying the area flags", lines)
+	}
+}
+
+// TestHandleMatch_CSVInJSONOut pins that a CSV body without Accept gets the
+// usual envelope — a client that asked for one document gets one.
+func TestHandleMatch_CSVInJSONOut(t *testing.T) {
+	db := seededRepo(t)
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/match", bytes.NewBufferString("verbatim\nCorynephorus canescens\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	httpx.NewRouter(httpx.Deps{Repo: db}).ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	results := rawResults(t, rr)
+	if len(results) != 1 || string(results[0]["id"]) != `"1"` {
+		t.Errorf("results = %v, want one result with the row number as id", results)
+	}
+}
+
+// TestHandleMatch_StreamRejectsBeforeWriting covers the errors that must
+// still be ordinary 400s: nothing has been written when they are found.
+func TestHandleMatch_StreamRejectsBeforeWriting(t *testing.T) {
+	db := seededRepo(t)
+	tests := []struct {
+		name, query, contentType, accept, body string
+	}{
+		{"no verbatim column", "", "text/csv", "text/csv", "id,name\n1,Corynephorus canescens\n"},
+		{"unknown filter from the query", "entry_backbone=nope", "text/csv", "text/csv", "verbatim\nCorynephorus canescens\n"},
+		{"explain has no CSV form", "explain=true", "", "text/csv", `{"names":[{"id":"1","verbatim":"Corynephorus canescens"}]}`},
+		{"malformed first row", "", "application/x-ndjson", "application/x-ndjson", "{not json\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := postMatchAs(t, db, tc.query, tc.contentType, tc.accept, tc.body)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", resp.StatusCode)
+			}
+		})
+	}
+}
+
+// TestHandleMatch_StreamReportsLateErrors pins what a client sees when a row
+// past the first is malformed: the good rows, an error line and the trailer.
+func TestHandleMatch_StreamReportsLateErrors(t *testing.T) {
+	db := seededRepo(t)
+	body := `{"id":"1","verbatim":"Corynephorus canescens"}` + "\n" + `{"id":"2","verbatim":42}` + "\n"
+
+	resp := postMatchAs(t, db, "", "application/x-ndjson", "application/x-ndjson", body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (the first row was already sent)", resp.StatusCode)
+	}
+	lines := ndjsonLines(t, resp)
+	if len(lines) != 2 || string(lines[0]["id"]) != `"1"` {
+		t.Fatalf("lines = %v, want the first result and an error line", lines)
+	}
+	if _, ok := lines[1]["error"]; !ok {
+		t.Errorf("last line = %v, want an error envelope", lines[1])
+	}
+	if got := resp.Trailer.Get("X-Match-Error"); !strings.HasPrefix(got, "INVALID_QUERY") {
+		t.Errorf("X-Match-Error = %q, want an INVALID_QUERY report", got)
+	}
+}
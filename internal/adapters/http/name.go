@@ -0,0 +1,206 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/httperr"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// nameDTO is one name in a GET /v1/name/{id} response. nom_status and
+// nom_status_judgement follow synonymDetailDTO's rule: the raw cell is
+// omitted when the source recorded nothing, the judgement is always present
+// and says `absent` for exactly that case.
+type nameDTO struct {
+	NameID     string `json:"name_id"`
+	Canonical  string `json:"canonical"`
+	Authorship string `json:"authorship,omitempty"`
+	Rank       string `json:"rank"`
+	// RankVerbatim is set only for rank OTHER, as on conceptDTO.
+	RankVerbatim string `json:"rank_verbatim,omitempty"`
+	IPNIID       string `json:"ipni_id,omitempty"`
+	PublishedIn  string `json:"published_in,omitempty"`
+	// Publication is PublishedIn split into its parts, omitted when nothing
+	// could be read from it (see publicationToDTO).
+	Publication        *publicationDTO `json:"publication,omitempty"`
+	BasionymID         string          `json:"basionym_id,omitempty"`
+	NomStatus          string          `json:"nom_status,omitempty"`
+	NomStatusJudgement string          `json:"nom_status_judgement"`
+}
+
+// nameUsageDTO is one concept that uses the name name_id. sec is omitted
+// for a backbone without sec. reference spaces, as on conceptDTO; homotypic
+// is omitted unless concept_name.homotypic is known, as on synonymDTO; the
+// accepted_* fields repeat the name itself for an accepted usage.
+type nameUsageDTO struct {
+	NameID             string           `json:"name_id"`
+	ConceptID          string           `json:"concept_id"`
+	Backbone           string           `json:"backbone"`
+	Sec                *secReferenceDTO `json:"sec,omitempty"`
+	ConceptStatus      string           `json:"concept_status"`
+	Role               string           `json:"role"`
+	Homotypic          *bool            `json:"homotypic,omitempty"`
+	AcceptedNameID     string           `json:"accepted_name_id"`
+	AcceptedCanonical  string           `json:"accepted_canonical"`
+	AcceptedAuthorship string           `json:"accepted_authorship,omitempty"`
+}
+
+// nameResponseDTO is the GET /v1/name/{id} envelope. The three arrays are
+// always present: an empty basionym_chain is the answer "no basionym
+// recorded", and homotypic_group always holds at least the name itself.
+type nameResponseDTO struct {
+	Name nameDTO `json:"name"`
+	// NomStatusReason is domain.NomStatusVerdict.Reason for name, the
+	// sentence a reviewer reads to check the judgement.
+	NomStatusReason string         `json:"nom_status_reason"`
+	BasionymChain   []nameDTO      `json:"basionym_chain"`
+	HomotypicGroup  []nameDTO      `json:"homotypic_group"`
+	Usages          []nameUsageDTO `json:"usages"`
+}
+
+// handleName serves GET /v1/name/{id}: the name, its nom_status verdict,
+// its basionym chain, its homotypic group and every concept that uses it.
+// An unknown name id is 404 NOT_FOUND.
+func handleName(repo output.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := application.Name(r.Context(), repo, mux.Vars(r)["id"])
+		if errors.Is(err, domain.ErrNotFound) {
+			httperr.Write(w, http.StatusNotFound, httperr.NotFound, "name not found")
+			return
+		}
+		if err != nil {
+			httperr.InternalError(w)
+			return
+		}
+		writeJSON(w, nameResponseDTO{
+			Name:            nameToDTO(res.Name),
+			NomStatusReason: res.Name.Status.Reason(),
+			BasionymChain:   namesToDTO(res.BasionymChain),
+			HomotypicGroup:  namesToDTO(res.HomotypicGroup),
+			Usages:          usagesToDTO(res.Usages),
+		})
+	}
+}
+
+// usageGroupDTO is one backbone and sec. reference space's usages in a GET
+// /v1/names/{name}/usages response.
+type usageGroupDTO struct {
+	Backbone string           `json:"backbone"`
+	Sec      *secReferenceDTO `json:"sec,omitempty"`
+	Usages   []nameUsageDTO   `json:"usages"`
+}
+
+// nameUsagesResponseDTO is the GET /v1/names/{name}/usages envelope. names
+// lists every name the path resolved to — one for a name id, possibly
+// several for a canonical — so a usage's name_id can be read against its
+// authorship; groups is an empty array when no concept uses any of them.
+type nameUsagesResponseDTO struct {
+	Query  string          `json:"query"`
+	Names  []nameDTO       `json:"names"`
+	Groups []usageGroupDTO `json:"groups"`
+}
+
+// handleNameUsages serves GET /v1/names/{name}/usages: every concept that
+// lists the name as accepted or synonym, grouped by backbone and sec.
+// reference. {name} is a name id when one exists, else a canonical name,
+// matched folded as /v1/match does. A name that resolves to nothing is 404
+// NOT_FOUND.
+func handleNameUsages(repo output.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := application.NameUsages(r.Context(), repo, mux.Vars(r)["name"])
+		if errors.Is(err, domain.ErrNotFound) {
+			httperr.Write(w, http.StatusNotFound, httperr.NotFound, "name not found")
+			return
+		}
+		if err != nil {
+			httperr.InternalError(w)
+			return
+		}
+		groups := make([]usageGroupDTO, len(res.Groups))
+		for i, g := range res.Groups {
+			groups[i] = usageGroupDTO{Backbone: g.BackboneID, Sec: optionalSecToDTO(g.SecReference), Usages: usagesToDTO(g.Usages)}
+		}
+		writeJSON(w, nameUsagesResponseDTO{Query: res.Key, Names: namesToDTO(res.Names), Groups: groups})
+	}
+}
+
+func usagesToDTO(usages []output.NameUsage) []nameUsageDTO {
+	out := make([]nameUsageDTO, len(usages))
+	for i, u := range usages {
+		out[i] = nameUsageDTO{
+			NameID:             u.NameID,
+			ConceptID:          u.ConceptID,
+			Backbone:           u.BackboneID,
+			Sec:                optionalSecToDTO(u.SecReference),
+			ConceptStatus:      string(u.ConceptStatus),
+			Role:               u.Role,
+			Homotypic:          u.Homotypic,
+			AcceptedNameID:     u.Accepted.ID,
+			AcceptedCanonical:  u.Accepted.Canonical,
+			AcceptedAuthorship: u.Accepted.Authorship,
+		}
+	}
+	return out
+}
+
+// optionalSecToDTO renders sec like secToDTO, or nil for the zero reference
+// of a backbone without sec. reference spaces.
+func optionalSecToDTO(sec domain.SecReference) *secReferenceDTO {
+	if sec.IsZero() {
+		return nil
+	}
+	dto := secToDTO(sec)
+	return &dto
+}
+
+// publicationDTO is a name's place of publication split into its parts
+// (domain.Publication); a part the citation does not carry is omitted.
+type publicationDTO struct {
+	Title  string `json:"title,omitempty"`
+	Volume string `json:"volume,omitempty"`
+	Pages  string `json:"pages,omitempty"`
+	Year   int    `json:"year,omitempty"`
+}
+
+// publicationToDTO parses n.PublishedIn, nil when nothing could be read from
+// it. The year stored at ingest wins over the parsed one; the parse is the
+// fallback for an index ingested before name.published_year existed.
+func publicationToDTO(n domain.Name) *publicationDTO {
+	p := domain.ParsePublication(n.PublishedIn)
+	if n.PublishedYear > 0 {
+		p.Year = n.PublishedYear
+	}
+	if p.IsZero() {
+		return nil
+	}
+	return &publicationDTO{Title: p.Title, Volume: p.Volume, Pages: p.Pages, Year: p.Year}
+}
+
+func nameToDTO(n application.ClassifiedName) nameDTO {
+	return nameDTO{
+		NameID:             n.ID,
+		Canonical:          n.Canonical,
+		Authorship:         n.Authorship,
+		Rank:               string(n.Rank),
+		RankVerbatim:       n.RankVerbatim,
+		IPNIID:             n.IPNIID,
+		PublishedIn:        n.PublishedIn,
+		Publication:        publicationToDTO(n.Name),
+		BasionymID:         n.BasionymID,
+		NomStatus:          n.NomStatus,
+		NomStatusJudgement: string(n.Status.Judgement),
+	}
+}
+
+func namesToDTO(names []application.ClassifiedName) []nameDTO {
+	out := make([]nameDTO, len(names))
+	for i, n := range names {
+		out[i] = nameToDTO(n)
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+)
+
+type nameRecordJSON struct {
+	NameID             string `json:"name_id"`
+	Canonical          string `json:"canonical"`
+	BasionymID         string `json:"basionym_id"`
+	NomStatus          string `json:"nom_status"`
+	NomStatusJudgement string `json:"nom_status_judgement"`
+}
+
+type nameResponse struct {
+	Name            nameRecordJSON   `json:"name"`
+	NomStatusReason string           `json:"nom_status_reason"`
+	BasionymChain   []nameRecordJSON `json:"basionym_chain"`
+	HomotypicGroup  []nameRecordJSON `json:"homotypic_group"`
+	Usages          []struct {
+		ConceptID         string `json:"concept_id"`
+		Backbone          string `json:"backbone"`
+		Role              string `json:"role"`
+		Homotypic         *bool  `json:"homotypic"`
+		AcceptedCanonical string `json:"accepted_canonical"`
+	} `json:"usages"`
+}
+
+// TestHandleName_RecombinationReachesItsBasionym reads Bromus ovinus (L.)
+// Scop. from the WCVP fixture: a recombination of Festuca ovina L., used as
+// a homotypic synonym of the Festuca ovina concept.
+func TestHandleName_RecombinationReachesItsBasionym(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/name/wcvp:name:401569", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	got := decodeJSON[nameResponse](t, rec.Body)
+	if got.Name.Canonical != "Bromus ovinus" || got.Name.BasionymID != "wcvp:name:415853" || got.Name.NomStatusJudgement != "absent" || got.NomStatusReason == "" {
+		t.Errorf("name = %+v (reason %q), want Bromus ovinus, basionym 415853, judged absent with a reason", got.Name, got.NomStatusReason)
+	}
+	if len(got.BasionymChain) != 1 || got.BasionymChain[0].Canonical != "Festuca ovina" {
+		t.Errorf("basionym_chain = %+v, want [Festuca ovina]", got.BasionymChain)
+	}
+	if len(got.HomotypicGroup) != 2 || got.HomotypicGroup[0].NameID != "wcvp:name:415853" || got.HomotypicGroup[1].NameID != "wcvp:name:401569" {
+		t.Errorf("homotypic_group = %+v, want the basionym 415853, then 401569", got.HomotypicGroup)
+	}
+	if len(got.Usages) != 1 {
+		t.Fatalf("usages = %+v, want one", got.Usages)
+	}
+	u := got.Usages[0]
+	if u.ConceptID != "wcvp:concept:415853" || u.Backbone != "wcvp" || u.Role != "synonym" || u.Homotypic == nil || !*u.Homotypic || u.AcceptedCanonical != "Festuca ovina" {
+		t.Errorf("usage = %+v, want a homotypic synonym of wcvp:concept:415853 (Festuca ovina)", u)
+	}
+}
+
+// TestHandleName_JudgesTheNomStatus pins that the verdict is rendered for a
+// recorded defect: Avena dura Salisb. carries ", nom. illeg. superfl.".
+func TestHandleName_JudgesTheNomStatus(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/name/wcvp:name:397471", nil))
+	got := decodeJSON[nameResponse](t, rec.Body)
+	if got.Name.NomStatus != ", nom. illeg. superfl." || got.Name.NomStatusJudgement != "disqualifying" {
+		t.Errorf("name = %+v, want the raw nom_status judged disqualifying", got.Name)
+	}
+	if len(got.BasionymChain) != 0 || len(got.HomotypicGroup) != 1 {
+		t.Errorf("chain = %+v, group = %+v; want no basionym and a group of one", got.BasionymChain, got.HomotypicGroup)
+	}
+}
+
+func TestHandleName_UnknownIsNotFound(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/name/wcvp:name:0", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404 (body: %s)", rec.Code, rec.Body.String())
+	}
+}
+
+type nameUsagesResponse struct {
+	Query  string           `json:"query"`
+	Names  []nameRecordJSON `json:"names"`
+	Groups []struct {
+		Backbone string `json:"backbone"`
+		Sec      *struct {
+			ID string `json:"id"`
+		} `json:"sec"`
+		Usages []struct {
+			NameID    string `json:"name_id"`
+			ConceptID string `json:"concept_id"`
+			Role      string `json:"role"`
+		} `json:"usages"`
+	} `json:"groups"`
+}
+
+// TestHandleNameUsages_ByCanonicalAndByID looks Festuca ovina up by its
+// canonical (URL-escaped, as a client sends it) and Bromus ovinus by name
+// id: each is used once in the WCVP fixture, the former accepted, the
+// latter a synonym of it.
+func TestHandleNameUsages_ByCanonicalAndByID(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	for _, tc := range []struct{ path, names, usage string }{
+		{"/v1/names/Festuca%20ovina/usages", "wcvp:name:415853", "wcvp:name:415853 accepted in wcvp:concept:415853"},
+		{"/v1/names/wcvp:name:401569/usages", "wcvp:name:401569", "wcvp:name:401569 synonym in wcvp:concept:415853"},
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d, want 200 (body: %s)", tc.path, rec.Code, rec.Body.String())
+		}
+		got := decodeJSON[nameUsagesResponse](t, rec.Body)
+		if len(got.Names) != 1 || got.Names[0].NameID != tc.names {
+			t.Errorf("GET %s: names = %+v, want [%s]", tc.path, got.Names, tc.names)
+		}
+		if len(got.Groups) != 1 || got.Groups[0].Backbone != "wcvp" || got.Groups[0].Sec != nil || len(got.Groups[0].Usages) != 1 {
+			t.Fatalf("GET %s: groups = %+v, want one WCVP group without sec and one usage", tc.path, got.Groups)
+		}
+		u := got.Groups[0].Usages[0]
+		if line := u.NameID + " " + u.Role + " in " + u.ConceptID; line != tc.usage {
+			t.Errorf("GET %s: usage = %q, want %q", tc.path, line, tc.usage)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/names/Festuca%20nonexistens/usages", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown name: status = %d, want 404 (body: %s)", rec.Code, rec.Body.String())
+	}
+}
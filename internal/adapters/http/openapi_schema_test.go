@@ -10,6 +10,7 @@ import (
 
 	yaml "go.yaml.in/yaml/v3"
 
+	"github.com/jobrunner/hostus/internal/explain"
 	"github.com/jobrunner/hostus/internal/httperr"
 )
 
@@ -37,8 +38,14 @@ func TestOpenAPISchemasMatchDTOs(t *testing.T) {
 		"BackboneRef":            reflect.TypeOf(backboneRefDTO{}),
 		"Synonym":                reflect.TypeOf(synonymDTO{}),
 		"ClassificationEntry":    reflect.TypeOf(classificationDTO{}),
+		"HybridParent":           reflect.TypeOf(hybridParentDTO{}),
 		"Distribution":           reflect.TypeOf(distributionDTO{}),
 		"Concept":                reflect.TypeOf(conceptDTO{}),
+		"ConceptIncluded":        reflect.TypeOf(conceptIncludedDTO{}),
+		"NameSpaceEntries":       reflect.TypeOf(nameSpaceEntriesDTO{}),
+		"NameSpaceEntry":         reflect.TypeOf(nameSpaceEntryDTO{}),
+		"ConceptsRequest":        reflect.TypeOf(conceptsRequestDTO{}),
+		"ConceptsResponse":       reflect.TypeOf(conceptsResponseDTO{}),
 		"SynonymDetail":          reflect.TypeOf(synonymDetailDTO{}),
 		"SynonymSummary":         reflect.TypeOf(synonymSummaryDTO{}),
 		"SynonymsResponse":       reflect.TypeOf(synonymsResponseDTO{}),
@@ -58,8 +65,32 @@ func TestOpenAPISchemasMatchDTOs(t *testing.T) {
 		"MatchRequest":           reflect.TypeOf(matchRequestDTO{}),
 		"MatchResult":            reflect.TypeOf(matchResultDTO{}),
 		"MatchResponse":          reflect.TypeOf(matchResponseDTO{}),
+		"MatchExplain":           reflect.TypeOf(explain.Trace{}),
+		"MatchExplainLookup":     reflect.TypeOf(explain.Lookup{}),
+		"MatchExplainCandidate":  reflect.TypeOf(explain.Candidate{}),
+		"MatchExplainFuzzy":      reflect.TypeOf(explain.Fuzzy{}),
 		"SuggestItem":            reflect.TypeOf(suggestItemDTO{}),
 		"SuggestResponse":        reflect.TypeOf(suggestResponseDTO{}),
+		"SuggestMatch":           reflect.TypeOf(suggestMatchDTO{}),
+		"TaxonNode":              reflect.TypeOf(taxonNodeDTO{}),
+		"ChildrenResponse":       reflect.TypeOf(childrenResponseDTO{}),
+		"RankCount":              reflect.TypeOf(rankCountDTO{}),
+		"DescendantsResponse":    reflect.TypeOf(descendantsResponseDTO{}),
+		"NameRecord":             reflect.TypeOf(nameDTO{}),
+		"Publication":            reflect.TypeOf(publicationDTO{}),
+		"NameUsage":              reflect.TypeOf(nameUsageDTO{}),
+		"NameResponse":           reflect.TypeOf(nameResponseDTO{}),
+		"UsageGroup":             reflect.TypeOf(usageGroupDTO{}),
+		"NameUsagesResponse":     reflect.TypeOf(nameUsagesResponseDTO{}),
+		"TreatmentConcept":       reflect.TypeOf(treatmentConceptDTO{}),
+		"SynonymDiff":            reflect.TypeOf(synonymDiffDTO{}),
+		"DistributionChange":     reflect.TypeOf(distributionChangeDTO{}),
+		"DistributionDiff":       reflect.TypeOf(distributionDiffDTO{}),
+		"Treatment":              reflect.TypeOf(treatmentDTO{}),
+		"TreatmentsResponse":     reflect.TypeOf(treatmentsResponseDTO{}),
+		"ChecklistEntry":         reflect.TypeOf(checklistEntryDTO{}),
+		"ChecklistFamily":        reflect.TypeOf(checklistFamilyDTO{}),
+		"ChecklistResponse":      reflect.TypeOf(checklistResponseDTO{}),
 		"Scale":                  reflect.TypeOf(scaleDTO{}),
 		"TraitValue":             reflect.TypeOf(traitValueDTO{}),
 		"TraitSet":               reflect.TypeOf(traitSetDTO{}),
@@ -131,6 +162,13 @@ func refName(s *jsonSchema) string {
 	return ref[strings.LastIndex(ref, "/")+1:]
 }
 
+// refOnPath reports whether the component schema ref is already being
+// compared further up path — as the root ("Area.children") or through an
+// earlier $ref ("AreaListResponse.areas[]->Area.children").
+func refOnPath(path, ref string) bool {
+	return strings.HasPrefix(path, ref+".") || strings.Contains(path, "->"+ref+".")
+}
+
 // compareStructToSchema asserts goType (a struct, or pointer to one) has
 // exactly the JSON properties the object schema s declares, with matching
 // required-ness, recursing into every property.
@@ -197,6 +235,9 @@ func comparePropToType(t *testing.T, path string, goType reflect.Type, prop *jso
 			t.Errorf("%s: property $refs %q which is not a component schema", path, ref)
 			return
 		}
+		if refOnPath(path, ref) {
+			return // a recursive schema (Area.children) is compared once, not forever
+		}
 		compareStructToSchema(t, path+"->"+ref, goType, target, schemas)
 		return
 	}
@@ -0,0 +1,84 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+)
+
+type publicationJSON struct {
+	Title  string `json:"title"`
+	Volume string `json:"volume"`
+	Pages  string `json:"pages"`
+	Year   int    `json:"year"`
+}
+
+// TestHandleName_SplitsThePublication reads Bromus ovinus, published in
+// "Fl. Carniol., ed. 2, 1: 77 (1771)": the edition stays in the title.
+func TestHandleName_SplitsThePublication(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/name/wcvp:name:401569", nil))
+	got := decodeJSON[struct {
+		Name struct {
+			Publication *publicationJSON `json:"publication"`
+		} `json:"name"`
+	}](t, rec.Body)
+	want := publicationJSON{Title: "Fl. Carniol., ed. 2", Volume: "1", Pages: "77", Year: 1771}
+	if got.Name.Publication == nil || *got.Name.Publication != want {
+		t.Errorf("publication = %+v, want %+v", got.Name.Publication, want)
+	}
+}
+
+func TestHandleConcept_CarriesTheAcceptedNamesPublication(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/wcvp:concept:405825", nil))
+	got := decodeJSON[struct {
+		Publication *publicationJSON `json:"publication"`
+	}](t, rec.Body)
+	want := publicationJSON{Title: "Ess. Agrostogr.", Pages: "90", Year: 1812}
+	if got.Publication == nil || *got.Publication != want {
+		t.Errorf("publication = %+v, want %+v", got.Publication, want)
+	}
+}
+
+// TestPublishedBefore_RejectsANonYear pins the 400 on both endpoints that
+// take the filter, and for match on the JSON body as well as the query string
+// of a CSV body.
+func TestPublishedBefore_RejectsANonYear(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	for _, tc := range []struct {
+		name string
+		req  *http.Request
+	}{
+		{"suggest text", httptest.NewRequest(http.MethodGet, "/v1/suggest?q=coryn&published_before=old", nil)},
+		{"suggest zero", httptest.NewRequest(http.MethodGet, "/v1/suggest?q=coryn&published_before=0", nil)},
+		{"match body", jsonRequest(`{"names":[{"id":"1","verbatim":"Corynephorus canescens"}],"published_before":-5}`)},
+		{"match csv", csvRequest("/v1/match?published_before=18x")},
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, tc.req)
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "published_before") {
+			t.Errorf("%s: status = %d, body = %s, want 400 naming published_before", tc.name, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func jsonRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/match", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func csvRequest(target string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader("id,verbatim\n1,Corynephorus canescens\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	return req
+}
@@ -69,6 +69,13 @@ type Deps struct {
 	// stays safe to serve.
 	Repo output.Repository
 
+	// Locator places lat/lon positions in WGSRPD areas for
+	// /v1/areas/resolve and the lat/lon parameters of suggest and match.
+	// Nil — a build without the generated geometry asset — keeps those
+	// routes mounted but answering 503 GEOMETRY_UNAVAILABLE, so a client
+	// can tell "not on this server" from "no such route".
+	Locator output.AreaLocator
+
 	// UIEnabled mounts the embedded test console at "/". False registers
 	// nothing at all, so "/" and every asset path below it are 404 — the
 	// zero value therefore keeps the router API-only. "Default on" is a
@@ -83,6 +90,12 @@ type Deps struct {
 	// placeholder for an unstamped build. It is display-only: no route
 	// behavior depends on it.
 	Version string
+
+	// SuggestPopularityWeight weights /v1/suggest's popularity term
+	// (domain.RankSuggestionsWeighted). The zero value ranks by bm25 alone,
+	// so a zero-value Deps keeps the pre-frequency order; the configured
+	// default comes from config.Defaults (suggest.popularity_weight).
+	SuggestPopularityWeight float64
 }
 
 // NewRouter assembles the hostus HTTP surface: the fixed middleware chain
@@ -150,17 +163,26 @@ func NewRouter(deps Deps) *mux.Router {
 	r.HandleFunc("/health/live", handleHealthLive).Methods(http.MethodGet)
 	r.HandleFunc("/health/ready", handleHealthReady(deps.Repo)).Methods(http.MethodGet)
 	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+	// Needs only the locator, so it is served with or without a Repo.
+	r.HandleFunc("/v1/areas/resolve", handleAreasResolve(deps.Locator)).Methods(http.MethodGet)
 
 	if deps.Repo != nil {
 		r.HandleFunc("/v1/concept/{id}", handleConcept(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/concepts", handleConcepts(deps.Repo)).Methods(http.MethodPost)
 		r.HandleFunc("/v1/xref", handleXref(deps.Repo)).Methods(http.MethodGet)
-		r.HandleFunc("/v1/match", handleMatch(deps.Repo)).Methods(http.MethodPost)
-		r.HandleFunc("/v1/suggest", handleSuggest(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/match", handleMatch(deps.Repo, deps.Locator)).Methods(http.MethodPost)
+		r.HandleFunc("/v1/suggest", handleSuggest(deps.Repo, deps.Locator, deps.SuggestPopularityWeight)).Methods(http.MethodGet)
 		r.HandleFunc("/v1/concept/{id}/traits", handleTraits(deps.Repo)).Methods(http.MethodGet)
 		r.HandleFunc("/v1/concept/{id}/synonyms", handleSynonyms(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/concept/{id}/children", handleChildren(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/concept/{id}/descendants", handleDescendants(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/concept/{id}/treatments", handleTreatments(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/name/{id}", handleName(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/names/{name}/usages", handleNameUsages(deps.Repo)).Methods(http.MethodGet)
 		r.HandleFunc("/v1/translate", handleTranslate(deps.Repo)).Methods(http.MethodPost)
 		r.HandleFunc("/v1/sec", handleSec(deps.Repo)).Methods(http.MethodGet)
 		r.HandleFunc("/v1/areas", handleAreas(deps.Repo)).Methods(http.MethodGet)
+		r.HandleFunc("/v1/checklist", handleChecklist(deps.Repo)).Methods(http.MethodGet)
 		r.HandleFunc("/v1/backbones", handleBackbones(deps.Repo)).Methods(http.MethodGet)
 		r.HandleFunc("/v1/spaces", handleSpaces(deps.Repo)).Methods(http.MethodGet)
 	}
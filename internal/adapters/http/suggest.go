@@ -47,6 +47,53 @@ type suggestItemDTO struct {
 	// cannot be carried into that space, which is what a caller picking a
 	// concept for downstream use needs to see while choosing.
 	TargetSpaceName string `json:"target_space_name,omitempty"`
+	// CorrectedFrom is the query as typed when this candidate was found by a
+	// near-miss spelling of it rather than as a prefix ("Festuka" finding
+	// Festuca). Such candidates follow every genuine hit. Omitted for a
+	// prefix hit, so the SP1/SP2 shape is unchanged.
+	CorrectedFrom string `json:"corrected_from,omitempty"`
+	// Frequency is the concept's count in an ingested frequency source (the
+	// highest over all sources), the popularity signal behind the ranking.
+	// Omitted when no source counts the concept, so the SP1/SP2 shape is
+	// unchanged without a `frequencies:` source.
+	Frequency int64 `json:"frequency,omitempty"`
+	// Match says which name the query matched — the displayed accepted name,
+	// a synonym, or a name-space alias — and which runes of it to highlight,
+	// folded exactly as the search folds (domain.BestSuggestMatch). Omitted
+	// only for an index built before matched names were recorded.
+	Match *suggestMatchDTO `json:"match,omitempty"`
+	// Family and Genus label the candidate ("Festuca ovina (Poaceae)")
+	// without a classification request (domain.HigherTaxa); each is omitted
+	// when neither the source nor the concept's lineage names one.
+	Family string `json:"family,omitempty"`
+	Genus  string `json:"genus,omitempty"`
+}
+
+// suggestMatchDTO is suggestItemDTO.Match. Highlights are half-open
+// [start, end) offsets counted in Unicode code points of name, in name
+// order; name equals the item's display when field is "accepted".
+type suggestMatchDTO struct {
+	Field      string    `json:"field"`
+	Name       string    `json:"name"`
+	Highlights []spanDTO `json:"highlights"`
+}
+
+// spanDTO is one highlighted range of suggestMatchDTO.Name.
+type spanDTO struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// suggestMatchToDTO renders m, or nil for the zero SuggestMatch.
+func suggestMatchToDTO(m domain.SuggestMatch) *suggestMatchDTO {
+	if m.Field == "" {
+		return nil
+	}
+	spans := make([]spanDTO, len(m.Highlights))
+	for i, h := range m.Highlights {
+		spans[i] = spanDTO{Start: h.Start, End: h.End}
+	}
+	return &suggestMatchDTO{Field: string(m.Field), Name: m.Name, Highlights: spans}
 }
 
 // suggestResponseDTO is the GET /v1/suggest response envelope, per spec
@@ -73,6 +120,11 @@ func suggestResponseToDTO(resp application.SuggestResponse) suggestResponseDTO {
 			Aggregate:    item.Aggregate,
 
 			TargetSpaceName: item.TargetSpaceName,
+			CorrectedFrom:   item.CorrectedFrom,
+			Frequency:       item.Frequency,
+			Match:           suggestMatchToDTO(item.Match),
+			Family:          item.HigherTaxa.Family,
+			Genus:           item.HigherTaxa.Genus,
 		}
 	}
 	return suggestResponseDTO{
@@ -135,10 +187,32 @@ func parseSuggestLimit(param string) (int, error) {
 	return strconv.Atoi(param)
 }
 
-// handleSuggest serves GET /v1/suggest?q=&area=&rank=&limit=, the frontend
-// autosuggest endpoint, per spec §B.1. A missing/empty q, an unknown rank
-// token, or a non-numeric limit all report 400 INVALID_QUERY.
-func handleSuggest(repo output.Repository) http.HandlerFunc {
+// errPublishedBefore is the 400 message for a published_before that is not
+// a positive year, on /v1/suggest and /v1/match alike.
+var errPublishedBefore = errors.New("published_before must be a positive year")
+
+// parsePublishedBefore reads the published_before parameter: empty is no
+// filter (0), anything but a positive integer is errPublishedBefore.
+func parsePublishedBefore(param string) (int, error) {
+	if param == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(param)
+	if err != nil || year <= 0 {
+		return 0, errPublishedBefore
+	}
+	return year, nil
+}
+
+// handleSuggest serves GET /v1/suggest?q=&area=&rank=&limit=&within=, the
+// frontend autosuggest endpoint, per spec §B.1. A missing/empty q, an
+// unknown rank token, a non-numeric limit, a published_before that is not a
+// positive year, or a within naming no concept all report 400 INVALID_QUERY.
+// lat/lon may replace area: the position is resolved to its level-3 area
+// through loc (areaFilter), and its errors are rendered by
+// writePositionError. popularityWeight is the server's configured popularity
+// term (Deps.SuggestPopularityWeight), not a query parameter.
+func handleSuggest(repo output.Repository, loc output.AreaLocator, popularityWeight float64) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query()
 
@@ -154,15 +228,37 @@ func handleSuggest(repo output.Repository) http.HandlerFunc {
 			return
 		}
 
+		publishedBefore, err := parsePublishedBefore(query.Get("published_before"))
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+
+		lat, lon, err := parsePosition(query.Get("lat"), query.Get("lon"))
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+		area, err := areaFilter(loc, query.Get("area"), lat, lon)
+		if err != nil {
+			writePositionError(w, err)
+			return
+		}
+
 		entryBackbone := query.Get("entry_backbone")
 		targetSpace := query.Get("target_space")
+		within := query.Get("within")
 		resp, err := application.Suggest(r.Context(), repo, application.SuggestRequest{
 			Q:             query.Get("q"),
-			Area:          query.Get("area"),
+			Area:          area,
 			Ranks:         ranks,
 			Limit:         limit,
 			EntryBackbone: entryBackbone,
 			TargetSpace:   targetSpace,
+			Within:        within,
+
+			PublishedBefore:  publishedBefore,
+			PopularityWeight: popularityWeight,
 		})
 		if errors.Is(err, application.ErrEmptyQuery) {
 			httperr.InvalidQueryError(w, "q query parameter is required")
@@ -176,6 +272,10 @@ func handleSuggest(repo output.Repository) http.HandlerFunc {
 			httperr.InvalidQueryError(w, "unknown target_space "+strconv.Quote(targetSpace))
 			return
 		}
+		if errors.Is(err, application.ErrUnknownWithin) {
+			httperr.InvalidQueryError(w, "unknown within "+strconv.Quote(within))
+			return
+		}
 		if err != nil {
 			httperr.InternalError(w)
 			return
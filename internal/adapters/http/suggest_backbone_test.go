@@ -77,3 +77,41 @@ func TestHandleSuggest_UnknownTargetSpaceIsInvalidQuery(t *testing.T) {
 		t.Errorf("body = %s, want an INVALID_QUERY envelope naming the offending value", body)
 	}
 }
+
+// TestHandleSuggest_WithinExcludesTheParent: within=<species> keeps the
+// species and drops its genus, which matches the same prefix but sits above
+// the subtree.
+func TestHandleSuggest_WithinExcludesTheParent(t *testing.T) {
+	repo := seededRepo(t)
+	r := httpx.NewRouter(httpx.Deps{Repo: repo})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/suggest?q=coryn&within=wcvp:concept:405825", nil)
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	got := decodeJSON[suggestResponse](t, rr.Body)
+	if len(got.Results) != 1 || got.Results[0].ConceptID != "wcvp:concept:405825" {
+		t.Errorf("results = %+v, want only wcvp:concept:405825", got.Results)
+	}
+}
+
+// TestHandleSuggest_UnknownWithinIsInvalidQuery: a within naming no concept
+// is a caller error, not an empty subtree.
+func TestHandleSuggest_UnknownWithinIsInvalidQuery(t *testing.T) {
+	repo := seededRepo(t)
+	r := httpx.NewRouter(httpx.Deps{Repo: repo})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/suggest?q=coryn&within=wcvp:concept:bogus", nil)
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400 (body: %s)", rr.Code, rr.Body.String())
+	}
+	if body := rr.Body.String(); !strings.Contains(body, "INVALID_QUERY") || !strings.Contains(body, "bogus") {
+		t.Errorf("body = %s, want an INVALID_QUERY envelope naming the offending value", body)
+	}
+}
@@ -11,14 +11,25 @@ import (
 )
 
 type suggestItemResponse struct {
-	ConceptID    string  `json:"concept_id"`
-	Display      string  `json:"display"`
-	Canonical    string  `json:"canonical"`
-	VernacularDE string  `json:"vernacular_de"`
-	Rank         string  `json:"rank"`
-	Status       string  `json:"status"`
-	InArea       bool    `json:"in_area"`
-	Score        float64 `json:"score"`
+	ConceptID     string  `json:"concept_id"`
+	Display       string  `json:"display"`
+	Canonical     string  `json:"canonical"`
+	VernacularDE  string  `json:"vernacular_de"`
+	Rank          string  `json:"rank"`
+	Status        string  `json:"status"`
+	InArea        bool    `json:"in_area"`
+	Score         float64 `json:"score"`
+	CorrectedFrom string  `json:"corrected_from"`
+	Family        string  `json:"family"`
+	Genus         string  `json:"genus"`
+	Match         *struct {
+		Field      string `json:"field"`
+		Name       string `json:"name"`
+		Highlights []struct {
+			Start int `json:"start"`
+			End   int `json:"end"`
+		} `json:"highlights"`
+	} `json:"match"`
 }
 
 type suggestResponse struct {
@@ -297,3 +308,75 @@ func TestHandleSuggest_NoMatches(t *testing.T) {
 		t.Errorf("len(results) = %d, want 0", len(got.Results))
 	}
 }
+
+// TestHandleSuggest_TypoIsCorrected pins the wire marker of the typo
+// fallback: a near-miss of a fixture name is answered, flagged with the query
+// as typed.
+func TestHandleSuggest_TypoIsCorrected(t *testing.T) {
+	repo := seededRepo(t)
+	r := httpx.NewRouter(httpx.Deps{Repo: repo})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/suggest?q="+url.QueryEscape("Corinephorus"), nil)
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	got := decodeJSON[suggestResponse](t, rr.Body)
+	if findSuggestResult(got.Results, corynephorusConceptID) == nil {
+		t.Fatalf("results = %+v, want Corynephorus canescens", got.Results)
+	}
+	for _, res := range got.Results {
+		if res.CorrectedFrom != "Corinephorus" {
+			t.Errorf("result %s corrected_from = %q, want %q", res.ConceptID, res.CorrectedFrom, "Corinephorus")
+		}
+	}
+}
+
+// TestHandleSuggest_ReportsTheMatchedName pins the match block on the wire: a
+// query reaching Corynephorus canescens only through its synonym names that
+// synonym and the runes of it to highlight.
+func TestHandleSuggest_ReportsTheMatchedName(t *testing.T) {
+	repo := seededRepo(t)
+	r := httpx.NewRouter(httpx.Deps{Repo: repo})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/suggest?q="+url.QueryEscape("weing can"), nil)
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	got := decodeJSON[suggestResponse](t, rr.Body)
+	coryn := findSuggestResult(got.Results, corynephorusConceptID)
+	if coryn == nil || coryn.Match == nil {
+		t.Fatalf("results = %+v, want Corynephorus canescens with a match", got.Results)
+	}
+	m := coryn.Match
+	if m.Field != "synonym" || !strings.HasPrefix(m.Name, "Weingaertneria canescens") {
+		t.Errorf("match = %+v, want the Weingaertneria synonym", m)
+	}
+	if len(m.Highlights) != 2 || m.Highlights[0].Start != 0 || m.Highlights[0].End != 5 ||
+		m.Highlights[1].Start != 15 || m.Highlights[1].End != 18 {
+		t.Errorf("highlights = %+v, want [0,5) and [15,18)", m.Highlights)
+	}
+}
+
+// TestHandleSuggest_CarriesFamilyAndGenus pins the higher taxa on the wire:
+// Corynephorus canescens carries its WCVP family label and the genus read
+// from its lineage.
+func TestHandleSuggest_CarriesFamilyAndGenus(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/suggest?q=coryn", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	got := decodeJSON[suggestResponse](t, rr.Body)
+	coryn := findSuggestResult(got.Results, corynephorusConceptID)
+	if coryn == nil || coryn.Family != "Poaceae" || coryn.Genus != "Corynephorus" {
+		t.Errorf("Corynephorus canescens = %+v, want family Poaceae and genus Corynephorus", coryn)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jobrunner/hostus/internal/application"
 	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/explain"
 	"github.com/jobrunner/hostus/internal/httperr"
 	"github.com/jobrunner/hostus/internal/ports/output"
 )
@@ -47,12 +48,25 @@ type classificationDTO struct {
 	Rank      string `json:"rank"`
 }
 
+// hybridParentDTO is one parent of a nothotaxon as its hybrid formula names
+// it. ConceptID is omitted when the name resolves to no concept of the
+// hybrid's backbone, or to several (see domain.HybridParent).
+type hybridParentDTO struct {
+	Canonical string `json:"canonical"`
+	ConceptID string `json:"concept_id,omitempty"`
+}
+
 // distributionDTO is one reference-area assignment for a concept, per
 // spec §4.3's distribution table (area_scheme, area_code — e.g.
-// {"area_scheme": "wgsrpd_l3", "area_code": "GER"}).
+// {"area_scheme": "wgsrpd_l3", "area_code": "GER"}). Status is the row's
+// verdict: native or introduced on WCVP rows, any DistributionStatus but
+// absent on a regional checklist's. Derived is
+// set on an area rolled up from an infraspecific taxon of the species.
 type distributionDTO struct {
 	AreaScheme string `json:"area_scheme"`
 	AreaCode   string `json:"area_code"`
+	Status     string `json:"status,omitempty"`
+	Derived    bool   `json:"derived,omitempty"`
 }
 
 // conceptDTO is the wire shape for GET /v1/concept/{id} and GET /v1/xref,
@@ -78,6 +92,9 @@ type conceptDTO struct {
 	RankVerbatim string         `json:"rank_verbatim,omitempty"`
 	Status       string         `json:"status"`
 	Backbone     backboneRefDTO `json:"backbone"`
+	// Publication is where the accepted name was published, split into its
+	// parts as on nameDTO; omitted when the backbone records no citation.
+	Publication *publicationDTO `json:"publication,omitempty"`
 	// Xrefs maps authority to ALL of its ext_ids for this concept, never
 	// just one: SP4's Wikidata-bridge ingest measured that a concept can
 	// legitimately carry several ids for one authority (954 wikidata, 635
@@ -94,12 +111,18 @@ type conceptDTO struct {
 	Classification []classificationDTO `json:"classification,omitempty"`
 	Synonyms       []synonymDTO        `json:"synonyms"`
 	Distribution   []distributionDTO   `json:"distribution,omitempty"`
+	// HybridParents are the parents of a nothotaxon in formula order,
+	// omitted for every concept the backbone records no formula for.
+	HybridParents []hybridParentDTO `json:"hybrid_parents,omitempty"`
 	// Sec names the concept's sec. reference space (id + title), present only
 	// for a sec-bearing concept (CDM). Since CDM added many concepts of the
 	// SAME name — one per reference work — this is what tells two otherwise
 	// identical results apart (SP5). Omitted (never empty) for a concept with
 	// no sec. reference (WCVP), so the SP1 shape is unchanged.
 	Sec *secReferenceDTO `json:"sec,omitempty"`
+	// Included carries the parts the `include` parameter asked for (see
+	// conceptIncludedDTO), omitted when it asked for none.
+	Included *conceptIncludedDTO `json:"included,omitempty"`
 }
 
 // conceptToDTO renders a resolved concept (as returned by
@@ -128,7 +151,7 @@ func conceptToDTO(c *domain.Concept, synonyms []output.SynonymName, xrefs []doma
 	if len(distribution) > 0 {
 		dists = make([]distributionDTO, len(distribution))
 		for i, d := range distribution {
-			dists[i] = distributionDTO{AreaScheme: d.AreaScheme, AreaCode: d.AreaCode}
+			dists[i] = distributionDTO{AreaScheme: d.AreaScheme, AreaCode: d.AreaCode, Status: string(d.Status), Derived: d.Derived}
 		}
 	}
 
@@ -148,6 +171,7 @@ func conceptToDTO(c *domain.Concept, synonyms []output.SynonymName, xrefs []doma
 		RankVerbatim:   c.RankVerbatim,
 		Status:         string(c.Status),
 		Backbone:       backboneRefDTO{ID: c.BackboneID, Version: c.BackboneVersion},
+		Publication:    publicationToDTO(c.AcceptedName),
 		Xrefs:          xrefMap,
 		Classification: classif,
 		Synonyms:       syns,
@@ -169,12 +193,22 @@ type matchNameDTO struct {
 // ambiguous tie; they apply to every name in the batch. (They replace the
 // former, never-implemented `sec_hint` field — an unknown JSON field is
 // ignored by the decoder, so old clients that still send `sec_hint` are
-// unaffected.)
+// unaffected.) Area names the region the batch was recorded in: it breaks
+// otherwise ambiguous ties and adds in_area/outside_known_range to every
+// resolved result, but never narrows resolution (application.MatchFilter).
+// Lat/Lon may replace Area with the position the batch was recorded at; they
+// are pointers so that 0 (the equator, the prime meridian) is a position and
+// not "absent". PublishedBefore drops candidates whose matched name is known
+// to be published in or after that year (application.MatchFilter).
 type matchRequestDTO struct {
-	Names         []matchNameDTO `json:"names"`
-	TargetSpace   string         `json:"target_space,omitempty"`
-	EntryBackbone string         `json:"entry_backbone,omitempty"`
-	EntrySec      string         `json:"entry_sec,omitempty"`
+	Names           []matchNameDTO `json:"names"`
+	TargetSpace     string         `json:"target_space,omitempty"`
+	EntryBackbone   string         `json:"entry_backbone,omitempty"`
+	EntrySec        string         `json:"entry_sec,omitempty"`
+	Area            string         `json:"area,omitempty"`
+	Lat             *float64       `json:"lat,omitempty"`
+	Lon             *float64       `json:"lon,omitempty"`
+	PublishedBefore int            `json:"published_before,omitempty"`
 }
 
 // matchResultDTO is one entry of POST /v1/match's response, per §B.2. An
@@ -188,6 +222,10 @@ type matchResultDTO struct {
 	Candidates     []string `json:"candidates,omitempty"`
 	RequiresReview bool     `json:"requires_review,omitempty"`
 	Note           string   `json:"note,omitempty"`
+	// Family and Genus label the resolved concept, as on suggestItemDTO;
+	// omitted for a result without a concept or a concept without either.
+	Family string `json:"family,omitempty"`
+	Genus  string `json:"genus,omitempty"`
 
 	// The three UC4 fields below appear ONLY when the request named a
 	// target_space; on the plain path they stay zero and omitempty drops them,
@@ -204,6 +242,17 @@ type matchResultDTO struct {
 	TargetSpaceName        string `json:"target_space_name,omitempty"`
 	AggregatePolicy        string `json:"aggregate_policy,omitempty"`
 	ESyDiagnosticRelevance string `json:"esy_diagnostic_relevance,omitempty"`
+
+	// InArea and OutsideKnownRange appear only when the request named an area
+	// and the result resolved to a concept (application.AreaRange) — and then
+	// always both, false included, so a client never reads an absent flag as
+	// "not outside".
+	InArea            *bool `json:"in_area,omitempty"`
+	OutsideKnownRange *bool `json:"outside_known_range,omitempty"`
+
+	// Explain is present only with ?explain=true: how the matcher arrived at
+	// this result (application.MatchTrace). Without it the shape is unchanged.
+	Explain *explain.Trace `json:"explain,omitempty"`
 }
 
 // esyRelevanceNotDeterminable is the sentinel value of every
@@ -231,42 +280,37 @@ func writeJSON(w http.ResponseWriter, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
-// writeConcept resolves id via repo.Concept and writes it as a conceptDTO,
-// or a 404 NOT_FOUND envelope if id is unknown. Shared by handleConcept and
-// handleXref (which resolves its own id via ConceptByXref first) so both
-// endpoints render the identical concept shape from the identical query
-// path.
-func writeConcept(w http.ResponseWriter, r *http.Request, repo output.Repository, id string) {
-	c, synonyms, xrefs, distribution, err := repo.Concept(r.Context(), id)
-	if errors.Is(err, domain.ErrNotFound) {
-		httperr.Write(w, http.StatusNotFound, httperr.NotFound, "concept not found")
-		return
-	}
+// writeConcept resolves id through application.Concepts and writes it as a
+// conceptDTO, or a 404 NOT_FOUND envelope if id is unknown. Shared by
+// handleConcept and handleXref (which resolves its own id via ConceptByXref
+// first) so both endpoints render the identical concept shape from the
+// identical query path — the one POST /v1/concepts batches. Classification
+// is always read here, as this endpoint has always returned it; include
+// adds the optional parts.
+func writeConcept(w http.ResponseWriter, r *http.Request, repo output.Repository, id string, include output.ConceptInclude) {
+	include.Classification = true
+	res, err := application.Concepts(r.Context(), repo, []string{id}, include)
 	if err != nil {
 		httperr.InternalError(w)
 		return
 	}
-	classification, err := repo.Classification(r.Context(), id)
-	if err != nil {
-		httperr.InternalError(w)
+	if len(res.Documents) == 0 {
+		httperr.Write(w, http.StatusNotFound, httperr.NotFound, "concept not found")
 		return
 	}
-	dto := conceptToDTO(c, synonyms, xrefs, distribution, classification)
-	// A sec-bearing concept (CDM) carries its reference space so same-name
-	// concepts are distinguishable (SP5). A missing sec_reference row is
-	// context, not the answer — omit it rather than fail the concept.
-	if c.SecReference != "" {
-		if sr, err := repo.SecReferenceByID(r.Context(), c.SecReference); err == nil {
-			dto.Sec = &secReferenceDTO{ID: sr.ID, Title: sr.Title}
-		}
-	}
-	writeJSON(w, dto)
+	writeJSON(w, conceptDocumentToDTO(res.Documents[0], include))
 }
 
-// handleConcept serves GET /v1/concept/{id}.
+// handleConcept serves GET /v1/concept/{id}?include=traits,synonyms,spaces.
+// An unknown include token reports 400 INVALID_QUERY.
 func handleConcept(repo output.Repository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		writeConcept(w, r, repo, mux.Vars(r)["id"])
+		include, err := parseConceptInclude(r.URL.Query().Get("include"))
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+		writeConcept(w, r, repo, mux.Vars(r)["id"], include)
 	}
 }
 
@@ -291,7 +335,7 @@ func handleXref(repo output.Repository) http.HandlerFunc {
 			httperr.InternalError(w)
 			return
 		}
-		writeConcept(w, r, repo, c.ID)
+		writeConcept(w, r, repo, c.ID, output.ConceptInclude{})
 	}
 }
 
@@ -299,47 +343,64 @@ func handleXref(repo output.Repository) http.HandlerFunc {
 // application.MatchNames. A per-item UNRESOLVABLE outcome is rendered as a
 // normal 200 result element (matchTypeUnresolvable), never as an HTTP
 // error; only a malformed request body is a (400 INVALID_QUERY) HTTP error.
-func handleMatch(repo output.Repository) http.HandlerFunc {
+//
+// ?explain=true resolves through application.ExplainMatches instead and
+// attaches each result's trace; the results themselves are identical.
+//
+// A text/csv or application/x-ndjson body, or an Accept asking for either,
+// goes through streamMatch instead: rows are read, resolved and written one at
+// a time. JSON in and JSON out stays on the path below, byte for byte.
+//
+// A lat/lon position is resolved to body.Area before matching (areaFilter),
+// so everything downstream sees an ordinary area request.
+func handleMatch(repo output.Repository, loc output.AreaLocator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		explain, err := parseExplain(r.URL.Query().Get("explain"))
+		if err != nil {
+			httperr.InvalidQueryError(w, "explain must be true or false")
+			return
+		}
+		in := matchInputFormat(r.Header.Get("Content-Type"))
+		out := matchOutputFormat(r.Header.Get("Accept"))
+		if in != matchFormatJSON || out != matchFormatJSON {
+			streamMatch(w, r, repo, loc, in, out, explain)
+			return
+		}
 		var body matchRequestDTO
 		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 			httperr.InvalidQueryError(w, "malformed request body")
 			return
 		}
+		if body.PublishedBefore < 0 {
+			httperr.InvalidQueryError(w, errPublishedBefore.Error())
+			return
+		}
+		if body.Area, err = areaFilter(loc, body.Area, body.Lat, body.Lon); err != nil {
+			writePositionError(w, err)
+			return
+		}
 
 		reqs := make([]application.MatchRequest, len(body.Names))
 		for i, n := range body.Names {
 			reqs[i] = application.MatchRequest{ID: n.ID, Verbatim: n.Verbatim}
 		}
 
-		results, err := application.MatchInSpace(r.Context(), repo, reqs, body.TargetSpace,
-			application.MatchFilter{Backbone: body.EntryBackbone, Sec: body.EntrySec})
-		if errors.Is(err, application.ErrUnknownTargetSpace) {
-			httperr.InvalidQueryError(w, "unknown target_space "+strconv.Quote(body.TargetSpace))
-			return
-		}
-		if errors.Is(err, application.ErrUnknownBackbone) {
-			httperr.InvalidQueryError(w, "unknown entry_backbone "+strconv.Quote(body.EntryBackbone))
-			return
-		}
-		if errors.Is(err, application.ErrUnknownSec) {
-			httperr.InvalidQueryError(w, "unknown entry_sec "+strconv.Quote(body.EntrySec))
-			return
+		match := application.MatchInSpace
+		if explain {
+			match = application.ExplainMatches
 		}
+		results, err := match(r.Context(), repo, reqs, body.TargetSpace,
+			application.MatchFilter{Backbone: body.EntryBackbone, Sec: body.EntrySec, Area: body.Area, PublishedBefore: body.PublishedBefore})
 		if err != nil {
-			httperr.InternalError(w)
+			writeMatchError(w, err, body)
 			return
 		}
 
-		versions, err := repo.BackboneVersions(r.Context())
+		backboneVersions, err := backboneVersionMap(r, repo)
 		if err != nil {
 			httperr.InternalError(w)
 			return
 		}
-		backboneVersions := make(map[string]string, len(versions))
-		for _, v := range versions {
-			backboneVersions[v.ID] = v.Version
-		}
 
 		writeJSON(w, matchResponseDTO{
 			BackboneVersions: backboneVersions,
@@ -348,6 +409,25 @@ func handleMatch(repo output.Repository) http.HandlerFunc {
 	}
 }
 
+// writeMatchError renders a matcher error: an unknown target space, backbone
+// or sec. reference is the client's 400, naming the value from opts; anything
+// else is a 500.
+func writeMatchError(w http.ResponseWriter, err error, opts matchRequestDTO) {
+	if errors.Is(err, application.ErrUnknownTargetSpace) {
+		httperr.InvalidQueryError(w, "unknown target_space "+strconv.Quote(opts.TargetSpace))
+		return
+	}
+	if errors.Is(err, application.ErrUnknownBackbone) {
+		httperr.InvalidQueryError(w, "unknown entry_backbone "+strconv.Quote(opts.EntryBackbone))
+		return
+	}
+	if errors.Is(err, application.ErrUnknownSec) {
+		httperr.InvalidQueryError(w, "unknown entry_sec "+strconv.Quote(opts.EntrySec))
+		return
+	}
+	httperr.InternalError(w)
+}
+
 // matchResultsToDTO renders application.MatchInSpace's results as the wire
 // shape, mapping the zero-value domain.MatchType (UNRESOLVABLE) to
 // matchTypeUnresolvable. targetSpace reports whether the request named a
@@ -371,13 +451,33 @@ func matchResultsToDTO(results []application.MatchResult, targetSpace bool) []ma
 			Candidates:     res.Candidates,
 			RequiresReview: res.RequiresReview,
 			Note:           res.Note,
+			Family:         res.HigherTaxa.Family,
+			Genus:          res.HigherTaxa.Genus,
 		}
 		if targetSpace {
 			dto.TargetSpaceName = res.TargetSpaceName
 			dto.AggregatePolicy = string(res.AggregatePolicy)
 			dto.ESyDiagnosticRelevance = esyRelevanceNotDeterminable
 		}
+		if res.Range != nil {
+			dto.InArea = &res.Range.InArea
+			dto.OutsideKnownRange = &res.Range.OutsideKnownRange
+		}
+		if res.Trace != nil {
+			trace := explain.FromTrace(res.Trace)
+			dto.Explain = &trace
+		}
 		out[i] = dto
 	}
 	return out
 }
+
+// parseExplain reads the ?explain= flag. Absent is false; anything
+// strconv.ParseBool does not accept is a client error rather than a silent
+// false, so a typo ("ture") does not look like a trace that came back empty.
+func parseExplain(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/jobrunner/hostus/internal/adapters/sqlite"
@@ -49,6 +50,7 @@ func (s wcvpRowSource) Taxa() []application.TaxonRow {
 			// make every fixture synonym look nomenclaturally clean.
 			PublishedIn: t.PublishedIn,
 			NomStatus:   t.NomenclaturalStatus,
+			Family:      t.Family,
 		})
 	}
 	return out
@@ -57,7 +59,7 @@ func (s wcvpRowSource) Taxa() []application.TaxonRow {
 func (s wcvpRowSource) Distributions() []application.DistributionRow {
 	out := make([]application.DistributionRow, 0, len(s.ds.Distributions))
 	for _, d := range s.ds.Distributions {
-		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode()})
+		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode(), Introduced: d.Introduced()})
 	}
 	return out
 }
@@ -96,6 +98,10 @@ func seededRepo(t *testing.T) *sqlite.DB {
 	if err := db.BuildDistributionClosure(context.Background()); err != nil {
 		t.Fatalf("BuildDistributionClosure: unexpected error: %v", err)
 	}
+	// concept_lineage likewise, for suggest's within filter.
+	if err := db.BuildLineageClosure(context.Background()); err != nil {
+		t.Fatalf("BuildLineageClosure: unexpected error: %v", err)
+	}
 	return db
 }
 
@@ -702,6 +708,59 @@ func TestHandleConcept_OtherRank_RendersRankVerbatim(t *testing.T) {
 	}
 }
 
+// TestHandleConcept_HybridParents pins the wire shape of a nothotaxon's
+// parents: formula order, concept_id where the parent resolves, omitted
+// where it does not — and no "hybrid_parents" key at all on an ordinary
+// concept.
+func TestHandleConcept_HybridParents(t *testing.T) {
+	db, err := sqlite.Open(":memory:")
+	if err != nil {
+		t.Fatalf("sqlite.Open(:memory:): unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	ds := &application.Dataset{Backbones: []application.Backbone{{ID: "wcvp", Version: "v1"}}, ManifestSHA: "x"}
+	taxa := []application.TaxonRow{
+		{TaxonID: "1", AcceptedTaxonID: "1", Accepted: true, Canonical: "Salix alba", Rank: "Species", Status: "Accepted"},
+		{TaxonID: "2", AcceptedTaxonID: "2", Accepted: true, Canonical: "Salix × rubens", Rank: "Species", Status: "Accepted", HybridFormula: "Salix alba × S. fragilis"},
+	}
+	readerFor := func(application.Backbone) (application.RowSource, error) {
+		return sliceRowSource{taxa: taxa}, nil
+	}
+	if _, err := application.Ingest(context.Background(), ds, readerFor, db); err != nil {
+		t.Fatalf("application.Ingest: unexpected error: %v", err)
+	}
+	r := httpx.NewRouter(httpx.Deps{Repo: db})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/concept/wcvp:concept:2", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rr.Code, rr.Body.String())
+	}
+	var got struct {
+		HybridParents []map[string]string `json:"hybrid_parents"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding JSON: %v (body: %s)", err, rr.Body.String())
+	}
+	want := []map[string]string{
+		{"canonical": "Salix alba", "concept_id": "wcvp:concept:1"},
+		{"canonical": "Salix fragilis"},
+	}
+	if !reflect.DeepEqual(got.HybridParents, want) {
+		t.Errorf("hybrid_parents = %v, want %v", got.HybridParents, want)
+	}
+
+	rr2 := httptest.NewRecorder()
+	r.ServeHTTP(rr2, httptest.NewRequest(http.MethodGet, "/v1/concept/wcvp:concept:1", nil))
+	var raw map[string]any
+	if err := json.Unmarshal(rr2.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("decoding raw JSON: %v (body: %s)", err, rr2.Body.String())
+	}
+	if _, present := raw["hybrid_parents"]; present {
+		t.Errorf("raw JSON = %s, want the \"hybrid_parents\" key OMITTED for a concept without a formula", rr2.Body.String())
+	}
+}
+
 // TestZeroValueDepsDoesNotMountTaxaRoutes documents that a nil Repo (the
 // zero value, as used by every pre-existing router_test.go case) leaves
 // /v1/... unmounted rather than panicking on first request.
@@ -0,0 +1,148 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/httperr"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// treatmentConceptDTO is one concept in a GET /v1/concept/{id}/treatments
+// response: who treats the taxon, and how — its accepted name and status.
+// sec is omitted for a backbone without sec. reference spaces, as on
+// conceptDTO.
+type treatmentConceptDTO struct {
+	ConceptID  string           `json:"concept_id"`
+	Display    string           `json:"display"`
+	Canonical  string           `json:"canonical"`
+	Authorship string           `json:"authorship,omitempty"`
+	Rank       string           `json:"rank"`
+	Status     string           `json:"status"`
+	Backbone   backboneRefDTO   `json:"backbone"`
+	Sec        *secReferenceDTO `json:"sec,omitempty"`
+}
+
+// synonymDiffDTO is application.SynonymDiff on the wire; both lists are
+// always present, empty when the two synonymies agree.
+type synonymDiffDTO struct {
+	Shared     int          `json:"shared"`
+	OnlyHere   []synonymDTO `json:"only_here"`
+	OnlySource []synonymDTO `json:"only_source"`
+}
+
+// distributionChangeDTO is one area both concepts record, status being the
+// treatment's and source_status the source concept's.
+type distributionChangeDTO struct {
+	AreaScheme   string `json:"area_scheme"`
+	AreaCode     string `json:"area_code"`
+	Status       string `json:"status,omitempty"`
+	SourceStatus string `json:"source_status,omitempty"`
+}
+
+// distributionDiffDTO is application.DistributionDiff on the wire; the
+// lists are always present.
+type distributionDiffDTO struct {
+	Shared     int                     `json:"shared"`
+	OnlyHere   []distributionDTO       `json:"only_here"`
+	OnlySource []distributionDTO       `json:"only_source"`
+	Changed    []distributionChangeDTO `json:"changed"`
+}
+
+// treatmentDTO is one other treatment of the source concept's taxon: the
+// concept, the links that found it (xref, name, congruent) and its
+// differences from the source.
+type treatmentDTO struct {
+	Concept      treatmentConceptDTO `json:"concept"`
+	Via          []string            `json:"via"`
+	Synonyms     synonymDiffDTO      `json:"synonyms"`
+	Distribution distributionDiffDTO `json:"distribution"`
+}
+
+// treatmentsResponseDTO is the GET /v1/concept/{id}/treatments envelope;
+// treatments is an empty array when nothing links to the source.
+type treatmentsResponseDTO struct {
+	Source     treatmentConceptDTO `json:"source"`
+	Treatments []treatmentDTO      `json:"treatments"`
+}
+
+// handleTreatments serves GET /v1/concept/{id}/treatments: the concepts of
+// other backbones and sec. reference spaces treating the same taxon, with
+// their synonymy and distribution compared against the source's. An unknown
+// concept id is 404 NOT_FOUND.
+func handleTreatments(repo output.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := application.Treatments(r.Context(), repo, mux.Vars(r)["id"])
+		if errors.Is(err, domain.ErrNotFound) {
+			httperr.Write(w, http.StatusNotFound, httperr.NotFound, "concept not found")
+			return
+		}
+		if err != nil {
+			httperr.InternalError(w)
+			return
+		}
+		out := treatmentsResponseDTO{Source: treatmentConceptToDTO(res.Source), Treatments: make([]treatmentDTO, len(res.Treatments))}
+		for i, t := range res.Treatments {
+			via := make([]string, len(t.Via))
+			for j, v := range t.Via {
+				via[j] = string(v)
+			}
+			out.Treatments[i] = treatmentDTO{
+				Concept: treatmentConceptToDTO(t.ConceptDocument),
+				Via:     via,
+				Synonyms: synonymDiffDTO{
+					Shared:     t.Synonyms.Shared,
+					OnlyHere:   synonymNamesToDTO(t.Synonyms.OnlyHere),
+					OnlySource: synonymNamesToDTO(t.Synonyms.OnlySource),
+				},
+				Distribution: distributionDiffToDTO(t.Distribution),
+			}
+		}
+		writeJSON(w, out)
+	}
+}
+
+func treatmentConceptToDTO(doc output.ConceptDocument) treatmentConceptDTO {
+	c := doc.Concept
+	display := c.AcceptedName.Canonical
+	if c.AcceptedName.Authorship != "" {
+		display = display + " " + c.AcceptedName.Authorship
+	}
+	return treatmentConceptDTO{
+		ConceptID:  c.ID,
+		Display:    display,
+		Canonical:  c.AcceptedName.Canonical,
+		Authorship: c.AcceptedName.Authorship,
+		Rank:       string(c.Rank),
+		Status:     string(c.Status),
+		Backbone:   backboneRefDTO{ID: c.BackboneID, Version: c.BackboneVersion},
+		Sec:        optionalSecToDTO(doc.Sec),
+	}
+}
+
+func synonymNamesToDTO(synonyms []output.SynonymName) []synonymDTO {
+	out := make([]synonymDTO, len(synonyms))
+	for i, s := range synonyms {
+		out[i] = synonymDTO{Canonical: s.Canonical, Authorship: s.Authorship, Homotypic: s.Homotypic}
+	}
+	return out
+}
+
+func distributionDiffToDTO(d application.DistributionDiff) distributionDiffDTO {
+	dists := func(in []domain.Distribution) []distributionDTO {
+		out := make([]distributionDTO, len(in))
+		for i, x := range in {
+			out[i] = distributionDTO{AreaScheme: x.AreaScheme, AreaCode: x.AreaCode, Status: string(x.Status), Derived: x.Derived}
+		}
+		return out
+	}
+	changed := make([]distributionChangeDTO, len(d.Changed))
+	for i, c := range d.Changed {
+		changed[i] = distributionChangeDTO{AreaScheme: c.Here.AreaScheme, AreaCode: c.Here.AreaCode, Status: string(c.Here.Status), SourceStatus: string(c.Source.Status)}
+	}
+	return distributionDiffDTO{Shared: d.Shared, OnlyHere: dists(d.OnlyHere), OnlySource: dists(d.OnlySource), Changed: changed}
+}
@@ -0,0 +1,81 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+)
+
+type treatmentConceptJSON struct {
+	ConceptID string `json:"concept_id"`
+	Canonical string `json:"canonical"`
+	Status    string `json:"status"`
+	Sec       *struct {
+		ID string `json:"id"`
+	} `json:"sec"`
+}
+
+type treatmentsResponse struct {
+	Source     treatmentConceptJSON `json:"source"`
+	Treatments []struct {
+		Concept  treatmentConceptJSON `json:"concept"`
+		Via      []string             `json:"via"`
+		Synonyms struct {
+			Shared     int   `json:"shared"`
+			OnlyHere   []any `json:"only_here"`
+			OnlySource []any `json:"only_source"`
+		} `json:"synonyms"`
+		Distribution struct {
+			Changed []any `json:"changed"`
+		} `json:"distribution"`
+	} `json:"treatments"`
+}
+
+// TestHandleTreatments_GathersOtherSecSpaces reads the translate fixture's
+// Abies alba from space A: B and C accept the same name, and B is also
+// congruent to A. The treatments come ordered by sec. space id, and the
+// diff arrays are present though the fixture has nothing to compare.
+func TestHandleTreatments_GathersOtherSecSpaces(t *testing.T) {
+	db := translateRepoDB(t, translateRelation("a", "b", "Congruent to"))
+	r := httpx.NewRouter(httpx.Deps{Repo: db})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/"+tConceptA+"/treatments", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	got := decodeJSON[treatmentsResponse](t, rec.Body)
+	if got.Source.ConceptID != tConceptA || got.Source.Sec == nil || got.Source.Sec.ID != tSecA {
+		t.Errorf("source = %+v, want %s in %s", got.Source, tConceptA, tSecA)
+	}
+	var lines []string
+	for _, tr := range got.Treatments {
+		lines = append(lines, tr.Concept.ConceptID+"["+strings.Join(tr.Via, " ")+"]")
+		if tr.Concept.Canonical != "Abies alba" || tr.Concept.Status != "ACCEPTED" || tr.Synonyms.OnlyHere == nil || tr.Synonyms.OnlySource == nil || tr.Distribution.Changed == nil {
+			t.Errorf("treatment = %+v, want accepted Abies alba with the diff arrays present", tr)
+		}
+	}
+	if got, want := strings.Join(lines, " "), tConceptC+"[name] "+tConceptB+"[name congruent]"; got != want {
+		t.Errorf("treatments = %q, want %q (sec-hegi before sec-wh98)", got, want)
+	}
+}
+
+func TestHandleTreatments_UnlinkedAndUnknown(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/wcvp:concept:415853/treatments", nil))
+	got := decodeJSON[treatmentsResponse](t, rec.Body)
+	if got.Source.Canonical != "Festuca ovina" || got.Treatments == nil || len(got.Treatments) != 0 {
+		t.Errorf("response = %+v, want Festuca ovina with an empty treatments array", got)
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/wcvp:concept:0/treatments", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404 (body: %s)", rec.Code, rec.Body.String())
+	}
+}
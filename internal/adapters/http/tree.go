@@ -0,0 +1,162 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/httperr"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// taxonNodeDTO is one child in a GET /v1/concept/{id}/children response.
+// child_count is always present: 0 is the answer "leaf", which is what a
+// tree view needs to decide whether to draw an expander.
+type taxonNodeDTO struct {
+	ConceptID  string `json:"concept_id"`
+	Canonical  string `json:"canonical"`
+	Rank       string `json:"rank"`
+	Status     string `json:"status"`
+	ChildCount int    `json:"child_count"`
+}
+
+// childrenResponseDTO is the GET /v1/concept/{id}/children envelope. total
+// counts every child matching the filters; limit and offset echo the
+// RESOLVED page, so a caller that sent no limit sees the default applied.
+type childrenResponseDTO struct {
+	ConceptID string         `json:"concept_id"`
+	Total     int            `json:"total"`
+	Limit     int            `json:"limit"`
+	Offset    int            `json:"offset"`
+	Children  []taxonNodeDTO `json:"children"`
+}
+
+// rankCountDTO is one entry of descendantsResponseDTO.ByRank.
+type rankCountDTO struct {
+	Rank  string `json:"rank"`
+	Count int    `json:"count"`
+}
+
+// descendantsResponseDTO is the GET /v1/concept/{id}/descendants envelope:
+// the concept's descendants per rank, broad to fine. by_rank is an empty
+// array for a leaf, never null.
+type descendantsResponseDTO struct {
+	ConceptID string         `json:"concept_id"`
+	Total     int            `json:"total"`
+	ByRank    []rankCountDTO `json:"by_rank"`
+}
+
+// parseTreeInt parses an optional integer query parameter; empty is 0.
+func parseTreeInt(param string) (int, error) {
+	if param == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(param)
+}
+
+// parseTreeRequest reads the query parameters both tree endpoints share.
+// The 400 message names the offending value, as every INVALID_QUERY does.
+func parseTreeRequest(r *http.Request) (application.ChildrenRequest, error) {
+	query := r.URL.Query()
+	ranks, err := parseSuggestRanks(query.Get("rank"))
+	if err != nil {
+		return application.ChildrenRequest{}, err
+	}
+	limit, err := parseTreeInt(query.Get("limit"))
+	if err != nil {
+		return application.ChildrenRequest{}, fmt.Errorf("limit %q is not an integer", query.Get("limit"))
+	}
+	offset, err := parseTreeInt(query.Get("offset"))
+	if err != nil {
+		return application.ChildrenRequest{}, fmt.Errorf("offset %q is not an integer", query.Get("offset"))
+	}
+	return application.ChildrenRequest{
+		ConceptID: mux.Vars(r)["id"],
+		Ranks:     ranks,
+		Area:      query.Get("area"),
+		Limit:     limit,
+		Offset:    offset,
+	}, nil
+}
+
+// handleChildren serves GET /v1/concept/{id}/children?rank=&area=&limit=&offset=,
+// one page of the concept's direct children ordered by canonical name. An
+// unknown rank, a non-numeric or out-of-range limit/offset report 400
+// INVALID_QUERY; an unknown concept 404 NOT_FOUND; a leaf 200 with an empty
+// `children` array.
+func handleChildren(repo output.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := parseTreeRequest(r)
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+		res, err := application.Children(r.Context(), repo, req)
+		if err != nil {
+			writeTreeError(w, err, r.URL.Query())
+			return
+		}
+		children := make([]taxonNodeDTO, len(res.Nodes))
+		for i, n := range res.Nodes {
+			children[i] = taxonNodeDTO{
+				ConceptID:  n.ConceptID,
+				Canonical:  n.Canonical,
+				Rank:       string(n.Rank),
+				Status:     string(n.Status),
+				ChildCount: n.ChildCount,
+			}
+		}
+		writeJSON(w, childrenResponseDTO{
+			ConceptID: res.ConceptID,
+			Total:     res.Total,
+			Limit:     res.Limit,
+			Offset:    res.Offset,
+			Children:  children,
+		})
+	}
+}
+
+// handleDescendants serves GET /v1/concept/{id}/descendants?rank=&area=, the
+// concept's descendants at every depth counted per rank. Errors as for
+// handleChildren; limit and offset are accepted and ignored.
+func handleDescendants(repo output.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := parseTreeRequest(r)
+		if err != nil {
+			httperr.InvalidQueryError(w, err.Error())
+			return
+		}
+		res, err := application.Descendants(r.Context(), repo, req)
+		if err != nil {
+			writeTreeError(w, err, r.URL.Query())
+			return
+		}
+		byRank := make([]rankCountDTO, len(res.ByRank))
+		for i, c := range res.ByRank {
+			byRank[i] = rankCountDTO{Rank: string(c.Rank), Count: c.Count}
+		}
+		writeJSON(w, descendantsResponseDTO{ConceptID: res.ConceptID, Total: res.Total, ByRank: byRank})
+	}
+}
+
+// writeTreeError maps the tree use cases' named failures onto the error
+// contract, composing each 400 from the raw query value (see
+// writeSynonymsError for why). Anything unrecognized is a 500.
+func writeTreeError(w http.ResponseWriter, err error, query url.Values) {
+	switch {
+	case errors.Is(err, application.ErrInvalidLimit):
+		httperr.InvalidQueryError(w, fmt.Sprintf("limit %q is not in [0, %d]", query.Get("limit"), application.MaxChildrenLimit))
+	case errors.Is(err, application.ErrInvalidOffset):
+		httperr.InvalidQueryError(w, fmt.Sprintf("offset %q is negative", query.Get("offset")))
+	case errors.Is(err, domain.ErrNotFound):
+		httperr.Write(w, http.StatusNotFound, httperr.NotFound, "concept not found")
+	default:
+		httperr.InternalError(w)
+	}
+}
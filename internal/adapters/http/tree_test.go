@@ -0,0 +1,116 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpx "github.com/jobrunner/hostus/internal/adapters/http"
+)
+
+type childrenResponse struct {
+	ConceptID string `json:"concept_id"`
+	Total     int    `json:"total"`
+	Limit     int    `json:"limit"`
+	Offset    int    `json:"offset"`
+	Children  []struct {
+		ConceptID  string `json:"concept_id"`
+		Canonical  string `json:"canonical"`
+		Rank       string `json:"rank"`
+		Status     string `json:"status"`
+		ChildCount int    `json:"child_count"`
+	} `json:"children"`
+}
+
+type descendantsResponse struct {
+	ConceptID string `json:"concept_id"`
+	Total     int    `json:"total"`
+	ByRank    []struct {
+		Rank  string `json:"rank"`
+		Count int    `json:"count"`
+	} `json:"by_rank"`
+}
+
+// TestHandleChildren_ListsTheGenusSpecies walks the WCVP fixture's one
+// genus down a level: Corynephorus holds Corynephorus canescens, a leaf.
+func TestHandleChildren_ListsTheGenusSpecies(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/"+corynephorusGenusID+"/children", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	got := decodeJSON[childrenResponse](t, rec.Body)
+	if got.Total != 1 || got.Limit != 100 || got.Offset != 0 || len(got.Children) != 1 {
+		t.Fatalf("response = %+v, want one child on a default page of 100", got)
+	}
+	c := got.Children[0]
+	if c.ConceptID != corynephorusConceptID || c.Canonical != "Corynephorus canescens" || c.Rank != "SPECIES" || c.Status != "ACCEPTED" || c.ChildCount != 0 {
+		t.Errorf("children[0] = %+v, want the accepted leaf species Corynephorus canescens", c)
+	}
+
+	// A leaf is an empty page, not a 404.
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/"+corynephorusConceptID+"/children", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("leaf status = %d, want 200", rec.Code)
+	}
+	if leaf := decodeJSON[childrenResponse](t, rec.Body); leaf.Children == nil || len(leaf.Children) != 0 {
+		t.Errorf("leaf children = %v, want []", leaf.Children)
+	}
+}
+
+// TestHandleChildren_AreaFilter pins the area filter on real distribution:
+// Corynephorus canescens is recorded in Austria and nowhere in New Zealand.
+func TestHandleChildren_AreaFilter(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	for area, want := range map[string]int{"AUT": 1, "NZN": 0} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/"+corynephorusGenusID+"/children?area="+area, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("area=%s: status = %d, want 200", area, rec.Code)
+		}
+		if got := decodeJSON[childrenResponse](t, rec.Body); got.Total != want || len(got.Children) != want {
+			t.Errorf("area=%s: total = %d, %d children, want %d", area, got.Total, len(got.Children), want)
+		}
+	}
+}
+
+func TestHandleDescendants_CountsPerRank(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/concept/"+corynephorusGenusID+"/descendants", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", rec.Code, rec.Body.String())
+	}
+	got := decodeJSON[descendantsResponse](t, rec.Body)
+	if got.Total != 1 || len(got.ByRank) != 1 || got.ByRank[0].Rank != "SPECIES" || got.ByRank[0].Count != 1 {
+		t.Errorf("response = %+v, want one SPECIES descendant", got)
+	}
+}
+
+func TestHandleTree_Errors(t *testing.T) {
+	r := httpx.NewRouter(httpx.Deps{Repo: seededRepo(t)})
+
+	for _, tc := range []struct {
+		path string
+		want int
+	}{
+		{"/v1/concept/wcvp:concept:0/children", http.StatusNotFound},
+		{"/v1/concept/wcvp:concept:0/descendants", http.StatusNotFound},
+		{"/v1/concept/" + corynephorusGenusID + "/children?limit=2001", http.StatusBadRequest},
+		{"/v1/concept/" + corynephorusGenusID + "/children?limit=-1", http.StatusBadRequest},
+		{"/v1/concept/" + corynephorusGenusID + "/children?limit=ten", http.StatusBadRequest},
+		{"/v1/concept/" + corynephorusGenusID + "/children?offset=-1", http.StatusBadRequest},
+		{"/v1/concept/" + corynephorusGenusID + "/descendants?rank=tribe-ish", http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if rec.Code != tc.want {
+			t.Errorf("GET %s: status = %d, want %d (body: %s)", tc.path, rec.Code, tc.want, rec.Body.String())
+		}
+	}
+}
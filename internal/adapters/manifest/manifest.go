@@ -129,13 +129,56 @@ type NameSpace struct {
 	Redistribution string `yaml:"redistribution" json:"redistribution"`
 }
 
+// DistributionSource is one pinned regional DISTRIBUTION source entry: a
+// checklist (Euro+Med, a Bavarian county checklist, ...) that records which
+// taxa occur in which areas of its own scheme, pinned by its canonical
+// distribution CSV (see internal/adapters/distribution and
+// pipelines/README.md's "Canonical CSV contract (distributions)"). Path is
+// resolved to an absolute path relative to the manifest file by Parse,
+// exactly like Backbone.Path.
+//
+// License/SourceURL are optional, as for NameSpace: the regional checklists
+// are mostly the sources without a findable license, which is why
+// Redistribution stays schema-required and gates ExportBundle.
+type DistributionSource struct {
+	ID        string `yaml:"id" json:"id"`
+	Version   string `yaml:"version" json:"version"`
+	License   string `yaml:"license,omitempty" json:"license,omitempty"`
+	SourceURL string `yaml:"source,omitempty" json:"source,omitempty"`
+	Path      string `yaml:"path" json:"path"`
+	Note      string `yaml:"note,omitempty" json:"note,omitempty"`
+	// Redistribution is required (schema-enforced): allowed|restricted|unknown.
+	Redistribution string `yaml:"redistribution" json:"redistribution"`
+}
+
+// FrequencySource is one pinned taxon-FREQUENCY source entry: per-taxon
+// occurrence counts (a national relevé database export, a locally dumped
+// GBIF occurrence count) that suggest ranking reads as a popularity signal,
+// pinned by its canonical frequency CSV (see internal/adapters/frequency and
+// pipelines/README.md's "Canonical CSV contract (frequencies)"). Path is
+// resolved to an absolute path relative to the manifest file by Parse,
+// exactly like Backbone.Path. License/SourceURL are optional, as for
+// DistributionSource.
+type FrequencySource struct {
+	ID        string `yaml:"id" json:"id"`
+	Version   string `yaml:"version" json:"version"`
+	License   string `yaml:"license,omitempty" json:"license,omitempty"`
+	SourceURL string `yaml:"source,omitempty" json:"source,omitempty"`
+	Path      string `yaml:"path" json:"path"`
+	Note      string `yaml:"note,omitempty" json:"note,omitempty"`
+	// Redistribution is required (schema-enforced): allowed|restricted|unknown.
+	Redistribution string `yaml:"redistribution" json:"redistribution"`
+}
+
 // Dataset is the parsed, validated contents of a dataset.yaml manifest.
 type Dataset struct {
-	Backbones         []Backbone        `yaml:"backbones" json:"backbones"`
-	TraitVocabularies []TraitVocabulary `yaml:"trait_vocabularies,omitempty" json:"trait_vocabularies,omitempty"`
-	XrefSources       []XrefSource      `yaml:"xref_sources,omitempty" json:"xref_sources,omitempty"`
-	ConceptSources    []ConceptSource   `yaml:"concept_sources,omitempty" json:"concept_sources,omitempty"`
-	NameSpaces        []NameSpace       `yaml:"name_spaces,omitempty" json:"name_spaces,omitempty"`
+	Backbones         []Backbone           `yaml:"backbones" json:"backbones"`
+	TraitVocabularies []TraitVocabulary    `yaml:"trait_vocabularies,omitempty" json:"trait_vocabularies,omitempty"`
+	XrefSources       []XrefSource         `yaml:"xref_sources,omitempty" json:"xref_sources,omitempty"`
+	ConceptSources    []ConceptSource      `yaml:"concept_sources,omitempty" json:"concept_sources,omitempty"`
+	NameSpaces        []NameSpace          `yaml:"name_spaces,omitempty" json:"name_spaces,omitempty"`
+	Distributions     []DistributionSource `yaml:"distributions,omitempty" json:"distributions,omitempty"`
+	Frequencies       []FrequencySource    `yaml:"frequencies,omitempty" json:"frequencies,omitempty"`
 
 	// Raw holds the exact bytes read from disk, and ManifestSHA their
 	// SHA-256 hex digest — so an ingest can record manifest_sha and bind
@@ -228,4 +271,10 @@ func resolvePaths(ds *Dataset, baseDir string) {
 	for i := range ds.NameSpaces {
 		ds.NameSpaces[i].Path = resolve(ds.NameSpaces[i].Path)
 	}
+	for i := range ds.Distributions {
+		ds.Distributions[i].Path = resolve(ds.Distributions[i].Path)
+	}
+	for i := range ds.Frequencies {
+		ds.Frequencies[i].Path = resolve(ds.Frequencies[i].Path)
+	}
 }
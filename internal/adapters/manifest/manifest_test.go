@@ -471,3 +471,44 @@ func TestParse_NameSpaceWithoutRedistributionIsRejected(t *testing.T) {
 		t.Errorf("Parse error = %q, want it to name the missing field", err)
 	}
 }
+
+func TestParse_ValidManifestDistributions(t *testing.T) {
+	ds, err := manifest.Parse("testdata/dataset-valid.yaml")
+	if err != nil {
+		t.Fatalf("Parse: unexpected error: %v", err)
+	}
+
+	if got, want := len(ds.Distributions), 1; got != want {
+		t.Fatalf("len(Distributions) = %d, want %d", got, want)
+	}
+	d := ds.Distributions[0]
+	if d.ID != "euromed" || d.Version != "2026-03" {
+		t.Errorf("Distributions[0] id/version = %q/%q, want euromed/2026-03", d.ID, d.Version)
+	}
+	if d.Redistribution != "restricted" {
+		t.Errorf("Distributions[0].Redistribution = %q, want %q", d.Redistribution, "restricted")
+	}
+	wantPath := filepath.Join("testdata", "..", "..", "distribution", "testdata", "euromed-sample.csv")
+	if d.Path != wantPath {
+		t.Errorf("Distributions[0].Path = %q, want %q", d.Path, wantPath)
+	}
+}
+
+func TestParse_ValidManifestFrequencies(t *testing.T) {
+	ds, err := manifest.Parse("testdata/dataset-valid.yaml")
+	if err != nil {
+		t.Fatalf("Parse: unexpected error: %v", err)
+	}
+
+	if got, want := len(ds.Frequencies), 1; got != want {
+		t.Fatalf("len(Frequencies) = %d, want %d", got, want)
+	}
+	f := ds.Frequencies[0]
+	if f.ID != "veg_relevees" || f.Version != "2026-01" || f.Redistribution != "unknown" {
+		t.Errorf("Frequencies[0] = %+v, want veg_relevees/2026-01, redistribution unknown", f)
+	}
+	wantPath := filepath.Join("testdata", "..", "..", "frequency", "testdata", "relevees-sample.csv")
+	if f.Path != wantPath {
+		t.Errorf("Frequencies[0].Path = %q, want %q", f.Path, wantPath)
+	}
+}
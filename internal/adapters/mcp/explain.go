@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/explain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// ---- explain_match ----------------------------------------------------------
+
+type explainMatchIn struct {
+	Names         []string `json:"names" jsonschema:"verbatim names to resolve, e.g. Festuca ovina agg."`
+	EntryBackbone string   `json:"entry_backbone,omitempty" jsonschema:"restrict resolution to one backbone id, as POST /v1/match entry_backbone"`
+	EntrySec      string   `json:"entry_sec,omitempty" jsonschema:"restrict resolution to one sec. reference id, as POST /v1/match entry_sec"`
+	Area          string   `json:"area,omitempty" jsonschema:"WGSRPD code of any level, ISO 3166 code (DE, DE-BY) or scheme:code of a regional checklist (euromed:Ge) the names were recorded in, as POST /v1/match area"`
+}
+
+type explainMatchOut struct {
+	Results []explainedMatch `json:"results"`
+	Count   int              `json:"count"`
+}
+
+// explainedMatch is one result with its trace. The trace is the shared
+// explain.Trace, embedded so its fields sit beside the result's — the same
+// field names as POST /v1/match?explain=true, so a curator reading both sees
+// one vocabulary.
+type explainedMatch struct {
+	MatchType         string   `json:"match_type"`
+	Confidence        float64  `json:"confidence"`
+	ConceptID         string   `json:"concept_id,omitempty"`
+	Candidates        []string `json:"candidates,omitempty"`
+	RequiresReview    bool     `json:"requires_review,omitempty"`
+	Note              string   `json:"note,omitempty"`
+	InArea            *bool    `json:"in_area,omitempty"`
+	OutsideKnownRange *bool    `json:"outside_known_range,omitempty"`
+	explain.Trace
+}
+
+func addExplainMatch(srv *sdkmcp.Server, repo output.Repository) {
+	sdkmcp.AddTool(srv, &sdkmcp.Tool{
+		Name: "explain_match",
+		Description: "Resolve verbatim names exactly as POST /v1/match does and return, per name, " +
+			"the parsed canonical/author, the path taken, every key looked up with its candidates " +
+			"and tie-break decision, and the scored fuzzy pool.",
+	}, func(ctx context.Context, _ *sdkmcp.CallToolRequest, in explainMatchIn) (*sdkmcp.CallToolResult, explainMatchOut, error) {
+		if len(in.Names) == 0 {
+			return nil, explainMatchOut{}, fmt.Errorf("names is required")
+		}
+		reqs := make([]application.MatchRequest, len(in.Names))
+		for i, n := range in.Names {
+			reqs[i] = application.MatchRequest{ID: n, Verbatim: n}
+		}
+		results, err := application.ExplainMatches(ctx, repo, reqs, "",
+			application.MatchFilter{Backbone: in.EntryBackbone, Sec: in.EntrySec, Area: in.Area})
+		if err != nil {
+			return nil, explainMatchOut{}, err
+		}
+		out := explainMatchOut{Results: make([]explainedMatch, 0, len(results)), Count: len(results)}
+		for _, r := range results {
+			out.Results = append(out.Results, toExplainedMatch(r))
+		}
+		return nil, out, nil
+	})
+}
+
+// matchTypeUnresolvable mirrors the HTTP adapter's spelling of the zero
+// MatchType.
+const matchTypeUnresolvable = "unresolvable"
+
+func toExplainedMatch(r application.MatchResult) explainedMatch {
+	mt := string(r.MatchType)
+	if mt == "" {
+		mt = matchTypeUnresolvable
+	}
+	e := explainedMatch{
+		MatchType:      mt,
+		Confidence:     r.Confidence,
+		ConceptID:      r.ConceptID,
+		Candidates:     r.Candidates,
+		RequiresReview: r.RequiresReview,
+		Note:           r.Note,
+		Trace:          explain.FromTrace(r.Trace),
+	}
+	if r.Range != nil {
+		e.InArea = &r.Range.InArea
+		e.OutsideKnownRange = &r.Range.OutsideKnownRange
+	}
+	return e
+}
@@ -0,0 +1,87 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	mcpAdapter "github.com/jobrunner/hostus/internal/adapters/mcp"
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/adapters/telemetry"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// seedOneGenus ingests a single accepted genus concept, enough for
+// explain_match to have something to resolve and trace.
+func seedOneGenus(t *testing.T) *sqlite.DB {
+	t.Helper()
+	db, err := sqlite.Open(":memory:")
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	tx, err := db.BeginIngest(context.Background(), domain.BackboneVersion{ID: "test", Version: "v1"})
+	if err != nil {
+		t.Fatalf("BeginIngest: %v", err)
+	}
+	name := domain.Name{ID: "test:name:festuca", Canonical: "Festuca", Rank: domain.RankGenus}
+	concept := domain.Concept{ID: "test:concept:festuca", BackboneID: "test", AcceptedName: name, Rank: domain.RankGenus, Status: domain.StatusAccepted}
+	if err := tx.UpsertName(name); err != nil {
+		t.Fatalf("UpsertName: %v", err)
+	}
+	if err := tx.UpsertConcept(concept); err != nil {
+		t.Fatalf("UpsertConcept: %v", err)
+	}
+	if err := tx.LinkName(concept.ID, name.ID, "accepted", nil); err != nil {
+		t.Fatalf("LinkName: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	return db
+}
+
+// TestExplainMatchTool resolves one name through the tool and checks the trace
+// reaches the client: the path, the key looked up and its decision.
+func TestExplainMatchTool(t *testing.T) {
+	srv := mcpAdapter.NewServer(telemetry.NewRingLog(16), telemetry.NewMemoryExporter(16),
+		mcpAdapter.WithRepository(seedOneGenus(t)))
+
+	out, err := srv.CallTool(context.Background(), "explain_match", map[string]any{"names": []string{"Festuca sp."}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Results []struct {
+			MatchType string `json:"match_type"`
+			ConceptID string `json:"concept_id"`
+			Path      string `json:"path"`
+			Lookups   []struct {
+				Key      string `json:"key"`
+				Decision string `json:"decision"`
+			} `json:"lookups"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decoding %q: %v", out, err)
+	}
+	if len(got.Results) != 1 {
+		t.Fatalf("results = %+v, want one", got.Results)
+	}
+	r := got.Results[0]
+	if r.MatchType != "higher_rank" || r.ConceptID != "test:concept:festuca" || r.Path != "higher_rank" {
+		t.Errorf("result = %+v, want higher_rank on test:concept:festuca", r)
+	}
+	if len(r.Lookups) != 1 || r.Lookups[0].Key != "festuca" || r.Lookups[0].Decision != "single_concept" {
+		t.Errorf("lookups = %+v, want one single_concept lookup of festuca", r.Lookups)
+	}
+}
+
+// TestExplainMatchToolAbsentWithoutRepository pins that a repo-less server —
+// the same degraded mode serve tolerates — simply has no such tool.
+func TestExplainMatchToolAbsentWithoutRepository(t *testing.T) {
+	srv := mcpAdapter.NewServer(telemetry.NewRingLog(16), telemetry.NewMemoryExporter(16))
+	if _, err := srv.CallTool(context.Background(), "explain_match", map[string]any{"names": []string{"Festuca sp."}}); err == nil {
+		t.Error("explain_match callable without a repository, want it unregistered")
+	}
+}
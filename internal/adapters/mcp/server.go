@@ -1,8 +1,9 @@
 // Package mcp exposes hostus' in-memory logs and spans (telemetry.RingLog,
 // telemetry.MemoryExporter — see S7) as a read-only stdio Model Context
 // Protocol server, so Claude Code can inspect a running hostus instance
-// while debugging. All four tools only read the injected buffers; nothing
-// they do can mutate hostus' state.
+// while debugging. The four telemetry tools only read the injected buffers;
+// explain_match, mounted when a repository is supplied, only reads the index.
+// Nothing any tool does can mutate hostus' state.
 package mcp
 
 import (
@@ -13,6 +14,7 @@ import (
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jobrunner/hostus/internal/adapters/telemetry"
+	"github.com/jobrunner/hostus/internal/ports/output"
 )
 
 // serverName/serverVersion identify this MCP server to connecting clients
@@ -28,15 +30,37 @@ type Server struct {
 	mcpServer *sdkmcp.Server
 }
 
+// Option adjusts what NewServer mounts beyond the four telemetry tools.
+type Option func(*options)
+
+type options struct {
+	repo output.Repository
+}
+
+// WithRepository mounts explain_match over repo. Without it (or with a nil
+// repo — serve tolerates a missing database, and so does this) the tool is
+// simply absent, exactly as the /v1 routes are absent from a repo-less router.
+func WithRepository(repo output.Repository) Option {
+	return func(o *options) { o.repo = repo }
+}
+
 // NewServer builds a Server that serves get_recent_logs, tail_errors,
-// get_trace, and list_spans over log and spans. Both buffers are read-only
-// from the tools' perspective — nothing here writes back into them.
-func NewServer(log *telemetry.RingLog, spans *telemetry.MemoryExporter) *Server {
+// get_trace, and list_spans over log and spans, plus explain_match when
+// WithRepository supplies an index. Both buffers are read-only from the
+// tools' perspective — nothing here writes back into them.
+func NewServer(log *telemetry.RingLog, spans *telemetry.MemoryExporter, opts ...Option) *Server {
+	var o options
+	for _, apply := range opts {
+		apply(&o)
+	}
 	srv := sdkmcp.NewServer(&sdkmcp.Implementation{
 		Name:    serverName,
 		Version: serverVersion,
 	}, nil)
 	registerTools(srv, log, spans)
+	if o.repo != nil {
+		addExplainMatch(srv, o.repo)
+	}
 	return &Server{mcpServer: srv}
 }
 
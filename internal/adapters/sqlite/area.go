@@ -3,37 +3,78 @@ package sqlite
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
 )
 
-// UpsertArea records one area's name keyed by (scheme, code). INSERT OR IGNORE:
-// the first non-empty name for a code wins, so calling it once per distinct
-// area during ingest is safe and order-independent. An empty name writes
-// nothing (the code stays nameless rather than storing "").
+// UpsertArea records one area's name keyed by (scheme, code). The first
+// non-empty name for a code wins, so calling it once per distinct area during
+// ingest is safe and order-independent. An empty name writes nothing (the
+// code stays nameless rather than storing ""). A non-empty Parent is always
+// recorded, also on a row that already exists: the WGSRPD hierarchy links an
+// area a backbone's distribution data may have named first.
 func (t *ingestTx) UpsertArea(a domain.Area) error {
 	if a.Name == "" {
 		return nil
 	}
-	if _, err := t.tx.ExecContext(t.ctx,
-		`INSERT OR IGNORE INTO area (scheme, code, name) VALUES (?, ?, ?)`,
-		a.Scheme, a.Code, a.Name); err != nil {
+	if _, err := t.tx.ExecContext(t.ctx, `
+		INSERT INTO area (scheme, code, name, parent) VALUES (?, ?, ?, ?)
+		ON CONFLICT (scheme, code) DO UPDATE SET parent = excluded.parent
+		WHERE excluded.parent <> ''`,
+		a.Scheme, a.Code, a.Name, a.Parent); err != nil {
 		return fmt.Errorf("sqlite: upserting area %s:%s: %w", a.Scheme, a.Code, err)
 	}
 	return nil
 }
 
+// AddAreaAlias records the WGSRPD codes a.Alias stands for, first deleting
+// any codes an earlier ingest recorded for it, so a re-ingest after the
+// alias table changed leaves no stale mapping behind.
+func (t *ingestTx) AddAreaAlias(a domain.AreaAlias) error {
+	if _, err := t.tx.ExecContext(t.ctx, `DELETE FROM area_alias WHERE alias = ?`, a.Alias); err != nil {
+		return fmt.Errorf("sqlite: replacing area alias %s: %w", a.Alias, err)
+	}
+	for _, code := range a.Codes {
+		if _, err := t.tx.ExecContext(t.ctx,
+			`INSERT OR IGNORE INTO area_alias (alias, code) VALUES (?, ?)`, a.Alias, code); err != nil {
+			return fmt.Errorf("sqlite: inserting area alias %s -> %s: %w", a.Alias, code, err)
+		}
+	}
+	return nil
+}
+
+// wgsrpdParentSchemeSQL is domain.WGSRPDParentScheme as a SQL expression
+// over a child row aliased c: the scheme c.parent is a code in, NULL for a
+// level-1 unit and for every scheme outside the hierarchy.
+const wgsrpdParentSchemeSQL = `CASE c.scheme
+	WHEN 'wgsrpd_l2' THEN 'wgsrpd_l1'
+	WHEN 'wgsrpd_l3' THEN 'wgsrpd_l2'
+	WHEN 'wgsrpd_l4' THEN 'wgsrpd_l3' END`
+
 // Areas lists every distribution area that carries data — a DISTINCT
-// (area_scheme, area_code) from the distribution table — each joined to its
-// ingested name (empty when none), ordered by (scheme, code). Only
-// areas-with-data are returned, so a picker built from this never offers a
-// region that yields nothing.
+// (area_scheme, area_code) from the distribution table — plus, once the WGSRPD
+// hierarchy is ingested, every level-1 and level-2 unit above one of them, so
+// the result is a closed tree: each Parent it names is in it. Each area is
+// joined to its ingested name and parent (empty when none), ordered by
+// (scheme, code). Only areas-with-data and their ancestors are returned, so a
+// picker built from this never offers a region that yields nothing.
 func (db *DB) Areas(ctx context.Context) ([]domain.Area, error) {
 	rows, err := db.sql.QueryContext(ctx, `
-		SELECT d.area_scheme, d.area_code, COALESCE(a.name, '')
-		FROM (SELECT DISTINCT area_scheme, area_code FROM distribution) d
-		LEFT JOIN area a ON a.scheme = d.area_scheme AND a.code = d.area_code
-		ORDER BY d.area_scheme, d.area_code`)
+		WITH RECURSIVE with_data(scheme, code) AS (
+			SELECT DISTINCT area_scheme, area_code FROM distribution
+			UNION
+			SELECT p.scheme, p.code
+			FROM with_data w
+			JOIN area c ON c.scheme = w.scheme AND c.code = w.code
+			JOIN area p ON p.code = c.parent AND p.scheme = `+wgsrpdParentSchemeSQL+`
+		)
+		SELECT w.scheme, w.code, COALESCE(a.name, ''), COALESCE(a.parent, '')
+		FROM with_data w
+		LEFT JOIN area a ON a.scheme = w.scheme AND a.code = w.code
+		ORDER BY w.scheme, w.code`)
 	if err != nil {
 		return nil, fmt.Errorf("sqlite: querying areas: %w", err)
 	}
@@ -42,7 +83,7 @@ func (db *DB) Areas(ctx context.Context) ([]domain.Area, error) {
 	var out []domain.Area
 	for rows.Next() {
 		var a domain.Area
-		if err := rows.Scan(&a.Scheme, &a.Code, &a.Name); err != nil {
+		if err := rows.Scan(&a.Scheme, &a.Code, &a.Name, &a.Parent); err != nil {
 			return nil, fmt.Errorf("sqlite: scanning area row: %w", err)
 		}
 		out = append(out, a)
@@ -52,3 +93,220 @@ func (db *DB) Areas(ctx context.Context) ([]domain.Area, error) {
 	}
 	return out, nil
 }
+
+// areaFilter resolves an area filter value (output.SuggestOpts.Area,
+// Repository.AreaPresence' area, one part of BundleOpts.Area) into the one
+// distribution scheme it filters on and the codes to match in it.
+//
+// A value of the form "scheme:code" names a regional scheme a
+// `distributions:` source was ingested in ("euromed:Ge", "bayern:09162"):
+// the code is matched verbatim, case and all, since regional schemes are
+// not upper-case by convention. A WGSRPD scheme prefix ("wgsrpd_l2:11")
+// and a bare value ("GER", "DE", "11") both resolve through areaCodes to
+// wgsrpd_l3. An empty area returns no codes (no area filter).
+func (db *DB) areaFilter(ctx context.Context, area string) (string, []string, error) {
+	area = strings.TrimSpace(area)
+	if scheme, code, ok := strings.Cut(area, ":"); ok {
+		scheme = strings.ToLower(strings.TrimSpace(scheme))
+		code = strings.TrimSpace(code)
+		if domain.WGSRPDLevel(scheme) == 0 {
+			return scheme, []string{code}, nil
+		}
+		area = code
+	}
+	codes, err := db.areaCodes(ctx, area)
+	if err != nil {
+		return "", nil, err
+	}
+	return domain.AreaSchemeWGSRPDL3, codes, nil
+}
+
+// areaCodes resolves a WGSRPD area filter value (see areaFilter) into the
+// WGSRPD level-3 codes to match against distribution, which is recorded at
+// level 3 only. aliasCodes resolves an ISO 3166 alias; each resulting code
+// is then looked up in the ingested hierarchy:
+//
+//   - a level-1 or level-2 unit expands to every level-3 area below it
+//     ("11" Middle Europe -> AUT, BGM, CZE, GER, ...);
+//   - a level-3 code is itself;
+//   - a level-4 unit resolves to the level-3 area containing it — the
+//     finest level data exists at, so "GER-OO" filters like "GER";
+//   - a code the hierarchy does not know is passed through unchanged, as
+//     before the hierarchy existed: a level-3 code on a database ingested
+//     without it still filters, an unknown code simply matches nothing.
+//
+// The result is sorted and free of duplicates. An empty area returns nil
+// (no area filter).
+func (db *DB) areaCodes(ctx context.Context, area string) ([]string, error) {
+	seen := make(map[string]bool)
+	var out []string
+	aliased, err := db.aliasCodes(ctx, area)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range aliased {
+		expanded, err := db.expandAreaCode(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if len(expanded) == 0 {
+			expanded = []string{code}
+		}
+		for _, c := range expanded {
+			if !seen[c] {
+				seen[c] = true
+				out = append(out, c)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// legacyAreaAlias holds the three ISO 3166 aliases hostus resolved before
+// area_alias existed. aliasCodes falls back to them only while area_alias
+// is empty, so a database ingested before the table existed keeps
+// answering "DE", "AT" and "CH" instead of silently matching nothing.
+var legacyAreaAlias = map[string][]string{
+	"DE": {"GER"},
+	"AT": {"AUT"},
+	"CH": {"SWI"},
+}
+
+// aliasCodes resolves the alias step of an area filter value: an alias in
+// area_alias becomes its WGSRPD code(s), anything else is returned as the
+// one upper-cased code it names (level 1-4 alike — areaCodes expands it from
+// there), so a caller can always bypass the alias table by supplying an
+// exact code. A database ingested before the alias table existed has no
+// rows in it and resolves through legacyAreaAlias instead. An empty area
+// returns nil (no area filter — see Suggest's doc comment on the
+// empty-Area convention).
+func (db *DB) aliasCodes(ctx context.Context, area string) ([]string, error) {
+	key := strings.ToUpper(strings.TrimSpace(area))
+	if key == "" {
+		return nil, nil
+	}
+	rows, err := db.sql.QueryContext(ctx,
+		`SELECT code FROM area_alias WHERE alias = ? ORDER BY code`, key)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: looking up area alias %q: %w", key, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning area alias row: %w", err)
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating area alias rows: %w", err)
+	}
+	if len(out) > 0 {
+		return out, nil
+	}
+	if codes, ok := legacyAreaAlias[key]; ok {
+		var ingested bool
+		if err := db.sql.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM area_alias)`).Scan(&ingested); err != nil {
+			return nil, fmt.Errorf("sqlite: checking for ingested area aliases: %w", err)
+		}
+		if !ingested {
+			return codes, nil
+		}
+	}
+	return []string{key}, nil
+}
+
+// expandAreaCode walks the area hierarchy from the unit(s) named code down
+// to level 3 (see areaCodes). WGSRPD codes are distinct across levels
+// ("1", "11", "GER", "GER-OO"), so code alone identifies the starting unit.
+// Returns nil when code is no hierarchy unit.
+func (db *DB) expandAreaCode(ctx context.Context, code string) ([]string, error) {
+	rows, err := db.sql.QueryContext(ctx, `
+		WITH RECURSIVE unit(scheme, code) AS (
+			SELECT scheme, code FROM area
+			WHERE code = ?1 AND scheme IN ('wgsrpd_l1', 'wgsrpd_l2', 'wgsrpd_l3')
+			UNION
+			SELECT 'wgsrpd_l3', parent FROM area
+			WHERE code = ?1 AND scheme = 'wgsrpd_l4' AND parent <> ''
+			UNION
+			SELECT c.scheme, c.code
+			FROM unit u
+			JOIN area c ON c.parent = u.code AND `+wgsrpdParentSchemeSQL+` = u.scheme
+			WHERE u.scheme IN ('wgsrpd_l1', 'wgsrpd_l2')
+		)
+		SELECT code FROM unit WHERE scheme = 'wgsrpd_l3'`, code)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: expanding area %q: %w", code, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning expanded area row: %w", err)
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating expanded area rows: %w", err)
+	}
+	return out, nil
+}
+
+// AreaPresence answers Repository.AreaPresence from distribution_effective in
+// one grouped query: every concept with an effective row in area's scheme
+// (see areaFilter) is returned, flagged by whether any of those rows lies in
+// area's codes. A concept known only in another scheme has no known range
+// HERE and is absent. Both lists are bound via json_each, so the statement
+// text never varies.
+func (db *DB) AreaPresence(ctx context.Context, area string, conceptIDs []string) (map[string]output.AreaPresence, error) {
+	out := make(map[string]output.AreaPresence, len(conceptIDs))
+	if len(conceptIDs) == 0 {
+		return out, nil
+	}
+	scheme, codes, err := db.areaFilter(ctx, area)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return out, nil
+	}
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return nil, err
+	}
+	codesJSON, err := marshalIDs(codes)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT de.concept_id, MAX(de.area_code IN (SELECT value FROM json_each(?)))
+		FROM distribution_effective de
+		WHERE de.area_scheme = ?
+		  AND de.concept_id IN (SELECT value FROM json_each(?))
+		GROUP BY de.concept_id`, codesJSON, scheme, idsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying area presence: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			id     string
+			inArea bool
+		)
+		if err := rows.Scan(&id, &inArea); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning area presence row: %w", err)
+		}
+		out[id] = output.AreaPresence{InArea: inArea}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating area presence rows: %w", err)
+	}
+	return out, nil
+}
@@ -2,7 +2,9 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/jobrunner/hostus/internal/domain"
@@ -140,3 +142,276 @@ func TestAreas_ClosedDBSurfacesError(t *testing.T) {
 		t.Error("Areas on a closed database: want an error, got nil")
 	}
 }
+
+// TestAreaPresence_SeparatesInOutsideAndUnknown pins the three answers the
+// match range flags are built from: corynephorus (GER + FRA) is in "DE" via
+// the alias and known-but-elsewhere for "AUT"; jacobaea has no distribution
+// and must be ABSENT — "no range known" is not "outside it".
+func TestAreaPresence_SeparatesInOutsideAndUnknown(t *testing.T) {
+	db := openSeededDB(t)
+	ctx := context.Background()
+	if err := db.BuildDistributionClosure(ctx); err != nil {
+		t.Fatalf("BuildDistributionClosure: %v", err)
+	}
+	ids := []string{corynephorusID, jacobaeaID}
+
+	got, err := db.AreaPresence(ctx, "DE", ids)
+	if err != nil {
+		t.Fatalf("AreaPresence(DE): %v", err)
+	}
+	if p, ok := got[corynephorusID]; !ok || !p.InArea {
+		t.Errorf("DE: corynephorus = %+v (present %v), want in area", p, ok)
+	}
+	if _, ok := got[jacobaeaID]; ok {
+		t.Errorf("DE: jacobaea present in %+v, want absent (no distribution)", got)
+	}
+
+	got, err = db.AreaPresence(ctx, "aut", ids)
+	if err != nil {
+		t.Fatalf("AreaPresence(aut): %v", err)
+	}
+	if p, ok := got[corynephorusID]; !ok || p.InArea {
+		t.Errorf("AUT: corynephorus = %+v (present %v), want known range outside the area", p, ok)
+	}
+
+	if got, err := db.AreaPresence(ctx, "", ids); err != nil || len(got) != 0 {
+		t.Errorf("AreaPresence(\"\") = %+v, %v; want empty, nil", got, err)
+	}
+}
+
+// recordEuropeHierarchy writes a cut of the WGSRPD hierarchy into db: Europe,
+// Middle and Southwestern Europe, the seed's GER and FRA plus AUT (which
+// carries no data), and one level-4 unit.
+func recordEuropeHierarchy(t *testing.T, db *DB) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := db.BeginTraitIngest(ctx)
+	if err != nil {
+		t.Fatalf("BeginTraitIngest: %v", err)
+	}
+	for _, u := range []domain.Area{
+		{Scheme: domain.AreaSchemeWGSRPDL1, Code: "1", Name: "Europe"},
+		{Scheme: domain.AreaSchemeWGSRPDL2, Code: "11", Name: "Middle Europe", Parent: "1"},
+		{Scheme: domain.AreaSchemeWGSRPDL2, Code: "12", Name: "Southwestern Europe", Parent: "1"},
+		{Scheme: domain.AreaSchemeWGSRPDL3, Code: "AUT", Name: "Austria", Parent: "11"},
+		{Scheme: domain.AreaSchemeWGSRPDL3, Code: "GER", Name: "Germany", Parent: "11"},
+		{Scheme: domain.AreaSchemeWGSRPDL3, Code: "FRA", Name: "France", Parent: "12"},
+		{Scheme: domain.AreaSchemeWGSRPDL4, Code: "GER-OO", Name: "Germany", Parent: "GER"},
+	} {
+		if err := tx.UpsertArea(u); err != nil {
+			t.Fatalf("UpsertArea(%s): %v", u.Code, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+}
+
+func TestAreaCodes_ExpandsThroughHierarchy(t *testing.T) {
+	db := openSeededDB(t)
+	recordEuropeHierarchy(t, db)
+	ctx := context.Background()
+
+	cases := map[string][]string{
+		"1":      {"AUT", "FRA", "GER"},
+		"11":     {"AUT", "GER"},
+		" 12 ":   {"FRA"},
+		"GER":    {"GER"},
+		"ger-oo": {"GER"},
+		"DE":     {"GER"},
+		"XYZ":    {"XYZ"}, // unknown: passed through, matches nothing
+		"":       nil,
+	}
+	for area, want := range cases {
+		got, err := db.areaCodes(ctx, area)
+		if err != nil {
+			t.Fatalf("areaCodes(%q): %v", area, err)
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("areaCodes(%q) = %v, want %v", area, got, want)
+		}
+	}
+}
+
+// TestAreaCodes_WithoutHierarchyPassesCodesThrough pins the pre-hierarchy
+// behaviour a database ingested before it keeps: a level-3 code filters, a
+// region code matches nothing instead of failing.
+func TestAreaCodes_WithoutHierarchyPassesCodesThrough(t *testing.T) {
+	db := openSeededDB(t)
+	for area, want := range map[string]string{"GER": "GER", "11": "11"} {
+		got, err := db.areaCodes(context.Background(), area)
+		if err != nil || len(got) != 1 || got[0] != want {
+			t.Errorf("areaCodes(%q) = %v, %v; want [%s]", area, got, err, want)
+		}
+	}
+}
+
+// TestAreas_ReturnsAncestorsOfAreasWithData: the tree is closed under Parent,
+// and a hierarchy unit without data below it (AUT) stays out.
+func TestAreas_ReturnsAncestorsOfAreasWithData(t *testing.T) {
+	db := openSeededDB(t)
+	recordEuropeHierarchy(t, db)
+
+	got, err := db.Areas(context.Background())
+	if err != nil {
+		t.Fatalf("Areas: %v", err)
+	}
+	want := []domain.Area{
+		{Scheme: "wgsrpd_l1", Code: "1", Name: "Europe"},
+		{Scheme: "wgsrpd_l2", Code: "11", Name: "Middle Europe", Parent: "1"},
+		{Scheme: "wgsrpd_l2", Code: "12", Name: "Southwestern Europe", Parent: "1"},
+		{Scheme: "wgsrpd_l3", Code: "FRA", Name: "France", Parent: "12"},
+		{Scheme: "wgsrpd_l3", Code: "GER", Name: "Germany", Parent: "11"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Areas = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Areas[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestUpsertArea_ParentLinksAnAlreadyNamedArea: a backbone named GER first;
+// the hierarchy still links it, and the first name stays.
+func TestUpsertArea_ParentLinksAnAlreadyNamedArea(t *testing.T) {
+	db := openSeededDB(t)
+	ctx := context.Background()
+	tx, err := db.BeginIngest(ctx, seedBackboneVersion)
+	if err != nil {
+		t.Fatalf("BeginIngest: %v", err)
+	}
+	if err := tx.UpsertArea(domain.Area{Scheme: "wgsrpd_l3", Code: "GER", Name: "Deutschland"}); err != nil {
+		t.Fatalf("UpsertArea: %v", err)
+	}
+	if err := tx.UpsertArea(domain.Area{Scheme: "wgsrpd_l3", Code: "GER", Name: "Germany", Parent: "11"}); err != nil {
+		t.Fatalf("UpsertArea(parent): %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	var name, parent string
+	if err := db.sql.QueryRow(`SELECT name, parent FROM area WHERE code = 'GER'`).Scan(&name, &parent); err != nil {
+		t.Fatalf("reading area row: %v", err)
+	}
+	if name != "Deutschland" || parent != "11" {
+		t.Errorf("GER = (%q, %q), want (Deutschland, 11)", name, parent)
+	}
+}
+
+// TestOpen_MigratesAreaParentColumn: an area table from before the hierarchy
+// gains parent on Open, existing names intact.
+func TestOpen_MigratesAreaParentColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.sqlite")
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	if _, err := legacy.Exec(`
+		CREATE TABLE area (scheme TEXT NOT NULL, code TEXT NOT NULL, name TEXT NOT NULL, PRIMARY KEY (scheme, code));
+		INSERT INTO area VALUES ('wgsrpd_l3', 'GER', 'Germany');`); err != nil {
+		t.Fatalf("creating pre-migration area table: %v", err)
+	}
+	_ = legacy.Close()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open(legacy): %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	var name, parent string
+	if err := db.sql.QueryRow(`SELECT name, parent FROM area WHERE code = 'GER'`).Scan(&name, &parent); err != nil {
+		t.Fatalf("reading migrated area row: %v", err)
+	}
+	if name != "Germany" || parent != "" {
+		t.Errorf("migrated GER = (%q, %q), want (Germany, \"\")", name, parent)
+	}
+}
+
+// TestExportBundle_RegionAreaCarriesHierarchy: --area 11 scopes the bundle to
+// Middle Europe's level-3 areas, and the bundle keeps the hierarchy, so it
+// expands region codes and renders the tree like its source.
+func TestExportBundle_RegionAreaCarriesHierarchy(t *testing.T) {
+	src := openSeededDB(t)
+	recordEuropeHierarchy(t, src)
+	ctx := context.Background()
+
+	out := filepath.Join(t.TempDir(), "bundle-region.sqlite")
+	if _, err := ExportBundle(ctx, src, out, BundleOpts{Area: "11", AllowRestricted: true}); err != nil {
+		t.Fatalf("ExportBundle: %v", err)
+	}
+	bundle, err := Open(out)
+	if err != nil {
+		t.Fatalf("Open(bundle): %v", err)
+	}
+	defer func() { _ = bundle.Close() }()
+
+	areas, err := bundle.Areas(ctx)
+	if err != nil {
+		t.Fatalf("bundle.Areas: %v", err)
+	}
+	var codes []string
+	for _, a := range areas {
+		codes = append(codes, a.Code)
+	}
+	if got := strings.Join(codes, ","); got != "1,11,GER" {
+		t.Errorf("bundle areas = %s, want 1,11,GER (FRA is outside Middle Europe)", got)
+	}
+	if got, err := bundle.areaCodes(ctx, "1"); err != nil || strings.Join(got, ",") != "AUT,FRA,GER" {
+		t.Errorf("bundle areaCodes(1) = %v, %v; want the full hierarchy's AUT,FRA,GER", got, err)
+	}
+	if got, err := bundle.areaCodes(ctx, "CH"); err != nil || strings.Join(got, ",") != "SWI" {
+		t.Errorf("bundle areaCodes(CH) = %v, %v; want SWI through the copied alias table", got, err)
+	}
+}
+
+// TestAddAreaAlias_ReplacesEarlierCodes: re-ingesting an alias replaces its
+// codes rather than adding to them, and an alias onto a region expands
+// through the hierarchy like the region code itself.
+func TestAddAreaAlias_ReplacesEarlierCodes(t *testing.T) {
+	db := openSeededDB(t)
+	recordEuropeHierarchy(t, db)
+	ctx := context.Background()
+
+	tx, err := db.BeginTraitIngest(ctx)
+	if err != nil {
+		t.Fatalf("BeginTraitIngest: %v", err)
+	}
+	if err := tx.AddAreaAlias(domain.AreaAlias{Alias: "DE", Codes: []string{"11"}}); err != nil {
+		t.Fatalf("AddAreaAlias: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got, err := db.areaCodes(ctx, "de"); err != nil || strings.Join(got, ",") != "AUT,GER" {
+		t.Errorf("areaCodes(de) = %v, %v; want AUT,GER (the seed's DE -> GER replaced by DE -> 11)", got, err)
+	}
+}
+
+// TestAliasCodes_LegacyFallbackOnlyWithoutAliasTable: a database ingested
+// before area_alias existed still resolves DE/AT/CH to GER/AUT/SWI, while
+// one with ingested aliases answers from the table alone.
+func TestAliasCodes_LegacyFallbackOnlyWithoutAliasTable(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	for area, want := range map[string]string{"de": "GER", "AT": "AUT", "ch": "SWI", "FR": "FR"} {
+		if got, err := db.aliasCodes(ctx, area); err != nil || strings.Join(got, ",") != want {
+			t.Errorf("aliasCodes(%s) without alias table = %v, %v; want %s", area, got, err, want)
+		}
+	}
+
+	tx, err := db.BeginTraitIngest(ctx)
+	if err != nil {
+		t.Fatalf("BeginTraitIngest: %v", err)
+	}
+	if err := tx.AddAreaAlias(domain.AreaAlias{Alias: "DE", Codes: []string{"GER"}}); err != nil {
+		t.Fatalf("AddAreaAlias: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got, err := db.aliasCodes(ctx, "CH"); err != nil || strings.Join(got, ",") != "CH" {
+		t.Errorf("aliasCodes(CH) with an ingested alias table = %v, %v; want CH passed through", got, err)
+	}
+}
@@ -15,10 +15,13 @@ import (
 // BundleOpts configures ExportBundle.
 type BundleOpts struct {
 	// Area restricts the bundle to concepts whose distribution intersects
-	// one of a comma-separated list of WGSRPD level-3 area codes (or one
-	// of areaCodes' convenience aliases, e.g. "DE" — the same resolution
-	// Suggest's Area option uses), e.g. "DE,AT,CH" for a Mitteleuropa
-	// bundle. A single value (no comma) keeps working exactly as before.
+	// one of a comma-separated list of WGSRPD level-3 area codes (or an
+	// ingested ISO 3166 alias, e.g. "DE" or "DE-BY", or a WGSRPD level-1/2
+	// unit expanded through the ingested hierarchy — the same resolution
+	// Suggest's Area option uses), e.g. "DE,AT,CH" or simply "11" for a
+	// Mitteleuropa bundle. A part of the form "scheme:code" names an area
+	// of a regional `distributions:` scheme instead ("euromed:Ge"). A single
+	// value (no comma) keeps working exactly as before.
 	// Empty means no filter: every concept in src is copied. See
 	// resolveAreaCodes for the exact comma/alias resolution.
 	Area string
@@ -48,9 +51,9 @@ type BundleReport struct {
 }
 
 // restrictedSource is one non-domain.RedistributionAllowed backbone,
-// trait-vocabulary or xref source that contributed data (a taxon_concept, a
-// trait_value row, or a source-attributed xref row) to a bundle's export
-// scope.
+// trait-vocabulary, xref or distribution source that contributed data (a
+// taxon_concept, a trait_value row, or a source-attributed xref or
+// distribution row) to a bundle's export scope.
 type restrictedSource struct {
 	ID             string
 	Redistribution string
@@ -99,24 +102,25 @@ func restrictedSourceIDs(rs []restrictedSource) string {
 func marshalIDs(ids []string) (string, error) {
 	b, err := json.Marshal(ids)
 	if err != nil {
-		return "", fmt.Errorf("sqlite: bundle: encoding id list: %w", err)
+		return "", fmt.Errorf("sqlite: encoding id list: %w", err)
 	}
 	return string(b), nil
 }
 
-// findRestrictedSources reports every backbone, trait-vocabulary or
-// xref source that contributes data to conceptIDs' scope (a taxon_concept
-// belonging to it, a trait_value row on one of conceptIDs, or an xref row on
-// one of conceptIDs attributed to it via xref.source) and whose
-// redistribution is not domain.RedistributionAllowed, sorted by id for a
-// deterministic result. An empty conceptIDs (nothing in scope) trivially
+// findRestrictedSources reports every backbone, trait-vocabulary, xref,
+// name-space or distribution source that contributes data to conceptIDs'
+// scope (a taxon_concept belonging to it, a trait_value row on one of
+// conceptIDs, an xref row on one of conceptIDs attributed to it via
+// xref.source, one of its name-space entries, or one of its distribution
+// rows inside areaScope) and whose redistribution is not
+// domain.RedistributionAllowed, sorted by id for a deterministic result. An empty conceptIDs (nothing in scope) trivially
 // contributes no sources.
 //
 // The xref query deliberately joins on xref.source, so it covers exactly the
 // rows an xref-source ingest wrote; xrefs the backbone ingest derived from a
 // taxon row carry source NULL and are already gated by the backbone query
 // above (see schema.sql's note on xref.source).
-func findRestrictedSources(ctx context.Context, src *DB, conceptIDs []string) ([]restrictedSource, error) {
+func findRestrictedSources(ctx context.Context, src *DB, conceptIDs []string, areaScope []areaKey) ([]restrictedSource, error) {
 	if len(conceptIDs) == 0 {
 		return nil, nil
 	}
@@ -171,6 +175,36 @@ func findRestrictedSources(ctx context.Context, src *DB, conceptIDs []string) ([
 	}
 	out = append(out, nameSpaces...)
 
+	// Regional distribution sources, joined through exactly the rows
+	// copyDistribution copies (distributionScope): an area-scoped export
+	// carries only the requested areas' rows, so a checklist whose rows all
+	// lie elsewhere contributes nothing and must not block it. Backbone rows
+	// carry source NULL and are gated by the backbone query above.
+	distQuery, distArgs, err := distributionScope(idsJSON, areaScope)
+	if err != nil {
+		return nil, err
+	}
+	distSources, err := queryNonAllowedSources(ctx, src, fmt.Sprintf(`
+		SELECT DISTINCT ds.id, ds.redistribution
+		FROM distribution_source ds
+		JOIN (%s) d ON d.source = ds.id`, distQuery), distArgs)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: bundle: checking distribution source redistribution: %w", err)
+	}
+	out = append(out, distSources...)
+
+	// Frequency sources, joined through the in-scope counts copyFrequencies
+	// copies.
+	freqSources, err := queryNonAllowedSources(ctx, src, `
+		SELECT DISTINCT fs.id, fs.redistribution
+		FROM frequency_source fs
+		JOIN concept_frequency cf ON cf.source = fs.id
+		WHERE cf.concept_id IN (SELECT value FROM json_each(?))`, []any{idsJSON})
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: bundle: checking frequency source redistribution: %w", err)
+	}
+	out = append(out, freqSources...)
+
 	out = dedupeRestrictedSourcesByID(out)
 
 	// out[i].ID < out[j].ID vs. <=: a provable-equivalence-class boundary,
@@ -259,8 +293,8 @@ func dedupeRestrictedSourcesByID(in []restrictedSource) []restrictedSource {
 // queryNonAllowedSources runs query (with args) against src, expecting two
 // columns (id, redistribution), and returns every row whose redistribution
 // is not domain.RedistributionAllowed — the shared scan loop
-// findRestrictedSources' three queries (backbone_version, trait_vocabulary,
-// xref_source) all use.
+// findRestrictedSources' queries (backbone_version, trait_vocabulary,
+// xref_source, name_space, distribution_source) all use.
 func queryNonAllowedSources(ctx context.Context, src *DB, query string, args []any) ([]restrictedSource, error) {
 	rows, err := src.sql.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -310,7 +344,7 @@ func ExportBundle(ctx context.Context, src *DB, out string, opts BundleOpts) (Bu
 		return BundleReport{}, err
 	}
 
-	restricted, err := findRestrictedSources(ctx, src, conceptIDs)
+	restricted, err := findRestrictedSources(ctx, src, conceptIDs, areaScope)
 	if err != nil {
 		return BundleReport{}, err
 	}
@@ -337,57 +371,100 @@ func ExportBundle(ctx context.Context, src *DB, out string, opts BundleOpts) (Bu
 	if err := bundle.BuildDistributionClosure(ctx); err != nil {
 		return BundleReport{}, fmt.Errorf("sqlite: bundle: building distribution closure: %w", err)
 	}
+	// concept_lineage is derived the same way and ships for the same reason:
+	// without it a served bundle's within filter matches nothing.
+	if err := bundle.BuildLineageClosure(ctx); err != nil {
+		return BundleReport{}, fmt.Errorf("sqlite: bundle: building lineage closure: %w", err)
+	}
 	report.Path = out
 	return report, nil
 }
 
+// areaKey is one (scheme, code) pair an area-scoped export is restricted
+// to.
+type areaKey struct {
+	Scheme string
+	Code   string
+}
+
 // resolveAreaCodes turns a BundleOpts.Area value into the deduplicated set
-// of WGSRPD level-3 codes ExportBundle scopes to: area is split on commas
-// (so "DE,AT,CH" resolves each part independently, e.g. via areaCodes'
-// alias table — "DE" alone expands to wgsrpdGermanyL3 — and unions the
-// results), blank parts are skipped, and an all-blank/empty area returns
-// nil, the existing "no filter" convention. A single value with no comma
-// (the pre-multi-area form) behaves exactly as before: it is just a
-// one-element split. Sorted so the result (and its json_each encoding) is
-// deterministic regardless of the order --area listed its parts in.
-func resolveAreaCodes(area string) []string {
-	seen := make(map[string]bool)
-	var out []string
+// of (scheme, code) pairs ExportBundle scopes to: area is split on commas
+// (so "DE,AT,CH" resolves each part independently via src.areaFilter — "DE"
+// alone expands to wgsrpd_l3 GER through the ingested alias table, "11" to
+// every level-3 area of Middle Europe through the hierarchy, "euromed:Ge"
+// to that one regional area — and unions the results), blank parts are
+// skipped, and an all-blank/empty area returns nil, the existing "no
+// filter" convention. A single value with no comma (the pre-multi-area
+// form) behaves exactly as before: it is just a one-element split. Sorted
+// so the result (and its json_each encoding) is deterministic regardless of
+// the order --area listed its parts in.
+func resolveAreaCodes(ctx context.Context, src *DB, area string) ([]areaKey, error) {
+	seen := make(map[areaKey]bool)
+	var out []areaKey
 	for _, part := range strings.Split(area, ",") {
-		for _, code := range areaCodes(part) {
-			if !seen[code] {
-				seen[code] = true
-				out = append(out, code)
+		scheme, codes, err := src.areaFilter(ctx, part)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: bundle: resolving area %q: %w", part, err)
+		}
+		for _, code := range codes {
+			k := areaKey{Scheme: scheme, Code: code}
+			if !seen[k] {
+				seen[k] = true
+				out = append(out, k)
 			}
 		}
 	}
-	sort.Strings(out)
-	return out
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Scheme != out[j].Scheme {
+			return out[i].Scheme < out[j].Scheme
+		}
+		return out[i].Code < out[j].Code
+	})
+	return out, nil
+}
+
+// marshalAreaKeys JSON-encodes keys as an array of [scheme, code] pairs for
+// binding as ONE parameter, matched with areaKeyScopeSQL — the
+// two-column counterpart of marshalIDs.
+func marshalAreaKeys(keys []areaKey) (string, error) {
+	pairs := make([][2]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = [2]string{k.Scheme, k.Code}
+	}
+	b, err := json.Marshal(pairs)
+	if err != nil {
+		return "", fmt.Errorf("sqlite: bundle: encoding area list: %w", err)
+	}
+	return string(b), nil
 }
 
+// areaKeyScopeSQL is the row-value subquery a (scheme, code) column pair is
+// tested against, over a marshalAreaKeys parameter.
+const areaKeyScopeSQL = `(SELECT json_extract(value, '$[0]'), json_extract(value, '$[1]') FROM json_each(?))`
+
 // scopeConceptIDs resolves BundleOpts.Area into the set of taxon_concept
 // ids ExportBundle copies (every concept id when area is blank, or every
 // concept id with at least one distribution row in one of area's resolved
-// WGSRPD level-3 codes otherwise) AND the resolved code set itself, which
+// (scheme, code) pairs otherwise) AND the resolved pair set itself, which
 // populateBundle/copyDistribution also needs to scope the distribution
 // table (see copyDistribution's doc comment) — computing it here, once,
 // keeps scopeByAreaQuery and copyDistribution's filter provably in sync:
-// both use exactly the same resolveAreaCodes(area) result.
-func scopeConceptIDs(ctx context.Context, src *DB, area string) ([]string, []string, error) {
-	codes := resolveAreaCodes(area)
+// both use exactly the same resolveAreaCodes result.
+func scopeConceptIDs(ctx context.Context, src *DB, area string) ([]string, []areaKey, error) {
+	keys, err := resolveAreaCodes(ctx, src, area)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	var (
-		rows *sql.Rows
-		err  error
-	)
-	if len(codes) == 0 {
+	var rows *sql.Rows
+	if len(keys) == 0 {
 		rows, err = src.sql.QueryContext(ctx, `SELECT id FROM taxon_concept ORDER BY id`)
 	} else {
-		codesJSON, jerr := marshalIDs(codes)
+		keysJSON, jerr := marshalAreaKeys(keys)
 		if jerr != nil {
 			return nil, nil, jerr
 		}
-		rows, err = src.sql.QueryContext(ctx, scopeByAreaQuery, codesJSON)
+		rows, err = src.sql.QueryContext(ctx, scopeByAreaQuery, keysJSON)
 	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("sqlite: bundle: resolving concept scope for area %q: %w", area, err)
@@ -405,19 +482,19 @@ func scopeConceptIDs(ctx context.Context, src *DB, area string) ([]string, []str
 	if err := rows.Err(); err != nil {
 		return nil, nil, fmt.Errorf("sqlite: bundle: iterating concept scope rows: %w", err)
 	}
-	return ids, codes, nil
+	return ids, keys, nil
 }
 
 // scopeByAreaQuery finds every concept with a distribution row in one of
-// the (any number of) area codes bound via json_each — see
+// the (any number of) (scheme, code) pairs bound via json_each — see
 // resolveAreaCodes for how BundleOpts.Area's comma-separated value becomes
-// that code list, and marshalIDs' doc comment for why the query text is a
-// fixed literal regardless of how many codes there are.
+// that list, and marshalIDs' doc comment for why the query text is a fixed
+// literal regardless of how many pairs there are.
 const scopeByAreaQuery = `
 	SELECT DISTINCT tc.id
 	FROM taxon_concept tc
 	JOIN distribution d ON d.concept_id = tc.id
-	WHERE d.area_scheme = 'wgsrpd_l3' AND d.area_code IN (SELECT value FROM json_each(?))
+	WHERE (d.area_scheme, d.area_code) IN ` + areaKeyScopeSQL + `
 	ORDER BY tc.id`
 
 // placeholdersFor returns n comma-joined "?" placeholders for a SQL IN
@@ -444,7 +521,7 @@ func placeholdersFor(n int) string {
 // (nil for a whole-DB export) — copyConceptScopedTables uses it to scope
 // the distribution copy to just the requested areas (see
 // copyDistribution's doc comment).
-func populateBundle(ctx context.Context, src, bundle *DB, conceptIDs, areaScope []string, opts BundleOpts, restrictedSources string) (BundleReport, error) {
+func populateBundle(ctx context.Context, src, bundle *DB, conceptIDs []string, areaScope []areaKey, opts BundleOpts, restrictedSources string) (BundleReport, error) {
 	var report BundleReport
 	if len(conceptIDs) == 0 {
 		if err := insertBundleMeta(ctx, bundle, opts, "", restrictedSources); err != nil {
@@ -472,11 +549,11 @@ func populateBundle(ctx context.Context, src, bundle *DB, conceptIDs, areaScope
 		nameBasionymCol = 8
 	)
 	names, err := copySelfReferencingRows(ctx, src, bundle,
-		`SELECT DISTINCT n.id, n.canonical, n.canonical_fold, n.authorship, n.rank, n.ipni_id, n.published_in, n.nom_status, n.basionym_id, n.rank_verbatim
+		`SELECT DISTINCT n.id, n.canonical, n.canonical_fold, n.authorship, n.rank, n.ipni_id, n.published_in, n.nom_status, n.basionym_id, n.rank_verbatim, n.published_year
 		 FROM name n
 		 JOIN concept_name cn ON cn.name_id = n.id
 		 WHERE cn.concept_id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
-		`INSERT INTO name (id, canonical, canonical_fold, authorship, rank, ipni_id, published_in, nom_status, basionym_id, rank_verbatim) VALUES (?,?,?,?,?,?,?,?,?,?)`,
+		`INSERT INTO name (id, canonical, canonical_fold, authorship, rank, ipni_id, published_in, nom_status, basionym_id, rank_verbatim, published_year) VALUES (?,?,?,?,?,?,?,?,?,?,?)`,
 		nameIDCol, nameBasionymCol, `UPDATE name SET basionym_id = ? WHERE id = ?`)
 	if err != nil {
 		return report, err
@@ -488,8 +565,8 @@ func populateBundle(ctx context.Context, src, bundle *DB, conceptIDs, areaScope
 		conceptParentIDCol = 4
 	)
 	concepts, err := copySelfReferencingRows(ctx, src, bundle,
-		`SELECT id, backbone_id, accepted_name, rank, parent_id, sec_reference, status, rank_verbatim FROM taxon_concept WHERE id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
-		`INSERT INTO taxon_concept (id, backbone_id, accepted_name, rank, parent_id, sec_reference, status, rank_verbatim) VALUES (?,?,?,?,?,?,?,?)`,
+		`SELECT id, backbone_id, accepted_name, rank, parent_id, sec_reference, status, rank_verbatim, family FROM taxon_concept WHERE id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
+		`INSERT INTO taxon_concept (id, backbone_id, accepted_name, rank, parent_id, sec_reference, status, rank_verbatim, family) VALUES (?,?,?,?,?,?,?,?,?)`,
 		conceptIDCol, conceptParentIDCol, `UPDATE taxon_concept SET parent_id = ? WHERE id = ?`)
 	if err != nil {
 		return report, err
@@ -526,7 +603,7 @@ func populateBundle(ctx context.Context, src, bundle *DB, conceptIDs, areaScope
 // to keep that function's cyclomatic complexity down; it carries no logic
 // of its own beyond sequencing copyRows calls (copyDistribution is the one
 // exception — see its own doc comment for the area-scoping it does).
-func copyConceptScopedTables(ctx context.Context, src, bundle *DB, idsJSON string, areaScope []string) error {
+func copyConceptScopedTables(ctx context.Context, src, bundle *DB, idsJSON string, areaScope []areaKey) error {
 	if err := copyRows(ctx, src, bundle,
 		`SELECT concept_id, name_id, role, homotypic FROM concept_name WHERE concept_id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
 		`INSERT INTO concept_name (concept_id, name_id, role, homotypic) VALUES (?,?,?,?)`); err != nil {
@@ -556,6 +633,9 @@ func copyConceptScopedTables(ctx context.Context, src, bundle *DB, idsJSON strin
 	if err := copyDistribution(ctx, src, bundle, idsJSON, areaScope); err != nil {
 		return err
 	}
+	if err := copyFrequencies(ctx, src, bundle, idsJSON); err != nil {
+		return err
+	}
 
 	if err := copyRows(ctx, src, bundle,
 		`SELECT concept_id, lang, name, preferred FROM vernacular WHERE concept_id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
@@ -650,12 +730,30 @@ func copyConceptScopedTables(ctx context.Context, src, bundle *DB, idsJSON strin
 	// keys, so copying an edge whose partner concept is out of scope would
 	// fail the insert. An area-scoped bundle therefore carries only the
 	// relations wholly inside its scope — which is also the honest answer,
-	// since half an edge asserts nothing.
-	return copyRows(ctx, src, bundle,
+	// since half an edge asserts nothing. misapplication follows the same
+	// both-ends rule for the same reason.
+	if err := copyRows(ctx, src, bundle,
 		`SELECT from_concept, to_concept, relation, source FROM concept_relation
 		 WHERE from_concept IN (SELECT value FROM json_each(?))
 		   AND to_concept IN (SELECT value FROM json_each(?))`, []any{idsJSON, idsJSON},
-		`INSERT INTO concept_relation (from_concept, to_concept, relation, source) VALUES (?,?,?,?)`)
+		`INSERT INTO concept_relation (from_concept, to_concept, relation, source) VALUES (?,?,?,?)`); err != nil {
+		return err
+	}
+	if err := copyRows(ctx, src, bundle,
+		`SELECT from_concept, to_concept, source FROM misapplication
+		 WHERE from_concept IN (SELECT value FROM json_each(?))
+		   AND to_concept IN (SELECT value FROM json_each(?))`, []any{idsJSON, idsJSON},
+		`INSERT INTO misapplication (from_concept, to_concept, source) VALUES (?,?,?)`); err != nil {
+		return err
+	}
+
+	// hybrid_parent needs only its nothotaxon in scope: a parent is stored by
+	// name, and resolving it to a concept is a read-time lookup that in a
+	// bundle simply finds fewer concepts.
+	return copyRows(ctx, src, bundle,
+		`SELECT concept_id, position, canonical, canonical_fold FROM hybrid_parent
+		 WHERE concept_id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
+		`INSERT INTO hybrid_parent (concept_id, position, canonical, canonical_fold) VALUES (?,?,?,?)`)
 }
 
 // copyDistribution copies distribution rows for the concepts named by
@@ -667,29 +765,72 @@ func copyConceptScopedTables(ctx context.Context, src, bundle *DB, idsJSON strin
 // its unique index, measured via `SELECT name, SUM(pgsize) FROM dbstat`).
 // A field bundle scoped to a region has no documented use case that needs
 // a concept's occurrence in areas OUTSIDE that region, so when areaScope
-// is non-empty (an area-scoped export), only the wgsrpd_l3 rows matching
-// one of areaScope are copied — a bundle's distribution table then answers
-// exactly "does this concept occur in the requested area(s)", not "what is
-// this concept's whole-world range". A whole-DB export (areaScope empty)
+// is non-empty (an area-scoped export), only the rows matching one of
+// areaScope's (scheme, code) pairs are copied — a bundle's distribution
+// table then answers exactly "does this concept occur in the requested
+// area(s)", not "what is this concept's whole-world range". A whole-DB export (areaScope empty)
 // is unaffected: every distribution row is still copied, because
 // "everything" genuinely means everything there too.
-func copyDistribution(ctx context.Context, src, bundle *DB, idsJSON string, areaScope []string) error {
-	if len(areaScope) == 0 {
-		return copyRows(ctx, src, bundle,
-			`SELECT concept_id, area_scheme, area_code FROM distribution WHERE concept_id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
-			`INSERT INTO distribution (concept_id, area_scheme, area_code) VALUES (?,?,?)`)
+func copyDistribution(ctx context.Context, src, bundle *DB, idsJSON string, areaScope []areaKey) error {
+	query, args, err := distributionScope(idsJSON, areaScope)
+	if err != nil {
+		return err
+	}
+	// distribution_source before distribution: distribution.source is an FK
+	// onto it. Scoped to the sources whose rows are copied, like name_space —
+	// a provenance row for a source that contributed nothing is unreachable
+	// from the bundle.
+	if err := copyRows(ctx, src, bundle,
+		fmt.Sprintf(`SELECT id, version, license, source_url, ingested_at, manifest_sha, redistribution FROM distribution_source
+		 WHERE id IN (SELECT DISTINCT source FROM (%s))`, query), args,
+		`INSERT INTO distribution_source (id, version, license, source_url, ingested_at, manifest_sha, redistribution) VALUES (?,?,?,?,?,?,?)`); err != nil {
+		return err
 	}
+	return copyRows(ctx, src, bundle, query, args,
+		`INSERT INTO distribution (concept_id, area_scheme, area_code, status, source) VALUES (?,?,?,?,?)`)
+}
 
-	areaScopeJSON, err := marshalIDs(areaScope)
-	if err != nil {
+// copyFrequencies copies the in-scope concepts' frequency counts, so a
+// bundle ranks suggestions like its source. frequency_source goes first
+// (concept_frequency.source is an FK onto it) and is scoped to the sources
+// whose counts are copied, like distribution_source.
+func copyFrequencies(ctx context.Context, src, bundle *DB, idsJSON string) error {
+	if err := copyRows(ctx, src, bundle,
+		`SELECT id, version, license, source_url, ingested_at, manifest_sha, redistribution FROM frequency_source
+		 WHERE id IN (
+			SELECT DISTINCT source FROM concept_frequency
+			WHERE concept_id IN (SELECT value FROM json_each(?))
+		 )`, []any{idsJSON},
+		`INSERT INTO frequency_source (id, version, license, source_url, ingested_at, manifest_sha, redistribution) VALUES (?,?,?,?,?,?,?)`); err != nil {
 		return err
 	}
 	return copyRows(ctx, src, bundle,
-		`SELECT concept_id, area_scheme, area_code FROM distribution
-		 WHERE concept_id IN (SELECT value FROM json_each(?))
-		   AND area_scheme = 'wgsrpd_l3' AND area_code IN (SELECT value FROM json_each(?))`,
-		[]any{idsJSON, areaScopeJSON},
-		`INSERT INTO distribution (concept_id, area_scheme, area_code) VALUES (?,?,?)`)
+		`SELECT concept_id, source, count FROM concept_frequency WHERE concept_id IN (SELECT value FROM json_each(?))`, []any{idsJSON},
+		`INSERT INTO concept_frequency (concept_id, source, count) VALUES (?,?,?)`)
+}
+
+const (
+	distributionScopeSQL = `SELECT concept_id, area_scheme, area_code, status, source FROM distribution
+		 WHERE concept_id IN (SELECT value FROM json_each(?))`
+	distributionAreaScopeSQL = distributionScopeSQL + `
+		   AND (area_scheme, area_code) IN ` + areaKeyScopeSQL
+)
+
+// distributionScope returns the query selecting exactly the distribution
+// rows a bundle carries, with its arguments: the rows of the concepts named
+// by idsJSON, narrowed to areaScope when it is non-empty (copyDistribution).
+// copyDistribution and findRestrictedSources both use it, so the gate sees
+// precisely the rows that are copied — a regional source whose rows an
+// area-scoped export leaves behind neither blocks the export nor travels.
+func distributionScope(idsJSON string, areaScope []areaKey) (string, []any, error) {
+	if len(areaScope) == 0 {
+		return distributionScopeSQL, []any{idsJSON}, nil
+	}
+	areaScopeJSON, err := marshalAreaKeys(areaScope)
+	if err != nil {
+		return "", nil, err
+	}
+	return distributionAreaScopeSQL, []any{idsJSON, areaScopeJSON}, nil
 }
 
 // copyAreas carries the area-name lookup for exactly the (scheme, code) pairs
@@ -698,26 +839,46 @@ func copyDistribution(ctx context.Context, src, bundle *DB, idsJSON string, area
 // point of the picker in the offline field-use scenario the bundle serves.
 // Scoping mirrors copyDistribution: whole-DB export copies the names of every
 // area the bundled concepts occur in; an area-scoped export copies only the
-// requested codes' names.
-func copyAreas(ctx context.Context, src, bundle *DB, idsJSON string, areaScope []string) error {
+// requested codes' names. The WGSRPD hierarchy units are then copied whole
+// (a few hundred rows of reference data): a bundle must expand area=11 and
+// render the /v1/areas tree exactly like its source, and Areas only ever
+// returns the units above areas the bundle has data for. The alias table
+// travels whole for the same reason: area=DE must work offline too.
+func copyAreas(ctx context.Context, src, bundle *DB, idsJSON string, areaScope []areaKey) error {
 	if len(areaScope) == 0 {
-		return copyRows(ctx, src, bundle,
-			`SELECT scheme, code, name FROM area
+		if err := copyRows(ctx, src, bundle,
+			`SELECT scheme, code, name, parent FROM area
 			 WHERE (scheme, code) IN (
 			   SELECT DISTINCT area_scheme, area_code FROM distribution
 			   WHERE concept_id IN (SELECT value FROM json_each(?)))`,
 			[]any{idsJSON},
-			`INSERT INTO area (scheme, code, name) VALUES (?,?,?)`)
+			`INSERT INTO area (scheme, code, name, parent) VALUES (?,?,?,?)`); err != nil {
+			return err
+		}
+	} else {
+		areaScopeJSON, err := marshalAreaKeys(areaScope)
+		if err != nil {
+			return err
+		}
+		if err := copyRows(ctx, src, bundle,
+			`SELECT scheme, code, name, parent FROM area
+			 WHERE (scheme, code) IN `+areaKeyScopeSQL,
+			[]any{areaScopeJSON},
+			`INSERT INTO area (scheme, code, name, parent) VALUES (?,?,?,?)`); err != nil {
+			return err
+		}
 	}
-	areaScopeJSON, err := marshalIDs(areaScope)
-	if err != nil {
+	if err := copyRows(ctx, src, bundle,
+		`SELECT scheme, code, name, parent FROM area
+		 WHERE scheme = 'wgsrpd_l1' OR parent <> ''`,
+		nil,
+		`INSERT OR IGNORE INTO area (scheme, code, name, parent) VALUES (?,?,?,?)`); err != nil {
 		return err
 	}
 	return copyRows(ctx, src, bundle,
-		`SELECT scheme, code, name FROM area
-		 WHERE scheme = 'wgsrpd_l3' AND code IN (SELECT value FROM json_each(?))`,
-		[]any{areaScopeJSON},
-		`INSERT INTO area (scheme, code, name) VALUES (?,?,?)`)
+		`SELECT alias, code FROM area_alias`,
+		nil,
+		`INSERT INTO area_alias (alias, code) VALUES (?,?)`)
 }
 
 // backboneVersionScopeQuery finds every backbone_version referenced by the
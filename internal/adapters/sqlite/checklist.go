@@ -0,0 +1,143 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// checklistEstablishment ranks the verdicts Checklist reports, broadest
+// first: a concept with several rows in the area (a region expanded to its
+// level-3 codes) reports the first one any of them carries. Its index is the
+// est value the query computes; len(checklistEstablishment) is "no verdict".
+var checklistEstablishment = []domain.DistributionStatus{
+	domain.DistributionNative,
+	domain.DistributionIntroduced,
+	domain.DistributionDoubtful,
+}
+
+// Checklist lists the accepted concepts in opts.Area. See the
+// output.Repository.Checklist doc comment for the contract.
+//
+// The hits CTE reads distribution_effective through its (scheme, code)
+// index, one group per concept, and takes each verdict from the concept's
+// own distribution row — a name-fallback or infraspecific row has none. The
+// family is taxon_concept.family, else the nearest FAMILY ancestor through
+// concept_lineage. Paging is keyset over the ORDER BY columns, so a page
+// deep into a large region costs what the first one does. Built with
+// literal-format Sprintf so gosec sees untainted SQL.
+func (db *DB) Checklist(ctx context.Context, opts output.ChecklistOpts) ([]domain.ChecklistEntry, error) {
+	scheme, codes, err := db.areaFilter(ctx, opts.Area)
+	if err != nil {
+		return nil, err
+	}
+	entries := []domain.ChecklistEntry{}
+	if len(codes) == 0 {
+		return entries, nil
+	}
+
+	args := []any{
+		string(checklistEstablishment[0]), string(checklistEstablishment[1]), string(checklistEstablishment[2]),
+		len(checklistEstablishment), originInfraspecific, scheme,
+	}
+	for _, c := range codes {
+		args = append(args, c)
+	}
+	args = append(args, string(domain.RankFamily), string(domain.StatusAccepted))
+
+	var conceptFilter strings.Builder
+	if len(opts.Ranks) > 0 {
+		ph := strings.TrimSuffix(strings.Repeat("?,", len(opts.Ranks)), ",")
+		fmt.Fprintf(&conceptFilter, " AND tc.rank IN (%s)", ph)
+		for _, r := range opts.Ranks {
+			args = append(args, string(r))
+		}
+	}
+	if opts.Backbone != "" {
+		conceptFilter.WriteString(" AND tc.backbone_id = ?")
+		args = append(args, opts.Backbone)
+	}
+
+	var pageFilter strings.Builder
+	if opts.Establishment != "" {
+		est := len(checklistEstablishment)
+		for i, s := range checklistEstablishment {
+			if s == opts.Establishment {
+				est = i
+			}
+		}
+		pageFilter.WriteString(" AND est = ?")
+		args = append(args, est)
+	}
+	if opts.After != (output.ChecklistKey{}) {
+		pageFilter.WriteString(" AND (family = '', family, canonical, id) > (?, ?, ?, ?)")
+		args = append(args, opts.After.Family == "", opts.After.Family, opts.After.Canonical, opts.After.ConceptID)
+	}
+	// LIMIT -1 is SQLite's "no limit".
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	args = append(args, limit)
+
+	ph := strings.TrimSuffix(strings.Repeat("?,", len(codes)), ",")
+	rows, err := db.sql.QueryContext(ctx, fmt.Sprintf(`
+		WITH hits AS (
+			SELECT de.concept_id AS id,
+			       MIN(CASE d.status WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE ? END) AS est,
+			       MIN(de.origin = ?) AS derived
+			FROM distribution_effective de
+			LEFT JOIN distribution d
+			  ON d.concept_id = de.concept_id AND d.area_scheme = de.area_scheme AND d.area_code = de.area_code
+			WHERE de.area_scheme = ? AND de.area_code IN (%s)
+			GROUP BY de.concept_id
+		), entries AS (
+			SELECT tc.id, tc.backbone_id, an.canonical, COALESCE(an.authorship, '') AS authorship, tc.rank,
+			       COALESCE(NULLIF(tc.family, ''), (
+			         SELECT fn.canonical FROM concept_lineage fl
+			         JOIN taxon_concept ft ON ft.id = fl.ancestor_id
+			         JOIN name fn ON fn.id = ft.accepted_name
+			         WHERE fl.concept_id = tc.id AND fl.depth > 0 AND ft.rank = ?
+			         ORDER BY fl.depth LIMIT 1
+			       ), '') AS family,
+			       h.est, h.derived
+			FROM hits h
+			JOIN taxon_concept tc ON tc.id = h.id
+			JOIN name an ON an.id = tc.accepted_name
+			WHERE tc.status = ?%s
+		)
+		SELECT id, backbone_id, canonical, authorship, rank, family, est, derived
+		FROM entries
+		WHERE 1 = 1%s
+		ORDER BY family = '', family, canonical, id
+		LIMIT ?`, ph, conceptFilter.String(), pageFilter.String()), args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: checklist for area %q: %w", opts.Area, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var e domain.ChecklistEntry
+		var rank string
+		var est int
+		if err := rows.Scan(&e.ConceptID, &e.BackboneID, &e.Canonical, &e.Authorship, &rank, &e.Family, &est, &e.Derived); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning checklist entry: %w", err)
+		}
+		r, err := domain.ParseRank(rank)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: checklist entry %q: %w", e.ConceptID, err)
+		}
+		e.Rank = r
+		if est < len(checklistEstablishment) {
+			e.Establishment = checklistEstablishment[est]
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating checklist for area %q: %w", opts.Area, err)
+	}
+	return entries, nil
+}
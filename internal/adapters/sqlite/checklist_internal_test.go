@@ -0,0 +1,166 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// seedChecklist writes a small GER flora: Pinaceae as a FAMILY concept
+// above its genus (the CDM shape), Poaceae only as the species' family label
+// (the WCVP shape), one species with no family at all, a species in GER only
+// through its subspecies, and a synonym-status concept with a GER row.
+func seedChecklist(t *testing.T) *DB {
+	t.Helper()
+	db := openTestDB(t)
+	bv := domain.BackboneVersion{ID: "wcvp", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "x"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		for _, c := range []struct {
+			id, canonical, parent, family string
+			rank                          domain.Rank
+			status                        domain.Status
+			ger                           domain.DistributionStatus
+			inGER                         bool
+		}{
+			{"fam", "Pinaceae", "", "", domain.RankFamily, domain.StatusAccepted, "", false},
+			{"g", "Abies", "fam", "", domain.RankGenus, domain.StatusAccepted, "", false},
+			{"alba", "Abies alba", "g", "", domain.RankSpecies, domain.StatusAccepted, domain.DistributionNative, true},
+			{"pect", "Abies pectinata", "", "", domain.RankSpecies, domain.StatusSynonym, domain.DistributionNative, true},
+			{"bor", "Abies borisii-regis", "g", "", domain.RankSpecies, domain.StatusAccepted, "", false},
+			{"bor-ssp", "Abies borisii-regis subsp. borisii-regis", "bor", "", domain.RankSubspecies, domain.StatusAccepted, "", true},
+			{"annua", "Poa annua", "", "Poaceae", domain.RankSpecies, domain.StatusAccepted, domain.DistributionIntroduced, true},
+			{"alpina", "Poa alpina", "", "Poaceae", domain.RankSpecies, domain.StatusAccepted, "", true},
+			{"sedis", "Incertae sedis", "", "", domain.RankSpecies, domain.StatusAccepted, "", true},
+		} {
+			n := domain.Name{ID: "n-" + c.id, Canonical: c.canonical, Rank: c.rank}
+			mustTx(t, tx.UpsertName(n))
+			concept := domain.Concept{ID: "wcvp:concept:" + c.id, BackboneID: "wcvp", AcceptedName: n, Rank: c.rank, Status: c.status, Family: c.family}
+			if c.parent != "" {
+				concept.ParentID = "wcvp:concept:" + c.parent
+			}
+			mustTx(t, tx.UpsertConcept(concept))
+			mustTx(t, tx.LinkName(concept.ID, n.ID, "accepted", nil))
+			if c.inGER {
+				mustTx(t, tx.AddDistribution(concept.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "GER", Status: c.ger}, ""))
+			}
+		}
+		// Poa alpina's verdict lives in another area: GER still has none.
+		mustTx(t, tx.AddDistribution("wcvp:concept:alpina", domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "AUT", Status: domain.DistributionNative}, ""))
+	})
+	ctx := context.Background()
+	mustTx(t, db.BuildDistributionClosure(ctx))
+	mustTx(t, db.BuildLineageClosure(ctx))
+	return db
+}
+
+// checklistLine renders e as "family/id/establishment/derived", the
+// "wcvp:concept:" prefix stripped.
+func checklistLine(e domain.ChecklistEntry) string {
+	derived := ""
+	if e.Derived {
+		derived = "derived"
+	}
+	return strings.Join([]string{e.Family, strings.TrimPrefix(e.ConceptID, "wcvp:concept:"), string(e.Establishment), derived}, "/")
+}
+
+func checklistLines(entries []domain.ChecklistEntry) string {
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = checklistLine(e)
+	}
+	return strings.Join(lines, " ")
+}
+
+// TestChecklist_GroupsByFamilyAndFilters pins the checklist's order and
+// filters: the family label or FAMILY ancestor first, the family-less
+// last; synonyms never listed; the verdict taken from the concept's own GER
+// row only; a species present through its subspecies flagged derived.
+func TestChecklist_GroupsByFamilyAndFilters(t *testing.T) {
+	db := seedChecklist(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		opts output.ChecklistOpts
+		want string
+	}{
+		{"all", output.ChecklistOpts{Area: "GER"},
+			"Pinaceae/alba/native/ Pinaceae/bor//derived Pinaceae/bor-ssp// Poaceae/alpina// Poaceae/annua/introduced/ /sedis//"},
+		{"species", output.ChecklistOpts{Area: "GER", Ranks: []domain.Rank{domain.RankSpecies}},
+			"Pinaceae/alba/native/ Pinaceae/bor//derived Poaceae/alpina// Poaceae/annua/introduced/ /sedis//"},
+		{"native", output.ChecklistOpts{Area: "GER", Establishment: domain.DistributionNative}, "Pinaceae/alba/native/"},
+		{"introduced", output.ChecklistOpts{Area: "GER", Establishment: domain.DistributionIntroduced}, "Poaceae/annua/introduced/"},
+		{"other area", output.ChecklistOpts{Area: "AUT"}, "Poaceae/alpina/native/"},
+		{"other backbone", output.ChecklistOpts{Area: "GER", Backbone: "cdm"}, ""},
+		{"unknown area", output.ChecklistOpts{Area: "ZZZ"}, ""},
+	} {
+		got, err := db.Checklist(ctx, tc.opts)
+		mustTx(t, err)
+		if lines := checklistLines(got); lines != tc.want {
+			t.Errorf("%s: Checklist = %q\nwant %q", tc.name, lines, tc.want)
+		}
+	}
+}
+
+// TestChecklist_KeysetPagingMatchesOnePage pins that paging by the last
+// entry's key walks the whole checklist exactly once, across the family
+// boundaries and into the family-less tail.
+func TestChecklist_KeysetPagingMatchesOnePage(t *testing.T) {
+	db := seedChecklist(t)
+	ctx := context.Background()
+
+	whole, err := db.Checklist(ctx, output.ChecklistOpts{Area: "GER"})
+	mustTx(t, err)
+
+	var paged []domain.ChecklistEntry
+	opts := output.ChecklistOpts{Area: "GER", Limit: 2}
+	for i := 0; i < 10; i++ {
+		page, err := db.Checklist(ctx, opts)
+		mustTx(t, err)
+		if len(page) == 0 {
+			break
+		}
+		paged = append(paged, page...)
+		last := page[len(page)-1]
+		opts.After = output.ChecklistKey{Family: last.Family, Canonical: last.Canonical, ConceptID: last.ConceptID}
+	}
+	if got, want := checklistLines(paged), checklistLines(whole); got != want {
+		t.Errorf("paged checklist = %q\nwant %q", got, want)
+	}
+}
+
+// TestOpen_MigratesConceptFamily pins that a database built before the
+// checklist gains taxon_concept.family on Open, empty for existing rows.
+func TestOpen_MigratesConceptFamily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.sqlite")
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	if _, err := legacy.Exec(`
+		CREATE TABLE taxon_concept (id TEXT PRIMARY KEY, backbone_id TEXT NOT NULL, accepted_name TEXT NOT NULL, rank TEXT NOT NULL,
+		  parent_id TEXT, sec_reference TEXT, status TEXT NOT NULL, rank_verbatim TEXT);
+		INSERT INTO taxon_concept (id, backbone_id, accepted_name, rank, status) VALUES ('c', 'wcvp', 'n', 'SPECIES', 'ACCEPTED');`); err != nil {
+		t.Fatalf("creating pre-migration taxon_concept table: %v", err)
+	}
+	_ = legacy.Close()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open(legacy): %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var family string
+	if err := db.sql.QueryRow(`SELECT family FROM taxon_concept WHERE id = 'c'`).Scan(&family); err != nil {
+		t.Fatalf("reading migrated family: %v", err)
+	}
+	if family != "" {
+		t.Errorf("family = %q, want empty for a pre-migration row", family)
+	}
+}
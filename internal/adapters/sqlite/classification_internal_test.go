@@ -23,7 +23,8 @@ func mustExecClassification(t *testing.T, db *DB, stmt string) {
 // seedClassificationChain builds a fresh FAMILY -> GENUS -> SPECIES
 // taxon_concept chain (c-family <- c-genus <- c-species, via parent_id),
 // entirely by hand, so Classification's tests don't depend on
-// application.Ingest or the WCVP fixture.
+// application.Ingest or the WCVP fixture. Raw inserts bypass Finalize, so it
+// builds concept_lineage itself.
 func seedClassificationChain(t *testing.T) *DB {
 	t.Helper()
 	db := openTestDB(t)
@@ -34,6 +35,9 @@ func seedClassificationChain(t *testing.T) *DB {
 	mustExecClassification(t, db, `INSERT INTO taxon_concept (id, backbone_id, accepted_name, rank, parent_id, status) VALUES ('c-family', 'wcvp', 'n-family', 'FAMILY', NULL, 'ACCEPTED')`)
 	mustExecClassification(t, db, `INSERT INTO taxon_concept (id, backbone_id, accepted_name, rank, parent_id, status) VALUES ('c-genus', 'wcvp', 'n-genus', 'GENUS', 'c-family', 'ACCEPTED')`)
 	mustExecClassification(t, db, `INSERT INTO taxon_concept (id, backbone_id, accepted_name, rank, parent_id, status) VALUES ('c-species', 'wcvp', 'n-species', 'SPECIES', 'c-genus', 'ACCEPTED')`)
+	if err := db.BuildLineageClosure(context.Background()); err != nil {
+		t.Fatalf("BuildLineageClosure: unexpected error: %v", err)
+	}
 	return db
 }
 
@@ -87,8 +91,8 @@ func TestClassification_UnknownConceptReturnsNotFound(t *testing.T) {
 	}
 }
 
-// TestClassification_BoundedWalkTerminatesOnCycle proves the depth bound
-// actually protects against a cyclic/corrupt parent_id chain: no real
+// TestClassification_CycleListsEachAncestorOnce proves a cyclic/corrupt
+// parent_id chain neither hangs the lineage build nor repeats itself: no real
 // application.Ingest run can ever produce one (parent_id only ever names an
 // ACCEPTED taxonID resolved in memory before any write, see
 // internal/application/ingest.go), but a corrupted database could — and
@@ -100,7 +104,7 @@ func TestClassification_UnknownConceptReturnsNotFound(t *testing.T) {
 // (now also safe) — the exact same "insert without the self-reference,
 // then link it in" two-sub-pass technique Ingest itself uses, deliberately
 // misused here to produce the otherwise-impossible cycle.
-func TestClassification_BoundedWalkTerminatesOnCycle(t *testing.T) {
+func TestClassification_CycleListsEachAncestorOnce(t *testing.T) {
 	db := openTestDB(t)
 	ctx := context.Background()
 
@@ -136,15 +140,18 @@ func TestClassification_BoundedWalkTerminatesOnCycle(t *testing.T) {
 		t.Fatalf("Commit: unexpected error: %v", err)
 	}
 
+	if err := db.BuildLineageClosure(ctx); err != nil {
+		t.Fatalf("BuildLineageClosure on a cyclic parent_id chain: unexpected error: %v", err)
+	}
+
 	chain, err := db.Classification(ctx, "c-a")
 	if err != nil {
 		t.Fatalf("Classification(c-a) on a cyclic parent_id chain: unexpected error: %v", err)
 	}
-	// maxClassificationDepth (see read.go) bounds the walk; a genuine
-	// 2-cycle keeps alternating c-b/c-a forever, so hitting exactly the
-	// depth bound (not looping forever, and not stopping early) is the
-	// proof the bound is what stopped it.
-	if len(chain) != maxClassificationDepth {
-		t.Fatalf("len(Classification(c-a)) = %d, want exactly %d (the depth bound stopping a genuine cycle)", len(chain), maxClassificationDepth)
+	// The walk alternates c-b/c-a until maxClassificationDepth stops it, but
+	// concept_lineage keeps one row per (ancestor, concept) pair at its
+	// shallowest depth: c-a itself only at depth 0, so the chain is c-b alone.
+	if len(chain) != 1 || chain[0].ConceptID != "c-b" {
+		t.Fatalf("Classification(c-a) = %+v, want exactly [c-b]", chain)
 	}
 }
@@ -3,13 +3,42 @@ package sqlite
 import (
 	"context"
 	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// Origins of a distribution_effective row. originOwn and originName are
+// asserted by a source (the concept's own rows, or its WCVP twin's);
+// originInfraspecific is DERIVED — rolled up from an accepted infraspecific
+// child — and is what conceptDistribution surfaces as Distribution.Derived.
+const (
+	originOwn           = "own"
+	originName          = "name"
+	originInfraspecific = "infraspecific"
 )
 
-// BuildDistributionClosure (re)builds distribution_effective from scratch: every
-// concept's own distribution (origin 'own'), plus — for a concept with NO own
-// distribution and a non-empty accepted canonical_fold — the areas of any WCVP
-// concept sharing that fold (origin 'name', the precomputed in_area name
-// fallback). Idempotent: safe to run repeatedly. Called at ingest time only
+// BuildDistributionClosure (re)builds distribution_effective from scratch, in
+// three steps whose order is the precedence (INSERT OR IGNORE, first wins):
+//
+//  1. every concept's own distribution (origin 'own');
+//  2. for a concept with NO own distribution in a scheme and a non-empty
+//     accepted canonical_fold, the areas in that scheme of any WCVP concept
+//     sharing that fold (origin 'name', the precomputed in_area name
+//     fallback). The fallback is per scheme, so a CDM concept a regional
+//     checklist attached euromed rows to still inherits its twin's WGSRPD
+//     range, and the reverse;
+//  3. for a species, every area its accepted infraspecific descendants hold
+//     after steps 1-2 (origin 'infraspecific'): a subspecies present in an
+//     area puts its species there too, so a species whose only rows sit on
+//     its subspecies still gets in_area. Never the reverse — a species'
+//     range says nothing about any one of its subspecies.
+//
+// Step 3 walks parent_id upward through infraspecific ranks only and stops at
+// the first SPECIES concept, so a section or subgenus (RankOther, parented on
+// a genus) never rolls anything into its genus. UNION (not UNION ALL) keeps
+// the walk finite on a malformed parent cycle.
+//
+// Idempotent: safe to run repeatedly. Called at ingest time only
 // (application/app.Ingest) — NEVER on the serve/Open path, whose startup must
 // not block on this multi-million-row build (see db.go's Open note). The
 // `wtc.backbone_id = 'wcvp'` join is fine here (batch build, not a per-row
@@ -21,23 +50,57 @@ func (db *DB) BuildDistributionClosure(ctx context.Context) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	stmts := []string{
-		`DELETE FROM distribution_effective`,
-		`INSERT OR IGNORE INTO distribution_effective (concept_id, area_scheme, area_code, origin)
-		 SELECT concept_id, area_scheme, area_code, 'own' FROM distribution`,
-		`INSERT OR IGNORE INTO distribution_effective (concept_id, area_scheme, area_code, origin)
-		 SELECT c.id, wd.area_scheme, wd.area_code, 'name'
-		 FROM taxon_concept c
-		 JOIN name an ON an.id = c.accepted_name
-		 JOIN name wn ON wn.canonical_fold = an.canonical_fold
-		 JOIN concept_name wcn ON wcn.name_id = wn.id
-		 JOIN taxon_concept wtc ON wtc.id = wcn.concept_id AND wtc.backbone_id = 'wcvp'
-		 JOIN distribution wd ON wd.concept_id = wtc.id
-		 WHERE an.canonical_fold <> ''
-		   AND NOT EXISTS (SELECT 1 FROM distribution d0 WHERE d0.concept_id = c.id)`,
+	stmts := []struct {
+		query string
+		args  []any
+	}{
+		{query: `DELETE FROM distribution_effective`},
+		{
+			query: `INSERT OR IGNORE INTO distribution_effective (concept_id, area_scheme, area_code, origin)
+			 SELECT concept_id, area_scheme, area_code, ? FROM distribution`,
+			args: []any{originOwn},
+		},
+		{
+			query: `INSERT OR IGNORE INTO distribution_effective (concept_id, area_scheme, area_code, origin)
+			 SELECT c.id, wd.area_scheme, wd.area_code, ?
+			 FROM taxon_concept c
+			 JOIN name an ON an.id = c.accepted_name
+			 JOIN name wn ON wn.canonical_fold = an.canonical_fold
+			 JOIN concept_name wcn ON wcn.name_id = wn.id
+			 JOIN taxon_concept wtc ON wtc.id = wcn.concept_id AND wtc.backbone_id = 'wcvp'
+			 JOIN distribution wd ON wd.concept_id = wtc.id
+			 WHERE an.canonical_fold <> ''
+			   AND NOT EXISTS (
+			     SELECT 1 FROM distribution d0
+			     WHERE d0.concept_id = c.id AND d0.area_scheme = wd.area_scheme)`,
+			args: []any{originName},
+		},
+		{
+			query: `WITH RECURSIVE up(child_id, ancestor_id) AS (
+			   SELECT c.id, c.parent_id FROM taxon_concept c
+			   WHERE c.parent_id IS NOT NULL AND c.status = ?
+			     AND c.rank NOT IN (?, ?, ?)
+			   UNION
+			   SELECT up.child_id, p.parent_id FROM up
+			   JOIN taxon_concept p ON p.id = up.ancestor_id
+			   WHERE p.parent_id IS NOT NULL AND p.rank NOT IN (?, ?, ?)
+			 )
+			 INSERT OR IGNORE INTO distribution_effective (concept_id, area_scheme, area_code, origin)
+			 SELECT s.id, de.area_scheme, de.area_code, ?
+			 FROM up
+			 JOIN taxon_concept s ON s.id = up.ancestor_id AND s.rank = ?
+			 JOIN distribution_effective de ON de.concept_id = up.child_id
+			 WHERE de.origin IN (?, ?)`,
+			args: []any{
+				string(domain.StatusAccepted),
+				string(domain.RankSpecies), string(domain.RankGenus), string(domain.RankFamily),
+				string(domain.RankSpecies), string(domain.RankGenus), string(domain.RankFamily),
+				originInfraspecific, string(domain.RankSpecies), originOwn, originName,
+			},
+		},
 	}
 	for _, s := range stmts {
-		if _, err := tx.ExecContext(ctx, s); err != nil {
+		if _, err := tx.ExecContext(ctx, s.query, s.args...); err != nil {
 			return fmt.Errorf("sqlite: closure build: %w", err)
 		}
 	}
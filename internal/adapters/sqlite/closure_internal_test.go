@@ -5,6 +5,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
 )
 
 func TestBuildDistributionClosure(t *testing.T) {
@@ -30,6 +33,107 @@ func TestBuildDistributionClosure(t *testing.T) {
 	}
 }
 
+// TestBuildDistributionClosure_NameFallbackIsPerScheme pins that a concept's
+// own rows in one scheme do not suppress the WCVP name fallback in another: a
+// CDM concept a regional checklist placed in euromed:Ge still inherits its
+// WCVP twin's wgsrpd_l3 range.
+func TestBuildDistributionClosure_NameFallbackIsPerScheme(t *testing.T) {
+	db := openTestDB(t)
+	seedWCVPInulaHirta(t, db)
+	seedCDMInulaHirta(t, db)
+	ctx := context.Background()
+	tx, err := db.BeginTraitIngest(ctx)
+	mustTx(t, err)
+	mustTx(t, tx.UpsertDistributionSource(domain.DistributionSourceMeta{ID: "euromed", Version: "v1", Redistribution: domain.RedistributionAllowed}))
+	mustTx(t, tx.AddDistribution("cdm:concept:inula-hirta", domain.Distribution{AreaScheme: "euromed", AreaCode: "Ge", Status: domain.DistributionNative}, "euromed"))
+	mustTx(t, tx.Commit())
+	mustTx(t, db.BuildDistributionClosure(ctx))
+
+	if got := effRows(t, db, "cdm:concept:inula-hirta"); got != "Ge:own,GER:name" {
+		t.Errorf("cdm inula-hirta: got %q, want Ge:own,GER:name", got)
+	}
+}
+
+// seedInfraspecificTree writes a WCVP genus with a species whose only rows
+// sit on its infraspecific taxa: an accepted subspecies in AUT, a variety
+// under that subspecies in GER, and a synonym-status subspecies in FRA. A
+// section parented on the genus carries ITA, and a second species has its
+// own SWI row plus a subspecies in SWI and CZE.
+func seedInfraspecificTree(t *testing.T, db *DB) {
+	t.Helper()
+	bv := domain.BackboneVersion{ID: "wcvp", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "x"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		for _, c := range []struct {
+			id, canonical, parent string
+			rank                  domain.Rank
+			status                domain.Status
+			areas                 []string
+		}{
+			{"g", "Abies", "", domain.RankGenus, domain.StatusAccepted, nil},
+			{"sect", "Abies sect. Abies", "g", domain.RankOther, domain.StatusAccepted, []string{"ITA"}},
+			{"sp", "Abies alba", "g", domain.RankSpecies, domain.StatusAccepted, nil},
+			{"ssp", "Abies alba subsp. alba", "sp", domain.RankSubspecies, domain.StatusAccepted, []string{"AUT"}},
+			{"var", "Abies alba subsp. alba var. alba", "ssp", domain.RankVariety, domain.StatusAccepted, []string{"GER"}},
+			{"ssp-syn", "Abies alba subsp. pectinata", "sp", domain.RankSubspecies, domain.StatusSynonym, []string{"FRA"}},
+			{"sp2", "Abies nordmanniana", "g", domain.RankSpecies, domain.StatusAccepted, []string{"SWI"}},
+			{"ssp2", "Abies nordmanniana subsp. equi-trojani", "sp2", domain.RankSubspecies, domain.StatusAccepted, []string{"SWI", "CZE"}},
+		} {
+			n := domain.Name{ID: "n-" + c.id, Canonical: c.canonical, Rank: c.rank}
+			mustTx(t, tx.UpsertName(n))
+			concept := domain.Concept{ID: "wcvp:concept:" + c.id, BackboneID: "wcvp", AcceptedName: n, Rank: c.rank, Status: c.status}
+			if c.parent != "" {
+				concept.ParentID = "wcvp:concept:" + c.parent
+			}
+			mustTx(t, tx.UpsertConcept(concept))
+			mustTx(t, tx.LinkName(concept.ID, n.ID, "accepted", nil))
+			for _, code := range c.areas {
+				mustTx(t, tx.AddDistribution(concept.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: code}, ""))
+			}
+		}
+	})
+}
+
+// TestBuildDistributionClosure_RollsInfraspecificAreasUpToSpecies pins the
+// roll-up: a species inherits the areas of its accepted infraspecific
+// descendants at any depth, an own row wins over a derived one, and neither
+// a synonym child nor a supraspecific rank contributes.
+func TestBuildDistributionClosure_RollsInfraspecificAreasUpToSpecies(t *testing.T) {
+	db := openTestDB(t)
+	seedInfraspecificTree(t, db)
+	mustTx(t, db.BuildDistributionClosure(context.Background()))
+
+	for id, want := range map[string]string{
+		"wcvp:concept:sp":   "AUT:infraspecific,GER:infraspecific",
+		"wcvp:concept:ssp":  "AUT:own",
+		"wcvp:concept:sp2":  "CZE:infraspecific,SWI:own",
+		"wcvp:concept:g":    "",
+		"wcvp:concept:sect": "ITA:own",
+	} {
+		if got := effRows(t, db, id); got != want {
+			t.Errorf("%s: got %q, want %q", id, got, want)
+		}
+	}
+}
+
+// TestConceptDistribution_FlagsDerivedRows pins what /v1/concept sees: the
+// rolled-up areas are listed next to the own ones, flagged Derived.
+func TestConceptDistribution_FlagsDerivedRows(t *testing.T) {
+	db := openTestDB(t)
+	seedInfraspecificTree(t, db)
+	ctx := context.Background()
+	mustTx(t, db.BuildDistributionClosure(ctx))
+
+	got, err := db.conceptDistribution(ctx, "wcvp:concept:sp2")
+	mustTx(t, err)
+	want := []domain.Distribution{
+		{AreaScheme: "wgsrpd_l3", AreaCode: "CZE", Derived: true},
+		{AreaScheme: "wgsrpd_l3", AreaCode: "SWI"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("conceptDistribution(sp2) = %+v, want %+v", got, want)
+	}
+}
+
 // TestOpenDoesNotBuildClosure pins the serve-startup fix: Open must NEVER build
 // distribution_effective. `hostus serve` opens the DB before it binds its
 // listener, so a heavy build here blocks (and can OOM-kill) the container before
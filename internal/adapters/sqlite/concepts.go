@@ -0,0 +1,191 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// classificationsQuery is Classification for a json_each id list: every
+// concept's ancestors from concept_lineage (depth 0 is the concept itself),
+// root first.
+const classificationsQuery = `
+	SELECT cl.concept_id, a.id, an.canonical, a.rank
+	FROM concept_lineage cl
+	JOIN taxon_concept a ON a.id = cl.ancestor_id
+	JOIN name an ON an.id = a.accepted_name
+	WHERE cl.concept_id IN (SELECT value FROM json_each(?)) AND cl.depth > 0
+	ORDER BY cl.concept_id, cl.depth DESC`
+
+// Concepts reads ids' ConceptDocuments with one query per part. See
+// output.Repository.Concepts for the contract.
+func (db *DB) Concepts(ctx context.Context, ids []string, include output.ConceptInclude) ([]output.ConceptDocument, error) {
+	ids = uniqueIDs(ids)
+	byID, err := db.conceptDocuments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]string, 0, len(byID))
+	for _, id := range ids {
+		if byID[id] != nil {
+			found = append(found, id)
+		}
+	}
+	if len(found) == 0 {
+		return []output.ConceptDocument{}, nil
+	}
+
+	synonyms, err := db.synonymsOf(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	xrefs, err := db.xrefsOf(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	dists, err := db.distributionsOf(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	hybridParents, err := db.hybridParentsOf(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		classifications map[string][]domain.ClassificationEntry
+		traits          map[string][]domain.TraitSet
+		candidates      map[string][]domain.SynonymCandidate
+		entries         map[string][]domain.NameSpaceEntry
+	)
+	if include.Classification {
+		if classifications, err = db.classificationsOf(ctx, found); err != nil {
+			return nil, err
+		}
+	}
+	if include.Traits {
+		if traits, err = db.traitsOf(ctx, found, nil); err != nil {
+			return nil, err
+		}
+	}
+	if include.SynonymCandidates {
+		if candidates, err = db.synonymCandidatesOf(ctx, found); err != nil {
+			return nil, err
+		}
+	}
+	if include.NameSpaceEntries {
+		if entries, err = db.nameSpaceEntriesOf(ctx, found, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]output.ConceptDocument, len(found))
+	for i, id := range found {
+		doc := byID[id]
+		doc.Synonyms, doc.Xrefs, doc.Distribution = synonyms[id], xrefs[id], dists[id]
+		doc.HybridParents = hybridParents[id]
+		if include.Classification {
+			doc.Classification = nonNil(classifications[id])
+		}
+		if include.Traits {
+			doc.Traits = nonNil(traits[id])
+		}
+		if include.SynonymCandidates {
+			doc.SynonymCandidates = nonNil(candidates[id])
+		}
+		if include.NameSpaceEntries {
+			doc.NameSpaceEntries = nonNil(entries[id])
+		}
+		out[i] = *doc
+	}
+	return out, nil
+}
+
+// conceptDocuments reads the concept row and sec. reference of every known
+// id in ids, keyed by id.
+func (db *DB) conceptDocuments(ctx context.Context, ids []string) (map[string]*output.ConceptDocument, error) {
+	idsJSON, err := marshalIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, `SELECT`+conceptColumns+`, COALESCE(sr.id, ''), COALESCE(sr.title, '')`+conceptJoin+`
+		LEFT JOIN sec_reference sr ON sr.id = tc.sec_reference
+		WHERE tc.id IN (SELECT value FROM json_each(?))`, idsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying concepts %q: %w", ids, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := map[string]*output.ConceptDocument{}
+	for rows.Next() {
+		var sec domain.SecReference
+		c, err := scanConcept(func(dest ...any) error {
+			return rows.Scan(append(dest, &sec.ID, &sec.Title)...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: scanning concepts %q: %w", ids, err)
+		}
+		out[c.ID] = &output.ConceptDocument{Concept: *c, Sec: sec}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating concepts %q: %w", ids, err)
+	}
+	return out, nil
+}
+
+// classificationsOf is Classification for every concept in conceptIDs,
+// keyed by concept id, each chain root-first. A concept without a parent is
+// absent from the map.
+func (db *DB) classificationsOf(ctx context.Context, conceptIDs []string) (map[string][]domain.ClassificationEntry, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, classificationsQuery, idsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying classification of concepts %q: %w", conceptIDs, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := map[string][]domain.ClassificationEntry{}
+	for rows.Next() {
+		var (
+			conceptID, rank string
+			e               domain.ClassificationEntry
+		)
+		if err := rows.Scan(&conceptID, &e.ConceptID, &e.Canonical, &rank); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning classification of concepts %q: %w", conceptIDs, err)
+		}
+		if e.Rank, err = domain.ParseRank(rank); err != nil {
+			return nil, fmt.Errorf("sqlite: classification ancestor %q: %w", e.ConceptID, err)
+		}
+		out[conceptID] = append(out[conceptID], e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating classification of concepts %q: %w", conceptIDs, err)
+	}
+	return out, nil
+}
+
+// uniqueIDs drops repeats from ids, keeping each id's first position.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// nonNil turns a part absent from its map into the empty slice an included
+// part promises.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
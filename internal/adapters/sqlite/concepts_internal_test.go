@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+var includeAll = output.ConceptInclude{Classification: true, Traits: true, SynonymCandidates: true, NameSpaceEntries: true}
+
+// TestConcepts_MatchesTheSingleConceptReads pins the batch against the
+// per-id methods it replaces: every part of every document equals what
+// Concept, Classification, Traits, SynonymCandidates and NameSpaceEntries
+// return for that id — including the concept with no traits and no
+// name-space entries, whose included parts are empty rather than nil.
+func TestConcepts_MatchesTheSingleConceptReads(t *testing.T) {
+	db := openSeededDB(t)
+	seedTraits(t, db, corynephorusID)
+	seedFloraVegEntries(t, db)
+	ctx := context.Background()
+
+	docs, err := db.Concepts(ctx, []string{jacobaeaID, "c-nope", corynephorusID, jacobaeaID}, includeAll)
+	mustTx(t, err)
+	if len(docs) != 2 || docs[0].Concept.ID != jacobaeaID || docs[1].Concept.ID != corynephorusID {
+		t.Fatalf("Concepts = %d documents (%+v), want jacobaea then corynephorus, the unknown id absent and the repeat once", len(docs), docs)
+	}
+
+	for _, doc := range docs {
+		id := doc.Concept.ID
+		concept, synonyms, xrefs, dists, err := db.Concept(ctx, id)
+		mustTx(t, err)
+		classification, err := db.Classification(ctx, id)
+		mustTx(t, err)
+		if classification == nil {
+			classification = []domain.ClassificationEntry{}
+		}
+		traits, err := db.Traits(ctx, id, nil)
+		mustTx(t, err)
+		candidates, err := db.SynonymCandidates(ctx, id)
+		mustTx(t, err)
+		entries, err := db.NameSpaceEntries(ctx, id, nil)
+		mustTx(t, err)
+
+		for _, part := range []struct {
+			name      string
+			got, want any
+		}{
+			{"concept", doc.Concept, *concept},
+			{"synonyms", doc.Synonyms, synonyms},
+			{"xrefs", doc.Xrefs, xrefs},
+			{"distribution", doc.Distribution, dists},
+			{"classification", doc.Classification, classification},
+			{"traits", doc.Traits, traits},
+			{"synonym candidates", doc.SynonymCandidates, candidates},
+			{"name space entries", doc.NameSpaceEntries, entries},
+		} {
+			if !reflect.DeepEqual(part.got, part.want) {
+				t.Errorf("Concepts(%s) %s = %+v, want %+v", id, part.name, part.got, part.want)
+			}
+		}
+	}
+	if len(docs[1].Traits) != 2 || len(docs[1].NameSpaceEntries) != 3 {
+		t.Errorf("corynephorus traits/entries = %d/%d, want the seeded 2/3", len(docs[1].Traits), len(docs[1].NameSpaceEntries))
+	}
+}
+
+// TestConcepts_LeavesPartsNotAskedForNil pins the zero include: the
+// always-read parts only.
+func TestConcepts_LeavesPartsNotAskedForNil(t *testing.T) {
+	db := openSeededDB(t)
+	seedTraits(t, db, corynephorusID)
+
+	docs, err := db.Concepts(context.Background(), []string{corynephorusID}, output.ConceptInclude{})
+	mustTx(t, err)
+	if len(docs) != 1 {
+		t.Fatalf("Concepts = %+v, want one document", docs)
+	}
+	if d := docs[0]; d.Classification != nil || d.Traits != nil || d.SynonymCandidates != nil || d.NameSpaceEntries != nil {
+		t.Errorf("document = %+v, want every optional part nil", d)
+	}
+	if docs, err := db.Concepts(context.Background(), []string{"c-nope"}, includeAll); err != nil || len(docs) != 0 {
+		t.Errorf("Concepts(unknown) = %+v, %v; want no documents and no error", docs, err)
+	}
+}
+
+// TestConcepts_ClassificationWalksLikeClassification runs the batch read
+// over the hand-built chain, then closes it into a cycle and rebuilds the
+// lineage: both reads must agree on every concept either way, root first.
+func TestConcepts_ClassificationWalksLikeClassification(t *testing.T) {
+	db := seedClassificationChain(t)
+	ctx := context.Background()
+	ids := []string{"c-species", "c-genus", "c-family"}
+
+	assertSame := func(label string) {
+		t.Helper()
+		docs, err := db.Concepts(ctx, ids, output.ConceptInclude{Classification: true})
+		mustTx(t, err)
+		for _, doc := range docs {
+			want, err := db.Classification(ctx, doc.Concept.ID)
+			mustTx(t, err)
+			if want == nil {
+				want = []domain.ClassificationEntry{}
+			}
+			if !reflect.DeepEqual(doc.Classification, want) {
+				t.Errorf("%s: Concepts(%s).Classification = %+v, want %+v", label, doc.Concept.ID, doc.Classification, want)
+			}
+		}
+	}
+	assertSame("chain")
+	mustExecClassification(t, db, `UPDATE taxon_concept SET parent_id = 'c-species' WHERE id = 'c-family'`)
+	mustTx(t, db.BuildLineageClosure(ctx))
+	assertSame("cycle")
+}
@@ -73,6 +73,26 @@ func Open(path string) (*DB, error) {
 		_ = sqlDB.Close()
 		return nil, err
 	}
+	if err := migrateAreaParent(context.Background(), sqlDB); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	if err := migrateDistributionColumns(context.Background(), sqlDB); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	if err := migrateFTSNameMapLabel(context.Background(), sqlDB); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	if err := migrateConceptFamily(context.Background(), sqlDB); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	if err := migrateNamePublishedYear(context.Background(), sqlDB); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
 	if err := verifySchemaColumns(context.Background(), sqlDB); err != nil {
 		_ = sqlDB.Close()
 		return nil, err
@@ -554,6 +574,55 @@ func migrateNameSpaceEntryStatus(ctx context.Context, sqlDB *sql.DB) error {
 	return addColumnIfMissing(ctx, sqlDB, "name_space_entry", "status", "TEXT NOT NULL DEFAULT ''")
 }
 
+// migrateAreaParent adds area.parent to an index built before the WGSRPD
+// hierarchy was ingested. Existing rows get an empty parent, so until a
+// re-ingest records the hierarchy, an area filter naming a continent or
+// region matches nothing, exactly as it did before; level-3 codes are
+// unaffected.
+func migrateAreaParent(ctx context.Context, sqlDB *sql.DB) error {
+	return addColumnIfMissing(ctx, sqlDB, "area", "parent", "TEXT NOT NULL DEFAULT ''")
+}
+
+// migrateDistributionColumns adds distribution.status and distribution.source
+// to an index built before regional distribution sources existed. Every
+// existing row is a backbone row, so the empty status (no verdict) and NULL
+// (the backbone's own data) are exactly right for them; the source column
+// takes the nullable, default-less form ALTER TABLE accepts with a
+// REFERENCES clause, like migrateXrefSourceColumn's.
+func migrateDistributionColumns(ctx context.Context, sqlDB *sql.DB) error {
+	if err := addColumnIfMissing(ctx, sqlDB, "distribution", "status", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	return addColumnIfMissing(ctx, sqlDB, "distribution", "source", "TEXT REFERENCES distribution_source(id)")
+}
+
+// migrateFTSNameMapLabel adds fts_name_map.kind and fts_name_map.label to an
+// index built before suggest reported its matched name. Existing rows keep
+// an empty kind and label, and Suggest reports no match for them rather than
+// guessing; a re-ingest indexes the names afresh with both filled.
+func migrateFTSNameMapLabel(ctx context.Context, sqlDB *sql.DB) error {
+	if err := addColumnIfMissing(ctx, sqlDB, "fts_name_map", "kind", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	return addColumnIfMissing(ctx, sqlDB, "fts_name_map", "label", "TEXT NOT NULL DEFAULT ''")
+}
+
+// migrateConceptFamily adds taxon_concept.family to an index built before the
+// area checklist grouped by family. Existing concepts get an empty family and
+// are grouped under their nearest FAMILY ancestor, if any, until a re-ingest
+// records the source's label.
+func migrateConceptFamily(ctx context.Context, sqlDB *sql.DB) error {
+	return addColumnIfMissing(ctx, sqlDB, "taxon_concept", "family", "TEXT NOT NULL DEFAULT ''")
+}
+
+// migrateNamePublishedYear adds name.published_year to an index built before
+// names carried their publication year. Existing names get 0 — "no year
+// known" — which every published-before filter keeps, so such an index
+// filters nothing out until a re-ingest parses the citations.
+func migrateNamePublishedYear(ctx context.Context, sqlDB *sql.DB) error {
+	return addColumnIfMissing(ctx, sqlDB, "name", "published_year", "INTEGER NOT NULL DEFAULT 0")
+}
+
 // Close releases the underlying database handle.
 func (db *DB) Close() error {
 	return db.sql.Close()
@@ -662,9 +731,9 @@ func nullString(s string) any {
 
 func (t *ingestTx) UpsertName(n domain.Name) error {
 	_, err := t.tx.ExecContext(t.ctx, `
-		INSERT OR REPLACE INTO name (id, canonical, canonical_fold, authorship, rank, ipni_id, published_in, nom_status, basionym_id, rank_verbatim)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		n.ID, n.Canonical, domain.Canonicalize(n.Canonical), n.Authorship, string(n.Rank), n.IPNIID, nullString(n.PublishedIn), nullString(n.NomStatus), nullableFK(n.BasionymID), nullString(n.RankVerbatim),
+		INSERT OR REPLACE INTO name (id, canonical, canonical_fold, authorship, rank, ipni_id, published_in, published_year, nom_status, basionym_id, rank_verbatim)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		n.ID, n.Canonical, domain.Canonicalize(n.Canonical), n.Authorship, string(n.Rank), n.IPNIID, nullString(n.PublishedIn), n.PublishedYear, nullString(n.NomStatus), nullableFK(n.BasionymID), nullString(n.RankVerbatim),
 	)
 	if err != nil {
 		return fmt.Errorf("sqlite: upserting name %q: %w", n.ID, err)
@@ -674,9 +743,9 @@ func (t *ingestTx) UpsertName(n domain.Name) error {
 
 func (t *ingestTx) UpsertConcept(c domain.Concept) error {
 	_, err := t.tx.ExecContext(t.ctx, `
-		INSERT OR REPLACE INTO taxon_concept (id, backbone_id, accepted_name, rank, parent_id, sec_reference, status, rank_verbatim)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		c.ID, c.BackboneID, c.AcceptedName.ID, string(c.Rank), nullableFK(c.ParentID), c.SecReference, string(c.Status), nullString(c.RankVerbatim),
+		INSERT OR REPLACE INTO taxon_concept (id, backbone_id, accepted_name, rank, parent_id, sec_reference, status, rank_verbatim, family)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ID, c.BackboneID, c.AcceptedName.ID, string(c.Rank), nullableFK(c.ParentID), c.SecReference, string(c.Status), nullString(c.RankVerbatim), c.Family,
 	)
 	if err != nil {
 		return fmt.Errorf("sqlite: upserting concept %q: %w", c.ID, err)
@@ -721,11 +790,14 @@ func (t *ingestTx) AddXref(conceptID string, x domain.Xref, source string) error
 	return nil
 }
 
-func (t *ingestTx) AddDistribution(conceptID string, d domain.Distribution) error {
+// AddDistribution writes one distribution row for conceptID, attributed to
+// the distribution_source named by source — "" (SQL NULL) for a backbone's
+// own ranges, the same convention as AddXref.
+func (t *ingestTx) AddDistribution(conceptID string, d domain.Distribution, source string) error {
 	_, err := t.tx.ExecContext(t.ctx, `
-		INSERT OR REPLACE INTO distribution (concept_id, area_scheme, area_code)
-		VALUES (?, ?, ?)`,
-		conceptID, d.AreaScheme, d.AreaCode,
+		INSERT OR REPLACE INTO distribution (concept_id, area_scheme, area_code, status, source)
+		VALUES (?, ?, ?, ?, ?)`,
+		conceptID, d.AreaScheme, d.AreaCode, string(d.Status), nullableFK(source),
 	)
 	if err != nil {
 		return fmt.Errorf("sqlite: adding distribution %s/%s for concept %q: %w", d.AreaScheme, d.AreaCode, conceptID, err)
@@ -740,15 +812,16 @@ func (t *ingestTx) AddDistribution(conceptID string, d domain.Distribution) erro
 type nameCanonicalPair struct {
 	conceptID string
 	canonical string
+	kind      domain.MatchField
 }
 
 // Finalize builds the FTS5 autosuggest index for every name this
 // transaction's backbone has linked to a concept — both the accepted name
 // and every synonym, via concept_name — so a prefix search on a synonym's
-// canonical resolves back to its accepted concept (fts_name_map only
-// stores concept_id, not which specific name matched, so Suggest always
-// reports the accepted name's own canonical/rank/status; indexing the
-// synonym text here is what makes it findABLE at all).
+// canonical resolves back to its accepted concept. Suggest reports the
+// accepted name's own canonical/rank/status either way; fts_name_map's kind
+// and label record which name a row indexes, so it can also say which one
+// the query matched (SuggestItem.Match).
 //
 // Known limitation: fts_name is a contentless FTS5 table (content=”,
 // schema.sql), and contentless tables reject plain DELETE ("cannot DELETE
@@ -762,9 +835,12 @@ type nameCanonicalPair struct {
 // GROUP BYs on tc.id, so duplicate index entries for the same concept
 // simply collapse back into one result — only the index's on-disk size
 // under repeated re-ingestion of the same backbone.
+//
+// It then rewrites the backbone's concept_lineage rows
+// (refreshBackboneLineage), which unlike the FTS rows can be replaced.
 func (t *ingestTx) Finalize() error {
 	rows, err := t.tx.QueryContext(t.ctx, `
-		SELECT cn.concept_id, n.canonical
+		SELECT cn.concept_id, n.canonical, cn.name_id = tc.accepted_name
 		FROM concept_name cn
 		JOIN name n ON n.id = cn.name_id
 		JOIN taxon_concept tc ON tc.id = cn.concept_id
@@ -775,10 +851,15 @@ func (t *ingestTx) Finalize() error {
 	var pairs []nameCanonicalPair
 	for rows.Next() {
 		var p nameCanonicalPair
-		if err := rows.Scan(&p.conceptID, &p.canonical); err != nil {
+		var accepted bool
+		if err := rows.Scan(&p.conceptID, &p.canonical, &accepted); err != nil {
 			_ = rows.Close()
 			return fmt.Errorf("sqlite: scanning concept_name row for FTS indexing (backbone %q): %w", t.backboneID, err)
 		}
+		p.kind = domain.MatchSynonym
+		if accepted {
+			p.kind = domain.MatchAccepted
+		}
 		pairs = append(pairs, p)
 	}
 	if err := rows.Err(); err != nil {
@@ -788,7 +869,7 @@ func (t *ingestTx) Finalize() error {
 	_ = rows.Close()
 
 	for _, p := range pairs {
-		res, err := t.tx.ExecContext(t.ctx, `INSERT INTO fts_name_map (concept_id) VALUES (?)`, p.conceptID)
+		res, err := t.tx.ExecContext(t.ctx, `INSERT INTO fts_name_map (concept_id, kind, label) VALUES (?, ?, ?)`, p.conceptID, string(p.kind), p.canonical)
 		if err != nil {
 			return fmt.Errorf("sqlite: inserting fts_name_map for concept %q: %w", p.conceptID, err)
 		}
@@ -803,7 +884,7 @@ func (t *ingestTx) Finalize() error {
 			return fmt.Errorf("sqlite: inserting fts_name for concept %q: %w", p.conceptID, err)
 		}
 	}
-	return nil
+	return refreshBackboneLineage(t.ctx, t.tx, t.backboneID)
 }
 
 // nullableFloat converts an optional *float64 into a driver value SQLite
@@ -895,6 +976,21 @@ func (t *ingestTx) AddConceptRelation(fromID, toID string, rel domain.Relation,
 	return nil
 }
 
+// AddMisapplication writes one misapplied-name assertion into misapplication,
+// in the direction the source states it. Both ends are FKs onto
+// taxon_concept, as for AddConceptRelation.
+func (t *ingestTx) AddMisapplication(fromID, toID, source string) error {
+	_, err := t.tx.ExecContext(t.ctx, `
+		INSERT OR REPLACE INTO misapplication (from_concept, to_concept, source)
+		VALUES (?, ?, ?)`,
+		fromID, toID, source,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: adding misapplication %s -> %s: %w", fromID, toID, err)
+	}
+	return nil
+}
+
 // UpsertXrefSource records one xref-source provenance row, the xref
 // counterpart of UpsertTraitVocabulary. ingested_at is stamped with the
 // current time here for the same reason it is there: provenance/timing
@@ -911,6 +1007,47 @@ func (t *ingestTx) UpsertXrefSource(meta domain.XrefSourceMeta) error {
 	return nil
 }
 
+// UpsertDistributionSource records one distribution_source provenance row,
+// the distribution counterpart of UpsertXrefSource.
+func (t *ingestTx) UpsertDistributionSource(meta domain.DistributionSourceMeta) error {
+	_, err := t.tx.ExecContext(t.ctx, `
+		INSERT OR REPLACE INTO distribution_source (id, version, license, source_url, ingested_at, manifest_sha, redistribution)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		meta.ID, meta.Version, meta.License, meta.SourceURL, time.Now().UTC().Format(time.RFC3339), meta.ManifestSHA, string(meta.Redistribution),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: upserting distribution source %s/%s: %w", meta.ID, meta.Version, err)
+	}
+	return nil
+}
+
+// UpsertFrequencySource records one frequency_source provenance row, the
+// frequency counterpart of UpsertDistributionSource.
+func (t *ingestTx) UpsertFrequencySource(meta domain.FrequencySourceMeta) error {
+	_, err := t.tx.ExecContext(t.ctx, `
+		INSERT OR REPLACE INTO frequency_source (id, version, license, source_url, ingested_at, manifest_sha, redistribution)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		meta.ID, meta.Version, meta.License, meta.SourceURL, time.Now().UTC().Format(time.RFC3339), meta.ManifestSHA, string(meta.Redistribution),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: upserting frequency source %s/%s: %w", meta.ID, meta.Version, err)
+	}
+	return nil
+}
+
+// SetFrequency records source's count for conceptID, replacing any count an
+// earlier run of the same source recorded.
+func (t *ingestTx) SetFrequency(conceptID, source string, count int64) error {
+	_, err := t.tx.ExecContext(t.ctx, `
+		INSERT OR REPLACE INTO concept_frequency (concept_id, source, count) VALUES (?, ?, ?)`,
+		conceptID, source, count,
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: setting %s frequency for concept %q: %w", source, conceptID, err)
+	}
+	return nil
+}
+
 func (t *ingestTx) Commit() error {
 	if err := t.tx.Commit(); err != nil {
 		return fmt.Errorf("sqlite: committing ingest transaction: %w", err)
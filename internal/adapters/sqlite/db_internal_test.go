@@ -282,7 +282,7 @@ func TestIngestTx_UpsertNameConceptLinkXrefDistribution(t *testing.T) {
 	if err := tx.AddXref(concept.ID, domain.Xref{Authority: "powo", ExtID: "396681-1"}, ""); err != nil {
 		t.Fatalf("AddXref: unexpected error: %v", err)
 	}
-	if err := tx.AddDistribution(concept.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "GER"}); err != nil {
+	if err := tx.AddDistribution(concept.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "GER"}, ""); err != nil {
 		t.Fatalf("AddDistribution: unexpected error: %v", err)
 	}
 	if err := tx.Commit(); err != nil {
@@ -0,0 +1,167 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// addEuroMedDistribution records a "euromed" distribution source with the
+// given redistribution value, places Corynephorus canescens (405825) in
+// euromed:Ge as native, and rebuilds distribution_effective so the area
+// filters see the new rows.
+func addEuroMedDistribution(t *testing.T, src *sqlite.DB, redistribution domain.Redistribution) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := src.BeginTraitIngest(ctx)
+	if err != nil {
+		t.Fatalf("BeginTraitIngest: unexpected error: %v", err)
+	}
+	if err := tx.UpsertDistributionSource(domain.DistributionSourceMeta{
+		ID: "euromed", Version: "2026-03", License: "CC BY-SA 4.0",
+		SourceURL:   "https://europlusmed.org",
+		ManifestSHA: "cafebabe", Redistribution: redistribution,
+	}); err != nil {
+		t.Fatalf("UpsertDistributionSource: unexpected error: %v", err)
+	}
+	d := domain.Distribution{AreaScheme: "euromed", AreaCode: "Ge", Status: domain.DistributionNative}
+	if err := tx.AddDistribution("wcvp:concept:405825", d, "euromed"); err != nil {
+		t.Fatalf("AddDistribution: unexpected error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: unexpected error: %v", err)
+	}
+	if err := src.BuildDistributionClosure(ctx); err != nil {
+		t.Fatalf("BuildDistributionClosure: unexpected error: %v", err)
+	}
+}
+
+func TestConcept_DistributionCarriesStatus(t *testing.T) {
+	db := ingestWCVPFixture(t)
+	addEuroMedDistribution(t, db, domain.RedistributionAllowed)
+
+	_, _, _, dists, err := db.Concept(context.Background(), "wcvp:concept:405825")
+	if err != nil {
+		t.Fatalf("Concept: unexpected error: %v", err)
+	}
+	// The fixture records the grass as introduced in British Columbia and
+	// Connecticut; every other WCVP row of it is native.
+	introduced := map[string]bool{"BRC": true, "CNT": true}
+	var found bool
+	for _, d := range dists {
+		want := domain.DistributionNative
+		if introduced[d.AreaCode] {
+			want = domain.DistributionIntroduced
+		}
+		switch {
+		case d.AreaScheme == "euromed":
+			found = d.AreaCode == "Ge" && d.Status == domain.DistributionNative
+		case d.Status != want:
+			t.Errorf("WCVP row %+v, want status %q", d, want)
+		}
+	}
+	if !found {
+		t.Errorf("distribution = %+v, want a euromed Ge native row", dists)
+	}
+}
+
+// TestAreaFilter_SchemePrefixedCode pins the "scheme:code" filter syntax: the
+// code is matched verbatim in that scheme only, and a concept with no row in
+// the scheme has no known range there.
+func TestAreaFilter_SchemePrefixedCode(t *testing.T) {
+	db := ingestWCVPFixture(t)
+	addEuroMedDistribution(t, db, domain.RedistributionAllowed)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		area string
+		want bool
+	}{
+		{"euromed:Ge", true},
+		{"euromed:Au", false},
+		{"euromed:ge", false},
+		{"AUT", true},
+	} {
+		got, err := db.Suggest(ctx, "coryn", output.SuggestOpts{Limit: 10, Area: tc.area})
+		if err != nil {
+			t.Fatalf("Suggest(Area=%q): unexpected error: %v", tc.area, err)
+		}
+		item, ok := conceptIDs(got)["wcvp:concept:405825"]
+		if !ok {
+			t.Fatalf("Suggest(Area=%q) = %+v, want wcvp:concept:405825", tc.area, got)
+		}
+		if item.InArea != tc.want {
+			t.Errorf("Suggest(Area=%q) InArea = %v, want %v", tc.area, item.InArea, tc.want)
+		}
+	}
+
+	presence, err := db.AreaPresence(ctx, "euromed:Ge", []string{"wcvp:concept:405825", "wcvp:concept:415853"})
+	if err != nil {
+		t.Fatalf("AreaPresence: unexpected error: %v", err)
+	}
+	if !presence["wcvp:concept:405825"].InArea {
+		t.Errorf("AreaPresence[405825] = %+v, want InArea", presence["wcvp:concept:405825"])
+	}
+	if _, known := presence["wcvp:concept:415853"]; known {
+		t.Error("AreaPresence[415853] present, want absent (no euromed rows: no known range in that scheme)")
+	}
+}
+
+// TestExportBundle_SchemeScopedBundleCarriesSourceAndIsGated pins both halves
+// of the bundle side: a euromed-scoped bundle carries the regional rows and
+// their source row, and the redistribution gate refuses it when that source
+// is not allowed.
+func TestExportBundle_SchemeScopedBundleCarriesSourceAndIsGated(t *testing.T) {
+	ctx := context.Background()
+	src := ingestWCVPFixture(t)
+	addEuroMedDistribution(t, src, domain.RedistributionRestricted)
+
+	refused := filepath.Join(t.TempDir(), "bundle-refused.sqlite")
+	_, err := sqlite.ExportBundle(ctx, src, refused, sqlite.BundleOpts{Area: "euromed:Ge", SnapshotVersion: "v1"})
+	if err == nil || !strings.Contains(err.Error(), "euromed (redistribution=restricted)") {
+		t.Fatalf("ExportBundle(euromed:Ge) err = %v, want a refusal naming euromed", err)
+	}
+
+	out := filepath.Join(t.TempDir(), "bundle.sqlite")
+	report, err := sqlite.ExportBundle(ctx, src, out, sqlite.BundleOpts{Area: "euromed:Ge", SnapshotVersion: "v1", AllowRestricted: true})
+	if err != nil {
+		t.Fatalf("ExportBundle(AllowRestricted): unexpected error: %v", err)
+	}
+	if report.Concepts != 1 {
+		t.Errorf("report.Concepts = %d, want 1", report.Concepts)
+	}
+	bundle, err := sqlite.Open(out)
+	if err != nil {
+		t.Fatalf("sqlite.Open(bundle): unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = bundle.Close() })
+	_, _, _, dists, err := bundle.Concept(ctx, "wcvp:concept:405825")
+	if err != nil {
+		t.Fatalf("bundle.Concept: unexpected error: %v", err)
+	}
+	if len(dists) != 1 || dists[0].AreaScheme != "euromed" || dists[0].Status != domain.DistributionNative {
+		t.Errorf("bundle distribution = %+v, want only the euromed Ge native row", dists)
+	}
+}
+
+// TestExportBundle_UnrelatedAreaIgnoresRegionalSource pins that the gate is
+// scoped to the rows actually copied: an AUT bundle carries no euromed rows,
+// so a restricted euromed source does not block it.
+func TestExportBundle_UnrelatedAreaIgnoresRegionalSource(t *testing.T) {
+	ctx := context.Background()
+	src := ingestWCVPFixture(t)
+	addEuroMedDistribution(t, src, domain.RedistributionRestricted)
+
+	out := filepath.Join(t.TempDir(), "bundle.sqlite")
+	if _, err := sqlite.ExportBundle(ctx, src, out, sqlite.BundleOpts{Area: "AUT", SnapshotVersion: "v1"}); err != nil {
+		t.Fatalf("ExportBundle(AUT): unexpected error: %v", err)
+	}
+	if meta := readBundleMeta(t, out); meta.RestrictedSources != "" {
+		t.Errorf("bundle_meta.restricted_sources = %q, want empty", meta.RestrictedSources)
+	}
+}
@@ -0,0 +1,67 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// addReleveeFrequency records a "veg_relevees" frequency source with the
+// given redistribution value and gives Corynephorus canescens (405825) a
+// count of 340 in it.
+func addReleveeFrequency(t *testing.T, src *sqlite.DB, redistribution domain.Redistribution) {
+	t.Helper()
+	tx, err := src.BeginTraitIngest(context.Background())
+	if err != nil {
+		t.Fatalf("BeginTraitIngest: unexpected error: %v", err)
+	}
+	if err := tx.UpsertFrequencySource(domain.FrequencySourceMeta{
+		ID: "veg_relevees", Version: "2026-01", ManifestSHA: "cafebabe", Redistribution: redistribution,
+	}); err != nil {
+		t.Fatalf("UpsertFrequencySource: unexpected error: %v", err)
+	}
+	if err := tx.SetFrequency("wcvp:concept:405825", "veg_relevees", 340); err != nil {
+		t.Fatalf("SetFrequency: unexpected error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: unexpected error: %v", err)
+	}
+}
+
+// TestExportBundle_FrequencySourceIsGatedAndCarried pins both halves of the
+// bundle side: a restricted frequency source refuses the export, and a
+// forced export carries the counts so the bundle ranks like its source.
+func TestExportBundle_FrequencySourceIsGatedAndCarried(t *testing.T) {
+	ctx := context.Background()
+	src := ingestWCVPFixture(t)
+	addReleveeFrequency(t, src, domain.RedistributionRestricted)
+
+	refused := filepath.Join(t.TempDir(), "bundle-refused.sqlite")
+	_, err := sqlite.ExportBundle(ctx, src, refused, sqlite.BundleOpts{SnapshotVersion: "v1"})
+	if err == nil || !strings.Contains(err.Error(), "veg_relevees (redistribution=restricted)") {
+		t.Fatalf("ExportBundle err = %v, want a refusal naming veg_relevees", err)
+	}
+
+	out := filepath.Join(t.TempDir(), "bundle.sqlite")
+	if _, err := sqlite.ExportBundle(ctx, src, out, sqlite.BundleOpts{SnapshotVersion: "v1", AllowRestricted: true}); err != nil {
+		t.Fatalf("ExportBundle(AllowRestricted): unexpected error: %v", err)
+	}
+	bundle, err := sqlite.Open(out)
+	if err != nil {
+		t.Fatalf("sqlite.Open(bundle): unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = bundle.Close() })
+	items, err := bundle.Suggest(ctx, "coryn", output.SuggestOpts{Limit: 10})
+	if err != nil {
+		t.Fatalf("bundle.Suggest: unexpected error: %v", err)
+	}
+	item, ok := conceptIDs(items)["wcvp:concept:405825"]
+	if !ok || item.Frequency != 340 {
+		t.Errorf("bundle.Suggest(coryn) 405825 = %+v (found %v), want Frequency 340", item, ok)
+	}
+}
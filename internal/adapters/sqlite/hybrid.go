@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// AddHybridParent writes one parent of a nothotaxon into hybrid_parent. The
+// fold is computed here, like UpsertName's canonical_fold, so no caller can
+// store a parent MatchHybridParents would not find.
+func (t *ingestTx) AddHybridParent(conceptID string, position int, canonical string) error {
+	_, err := t.tx.ExecContext(t.ctx, `
+		INSERT OR REPLACE INTO hybrid_parent (concept_id, position, canonical, canonical_fold)
+		VALUES (?, ?, ?, ?)`,
+		conceptID, position, canonical, domain.Canonicalize(canonical),
+	)
+	if err != nil {
+		return fmt.Errorf("sqlite: adding hybrid parent %q of %s: %w", canonical, conceptID, err)
+	}
+	return nil
+}
+
+// hybridParentsOf reads the hybrid parents of every concept in conceptIDs,
+// keyed by concept id, in formula order. A parent resolves to a concept of
+// the hybrid's own backbone through any name linked to it (accepted or
+// synonym), and only when exactly one concept holds the name — a parent
+// named by two concepts is left unresolved rather than guessed. A concept
+// without a stored formula is absent from the map.
+func (db *DB) hybridParentsOf(ctx context.Context, conceptIDs []string) (map[string][]domain.HybridParent, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT hp.concept_id, hp.canonical, COALESCE((
+			SELECT CASE WHEN COUNT(DISTINCT cn.concept_id) = 1 THEN MIN(cn.concept_id) END
+			FROM name n
+			JOIN concept_name cn ON cn.name_id = n.id
+			JOIN taxon_concept pc ON pc.id = cn.concept_id
+			WHERE n.canonical_fold = hp.canonical_fold AND pc.backbone_id = tc.backbone_id
+		), '')
+		FROM hybrid_parent hp
+		JOIN taxon_concept tc ON tc.id = hp.concept_id
+		WHERE hp.concept_id IN (SELECT value FROM json_each(?))
+		ORDER BY hp.concept_id, hp.position`, idsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying hybrid parents of concepts %q: %w", conceptIDs, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := map[string][]domain.HybridParent{}
+	for rows.Next() {
+		var (
+			conceptID string
+			p         domain.HybridParent
+		)
+		if err := rows.Scan(&conceptID, &p.Canonical, &p.ConceptID); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning hybrid parent row: %w", err)
+		}
+		out[conceptID] = append(out[conceptID], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating hybrid parent rows: %w", err)
+	}
+	return out, nil
+}
+
+// MatchHybridParents returns the concepts whose hybrid parents are exactly
+// parents, folded and in any order. The first parent's fold narrows the
+// scan through idx_hybrid_parent_fold; the HAVING clause then asks of each
+// remaining concept that it has as many parents as were named, all distinct,
+// and every one of them among the named.
+func (db *DB) MatchHybridParents(ctx context.Context, parents []string) ([]output.MatchCandidate, error) {
+	seen := make(map[string]bool, len(parents))
+	folds := make([]string, 0, len(parents))
+	for _, p := range parents {
+		if f := domain.Canonicalize(p); !seen[f] {
+			seen[f] = true
+			folds = append(folds, f)
+		}
+	}
+	if len(folds) == 0 {
+		return nil, nil
+	}
+	foldsJSON, err := marshalIDs(folds)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT 'accepted', NULL,
+			an.id, an.canonical, COALESCE(an.authorship, ''), an.rank, COALESCE(an.ipni_id, ''), COALESCE(an.published_in, ''), an.published_year, COALESCE(an.nom_status, ''), COALESCE(an.basionym_id, ''), COALESCE(an.rank_verbatim, ''),`+
+		conceptColumns+conceptJoin+`
+		WHERE tc.id IN (
+			SELECT concept_id FROM hybrid_parent
+			WHERE concept_id IN (SELECT concept_id FROM hybrid_parent WHERE canonical_fold = ?1)
+			GROUP BY concept_id
+			HAVING COUNT(*) = ?2 AND COUNT(DISTINCT canonical_fold) = ?2
+				AND SUM(canonical_fold IN (SELECT value FROM json_each(?3))) = ?2
+		)
+		ORDER BY tc.id`, folds[0], len(folds), foldsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying MatchHybridParents %q: %w", parents, err)
+	}
+	return scanMatchCandidateRows(rows, "MatchHybridParents", fmt.Sprint(parents))
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// seedSalixHybrid writes "Salix × rubens" with the parents "Salix alba" and
+// "Salix fragilis" into a WCVP backbone that carries Salix alba as an
+// accepted concept, Salix fragilis only as a synonym of Salix euxina, and a
+// second nothospecies "Salix × meyeriana" sharing Salix fragilis but crossed
+// with Salix pentandra, which the backbone does not carry at all.
+func seedSalixHybrid(t *testing.T) *DB {
+	t.Helper()
+	db := openTestDB(t)
+	bv := domain.BackboneVersion{ID: "wcvp", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "x"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		for _, c := range []struct{ id, canonical string }{
+			{"alba", "Salix alba"},
+			{"euxina", "Salix euxina"},
+			{"rubens", "Salix × rubens"},
+			{"meyeriana", "Salix × meyeriana"},
+		} {
+			n := species("n-"+c.id, c.canonical)
+			mustTx(t, tx.UpsertName(n))
+			mustTx(t, tx.UpsertConcept(domain.Concept{ID: "wcvp:concept:" + c.id, BackboneID: "wcvp", AcceptedName: n, Rank: domain.RankSpecies, Status: domain.StatusAccepted}))
+			mustTx(t, tx.LinkName("wcvp:concept:"+c.id, n.ID, "accepted", nil))
+		}
+		mustTx(t, tx.UpsertName(species("n-fragilis", "Salix fragilis")))
+		mustTx(t, tx.LinkName("wcvp:concept:euxina", "n-fragilis", "synonym", nil))
+
+		mustTx(t, tx.AddHybridParent("wcvp:concept:rubens", 0, "Salix alba"))
+		mustTx(t, tx.AddHybridParent("wcvp:concept:rubens", 1, "Salix fragilis"))
+		mustTx(t, tx.AddHybridParent("wcvp:concept:meyeriana", 0, "Salix fragilis"))
+		mustTx(t, tx.AddHybridParent("wcvp:concept:meyeriana", 1, "Salix pentandra"))
+	})
+	return db
+}
+
+// TestHybridParentsOf_ResolvesParentsInBackbone pins the formula order and
+// the resolution of each parent: accepted (alba) and synonym (fragilis) names
+// both resolve to their concept, a name the backbone lacks stays unresolved.
+func TestHybridParentsOf_ResolvesParentsInBackbone(t *testing.T) {
+	db := seedSalixHybrid(t)
+	got, err := db.hybridParentsOf(context.Background(), []string{"wcvp:concept:rubens", "wcvp:concept:meyeriana", "wcvp:concept:alba"})
+	mustTx(t, err)
+	want := map[string][]domain.HybridParent{
+		"wcvp:concept:rubens": {
+			{Canonical: "Salix alba", ConceptID: "wcvp:concept:alba"},
+			{Canonical: "Salix fragilis", ConceptID: "wcvp:concept:euxina"},
+		},
+		"wcvp:concept:meyeriana": {
+			{Canonical: "Salix fragilis", ConceptID: "wcvp:concept:euxina"},
+			{Canonical: "Salix pentandra"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("hybridParentsOf = %+v\nwant %+v", got, want)
+	}
+}
+
+// TestMatchHybridParents_ExactParentSet pins that the parents match as a set:
+// either order finds the hybrid, a subset or a superset does not.
+func TestMatchHybridParents_ExactParentSet(t *testing.T) {
+	db := seedSalixHybrid(t)
+	ctx := context.Background()
+	for _, tc := range []struct {
+		parents []string
+		want    []string
+	}{
+		{[]string{"Salix alba", "Salix fragilis"}, []string{"wcvp:concept:rubens"}},
+		{[]string{"salix  fragilis", "Salix alba"}, []string{"wcvp:concept:rubens"}},
+		{[]string{"Salix fragilis"}, nil},
+		{[]string{"Salix alba", "Salix fragilis", "Salix pentandra"}, nil},
+		{[]string{"Salix alba", "Salix pentandra"}, nil},
+	} {
+		cands, err := db.MatchHybridParents(ctx, tc.parents)
+		mustTx(t, err)
+		var got []string
+		for _, c := range cands {
+			if c.Role != "accepted" {
+				t.Errorf("MatchHybridParents(%q) role = %q, want accepted", tc.parents, c.Role)
+			}
+			got = append(got, c.Concept.ID)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("MatchHybridParents(%q) = %v, want %v", tc.parents, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// lineageInsert walks parent_id upward from the concepts the %s filter on
+// taxon_concept selects and writes every (ancestor, concept, depth) row,
+// bounded to maxClassificationDepth hops (the last parameter) so a cyclic
+// parent chain ends rather than recursing forever. Where a cycle reaches the
+// same ancestor at several depths the shallowest wins: SQLite runs a
+// recursive CTE without ORDER BY as a FIFO queue, so rows arrive depth by
+// depth and INSERT OR IGNORE keeps the first — with no sort over the whole
+// closure. A parent_id naming no concept ends the chain there (the join on
+// the ancestor drops it).
+const lineageInsert = `
+	WITH RECURSIVE up(concept_id, ancestor_id, depth) AS (
+	  SELECT id, id, 0 FROM taxon_concept%s
+	  UNION ALL
+	  SELECT up.concept_id, tc.parent_id, up.depth + 1
+	  FROM up JOIN taxon_concept tc ON tc.id = up.ancestor_id
+	  WHERE tc.parent_id IS NOT NULL AND tc.parent_id <> '' AND up.depth < ?
+	)
+	INSERT OR IGNORE INTO concept_lineage (ancestor_id, concept_id, depth)
+	SELECT up.ancestor_id, up.concept_id, up.depth FROM up
+	JOIN taxon_concept a ON a.id = up.ancestor_id`
+
+// BuildLineageClosure (re)builds concept_lineage from scratch: one row per
+// concept at depth 0, plus one per ancestor reachable through parent_id (see
+// lineageInsert). Every ingest's Finalize already writes its own backbone's
+// rows; this full rebuild additionally catches a chain that crosses into a
+// backbone ingested later. Like BuildDistributionClosure it is an
+// ingest-time build step, never run on the serve/Open path.
+func (db *DB) BuildLineageClosure(ctx context.Context) error {
+	tx, err := db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite: lineage begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM concept_lineage`); err != nil {
+		return fmt.Errorf("sqlite: lineage clear: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(lineageInsert, ""), maxClassificationDepth); err != nil {
+		return fmt.Errorf("sqlite: lineage build: %w", err)
+	}
+	return tx.Commit()
+}
+
+// refreshBackboneLineage rewrites the concept_lineage rows of backboneID's
+// concepts — the Finalize half of the closure, so Classification and the tree
+// reads see a backbone's hierarchy as soon as its ingest commits.
+func refreshBackboneLineage(ctx context.Context, tx sqlTx, backboneID string) error {
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM concept_lineage
+		WHERE concept_id IN (SELECT id FROM taxon_concept WHERE backbone_id = ?)`, backboneID); err != nil {
+		return fmt.Errorf("sqlite: clearing lineage of backbone %q: %w", backboneID, err)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(lineageInsert, " WHERE backbone_id = ?"), backboneID, maxClassificationDepth); err != nil {
+		return fmt.Errorf("sqlite: building lineage of backbone %q: %w", backboneID, err)
+	}
+	return nil
+}
+
+// higherTaxaQuery is HigherTaxa for a json_each id list (the first
+// parameter): the source's family label, else the nearest concept of rank
+// ?2 on the lineage, and the nearest of rank ?3 — depth 0, the concept
+// itself, included.
+const higherTaxaQuery = `
+	SELECT tc.id,
+	       COALESCE(NULLIF(tc.family, ''), (
+	         SELECT fn.canonical FROM concept_lineage fl
+	         JOIN taxon_concept ft ON ft.id = fl.ancestor_id
+	         JOIN name fn ON fn.id = ft.accepted_name
+	         WHERE fl.concept_id = tc.id AND ft.rank = ?2
+	         ORDER BY fl.depth LIMIT 1
+	       ), ''),
+	       COALESCE((
+	         SELECT gn.canonical FROM concept_lineage gl
+	         JOIN taxon_concept gt ON gt.id = gl.ancestor_id
+	         JOIN name gn ON gn.id = gt.accepted_name
+	         WHERE gl.concept_id = tc.id AND gt.rank = ?3
+	         ORDER BY gl.depth LIMIT 1
+	       ), '')
+	FROM taxon_concept tc
+	WHERE tc.id IN (SELECT value FROM json_each(?1))`
+
+// HigherTaxa reads conceptIDs' family and genus in one query. See the
+// output.Repository.HigherTaxa doc comment for the contract.
+func (db *DB) HigherTaxa(ctx context.Context, conceptIDs []string) (map[string]domain.HigherTaxa, error) {
+	out := map[string]domain.HigherTaxa{}
+	if len(conceptIDs) == 0 {
+		return out, nil
+	}
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, higherTaxaQuery, idsJSON, string(domain.RankFamily), string(domain.RankGenus))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying higher taxa of concepts %q: %w", conceptIDs, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id string
+		var h domain.HigherTaxa
+		if err := rows.Scan(&id, &h.Family, &h.Genus); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning higher taxa of concepts %q: %w", conceptIDs, err)
+		}
+		out[id] = h
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating higher taxa of concepts %q: %w", conceptIDs, err)
+	}
+	return out, nil
+}
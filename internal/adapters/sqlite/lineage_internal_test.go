@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// lineageRows returns conceptID's ancestors as "ancestor:depth", sorted by
+// depth, with the "wcvp:concept:" prefix stripped.
+func lineageRows(t *testing.T, db *DB, conceptID string) string {
+	t.Helper()
+	rows, err := db.sql.Query(`SELECT ancestor_id, depth FROM concept_lineage WHERE concept_id = ? ORDER BY depth`, conceptID)
+	mustTx(t, err)
+	defer func() { _ = rows.Close() }()
+	var out []string
+	for rows.Next() {
+		var id string
+		var depth int
+		mustTx(t, rows.Scan(&id, &depth))
+		out = append(out, strings.TrimPrefix(id, "wcvp:concept:")+":"+string(rune('0'+depth)))
+	}
+	mustTx(t, rows.Err())
+	return strings.Join(out, ",")
+}
+
+// TestBuildLineageClosure_ListsEveryAncestorWithDepth pins the closure's
+// shape: the concept itself at depth 0, then each parent in turn.
+func TestBuildLineageClosure_ListsEveryAncestorWithDepth(t *testing.T) {
+	db := openTestDB(t)
+	seedInfraspecificTree(t, db)
+	mustTx(t, db.BuildLineageClosure(context.Background()))
+
+	for id, want := range map[string]string{
+		"wcvp:concept:var":  "var:0,ssp:1,sp:2,g:3",
+		"wcvp:concept:sect": "sect:0,g:1",
+		"wcvp:concept:g":    "g:0",
+	} {
+		if got := lineageRows(t, db, id); got != want {
+			t.Errorf("%s: got %q, want %q", id, got, want)
+		}
+	}
+}
+
+// TestBuildLineageClosure_EndsOnCycle pins that a malformed parent cycle
+// terminates, each member holding the other at its shallowest depth.
+func TestBuildLineageClosure_EndsOnCycle(t *testing.T) {
+	db := openTestDB(t)
+	bv := domain.BackboneVersion{ID: "wcvp", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "x"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		// a is written parentless first: parent_id is a foreign key, so the
+		// cycle can only be closed by re-upserting a once b exists.
+		for _, c := range []struct{ id, parent string }{{"a", ""}, {"b", "a"}, {"a", "b"}} {
+			n := species("n-"+c.id, "Cyclus "+c.id)
+			mustTx(t, tx.UpsertName(n))
+			concept := domain.Concept{
+				ID: "wcvp:concept:" + c.id, BackboneID: "wcvp", AcceptedName: n,
+				Rank: domain.RankSpecies, Status: domain.StatusAccepted,
+			}
+			if c.parent != "" {
+				concept.ParentID = "wcvp:concept:" + c.parent
+			}
+			mustTx(t, tx.UpsertConcept(concept))
+		}
+	})
+	mustTx(t, db.BuildLineageClosure(context.Background()))
+
+	if got := lineageRows(t, db, "wcvp:concept:a"); got != "a:0,b:1" {
+		t.Errorf("a: got %q, want %q", got, "a:0,b:1")
+	}
+}
+
+// TestSuggest_WithinKeepsDescendantsBeyondPool pins that within narrows the
+// page to the concept's subtree AND recovers subtree matches the bm25 pool
+// dropped: with a one-row pool, every Abies alba name must still come back.
+func TestSuggest_WithinKeepsDescendantsBeyondPool(t *testing.T) {
+	db := openTestDB(t)
+	seedInfraspecificTree(t, db)
+	ctx := context.Background()
+	mustTx(t, db.BuildLineageClosure(ctx))
+
+	orig := suggestMatchPool
+	t.Cleanup(func() { suggestMatchPool = orig })
+	suggestMatchPool = 1
+
+	items, err := db.Suggest(ctx, "abies", output.SuggestOpts{Limit: 20, Within: "wcvp:concept:sp"})
+	mustTx(t, err)
+
+	var got []string
+	for _, it := range items {
+		got = append(got, strings.TrimPrefix(it.ConceptID, "wcvp:concept:"))
+	}
+	sort.Strings(got)
+	if want := "sp,ssp,ssp-syn,var"; strings.Join(got, ",") != want {
+		t.Errorf("Suggest(within=sp, pool=1) = %v, want %s", got, want)
+	}
+}
+
+// TestFinalize_RewritesTheBackbonesLineage pins that an ingest's Finalize
+// leaves its concepts' lineage readable without BuildLineageClosure, and that
+// a re-ingest moving a concept replaces its rows rather than adding to them.
+func TestFinalize_RewritesTheBackbonesLineage(t *testing.T) {
+	db := openTestDB(t)
+	seedInfraspecificTree(t, db)
+	if got, want := lineageRows(t, db, "wcvp:concept:var"), "var:0,ssp:1,sp:2,g:3"; got != want {
+		t.Errorf("after the first ingest: got %q, want %q", got, want)
+	}
+
+	bv := domain.BackboneVersion{ID: "wcvp", Version: "v2", IngestedAt: "2026-08-15T00:00:00Z", ManifestSHA: "y"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		n := domain.Name{ID: "n-var", Canonical: "Abies alba subsp. alba var. alba", Rank: domain.RankVariety}
+		mustTx(t, tx.UpsertConcept(domain.Concept{
+			ID: "wcvp:concept:var", BackboneID: "wcvp", AcceptedName: n, ParentID: "wcvp:concept:sp",
+			Rank: domain.RankVariety, Status: domain.StatusAccepted,
+		}))
+	})
+	if got, want := lineageRows(t, db, "wcvp:concept:var"), "var:0,sp:1,g:2"; got != want {
+		t.Errorf("after moving var under sp: got %q, want %q", got, want)
+	}
+}
+
+// TestHigherTaxa_LabelLineageAndSelf runs the three sources: a FAMILY and a
+// GENUS concept on the lineage, the genus counting for itself, and the
+// source's family label winning over the lineage.
+func TestHigherTaxa_LabelLineageAndSelf(t *testing.T) {
+	db := openTestDB(t)
+	seedInfraspecificTree(t, db)
+	bv := domain.BackboneVersion{ID: "cdm", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "y"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		for _, c := range []struct {
+			id, canonical, parent, family string
+			rank                          domain.Rank
+		}{
+			{"f", "Pinaceae", "", "", domain.RankFamily},
+			{"g", "Picea", "f", "", domain.RankGenus},
+			{"sp", "Picea abies", "g", "", domain.RankSpecies},
+			{"labelled", "Picea omorika", "g", "Pinaceae s.l.", domain.RankSpecies},
+		} {
+			n := domain.Name{ID: "n-cdm-" + c.id, Canonical: c.canonical, Rank: c.rank}
+			mustTx(t, tx.UpsertName(n))
+			concept := domain.Concept{ID: "cdm:concept:" + c.id, BackboneID: "cdm", AcceptedName: n, Rank: c.rank, Status: domain.StatusAccepted, Family: c.family}
+			if c.parent != "" {
+				concept.ParentID = "cdm:concept:" + c.parent
+			}
+			mustTx(t, tx.UpsertConcept(concept))
+		}
+	})
+
+	got, err := db.HigherTaxa(context.Background(), []string{"wcvp:concept:var", "cdm:concept:sp", "cdm:concept:g", "cdm:concept:labelled", "cdm:concept:nope"})
+	mustTx(t, err)
+	want := map[string]domain.HigherTaxa{
+		"wcvp:concept:var":     {Genus: "Abies"},
+		"cdm:concept:sp":       {Family: "Pinaceae", Genus: "Picea"},
+		"cdm:concept:g":        {Family: "Pinaceae", Genus: "Picea"},
+		"cdm:concept:labelled": {Family: "Pinaceae s.l.", Genus: "Picea"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HigherTaxa = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,208 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// maxBasionymDepth bounds NameRecord's walks along name.basionym_id, up the
+// chain and down the homotypic group, so a cyclic basionym_id chain can never
+// hang the request. Real chains are one hop — a recombination points at its
+// basionym, which has none — and 5 leaves room for the odd replacement name
+// on top.
+const maxBasionymDepth = 5
+
+// nameColumns is the per-name column list scanName reads.
+const nameColumns = `n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.ipni_id, ''), COALESCE(n.published_in, ''), n.published_year, COALESCE(n.nom_status, ''), COALESCE(n.basionym_id, ''), COALESCE(n.rank_verbatim, '')`
+
+// homotypicGroupQuery collects the names reaching the root (the first
+// parameter) through basionym_id, downward, within the depth bound (the
+// second). The depth bound is what ends a cycle, whose names recur at every
+// depth; GROUP BY folds each name back to one row, at its shallowest depth.
+const homotypicGroupQuery = `
+	WITH RECURSIVE grp(id, depth) AS (
+		SELECT ?, 0
+		UNION
+		SELECT n.id, grp.depth + 1 FROM name n JOIN grp ON n.basionym_id = grp.id
+		WHERE grp.depth < ?
+	)
+	SELECT ` + nameColumns + `, MIN(grp.depth)
+	FROM grp JOIN name n ON n.id = grp.id
+	GROUP BY n.id
+	ORDER BY MIN(grp.depth) > 0, n.id`
+
+// NameRecord resolves nameID with its basionym chain, homotypic group and
+// concept links. See output.Repository.NameRecord for the contract.
+func (db *DB) NameRecord(ctx context.Context, nameID string) (output.NameRecord, error) {
+	name, err := db.nameByID(ctx, nameID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return output.NameRecord{}, fmt.Errorf("sqlite: name %q: %w", nameID, domain.ErrNotFound)
+	}
+	if err != nil {
+		return output.NameRecord{}, fmt.Errorf("sqlite: reading name %q: %w", nameID, err)
+	}
+	rec := output.NameRecord{Name: *name, BasionymChain: []domain.Name{}}
+
+	// The chain is walked hop by hop like Classification's parent_id walk;
+	// seen stops it at the first name it has already visited, so a cycle
+	// back to nameID is not reported as nameID's own basionym.
+	seen := map[string]bool{nameID: true}
+	root := nameID
+	for next := name.BasionymID; next != "" && !seen[next] && len(rec.BasionymChain) < maxBasionymDepth; {
+		basionym, err := db.nameByID(ctx, next)
+		if errors.Is(err, sql.ErrNoRows) {
+			// A dangling basionym_id (shouldn't happen under FK
+			// enforcement): stop at the last name that exists.
+			break
+		}
+		if err != nil {
+			return output.NameRecord{}, fmt.Errorf("sqlite: walking basionym chain of name %q: %w", nameID, err)
+		}
+		rec.BasionymChain = append(rec.BasionymChain, *basionym)
+		seen[next] = true
+		root = next
+		next = basionym.BasionymID
+	}
+
+	if rec.HomotypicGroup, err = db.homotypicGroup(ctx, root); err != nil {
+		return output.NameRecord{}, fmt.Errorf("sqlite: homotypic group of name %q: %w", nameID, err)
+	}
+	if rec.Usages, err = db.nameUsages(ctx, []string{nameID}); err != nil {
+		return output.NameRecord{}, err
+	}
+	return rec, nil
+}
+
+// nameByID reads one name row; sql.ErrNoRows reports an unknown id.
+func (db *DB) nameByID(ctx context.Context, id string) (*domain.Name, error) {
+	return scanName(db.sql.QueryRowContext(ctx, `SELECT `+nameColumns+` FROM name n WHERE n.id = ?`, id).Scan)
+}
+
+// homotypicGroup runs homotypicGroupQuery from root.
+func (db *DB) homotypicGroup(ctx context.Context, root string) ([]domain.Name, error) {
+	rows, err := db.sql.QueryContext(ctx, homotypicGroupQuery, root, maxBasionymDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []domain.Name{}
+	for rows.Next() {
+		var depth int
+		n, err := scanName(func(dest ...any) error { return rows.Scan(append(dest, &depth)...) })
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *n)
+	}
+	return out, rows.Err()
+}
+
+// NameUsages resolves key to its names and reads their concept links. See
+// output.Repository.NameUsages for the contract.
+func (db *DB) NameUsages(ctx context.Context, key string) ([]domain.Name, []output.NameUsage, error) {
+	names, err := db.namesByKey(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("sqlite: name %q: %w", key, domain.ErrNotFound)
+	}
+	ids := make([]string, len(names))
+	for i, n := range names {
+		ids[i] = n.ID
+	}
+	usages, err := db.nameUsages(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return names, usages, nil
+}
+
+// namesByKey reads the name whose id is key or, failing that, every name
+// whose canonical folds to key's, by id.
+func (db *DB) namesByKey(ctx context.Context, key string) ([]domain.Name, error) {
+	name, err := db.nameByID(ctx, key)
+	if err == nil {
+		return []domain.Name{*name}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("sqlite: reading name %q: %w", key, err)
+	}
+	rows, err := db.sql.QueryContext(ctx, `SELECT `+nameColumns+` FROM name n WHERE n.canonical_fold = ? ORDER BY n.id`, domain.Canonicalize(key))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying names of canonical %q: %w", key, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []domain.Name
+	for rows.Next() {
+		n, err := scanName(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: scanning name of canonical %q: %w", key, err)
+		}
+		out = append(out, *n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating names of canonical %q: %w", key, err)
+	}
+	return out, nil
+}
+
+// nameUsages reads every concept_name link of nameIDs, with the linking
+// concept, its sec. reference and its accepted name — the join
+// SynonymCandidates makes, entered from the name's side. Built with
+// literal-format Sprintf so gosec sees untainted SQL.
+func (db *DB) nameUsages(ctx context.Context, nameIDs []string) ([]output.NameUsage, error) {
+	args := make([]any, len(nameIDs))
+	for i, id := range nameIDs {
+		args[i] = id
+	}
+	ph := strings.TrimSuffix(strings.Repeat("?,", len(nameIDs)), ",")
+	rows, err := db.sql.QueryContext(ctx, fmt.Sprintf(`
+		SELECT cn.name_id, tc.id, tc.backbone_id, COALESCE(tc.sec_reference, ''), COALESCE(sr.title, ''), tc.status, cn.role, cn.homotypic,
+		       an.id, an.canonical, COALESCE(an.authorship, ''), an.rank, COALESCE(an.ipni_id, ''), COALESCE(an.published_in, ''), an.published_year, COALESCE(an.nom_status, ''), COALESCE(an.basionym_id, ''), COALESCE(an.rank_verbatim, '')
+		FROM concept_name cn
+		JOIN taxon_concept tc ON tc.id = cn.concept_id
+		JOIN name an ON an.id = tc.accepted_name
+		LEFT JOIN sec_reference sr ON sr.id = tc.sec_reference
+		WHERE cn.name_id IN (%s)
+		ORDER BY tc.backbone_id, COALESCE(tc.sec_reference, ''), tc.id, cn.name_id`, ph), args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying concept links of names %q: %w", nameIDs, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []output.NameUsage{}
+	for rows.Next() {
+		var (
+			u         output.NameUsage
+			status    string
+			homotypic sql.NullBool
+		)
+		accepted, err := scanName(func(dest ...any) error {
+			return rows.Scan(append([]any{&u.NameID, &u.ConceptID, &u.BackboneID, &u.SecReference.ID, &u.SecReference.Title, &status, &u.Role, &homotypic}, dest...)...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: scanning concept link of names %q: %w", nameIDs, err)
+		}
+		u.Accepted = *accepted
+		u.ConceptStatus = domain.ParseStatus(status)
+		if homotypic.Valid {
+			// A fresh variable per row, as in SynonymCandidates.
+			value := homotypic.Bool
+			u.Homotypic = &value
+		}
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating concept links of names %q: %w", nameIDs, err)
+	}
+	return out, nil
+}
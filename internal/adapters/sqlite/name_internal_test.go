@@ -0,0 +1,156 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// seedNames writes one homotypic group — the basionym "Avena x", its
+// recombination "Festuca x" and that name's replacement "Festuca y" — with
+// "Festuca x" accepted in one concept and the basionym its synonym, plus an
+// unrelated name nobody uses.
+func seedNames(t *testing.T) *DB {
+	t.Helper()
+	db := openTestDB(t)
+	bv := domain.BackboneVersion{ID: "wcvp", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "x"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		for _, n := range []domain.Name{
+			{ID: "n-avena", Canonical: "Avena x", Authorship: "L.", Rank: domain.RankSpecies},
+			{ID: "n-festuca", Canonical: "Festuca x", Authorship: "(L.) Scop.", Rank: domain.RankSpecies, BasionymID: "n-avena"},
+			{ID: "n-repl", Canonical: "Festuca y", Rank: domain.RankSpecies, BasionymID: "n-festuca", NomStatus: "nom. nov."},
+			{ID: "n-other", Canonical: "Poa z", Rank: domain.RankSpecies},
+		} {
+			mustTx(t, tx.UpsertName(n))
+		}
+		accepted := domain.Name{ID: "n-festuca"}
+		mustTx(t, tx.UpsertConcept(domain.Concept{ID: "wcvp:concept:festuca", BackboneID: "wcvp", AcceptedName: accepted, Rank: domain.RankSpecies, Status: domain.StatusAccepted}))
+		mustTx(t, tx.LinkName("wcvp:concept:festuca", "n-festuca", "accepted", nil))
+		homotypic := true
+		mustTx(t, tx.LinkName("wcvp:concept:festuca", "n-avena", "synonym", &homotypic))
+	})
+	return db
+}
+
+func nameIDs(names []domain.Name) string {
+	ids := make([]string, len(names))
+	for i, n := range names {
+		ids[i] = n.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+// TestNameRecord_ChainGroupAndUsages pins the three walks from every member
+// of the group: each reaches the same root and so the same group, root
+// first; the chain lists the basionyms nearest first.
+func TestNameRecord_ChainGroupAndUsages(t *testing.T) {
+	db := seedNames(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ id, chain, group string }{
+		{"n-avena", "", "n-avena,n-festuca,n-repl"},
+		{"n-festuca", "n-avena", "n-avena,n-festuca,n-repl"},
+		{"n-repl", "n-festuca,n-avena", "n-avena,n-festuca,n-repl"},
+		{"n-other", "", "n-other"},
+	} {
+		rec, err := db.NameRecord(ctx, tc.id)
+		mustTx(t, err)
+		if got := nameIDs(rec.BasionymChain); got != tc.chain {
+			t.Errorf("NameRecord(%s).BasionymChain = %q, want %q", tc.id, got, tc.chain)
+		}
+		if got := nameIDs(rec.HomotypicGroup); got != tc.group {
+			t.Errorf("NameRecord(%s).HomotypicGroup = %q, want %q", tc.id, got, tc.group)
+		}
+	}
+
+	rec, err := db.NameRecord(ctx, "n-avena")
+	mustTx(t, err)
+	if len(rec.Usages) != 1 {
+		t.Fatalf("NameRecord(n-avena).Usages = %+v, want one synonym usage", rec.Usages)
+	}
+	u := rec.Usages[0]
+	if u.ConceptID != "wcvp:concept:festuca" || u.Role != "synonym" || u.Homotypic == nil || !*u.Homotypic || u.Accepted.Canonical != "Festuca x" || u.ConceptStatus != domain.StatusAccepted {
+		t.Errorf("usage = %+v, want a homotypic synonym of the accepted Festuca x concept", u)
+	}
+	if rec, err := db.NameRecord(ctx, "n-other"); err != nil || len(rec.Usages) != 0 || rec.Name.Canonical != "Poa z" {
+		t.Errorf("NameRecord(n-other) = %+v, %v; want the name with no usages", rec, err)
+	}
+}
+
+// TestNameRecord_CycleEnds pins that a basionym_id cycle ends both walks:
+// the chain stops before revisiting the name, the group lists each member
+// once.
+func TestNameRecord_CycleEnds(t *testing.T) {
+	db := seedNames(t)
+	ctx := context.Background()
+	if _, err := db.sql.Exec(`UPDATE name SET basionym_id = 'n-repl' WHERE id = 'n-avena'`); err != nil {
+		t.Fatalf("closing the cycle: %v", err)
+	}
+
+	rec, err := db.NameRecord(ctx, "n-festuca")
+	mustTx(t, err)
+	if got := nameIDs(rec.BasionymChain); got != "n-avena,n-repl" {
+		t.Errorf("BasionymChain = %q, want n-avena,n-repl", got)
+	}
+	if got := nameIDs(rec.HomotypicGroup); got != "n-repl,n-avena,n-festuca" {
+		t.Errorf("HomotypicGroup = %q, want the root n-repl first, then the rest once each", got)
+	}
+}
+
+func TestNameRecord_UnknownNameIsNotFound(t *testing.T) {
+	db := seedNames(t)
+	if _, err := db.NameRecord(context.Background(), "n-nope"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("NameRecord(unknown) error = %v, want domain.ErrNotFound", err)
+	}
+}
+
+// TestNameUsages_ResolvesIDOrCanonical adds a sec-bearing backbone that
+// accepts a homonym "Festuca x" and lists seedNames' "Festuca x" as its
+// synonym, then looks the name up both ways: by id only that name, by
+// canonical both homonyms, their usages ordered by backbone, sec. reference
+// and concept.
+func TestNameUsages_ResolvesIDOrCanonical(t *testing.T) {
+	db := seedNames(t)
+	ctx := context.Background()
+	bv := domain.BackboneVersion{ID: "cdm", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "y"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		mustTx(t, tx.UpsertSecReference(domain.SecReference{ID: "sec-x", Title: "Fl. X"}))
+		homonym := domain.Name{ID: "n-homonym", Canonical: "Festuca x", Authorship: "Hack.", Rank: domain.RankSpecies}
+		mustTx(t, tx.UpsertName(homonym))
+		mustTx(t, tx.UpsertConcept(domain.Concept{ID: "cdm:concept:x", BackboneID: "cdm", AcceptedName: homonym, Rank: domain.RankSpecies, SecReference: "sec-x", Status: domain.StatusAccepted}))
+		mustTx(t, tx.LinkName("cdm:concept:x", "n-homonym", "accepted", nil))
+		mustTx(t, tx.LinkName("cdm:concept:x", "n-festuca", "synonym", nil))
+	})
+
+	usageLines := func(usages []output.NameUsage) string {
+		lines := make([]string, len(usages))
+		for i, u := range usages {
+			lines[i] = strings.Join([]string{u.BackboneID, u.SecReference.ID, u.SecReference.Title, u.ConceptID, u.NameID, u.Role}, "/")
+		}
+		return strings.Join(lines, " ")
+	}
+
+	names, usages, err := db.NameUsages(ctx, "n-festuca")
+	mustTx(t, err)
+	if got, want := nameIDs(names)+" | "+usageLines(usages), "n-festuca | cdm/sec-x/Fl. X/cdm:concept:x/n-festuca/synonym wcvp///wcvp:concept:festuca/n-festuca/accepted"; got != want {
+		t.Errorf("NameUsages(id) = %q\nwant %q", got, want)
+	}
+
+	names, usages, err = db.NameUsages(ctx, "festuca  X")
+	mustTx(t, err)
+	want := "n-festuca,n-homonym | cdm/sec-x/Fl. X/cdm:concept:x/n-festuca/synonym cdm/sec-x/Fl. X/cdm:concept:x/n-homonym/accepted wcvp///wcvp:concept:festuca/n-festuca/accepted"
+	if got := nameIDs(names) + " | " + usageLines(usages); got != want {
+		t.Errorf("NameUsages(canonical) = %q\nwant %q", got, want)
+	}
+
+	if names, usages, err := db.NameUsages(ctx, "Poa z"); err != nil || nameIDs(names) != "n-other" || len(usages) != 0 {
+		t.Errorf("NameUsages(unused) = %v, %v, %v; want the name and no usages", names, usages, err)
+	}
+	if _, _, err := db.NameUsages(ctx, "Poa nope"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("NameUsages(unknown) error = %v, want domain.ErrNotFound", err)
+	}
+}
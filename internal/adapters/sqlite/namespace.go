@@ -47,7 +47,7 @@ func (t *ingestTx) AddNameSpaceEntry(conceptID string, e domain.NameSpaceEntry)
 	// aliases here.
 	if e.Aggregate && conceptID != "" {
 		res, err := t.tx.ExecContext(t.ctx,
-			`INSERT INTO fts_name_map (concept_id, is_aggregate) VALUES (?, 1)`, conceptID)
+			`INSERT INTO fts_name_map (concept_id, is_aggregate, kind, label) VALUES (?, 1, ?, ?)`, conceptID, string(domain.MatchAlias), e.Name)
 		if err != nil {
 			return fmt.Errorf("sqlite: indexing aggregate alias %s:%s: %w", e.Space, e.ExtID, err)
 		}
@@ -86,53 +86,76 @@ func (db *DB) NameSpaceEntries(ctx context.Context, conceptID string, spaces []s
 		return nil, fmt.Errorf("sqlite: concept %q: %w", conceptID, domain.ErrNotFound)
 	}
 
-	query, args := nameSpaceEntriesQuery(conceptID, spaces)
+	byConcept, err := db.nameSpaceEntriesOf(ctx, []string{conceptID}, spaces)
+	if err != nil {
+		return nil, err
+	}
+	if entries := byConcept[conceptID]; entries != nil {
+		return entries, nil
+	}
+	return []domain.NameSpaceEntry{}, nil
+}
+
+// nameSpaceEntriesOf is NameSpaceEntries for every concept in conceptIDs,
+// keyed by concept id, without the existence check: a concept with no entry
+// is absent from the map.
+func (db *DB) nameSpaceEntriesOf(ctx context.Context, conceptIDs []string, spaces []string) (map[string][]domain.NameSpaceEntry, error) {
+	query, args, err := nameSpaceEntriesQuery(conceptIDs, spaces)
+	if err != nil {
+		return nil, err
+	}
 	rows, err := db.sql.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("sqlite: querying name space entries for concept %q: %w", conceptID, err)
+		return nil, fmt.Errorf("sqlite: querying name space entries for concepts %q: %w", conceptIDs, err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	out := []domain.NameSpaceEntry{}
+	out := map[string][]domain.NameSpaceEntry{}
 	for rows.Next() {
 		var (
+			conceptID  string
 			e          domain.NameSpaceEntry
 			aggregate  int
 			resolution sql.NullString
 		)
-		if err := rows.Scan(&e.Space, &e.ExtID, &e.Name, &aggregate, &resolution, &e.Status); err != nil {
-			return nil, fmt.Errorf("sqlite: scanning name space entry for concept %q: %w", conceptID, err)
+		if err := rows.Scan(&conceptID, &e.Space, &e.ExtID, &e.Name, &aggregate, &resolution, &e.Status); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning name space entry for concepts %q: %w", conceptIDs, err)
 		}
 		e.Aggregate = aggregate != 0
 		// A NULL resolution is the ordinary exact match and maps back to the
 		// empty string, exactly as AddNameSpaceEntry wrote it.
 		e.Resolution = resolution.String
-		out = append(out, e)
+		out[conceptID] = append(out[conceptID], e)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sqlite: iterating name space entries for concept %q: %w", conceptID, err)
+		return nil, fmt.Errorf("sqlite: iterating name space entries for concepts %q: %w", conceptIDs, err)
 	}
 	return out, nil
 }
 
-// nameSpaceEntriesQuery builds the query+args NameSpaceEntries runs. The
-// space filter uses a bounded placeholder list rather than json_each: unlike
-// bundle.go's concept-id lists it is caller-bounded (a handful of ingested
-// spaces at most), the same trade-off traitsQuery makes for its vocab list.
-func nameSpaceEntriesQuery(conceptID string, spaces []string) (string, []any) {
+// nameSpaceEntriesQuery builds the query+args nameSpaceEntriesOf runs. The
+// concept ids are one json_each parameter, as in bundle.go; the space filter
+// uses a bounded placeholder list instead: it is caller-bounded (a handful
+// of ingested spaces at most), the same trade-off traitsQuery makes for its
+// vocab list.
+func nameSpaceEntriesQuery(conceptIDs []string, spaces []string) (string, []any, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return "", nil, err
+	}
 	query := `
-		SELECT space, ext_id, name, aggregate, resolution, status
+		SELECT concept_id, space, ext_id, name, aggregate, resolution, status
 		FROM name_space_entry
-		WHERE concept_id = ?`
-	args := []any{conceptID}
+		WHERE concept_id IN (SELECT value FROM json_each(?))`
+	args := []any{idsJSON}
 	if len(spaces) > 0 {
 		query += ` AND space IN (` + placeholdersFor(len(spaces)) + `)`
 		for _, s := range spaces {
 			args = append(args, s)
 		}
 	}
-	query += ` ORDER BY space, ext_id`
-	return query, args
+	query += ` ORDER BY concept_id, space, ext_id`
+	return query, args, nil
 }
 
 // NameSpaces lists every ingested name-space provenance row, ordered by id.
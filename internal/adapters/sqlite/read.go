@@ -17,7 +17,7 @@ import (
 // and MatchExact so the three reads decode identically.
 const conceptColumns = `
 	tc.id, tc.backbone_id, bv.version, tc.rank, COALESCE(tc.parent_id, ''), COALESCE(tc.sec_reference, ''), tc.status, COALESCE(tc.rank_verbatim, ''),
-	an.id, an.canonical, COALESCE(an.authorship, ''), an.rank, COALESCE(an.ipni_id, ''), COALESCE(an.published_in, ''), COALESCE(an.nom_status, ''), COALESCE(an.basionym_id, ''), COALESCE(an.rank_verbatim, '')`
+	an.id, an.canonical, COALESCE(an.authorship, ''), an.rank, COALESCE(an.ipni_id, ''), COALESCE(an.published_in, ''), an.published_year, COALESCE(an.nom_status, ''), COALESCE(an.basionym_id, ''), COALESCE(an.rank_verbatim, '')`
 
 const conceptJoin = `
 	FROM taxon_concept tc
@@ -35,7 +35,7 @@ func scanConcept(scan func(dest ...any) error) (*domain.Concept, error) {
 	)
 	if err := scan(
 		&c.ID, &c.BackboneID, &c.BackboneVersion, &conceptRank, &parentID, &secReference, &status, &conceptRankVerbatim,
-		&an.ID, &an.Canonical, &an.Authorship, &nameRank, &an.IPNIID, &an.PublishedIn, &an.NomStatus, &an.BasionymID, &nameRankVerbatim,
+		&an.ID, &an.Canonical, &an.Authorship, &nameRank, &an.IPNIID, &an.PublishedIn, &an.PublishedYear, &an.NomStatus, &an.BasionymID, &nameRankVerbatim,
 	); err != nil {
 		return nil, err
 	}
@@ -93,51 +93,68 @@ func (db *DB) Concept(ctx context.Context, id string) (*domain.Concept, []output
 }
 
 func (db *DB) conceptSynonyms(ctx context.Context, conceptID string) ([]output.SynonymName, error) {
+	byConcept, err := db.synonymsOf(ctx, []string{conceptID})
+	return byConcept[conceptID], err
+}
+
+// synonymsOf reads the synonym names of every concept in conceptIDs, keyed
+// by concept id and ordered by name id. A concept without synonyms is
+// absent from the map.
+func (db *DB) synonymsOf(ctx context.Context, conceptIDs []string) (map[string][]output.SynonymName, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return nil, err
+	}
 	rows, err := db.sql.QueryContext(ctx, `
-		SELECT n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.ipni_id, ''), COALESCE(n.published_in, ''), COALESCE(n.nom_status, ''), COALESCE(n.basionym_id, ''), COALESCE(n.rank_verbatim, ''), cn.homotypic
+		SELECT cn.concept_id, n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.ipni_id, ''), COALESCE(n.published_in, ''), n.published_year, COALESCE(n.nom_status, ''), COALESCE(n.basionym_id, ''), COALESCE(n.rank_verbatim, ''), cn.homotypic
 		FROM concept_name cn
 		JOIN name n ON n.id = cn.name_id
-		WHERE cn.concept_id = ? AND cn.role = 'synonym'
-		ORDER BY n.id`, conceptID)
+		WHERE cn.concept_id IN (SELECT value FROM json_each(?)) AND cn.role = 'synonym'
+		ORDER BY cn.concept_id, n.id`, idsJSON)
 	if err != nil {
-		return nil, fmt.Errorf("sqlite: querying synonyms of concept %q: %w", conceptID, err)
+		return nil, fmt.Errorf("sqlite: querying synonyms of concepts %q: %w", conceptIDs, err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	var out []output.SynonymName
+	out := map[string][]output.SynonymName{}
 	for rows.Next() {
-		var homotypic sql.NullBool
+		var (
+			conceptID string
+			homotypic sql.NullBool
+		)
 		n, err := scanName(func(dest ...any) error {
-			return rows.Scan(append(dest, &homotypic)...)
+			return rows.Scan(append(append([]any{&conceptID}, dest...), &homotypic)...)
 		})
 		if err != nil {
-			return nil, fmt.Errorf("sqlite: scanning synonym of concept %q: %w", conceptID, err)
+			return nil, fmt.Errorf("sqlite: scanning synonym of concepts %q: %w", conceptIDs, err)
 		}
 		sn := output.SynonymName{Name: *n}
 		if homotypic.Valid {
 			sn.Homotypic = &homotypic.Bool
 		}
-		out = append(out, sn)
+		out[conceptID] = append(out[conceptID], sn)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sqlite: iterating synonyms of concept %q: %w", conceptID, err)
+		return nil, fmt.Errorf("sqlite: iterating synonyms of concepts %q: %w", conceptIDs, err)
 	}
 	return out, nil
 }
 
-// maxClassificationDepth bounds Classification's upward parent_id walk, so
-// a cyclic or otherwise corrupt parent_id chain can never hang the request
-// — 10 hops comfortably exceeds any real taxonomic rank depth this system
-// models (FAMILY > ... > FORM is far shallower).
+// maxClassificationDepth bounds the parent_id walk that builds
+// concept_lineage (lineageInsert), so a cyclic or otherwise corrupt
+// parent_id chain can never run away — 10 hops comfortably exceeds any real
+// taxonomic rank depth this system models (FAMILY > ... > FORM is far
+// shallower). application.Ingest reports such chains per backbone
+// (BackboneReport.ParentChains); this bound is what keeps them harmless.
 const maxClassificationDepth = 10
 
-// Classification walks conceptID's taxon_concept.parent_id chain upward,
-// bounded to maxClassificationDepth hops, and returns the ancestor chain
-// ROOT-FIRST: index 0 is the topmost ancestor reached, and the last element
-// is conceptID's immediate parent. conceptID itself is never included. A
-// NULL parent_id (no further ancestor) or hitting the depth bound (a
-// cyclic/corrupt chain) both stop the walk without error — a partial or
-// empty chain is a normal, valid result.
+// Classification returns conceptID's ancestor chain ROOT-FIRST — index 0 is
+// the topmost ancestor reached, the last element conceptID's immediate
+// parent, conceptID itself never included — in one query over
+// concept_lineage (classificationsQuery). A concept with no parent, or a
+// database whose lineage was never built, yields an empty chain; a cyclic
+// chain lists each ancestor once, since the lineage keeps one row per
+// (ancestor, concept) pair.
 func (db *DB) Classification(ctx context.Context, conceptID string) ([]domain.ClassificationEntry, error) {
 	exists, err := db.conceptExists(ctx, conceptID)
 	if err != nil {
@@ -146,51 +163,11 @@ func (db *DB) Classification(ctx context.Context, conceptID string) ([]domain.Cl
 	if !exists {
 		return nil, fmt.Errorf("sqlite: concept %q: %w", conceptID, domain.ErrNotFound)
 	}
-
-	var chain []domain.ClassificationEntry
-	current := conceptID
-	for i := 0; i < maxClassificationDepth; i++ {
-		var parentID sql.NullString
-		if err := db.sql.QueryRowContext(ctx, `SELECT parent_id FROM taxon_concept WHERE id = ?`, current).Scan(&parentID); err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				break
-			}
-			return nil, fmt.Errorf("sqlite: walking classification for concept %q: %w", conceptID, err)
-		}
-		if !parentID.Valid || parentID.String == "" {
-			break
-		}
-
-		var canonical, rank string
-		err := db.sql.QueryRowContext(ctx, `
-			SELECT an.canonical, tc.rank
-			FROM taxon_concept tc JOIN name an ON an.id = tc.accepted_name
-			WHERE tc.id = ?`, parentID.String).Scan(&canonical, &rank)
-		if errors.Is(err, sql.ErrNoRows) {
-			// parent_id names a concept id no longer present (shouldn't
-			// happen under FK enforcement, but stop defensively rather
-			// than erroring the whole request).
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("sqlite: reading classification ancestor %q: %w", parentID.String, err)
-		}
-		r, err := domain.ParseRank(rank)
-		if err != nil {
-			return nil, fmt.Errorf("sqlite: classification ancestor %q: %w", parentID.String, err)
-		}
-		chain = append(chain, domain.ClassificationEntry{ConceptID: parentID.String, Canonical: canonical, Rank: r})
-		current = parentID.String
-	}
-
-	// i < j is a genuinely equivalent mutant at CONDITIONALS_BOUNDARY (<=):
-	// for an odd-length chain, i <= j would run one further iteration where
-	// i == j, swapping chain[i] with itself — a no-op, producing the exact
-	// same final order either way. No test can observe the difference.
-	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
-		chain[i], chain[j] = chain[j], chain[i]
+	chains, err := db.classificationsOf(ctx, []string{conceptID})
+	if err != nil {
+		return nil, err
 	}
-	return chain, nil
+	return chains[conceptID], nil
 }
 
 // scanName reads a name row shaped like conceptSynonyms'/MatchExact's
@@ -198,7 +175,7 @@ func (db *DB) Classification(ctx context.Context, conceptID string) ([]domain.Cl
 func scanName(scan func(dest ...any) error) (*domain.Name, error) {
 	var n domain.Name
 	var rank, rankVerbatim string
-	if err := scan(&n.ID, &n.Canonical, &n.Authorship, &rank, &n.IPNIID, &n.PublishedIn, &n.NomStatus, &n.BasionymID, &rankVerbatim); err != nil {
+	if err := scan(&n.ID, &n.Canonical, &n.Authorship, &rank, &n.IPNIID, &n.PublishedIn, &n.PublishedYear, &n.NomStatus, &n.BasionymID, &rankVerbatim); err != nil {
 		return nil, err
 	}
 	r, err := domain.ParseRank(rank)
@@ -212,53 +189,88 @@ func scanName(scan func(dest ...any) error) (*domain.Name, error) {
 	return &n, nil
 }
 
-// conceptStringPairs runs a two-column (concept_id-scoped) query and
-// collects each row's two string columns via collect. It backs both
-// conceptXrefs and conceptDistribution, which otherwise differ only in
-// their SQL text and the two-field struct they build.
-func conceptStringPairs(ctx context.Context, db *DB, query, what, conceptID string, collect func(a, b string)) error {
-	rows, err := db.sql.QueryContext(ctx, query, conceptID)
+func (db *DB) conceptXrefs(ctx context.Context, conceptID string) ([]domain.Xref, error) {
+	byConcept, err := db.xrefsOf(ctx, []string{conceptID})
+	return byConcept[conceptID], err
+}
+
+// xrefsOf reads the cross-references of every concept in conceptIDs, keyed
+// by concept id and ordered by (authority, ext_id).
+func (db *DB) xrefsOf(ctx context.Context, conceptIDs []string) (map[string][]domain.Xref, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT concept_id, authority, ext_id FROM xref
+		WHERE concept_id IN (SELECT value FROM json_each(?))
+		ORDER BY concept_id, authority, ext_id`, idsJSON)
 	if err != nil {
-		return fmt.Errorf("sqlite: querying %s of concept %q: %w", what, conceptID, err)
+		return nil, fmt.Errorf("sqlite: querying xrefs of concepts %q: %w", conceptIDs, err)
 	}
 	defer func() { _ = rows.Close() }()
 
+	out := map[string][]domain.Xref{}
 	for rows.Next() {
-		var a, b string
-		if err := rows.Scan(&a, &b); err != nil {
-			return fmt.Errorf("sqlite: scanning %s of concept %q: %w", what, conceptID, err)
+		var (
+			conceptID string
+			x         domain.Xref
+		)
+		if err := rows.Scan(&conceptID, &x.Authority, &x.ExtID); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning xref of concepts %q: %w", conceptIDs, err)
 		}
-		collect(a, b)
+		out[conceptID] = append(out[conceptID], x)
 	}
 	if err := rows.Err(); err != nil {
-		return fmt.Errorf("sqlite: iterating %s of concept %q: %w", what, conceptID, err)
-	}
-	return nil
-}
-
-func (db *DB) conceptXrefs(ctx context.Context, conceptID string) ([]domain.Xref, error) {
-	var out []domain.Xref
-	err := conceptStringPairs(ctx, db, `
-		SELECT authority, ext_id FROM xref WHERE concept_id = ? ORDER BY authority, ext_id`,
-		"xrefs", conceptID, func(authority, extID string) {
-			out = append(out, domain.Xref{Authority: authority, ExtID: extID})
-		})
-	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("sqlite: iterating xrefs of concepts %q: %w", conceptIDs, err)
 	}
 	return out, nil
 }
 
+// conceptDistribution returns the concept's own distribution rows plus the
+// areas BuildDistributionClosure rolled up from its infraspecific taxa,
+// flagged Derived. An own row for the same area wins (the closure never
+// derives over it). The name-fallback rows are not listed: they are the WCVP
+// twin's assertion, not this concept's.
 func (db *DB) conceptDistribution(ctx context.Context, conceptID string) ([]domain.Distribution, error) {
-	var out []domain.Distribution
-	err := conceptStringPairs(ctx, db, `
-		SELECT area_scheme, area_code FROM distribution WHERE concept_id = ? ORDER BY area_scheme, area_code`,
-		"distribution", conceptID, func(areaScheme, areaCode string) {
-			out = append(out, domain.Distribution{AreaScheme: areaScheme, AreaCode: areaCode})
-		})
+	byConcept, err := db.distributionsOf(ctx, []string{conceptID})
+	return byConcept[conceptID], err
+}
+
+// distributionsOf is conceptDistribution for every concept in conceptIDs,
+// keyed by concept id, each ordered by (area_scheme, area_code).
+func (db *DB) distributionsOf(ctx context.Context, conceptIDs []string) (map[string][]domain.Distribution, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
 	if err != nil {
 		return nil, err
 	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT concept_id, area_scheme, area_code, status, 0 FROM distribution
+		WHERE concept_id IN (SELECT value FROM json_each(?))
+		UNION ALL
+		SELECT concept_id, area_scheme, area_code, '', 1 FROM distribution_effective
+		WHERE concept_id IN (SELECT value FROM json_each(?)) AND origin = ?
+		ORDER BY 1, 2, 3`,
+		idsJSON, idsJSON, originInfraspecific)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying distribution of concepts %q: %w", conceptIDs, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := map[string][]domain.Distribution{}
+	for rows.Next() {
+		var (
+			conceptID string
+			d         domain.Distribution
+		)
+		if err := rows.Scan(&conceptID, &d.AreaScheme, &d.AreaCode, &d.Status, &d.Derived); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning distribution of concepts %q: %w", conceptIDs, err)
+		}
+		out[conceptID] = append(out[conceptID], d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating distribution of concepts %q: %w", conceptIDs, err)
+	}
 	return out, nil
 }
 
@@ -335,7 +347,7 @@ func (db *DB) MatchExact(ctx context.Context, canon string) ([]output.MatchCandi
 
 	rows, err := db.sql.QueryContext(ctx, `
 		SELECT cn.role, cn.homotypic,
-			n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.ipni_id, ''), COALESCE(n.published_in, ''), COALESCE(n.nom_status, ''), COALESCE(n.basionym_id, ''), COALESCE(n.rank_verbatim, ''),`+
+			n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.ipni_id, ''), COALESCE(n.published_in, ''), n.published_year, COALESCE(n.nom_status, ''), COALESCE(n.basionym_id, ''), COALESCE(n.rank_verbatim, ''),`+
 		conceptColumns+`
 		FROM name n
 		JOIN concept_name cn ON cn.name_id = n.id
@@ -434,7 +446,7 @@ func (db *DB) MatchFuzzyCandidates(ctx context.Context, canon string, limit int,
 
 	rows, err := db.sql.QueryContext(ctx, `
 		SELECT cn.role, cn.homotypic,
-			n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.ipni_id, ''), COALESCE(n.published_in, ''), COALESCE(n.nom_status, ''), COALESCE(n.basionym_id, ''), COALESCE(n.rank_verbatim, ''),`+
+			n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.ipni_id, ''), COALESCE(n.published_in, ''), n.published_year, COALESCE(n.nom_status, ''), COALESCE(n.basionym_id, ''), COALESCE(n.rank_verbatim, ''),`+
 		conceptColumns+`
 		FROM name n
 		JOIN concept_name cn ON cn.name_id = n.id
@@ -558,9 +570,9 @@ func scanMatchCandidateRows(rows *sql.Rows, op, arg string) ([]output.MatchCandi
 		var nameRank, nameRankVerbatim string
 		if err := rows.Scan(
 			&role, &homotypic,
-			&matched.ID, &matched.Canonical, &matched.Authorship, &matchedRank, &matched.IPNIID, &matched.PublishedIn, &matched.NomStatus, &matched.BasionymID, &matchedRankVerbatim,
+			&matched.ID, &matched.Canonical, &matched.Authorship, &matchedRank, &matched.IPNIID, &matched.PublishedIn, &matched.PublishedYear, &matched.NomStatus, &matched.BasionymID, &matchedRankVerbatim,
 			&c.ID, &c.BackboneID, &c.BackboneVersion, &conceptRank, &parentID, &secReference, &status, &conceptRankVerbatim,
-			&an.ID, &an.Canonical, &an.Authorship, &nameRank, &an.IPNIID, &an.PublishedIn, &an.NomStatus, &an.BasionymID, &nameRankVerbatim,
+			&an.ID, &an.Canonical, &an.Authorship, &nameRank, &an.IPNIID, &an.PublishedIn, &an.PublishedYear, &an.NomStatus, &an.BasionymID, &nameRankVerbatim,
 		); err != nil {
 			return nil, fmt.Errorf("sqlite: scanning %s %q row: %w", op, arg, err)
 		}
@@ -749,3 +761,51 @@ func (db *DB) ConceptRelationsInSec(ctx context.Context, conceptID, targetSec st
 	}
 	return out, nil
 }
+
+// UsageTargets returns the misapplication and pro-parte edges leaving
+// fromIDs, each with its target's accepted canonical. The two kinds live in
+// different tables (see schema.sql's note on misapplication) and are read in
+// one UNION so the caller sees a single, consistently ordered list.
+func (db *DB) UsageTargets(ctx context.Context, fromIDs []string) ([]output.UsageTarget, error) {
+	if len(fromIDs) == 0 {
+		return nil, nil
+	}
+	idsJSON, err := json.Marshal(fromIDs)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: encoding concept id list: %w", err)
+	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT u.from_concept, u.to_concept, u.relation, an.canonical
+		FROM (
+			SELECT from_concept, to_concept, 'misapplied' AS relation FROM misapplication
+			UNION
+			SELECT from_concept, to_concept, relation FROM concept_relation WHERE relation = 'pro_parte'
+		) u
+		JOIN taxon_concept tc ON tc.id = u.to_concept
+		JOIN name an ON an.id = tc.accepted_name
+		WHERE u.from_concept IN (SELECT value FROM json_each(?))
+		ORDER BY u.from_concept, u.relation, u.to_concept`, string(idsJSON))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying usage targets: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []output.UsageTarget
+	for rows.Next() {
+		var (
+			t        output.UsageTarget
+			relation string
+		)
+		if err := rows.Scan(&t.FromID, &t.ToID, &relation, &t.ToName); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning usage target row: %w", err)
+		}
+		if t.Relation, err = domain.ParseRelation(relation); err != nil {
+			return nil, fmt.Errorf("sqlite: usage target of concept %q: %w", t.FromID, err)
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating usage target rows: %w", err)
+	}
+	return out, nil
+}
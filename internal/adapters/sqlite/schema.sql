@@ -35,6 +35,9 @@ CREATE TABLE IF NOT EXISTS name (
   rank           TEXT NOT NULL,      -- FAMILY|GENUS|...|SUBFORM|NOTHOSUBSPECIES|NOTHOVARIETY|NOTHOFORM|OTHER (domain.Rank)
   ipni_id        TEXT,
   published_in   TEXT,
+  -- The year domain.ParsePublication reads from published_in, 0 when it
+  -- names none. Read by the published-before filters of suggest and match.
+  published_year INTEGER NOT NULL DEFAULT 0,
   nom_status     TEXT,               -- NULL|nom_nud|nom_superfl|pro_syn|...
   basionym_id    TEXT REFERENCES name(id),
   -- rank_verbatim carries the original source "taxonrank" spelling
@@ -48,6 +51,8 @@ CREATE TABLE IF NOT EXISTS name (
 
 CREATE INDEX IF NOT EXISTS idx_name_canonical_fold ON name(canonical_fold);
 CREATE INDEX IF NOT EXISTS idx_name_basionym_id ON name(basionym_id);
+-- TreatmentLinks' xref link joins accepted names on their IPNI id.
+CREATE INDEX IF NOT EXISTS idx_name_ipni_id ON name(ipni_id);
 
 -- Hardening Task 2 (2026-08-01): FK child-column indexes.
 --
@@ -100,7 +105,11 @@ CREATE TABLE IF NOT EXISTS taxon_concept (
   -- rank (which is always the same value as its accepted name's rank, but
   -- copied independently here since Concept and Name are separate rows/
   -- structs — see domain.Concept.RankVerbatim).
-  rank_verbatim  TEXT
+  rank_verbatim  TEXT,
+  -- The source's family label (domain.Concept.Family), '' when it gives
+  -- none. Read by the area checklist's family grouping; added by
+  -- migrateConceptFamily on older databases.
+  family         TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX IF NOT EXISTS idx_taxon_concept_backbone_id ON taxon_concept(backbone_id);
@@ -173,11 +182,63 @@ CREATE TABLE IF NOT EXISTS vernacular (
   PRIMARY KEY (concept_id, lang, name)
 );
 
--- Distribution (reference-area ranking).
+-- Regional distribution sources: one provenance row per pinned
+-- `distributions:` manifest entry (a Euro+Med or Bavarian county checklist),
+-- the distribution counterpart of xref_source. What ExportBundle's
+-- redistribution gate joins distribution.source against.
+CREATE TABLE IF NOT EXISTS distribution_source (
+  id             TEXT PRIMARY KEY,   -- e.g. "euromed"
+  version        TEXT NOT NULL,      -- checklist edition, never "latest"
+  license        TEXT,
+  source_url     TEXT,
+  ingested_at    TEXT NOT NULL,
+  manifest_sha   TEXT NOT NULL,      -- checksum of the validated manifest
+  redistribution TEXT NOT NULL DEFAULT 'unknown' -- allowed|restricted|unknown (domain.Redistribution); gates ExportBundle, never local ingest
+);
+
+-- Taxon-frequency sources: one provenance row per pinned `frequencies:`
+-- manifest entry (a relevé database's per-taxon counts, a local GBIF
+-- occurrence dump), the frequency counterpart of distribution_source.
+CREATE TABLE IF NOT EXISTS frequency_source (
+  id             TEXT PRIMARY KEY,   -- e.g. "veg_relevees"
+  version        TEXT NOT NULL,      -- export edition, never "latest"
+  license        TEXT,
+  source_url     TEXT,
+  ingested_at    TEXT NOT NULL,
+  manifest_sha   TEXT NOT NULL,      -- checksum of the validated manifest
+  redistribution TEXT NOT NULL DEFAULT 'unknown' -- allowed|restricted|unknown (domain.Redistribution); gates ExportBundle, never local ingest
+);
+
+-- How often one source records a concept: the sum of the source's counts for
+-- every row resolved to it (a count filed under a synonym is the accepted
+-- taxon's). Read by Suggest as a popularity signal, the highest count over
+-- all sources (attachFrequencies). Keyed by concept first: Suggest only ever
+-- asks about the concepts of one result page.
+CREATE TABLE IF NOT EXISTS concept_frequency (
+  concept_id   TEXT NOT NULL REFERENCES taxon_concept(id),
+  source       TEXT NOT NULL REFERENCES frequency_source(id),
+  count        INTEGER NOT NULL,
+  PRIMARY KEY (concept_id, source)
+);
+
+-- Distribution (reference-area ranking). A presence table: every row is
+-- positive evidence, so a checklist's explicit "absent" is never stored.
+--
+-- source is the distribution_source the row was ingested from, NULL for the
+-- WGSRPD ranges a backbone carries itself (gated by the backbone's own
+-- redistribution value, exactly like xref.source). status is the checklist's
+-- verdict (domain.DistributionStatus): native or introduced for backbone
+-- rows, '' only on a database ingested before the backbone's verdict was
+-- kept. Both follow
+-- AddDistribution's INSERT OR REPLACE: a source restating a (concept, scheme,
+-- code) the backbone already holds takes the row over, attribution included.
+-- Added by migrateDistributionColumns on older databases.
 CREATE TABLE IF NOT EXISTS distribution (
   concept_id   TEXT NOT NULL REFERENCES taxon_concept(id),
   area_scheme  TEXT NOT NULL,       -- wgsrpd_l3|euromed|bayern
   area_code    TEXT NOT NULL,
+  status       TEXT NOT NULL DEFAULT '', -- ''|native|introduced|doubtful
+  source       TEXT REFERENCES distribution_source(id),
   PRIMARY KEY (concept_id, area_scheme, area_code)
 );
 
@@ -190,34 +251,71 @@ CREATE TABLE IF NOT EXISTS distribution (
 -- idx_distribution_effective_area; keep this index for the two callers above.
 CREATE INDEX IF NOT EXISTS idx_distribution_area ON distribution(area_scheme, area_code);
 
--- Derived: the EFFECTIVE distribution per concept = own distribution, OR — for a
--- concept with none of its own (CDM sec. concepts) — the areas of any WCVP
--- concept sharing its accepted canonical_fold (the in_area name fallback,
--- precomputed). Lets Suggest resolve in_area as an indexed point lookup instead
--- of a per-row correlated name-fallback. Rebuilt by BuildDistributionClosure
--- at ingest time only (never on the serve/Open path — that would block/OOM
--- serve startup); never written directly.
+-- Derived: the EFFECTIVE distribution per concept = own distribution, OR — per
+-- scheme, for a concept with none of its own in that scheme (CDM sec.
+-- concepts) — the areas of any WCVP concept sharing its accepted
+-- canonical_fold (the in_area name fallback, precomputed), PLUS for a species
+-- every area of its accepted infraspecific descendants (origin
+-- 'infraspecific', derived rather than asserted). Lets Suggest resolve
+-- in_area as an indexed point lookup instead of a per-row correlated
+-- name-fallback. Rebuilt by BuildDistributionClosure at ingest time only
+-- (never on the serve/Open path — that would block/OOM serve startup); never
+-- written directly.
 CREATE TABLE IF NOT EXISTS distribution_effective (
   concept_id  TEXT NOT NULL REFERENCES taxon_concept(id),
   area_scheme TEXT NOT NULL,
   area_code   TEXT NOT NULL,
-  origin      TEXT NOT NULL,          -- 'own' | 'name'
+  origin      TEXT NOT NULL,          -- 'own' | 'name' | 'infraspecific'
   PRIMARY KEY (concept_id, area_scheme, area_code)
 );
 CREATE INDEX IF NOT EXISTS idx_distribution_effective_area
   ON distribution_effective(area_scheme, area_code);
 
+-- Derived: every concept's ancestors along taxon_concept.parent_id, one row
+-- per (ancestor, descendant) pair, plus the concept itself at depth 0 —
+-- the materialised form of the parent walk, so "is X within Poaceae" is one
+-- indexed lookup instead of a per-row recursive walk. Keyed ancestor-first
+-- for the suggest `within` filter (all descendants of one concept); the
+-- concept_id index serves the reverse. Rebuilt by BuildLineageClosure at
+-- ingest time only, like distribution_effective; never written directly.
+CREATE TABLE IF NOT EXISTS concept_lineage (
+  ancestor_id TEXT NOT NULL REFERENCES taxon_concept(id),
+  concept_id  TEXT NOT NULL REFERENCES taxon_concept(id),
+  depth       INTEGER NOT NULL,       -- 0 = the concept itself, 1 = parent, ...
+  PRIMARY KEY (ancestor_id, concept_id)
+);
+CREATE INDEX IF NOT EXISTS idx_concept_lineage_concept ON concept_lineage(concept_id);
+
 -- Human-readable name per (scheme, code), self-sourced from the WCVP
 -- distribution dump's Locality column at ingest. Lets GET /v1/areas offer
 -- "Germany (GER)" instead of a bare WGSRPD code. Keyed by (scheme, code), NOT
 -- per concept — one row per area, first non-empty name wins (INSERT OR IGNORE).
+-- The WGSRPD hierarchy (internal/adapters/wgsrpd) adds its level 1-4 units
+-- here too, with parent = the code one level up (the parent's scheme follows
+-- from the child's: wgsrpd_l3 -> wgsrpd_l2). An area filter naming a level-1
+-- or level-2 unit expands through parent to the level-3 codes distribution
+-- is recorded at (areaCodes in area.go). '' = no parent: a continent, or an
+-- area outside the hierarchy. Added by migrateAreaParent on older databases.
 CREATE TABLE IF NOT EXISTS area (
   scheme  TEXT NOT NULL,
   code    TEXT NOT NULL,
   name    TEXT NOT NULL,
+  parent  TEXT NOT NULL DEFAULT '',
   PRIMARY KEY (scheme, code)
 );
 
+-- area_alias: the ISO 3166 codes an area filter accepts in place of a WGSRPD
+-- code, ingested from internal/adapters/wgsrpd's alias table alongside the
+-- hierarchy. One row per (alias, code); an alias standing for several areas
+-- ("ES" -> SPA, BAL, CNY) has several rows. code may be of any WGSRPD level
+-- and is expanded through area like a code given directly (aliasCodes in
+-- area.go). Aliases are stored upper-cased.
+CREATE TABLE IF NOT EXISTS area_alias (
+  alias TEXT NOT NULL,
+  code  TEXT NOT NULL,
+  PRIMARY KEY (alias, code)
+);
+
 -- Indicator/trait values (pointer to concept + vocabulary version, not the
 -- numbers as ground truth). value is always present (domain.TraitValue.Value
 -- is a plain float64, never a pointer); niche_width/n_systems are nullable
@@ -373,6 +471,41 @@ CREATE TABLE IF NOT EXISTS concept_relation (
 -- to_concept is not, so it needs its own index.
 CREATE INDEX IF NOT EXISTS idx_concept_relation_to_concept ON concept_relation(to_concept);
 
+-- Misapplied-name assertions (CDM "is misapplied name for",
+-- conceptRelationship = false): from_concept is the concept a name was
+-- WRONGLY used for ("Festuca ovina auct."), to_concept the one the users of
+-- that name actually meant. Deliberately NOT a concept_relation row — it says
+-- nothing about how two circumscriptions relate (domain.IsConceptRelation),
+-- and /translate must never see it — but it is exactly what the matcher
+-- needs to answer an "auct." verbatim with the intended concept instead of
+-- the nomenclatural homonym. Until this table existed the rows were counted
+-- and dropped at ingest.
+CREATE TABLE IF NOT EXISTS misapplication (
+  from_concept  TEXT NOT NULL REFERENCES taxon_concept(id),
+  to_concept    TEXT NOT NULL REFERENCES taxon_concept(id),
+  source        TEXT,               -- the backbone/source id asserting it, e.g. "cdm"
+  PRIMARY KEY (from_concept, to_concept, source)
+);
+
+CREATE INDEX IF NOT EXISTS idx_misapplication_to_concept ON misapplication(to_concept);
+
+-- Hybrid parentage of a nothotaxon, from its hybrid formula (WCVP's
+-- dynamicproperties "hybridformula", parsed by domain.ParseHybridFormula):
+-- one row per parent, position its 0-based place in the formula. A parent is
+-- stored by NAME, not as a concept FK — a formula names its parents, and the
+-- backbone need not carry them as concepts; the concept a parent resolves to
+-- is looked up on read. canonical_fold is domain.Canonicalize(canonical), the
+-- key a formula in a match request is compared on (idx_hybrid_parent_fold).
+CREATE TABLE IF NOT EXISTS hybrid_parent (
+  concept_id     TEXT NOT NULL REFERENCES taxon_concept(id),
+  position       INTEGER NOT NULL,
+  canonical      TEXT NOT NULL,
+  canonical_fold TEXT NOT NULL,
+  PRIMARY KEY (concept_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_hybrid_parent_fold ON hybrid_parent(canonical_fold);
+
 -- Full-text/prefix search.
 --
 -- fts_name is a "contentless" FTS5 table (content=''): FTS5 stores only the
@@ -397,7 +530,14 @@ CREATE TABLE IF NOT EXISTS fts_name_map (
   -- 1 when this fts_name row is an AGGREGATE name-space alias (e.g. FloraVeg's
   -- "Achillea millefolium aggr.") rather than a backbone name. Suggest surfaces
   -- MAX(is_aggregate) per concept so a hit can be badged as an aggregate.
-  is_aggregate INTEGER NOT NULL DEFAULT 0
+  is_aggregate INTEGER NOT NULL DEFAULT 0,
+  -- Which name this fts_name row indexes, so Suggest can report what a query
+  -- matched: kind is 'accepted', 'synonym' or 'alias' (domain.MatchField) and
+  -- label the name as displayed — name.canonical, or an alias's verbatim
+  -- name-space spelling. Both are '' in an index built before they existed;
+  -- Suggest then reports no match for the row.
+  kind         TEXT NOT NULL DEFAULT '',
+  label        TEXT NOT NULL DEFAULT ''
 );
 
 -- rowid IS the table's own INTEGER PRIMARY KEY, but concept_id is a
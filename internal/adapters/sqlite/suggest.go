@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -9,41 +10,6 @@ import (
 	"github.com/jobrunner/hostus/internal/ports/output"
 )
 
-// wgsrpdGermanyL3 is the single WGSRPD level-3 code WCVP uses for Germany.
-const wgsrpdGermanyL3 = "GER"
-
-// wgsrpdAlias maps a small set of convenience area names to their WGSRPD
-// level-3 area code(s). Any output.SuggestOpts.Area value not found here
-// (case-insensitively) is treated as a raw WGSRPD level-3 code and passed
-// through unchanged (upper-cased) — so a caller can always bypass the alias
-// table entirely by supplying an exact L3 code (e.g. "GER") directly. Add
-// further aliases here as UC1's frontend needs them. "AT"/"CH" were added
-// alongside Task 4's multi-area bundle scoping (BundleOpts.Area,
-// resolveAreaCodes in bundle.go) so a Mitteleuropa bundle can be requested
-// as "hostus bundle --area DE,AT,CH", mirroring the ISO-3166 alpha-2 style
-// "DE" already used, rather than requiring the raw WGSRPD codes
-// (GER/AUT/SWI) for two of the three countries but not the first.
-var wgsrpdAlias = map[string][]string{
-	"DE": {wgsrpdGermanyL3},
-	"AT": {"AUT"},
-	"CH": {"SWI"},
-}
-
-// areaCodes resolves a Repository.Suggest/output.SuggestOpts.Area value
-// into the set of WGSRPD level-3 area codes to match against
-// distribution.area_code. An empty area returns nil (no area filter — see
-// Suggest's doc comment on the empty-Area convention).
-func areaCodes(area string) []string {
-	if strings.TrimSpace(area) == "" {
-		return nil
-	}
-	key := strings.ToUpper(strings.TrimSpace(area))
-	if codes, ok := wgsrpdAlias[key]; ok {
-		return codes
-	}
-	return []string{key}
-}
-
 // minQueryRunes is the minimum domain.Canonicalize'd length of q that
 // Suggest will search on. Below this, ftsPrefixToken returns "" and Suggest
 // returns an empty result without ever touching FTS5: a 0- or 1-rune
@@ -87,27 +53,50 @@ func fetchBudget(limit int) int {
 var suggestMatchPool = 5000
 
 // ftsPrefixToken turns q into a SQLite FTS5 MATCH query string performing a
-// left-anchored prefix search over q's canonical form. It is
-// injection-safe against FTS5's query syntax (which gives special meaning
-// to *, -, (, ), " and bareword operators like AND/OR/NOT): the
-// canonicalized token is wrapped in a double-quoted FTS5 string literal,
-// with any embedded `"` doubled per FTS5's own escaping rule, which strips
-// special meaning from every character inside the quotes; the single
-// trailing `*` placed OUTSIDE the quotes is what turns the quoted phrase
-// into a prefix query (FTS5 supports "phrase"* as a documented prefix-query
-// form). Returns "" if the canonicalized query is shorter than
-// minQueryRunes, signaling Suggest to skip the query entirely.
+// per-word prefix search over q's canonical form: every word of q must
+// prefix some word of the name, in any position. That is what a botanist in
+// the field types — "fe ov", "F. ov" or the bare epithet "ovina" all reach
+// Festuca ovina — where the earlier single phrase prefix needed every word but
+// the last spelt out in full. An abbreviated genus needs no special case: "F."
+// is the one-letter prefix "f", like any other short word.
+//
+// q is split into words exactly where FTS5's unicode61 tokenizer splits the
+// indexed names (at every rune that is not a letter or digit; see
+// domain.SuggestQueryWords, which the match highlighting shares), so a query
+// word is always a single token. That also makes the query injection-safe against
+// FTS5's syntax: *, -, (, ) and " are separators and never reach it, and each
+// word is wrapped in a double-quoted FTS5 string literal so that a bareword
+// operator (AND/OR/NOT) is searched as text. The trailing `*` OUTSIDE the
+// quotes makes each a prefix term, and FTS5 ANDs terms written side by side.
+// Returns "" if the words together are shorter than minQueryRunes, signaling
+// Suggest to skip the query entirely.
+//
+// Where the word sits decides the ranking, not the match: see
+// ftsAnchoredToken.
 func ftsPrefixToken(q string) string {
 	// Strip any trailing aggregate marker so the marker SPELLING is irrelevant:
 	// "X agg.", "X aggr." and "X s.l." all search the base X (see
 	// domain.StripAggregateMarkers). Combined with the aggregate name-space
 	// aliases indexed at ingest, an aggregate query reliably reaches its taxon.
-	token := domain.StripAggregateMarkers(domain.Canonicalize(q))
-	if len([]rune(token)) < minQueryRunes {
+	words := domain.SuggestQueryWords(q)
+	if len([]rune(strings.Join(words, ""))) < minQueryRunes {
 		return ""
 	}
-	escaped := strings.ReplaceAll(token, `"`, `""`)
-	return `"` + escaped + `"*`
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = `"` + w + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+// ftsAnchoredToken narrows a ftsPrefixToken query to names whose FIRST word
+// the query's first word prefixes — FTS5's initial-token operator `^`, which
+// binds to the phrase right after it. A row matching it is a genus-prefix hit
+// (domain.SuggestItem.PrefixHit); a row matching only the unanchored query was
+// found by a later word, typically the epithet, and ranks after every
+// genus-prefix hit per the §B.1 ordering.
+func ftsAnchoredToken(match string) string {
+	return "^" + match
 }
 
 // Suggest returns FTS5 prefix-match candidates for q. See the
@@ -120,75 +109,117 @@ func (db *DB) Suggest(ctx context.Context, q string, opts output.SuggestOpts) ([
 	}
 
 	// args must be built in the same left-to-right order the placeholders
-	// appear in the final query text below: match + pool cap (pool CTE), then
-	// — only with an area — match again (match_rows CTE), the area codes for
-	// in_area_rows, and the area codes for the in_area EXISTS (SELECT list),
-	// then the rank-filter codes (WHERE), the backbone id (WHERE), then the
-	// LIMIT budget.
-	args := []any{match, suggestMatchPool}
-
-	codes := areaCodes(opts.Area)
-
-	// cteClause feeds the final SELECT's `matches` source. Without an area it is
-	// just the bm25 relevance pool (top suggestMatchPool matches). With an area
-	// the pool alone would silently drop in-area concepts whose prefix relevance
-	// is poor — and in_area is the PRIMARY rank key, so in a SPARSE area (fewer
-	// in-area concepts than a result page) those would vanish from page 1. So we
-	// UNION the pool with in_area_rows: every prefix match whose concept has an
-	// effective (own OR closure-derived name-fallback) distribution in the area,
-	// found cheaply via idx_distribution_effective_area joined to the bm25-free
-	// match_rows membership set (no second full ranking pass). Union-only rows
-	// carry a sentinel score so they sort after real pool hits but, being
-	// in_area, still ahead of every not-in-area concept.
+	// appear in the final query text below: the anchored query (anchored
+	// CTE), match + pool cap (pool CTE), then — only with an area or a within
+	// concept — match again (match_rows CTE), the area scheme and codes for
+	// in_area_rows, the within concept for within_rows, then the area scheme
+	// and codes for the in_area EXISTS (SELECT list), then the rank-filter
+	// codes, the backbone id, the within concept and the published-before
+	// year (WHERE), then the LIMIT budget.
+	args := []any{ftsAnchoredToken(match), match, suggestMatchPool}
+
+	scheme, codes, err := db.areaFilter(ctx, opts.Area)
+	if err != nil {
+		return nil, err
+	}
+
+	// cteClause feeds the final SELECT's `matches` source. Without an area or
+	// a within concept it is just the bm25 relevance pool (top
+	// suggestMatchPool matches). Otherwise the pool alone would silently drop
+	// rows that must not be lost to relevance truncation, so we UNION the pool
+	// with recovery sets over match_rows (the full, bm25-free match set):
+	//
+	//   - in_area_rows: every prefix match whose concept has an effective (own
+	//     OR closure-derived) distribution in the area, found cheaply via
+	//     idx_distribution_effective_area. in_area is the PRIMARY rank key, so
+	//     in a SPARSE area (fewer in-area concepts than a result page) those
+	//     would otherwise vanish from page 1.
+	//   - within_rows: every prefix match inside the within concept, found via
+	//     concept_lineage's ancestor-first key. A family is a small slice of
+	//     a 2-rune prefix's ~100k matches: for "ca" within Poaceae the pool
+	//     may hold none of Calamagrostis, and the filter below would then
+	//     return an empty page.
+	//
+	// Union-only rows carry a sentinel score so they sort after real pool hits
+	// (in_area ones still ahead of every not-in-area concept).
 	cteClause := `matches AS MATERIALIZED (
 			SELECT rowid, bm25(fts_name) AS score
 			FROM fts_name WHERE fts_name MATCH ? ORDER BY score LIMIT ?
 		)`
 
+	var recover []string
+	var recoverArgs []any
+
 	// in_area is a POSITIVE presence test against the precomputed
 	// distribution_effective closure, which already folds in both a concept's
-	// own distribution and — for a concept with none of its own — its WCVP
-	// name twin's distribution (see BuildDistributionClosure). A false result
-	// means "no positive evidence", never "absent". The codes are bound twice
-	// with an area (in_area_rows, in_area EXISTS). Built with literal-format
+	// own distribution and — for a concept with none of its own in the
+	// scheme — its WCVP name twin's distribution (see
+	// BuildDistributionClosure). A false result means "no positive
+	// evidence", never "absent". The scheme and codes (areaFilter) are bound
+	// twice with an area (in_area_rows, in_area EXISTS). Built with literal-format
 	// Sprintf so gosec sees untainted SQL.
 	inAreaExpr := "0"
+	var inAreaArgs []any
 	if len(codes) != 0 {
 		ph := strings.TrimSuffix(strings.Repeat("?,", len(codes)), ",")
 		codeArgs := make([]any, len(codes))
 		for i, c := range codes {
 			codeArgs[i] = c
 		}
-		args = append(args, match)       // match_rows MATCH ?
-		args = append(args, codeArgs...) // in_area_rows area codes
-		args = append(args, codeArgs...) // in_area EXISTS area codes
+		recoverArgs = append(recoverArgs, scheme)      // in_area_rows area scheme
+		recoverArgs = append(recoverArgs, codeArgs...) // in_area_rows area codes
+		inAreaArgs = append(inAreaArgs, scheme)        // in_area EXISTS area scheme
+		inAreaArgs = append(inAreaArgs, codeArgs...)   // in_area EXISTS area codes
+
+		recover = append(recover, fmt.Sprintf(`in_area_rows AS (
+			SELECT DISTINCT fnm.rowid
+			FROM distribution_effective de
+			JOIN fts_name_map fnm ON fnm.concept_id = de.concept_id
+			WHERE de.area_scheme = ? AND de.area_code IN (%s)
+			  AND fnm.rowid IN (SELECT rowid FROM match_rows)
+		)`, ph))
+
+		inAreaExpr = fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM distribution_effective de
+			WHERE de.concept_id = tc.id AND de.area_scheme = ? AND de.area_code IN (%s)
+		)`, ph)
+	}
+	if opts.Within != "" {
+		recoverArgs = append(recoverArgs, opts.Within) // within_rows ancestor
+		recover = append(recover, `within_rows AS (
+			SELECT DISTINCT fnm.rowid
+			FROM concept_lineage cl
+			JOIN fts_name_map fnm ON fnm.concept_id = cl.concept_id
+			WHERE cl.ancestor_id = ?
+			  AND fnm.rowid IN (SELECT rowid FROM match_rows)
+		)`)
+	}
+
+	if len(recover) != 0 {
+		args = append(args, match) // match_rows MATCH ?
+		args = append(args, recoverArgs...)
+		args = append(args, inAreaArgs...)
 
 		// match_rows is the FULL prefix match set as bare rowids (no bm25, so
 		// cheap ~12ms) purely to test membership; the bm25 ranking still only
-		// happens on the bounded pool. in_area_rows recovers in-area matches
-		// (own or closure-derived) that the pool dropped.
-		cteClause = fmt.Sprintf(`pool AS MATERIALIZED (
+		// happens on the bounded pool.
+		names := make([]string, len(recover))
+		for i, r := range recover {
+			names[i] = `SELECT rowid FROM ` + strings.Fields(r)[0]
+		}
+		cteClause = `pool AS MATERIALIZED (
 			SELECT rowid, bm25(fts_name) AS score
 			FROM fts_name WHERE fts_name MATCH ? ORDER BY score LIMIT ?
 		),
 		match_rows AS MATERIALIZED (SELECT rowid FROM fts_name WHERE fts_name MATCH ?),
-		in_area_rows AS (
-			SELECT DISTINCT fnm.rowid
-			FROM distribution_effective de
-			JOIN fts_name_map fnm ON fnm.concept_id = de.concept_id
-			WHERE de.area_scheme = 'wgsrpd_l3' AND de.area_code IN (%s)
-			  AND fnm.rowid IN (SELECT rowid FROM match_rows)
-		),
+		` + strings.Join(recover, `,
+		`) + `,
 		matches AS (
 			SELECT rowid, score FROM pool
 			UNION
-			SELECT rowid, 1e18 FROM in_area_rows WHERE rowid NOT IN (SELECT rowid FROM pool)
-		)`, ph)
-
-		inAreaExpr = fmt.Sprintf(`EXISTS (
-			SELECT 1 FROM distribution_effective de
-			WHERE de.concept_id = tc.id AND de.area_scheme = 'wgsrpd_l3' AND de.area_code IN (%s)
-		)`, ph)
+			SELECT rowid, 1e18 FROM (` + strings.Join(names, ` UNION `) + `)
+			WHERE rowid NOT IN (SELECT rowid FROM pool)
+		)`
 	}
 
 	rankFilter := ""
@@ -227,6 +258,28 @@ func (db *DB) Suggest(ctx context.Context, q string, opts output.SuggestOpts) ([
 		args = append(args, opts.Backbone)
 	}
 
+	// within is a WHERE filter for the same reason as the backbone filter: it
+	// must narrow the page ahead of the LIMIT. Unlike the backbone filter it
+	// does get a pool recovery set (within_rows above) — it is far more
+	// selective than a backbone, so relevance truncation would starve it.
+	withinFilter := ""
+	if opts.Within != "" {
+		withinFilter = " AND tc.id IN (SELECT concept_id FROM concept_lineage WHERE ancestor_id = ?)"
+		args = append(args, opts.Within)
+	}
+
+	// published_before is a WHERE filter like the backbone filter, with no
+	// pool recovery set: it drops only concepts whose every name is known to
+	// be younger, so it thins a page rather than starving it.
+	publishedFilter := ""
+	if opts.PublishedBefore > 0 {
+		publishedFilter = ` AND EXISTS (
+			SELECT 1 FROM concept_name pcn JOIN name pn ON pn.id = pcn.name_id
+			WHERE pcn.concept_id = tc.id AND pn.published_year < ?
+		)`
+		args = append(args, opts.PublishedBefore)
+	}
+
 	args = append(args, fetchBudget(opts.Limit))
 
 	// bm25(fts_name) can only be evaluated directly against fts_name's own
@@ -241,15 +294,24 @@ func (db *DB) Suggest(ctx context.Context, q string, opts output.SuggestOpts) ([
 	// the outer query then aggregates the already-materialized score
 	// column (MIN(m.score), not MIN(bm25(...))) when collapsing a
 	// concept's several matching names (accepted + synonyms) into one row.
-	query := `WITH ` + cteClause + `
-		SELECT tc.id, an.canonical, an.rank, tc.status, MIN(m.score) AS score, ` + inAreaExpr + ` AS in_area, COALESCE(tc.sec_reference, '') AS sec_reference, MAX(fnm.is_aggregate) AS aggregate
+	//
+	// anchored is the genus-prefix half of the ranking (ftsAnchoredToken): the
+	// rowids whose name STARTS with the query, as bare rowids like match_rows
+	// — a membership set, no bm25, so it costs one cheap FTS scan. A concept is
+	// a prefix hit when any of its matched names is, so "ovina" ranks Festuca
+	// ovina after the Ovidia names but a synonym starting with the query still
+	// lifts its accepted concept. It leads the ORDER BY as it leads domain.RankSuggestions, so
+	// the fetch budget never cuts a genus-prefix hit for an epithet hit.
+	query := `WITH anchored AS MATERIALIZED (SELECT rowid FROM fts_name WHERE fts_name MATCH ?),
+		` + cteClause + `
+		SELECT tc.id, an.canonical, an.rank, tc.status, MIN(m.score) AS score, ` + inAreaExpr + ` AS in_area, COALESCE(tc.sec_reference, '') AS sec_reference, MAX(fnm.is_aggregate) AS aggregate, MAX(m.rowid IN (SELECT rowid FROM anchored)) AS prefix_hit, json_group_array(json_array(fnm.kind, fnm.label)) AS matched_names
 		FROM matches m
 		JOIN fts_name_map fnm ON fnm.rowid = m.rowid
 		JOIN taxon_concept tc ON tc.id = fnm.concept_id
 		JOIN name an ON an.id = tc.accepted_name
-		WHERE 1 = 1` + rankFilter + backboneFilter + `
+		WHERE 1 = 1` + rankFilter + backboneFilter + withinFilter + publishedFilter + `
 		GROUP BY tc.id
-		ORDER BY in_area DESC, score ASC
+		ORDER BY prefix_hit DESC, in_area DESC, score ASC
 		LIMIT ?`
 
 	rows, err := db.sql.QueryContext(ctx, query, args...)
@@ -260,7 +322,7 @@ func (db *DB) Suggest(ctx context.Context, q string, opts output.SuggestOpts) ([
 
 	var out []domain.SuggestItem
 	for rows.Next() {
-		item, err := scanSuggestItem(rows.Scan)
+		item, err := scanSuggestItem(q, rows.Scan)
 		if err != nil {
 			return nil, fmt.Errorf("sqlite: scanning suggest %q row: %w", q, err)
 		}
@@ -273,9 +335,58 @@ func (db *DB) Suggest(ctx context.Context, q string, opts output.SuggestOpts) ([
 	if err := db.attachTargetSpaceNames(ctx, out, opts.TargetSpace, domain.IsAggregateName(q)); err != nil {
 		return nil, err
 	}
+	if err := db.attachFrequencies(ctx, out); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
+// attachFrequencies fills Frequency on every item some frequency source
+// counts, with the highest count over all sources: sources count different
+// things (relevés, occurrence records), so their counts are not summed.
+// Like attachTargetSpaceNames it runs one query for the whole page. The
+// popularity term is applied by domain.RankSuggestionsWeighted over this
+// page, so it reorders the fetch budget's candidates and never reaches past
+// it.
+func (db *DB) attachFrequencies(ctx context.Context, items []domain.SuggestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]string, len(items))
+	for i, it := range items {
+		ids[i] = it.ConceptID
+	}
+	idsJSON, err := marshalIDs(ids)
+	if err != nil {
+		return err
+	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT concept_id, MAX(count) FROM concept_frequency
+		WHERE concept_id IN (SELECT value FROM json_each(?))
+		GROUP BY concept_id`, idsJSON)
+	if err != nil {
+		return fmt.Errorf("sqlite: suggest frequencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[string]int64, len(items))
+	for rows.Next() {
+		var conceptID string
+		var count int64
+		if err := rows.Scan(&conceptID, &count); err != nil {
+			return fmt.Errorf("sqlite: scanning suggest frequency row: %w", err)
+		}
+		counts[conceptID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sqlite: iterating suggest frequency rows: %w", err)
+	}
+	for i := range items {
+		items[i].Frequency = counts[items[i].ConceptID]
+	}
+	return nil
+}
+
 // attachTargetSpaceNames fills TargetSpaceName on every item that has a
 // spelling in space. It runs ONE query for the whole page rather than one per
 // hit: a suggest page holds up to the fetch budget of concepts, and a
@@ -350,15 +461,21 @@ func (db *DB) attachTargetSpaceNames(ctx context.Context, items []domain.Suggest
 }
 
 // scanSuggestItem decodes one Suggest result row into a domain.SuggestItem.
-// PrefixHit is always true: every row Suggest produces came from an FTS5
-// MATCH, so there is no other value it could carry here.
-func scanSuggestItem(scan func(dest ...any) error) (domain.SuggestItem, error) {
+// PrefixHit is the row's prefix_hit: whether the name starts with the query,
+// rather than only containing a word it prefixes (ftsAnchoredToken). Match is
+// chosen from the row's matched_names for q (suggestMatch).
+func scanSuggestItem(q string, scan func(dest ...any) error) (domain.SuggestItem, error) {
 	var item domain.SuggestItem
-	var rank, status string
-	var inArea, aggregate int
-	if err := scan(&item.ConceptID, &item.Canonical, &rank, &status, &item.Score, &inArea, &item.SecReference, &aggregate); err != nil {
+	var rank, status, matchedNames string
+	var inArea, aggregate, prefixHit int
+	if err := scan(&item.ConceptID, &item.Canonical, &rank, &status, &item.Score, &inArea, &item.SecReference, &aggregate, &prefixHit, &matchedNames); err != nil {
 		return domain.SuggestItem{}, err
 	}
+	match, err := suggestMatch(q, matchedNames)
+	if err != nil {
+		return domain.SuggestItem{}, fmt.Errorf("concept %q: %w", item.ConceptID, err)
+	}
+	item.Match = match
 	r, err := domain.ParseRank(rank)
 	if err != nil {
 		return domain.SuggestItem{}, fmt.Errorf("concept %q: %w", item.ConceptID, err)
@@ -368,6 +485,84 @@ func scanSuggestItem(scan func(dest ...any) error) (domain.SuggestItem, error) {
 	item.Display = item.Canonical
 	item.InArea = inArea != 0
 	item.Aggregate = aggregate != 0
-	item.PrefixHit = true
+	item.PrefixHit = prefixHit != 0
 	return item, nil
 }
+
+// suggestMatch decodes a Suggest row's matched_names — a JSON array of the
+// [kind, label] pairs of every fts_name row the concept was matched by — and
+// picks the one to report (domain.BestSuggestMatch). Rows of an index built
+// before kind was recorded are skipped, so such an index reports no match
+// instead of a wrong one. A concept indexed under one name several times
+// (a re-ingest appends, see Finalize) lists it once.
+func suggestMatch(q, matchedNames string) (domain.SuggestMatch, error) {
+	var pairs [][2]string
+	if err := json.Unmarshal([]byte(matchedNames), &pairs); err != nil {
+		return domain.SuggestMatch{}, fmt.Errorf("decoding matched names: %w", err)
+	}
+	seen := make(map[[2]string]bool, len(pairs))
+	var candidates []domain.SuggestMatch
+	for _, p := range pairs {
+		if p[0] == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		candidates = append(candidates, domain.SuggestMatch{Field: domain.MatchField(p[0]), Name: p[1]})
+	}
+	return domain.BestSuggestMatch(q, candidates), nil
+}
+
+// suggestPrefixPool is SuggestPrefixes' default cap on distinct prefixes. The
+// first-letter prefilter still leaves tens of thousands of names for a common
+// initial on the full index, but far fewer DISTINCT prefixes of a typed
+// fragment's length: a cap this size truncates only for short fragments under
+// a crowded initial, where domain.SuggestCorrections would find nothing within
+// its threshold anyway.
+const suggestPrefixPool = 10000
+
+// SuggestPrefixes returns distinct canonical_fold prefixes of canon's rune
+// length among the names sharing canon's first rune. The prefilter is
+// MatchFuzzyCandidates' GLOB first-rune range over idx_name_canonical_fold,
+// with its recall trade-off: a fragment whose first letter is the typo is not
+// corrected. Unlike that lookup there is no length window — every name at
+// least as long as the fragment has a prefix of its length — and a shorter
+// name is left out, since its "prefix" would be the whole name judged against
+// letters it does not have.
+//
+// Prefixes come from scientific names only; a vernacular fragment is not
+// corrected. That keeps the scan on one indexed column instead of the FTS
+// vocabulary, and a misspelled German name is rarer than a misspelled Latin
+// one.
+func (db *DB) SuggestPrefixes(ctx context.Context, canon string, limit int) ([]string, error) {
+	want := domain.Canonicalize(canon)
+	n := len([]rune(want))
+	if n < minQueryRunes {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = suggestPrefixPool
+	}
+	firstRunePrefix := globEscape(string([]rune(want)[:1])) + "*"
+
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT DISTINCT substr(canonical_fold, 1, ?) FROM name
+		WHERE canonical_fold GLOB ? AND length(canonical_fold) >= ?
+		LIMIT ?`, n, firstRunePrefix, n, limit)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: suggest prefixes %q: %w", canon, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning suggest prefix %q row: %w", canon, err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating suggest prefix %q rows: %w", canon, err)
+	}
+	return out, nil
+}
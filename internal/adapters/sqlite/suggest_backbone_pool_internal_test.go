@@ -37,7 +37,7 @@ func seedMajorityWCVPMinorityCDM(t *testing.T, db *DB) {
 		c := domain.Concept{ID: "cdm:concept:zzq-e", BackboneID: "cdm", AcceptedName: n, Rank: domain.RankSpecies, Status: domain.StatusAccepted}
 		mustTx(t, tx.UpsertConcept(c))
 		mustTx(t, tx.LinkName(c.ID, n.ID, "accepted", nil))
-		mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "ZZZ"}))
+		mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "ZZZ"}, ""))
 	})
 }
 
@@ -42,7 +42,7 @@ func seedWCVPInulaHirta(t *testing.T, db *DB) {
 		mustTx(t, tx.UpsertConcept(c))
 		mustTx(t, tx.LinkName(c.ID, accepted.ID, "accepted", nil))
 		mustTx(t, tx.LinkName(c.ID, synonym.ID, "synonym", nil))
-		mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "GER"}))
+		mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "GER"}, ""))
 	})
 }
 
@@ -66,7 +66,7 @@ func seedCDMInulaHirta(t *testing.T, db *DB) {
 			mustTx(t, tx.UpsertConcept(c))
 			mustTx(t, tx.LinkName(c.ID, c.AcceptedName.ID, "accepted", nil))
 		}
-		mustTx(t, tx.AddDistribution("cdm:concept:inula-hirta-fra", domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "FRA"}))
+		mustTx(t, tx.AddDistribution("cdm:concept:inula-hirta-fra", domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "FRA"}, ""))
 	})
 }
 
@@ -122,7 +122,7 @@ func seedSparseCDMTwin(t *testing.T, db *DB) {
 		c := domain.Concept{ID: "wcvp:concept:foo-bar", BackboneID: "wcvp", AcceptedName: n, Rank: domain.RankSpecies, Status: domain.StatusAccepted}
 		mustTx(t, tx.UpsertConcept(c))
 		mustTx(t, tx.LinkName(c.ID, n.ID, "accepted", nil))
-		mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "ZZ"}))
+		mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "ZZ"}, ""))
 	})
 
 	bvCDM := domain.BackboneVersion{ID: "cdm", Version: "v1", IngestedAt: "2026-08-16T00:00:00Z", ManifestSHA: "x"}
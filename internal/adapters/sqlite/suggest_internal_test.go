@@ -2,7 +2,9 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -59,6 +61,26 @@ func TestFtsPrefixToken_TwoRuneBoundary(t *testing.T) {
 	}
 }
 
+// TestFtsPrefixToken_OneTermPerWord pins the query shape: one quoted prefix
+// term per word, split where the tokenizer splits, so an abbreviated genus is
+// a one-letter prefix and an operator word is searched as text.
+func TestFtsPrefixToken_OneTermPerWord(t *testing.T) {
+	cases := map[string]string{
+		"F. ov":             `"f"* "ov"*`,
+		"festuca  ovina":    `"festuca"* "ovina"*`,
+		"co-n OR canescens": `"co"* "n"* "or"* "canescens"*`,
+		"× Festulolium":     `"festulolium"*`,
+	}
+	for q, want := range cases {
+		if got := ftsPrefixToken(q); got != want {
+			t.Errorf("ftsPrefixToken(%q) = %q, want %q", q, got, want)
+		}
+	}
+	if got := ftsAnchoredToken(ftsPrefixToken("F. ov")); got != `^"f"* "ov"*` {
+		t.Errorf("ftsAnchoredToken = %q, want the first term anchored", got)
+	}
+}
+
 // TestFtsPrefixToken_StripsAggregateMarker pins that the FTS query is
 // marker-insensitive: an aggregate spelling produces the same prefix token as
 // the bare base, so "Achillea millefolium agg./aggr./s.l." all search the base.
@@ -106,6 +128,9 @@ func TestSuggest_AggregateSpellingFindsConceptAndFlagsIt(t *testing.T) {
 	if !agg.Aggregate {
 		t.Error("Aggregate = false, want true (matched via an aggregate alias)")
 	}
+	if agg.Match.Field != domain.MatchAlias || agg.Match.Name != "Festuca ovina aggr." {
+		t.Errorf("Match = %+v, want the aggregate alias as written", agg.Match)
+	}
 
 	own, ok := find("Corynephorus can")
 	if !ok {
@@ -114,6 +139,9 @@ func TestSuggest_AggregateSpellingFindsConceptAndFlagsIt(t *testing.T) {
 	if own.Aggregate {
 		t.Error("Aggregate = true, want false (matched the concept's own non-aggregate name)")
 	}
+	if own.Match.Field != domain.MatchAccepted || own.Match.Name != own.Display {
+		t.Errorf("Match = %+v, want the accepted name, equal to Display %q", own.Match, own.Display)
+	}
 }
 
 // seedCorynephorusConcept ingests one accepted concept ("Corynephorus
@@ -263,7 +291,7 @@ func seedFetchBudgetOverflowFixture(t *testing.T, db *DB, noiseCount int, areaCo
 	if err := tx.LinkName(targetConcept.ID, targetName.ID, "accepted", nil); err != nil {
 		t.Fatalf("LinkName(target): %v", err)
 	}
-	if err := tx.AddDistribution(targetConceptID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: areaCode}); err != nil {
+	if err := tx.AddDistribution(targetConceptID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: areaCode}, ""); err != nil {
 		t.Fatalf("AddDistribution(target): %v", err)
 	}
 
@@ -323,3 +351,41 @@ func conceptIDsList(items []domain.SuggestItem) []string {
 	}
 	return out
 }
+
+// TestOpen_MigratesFTSNameMapLabel: an fts_name_map from before matched names
+// were recorded gains kind and label on Open, and rows still carrying the
+// empty defaults are found as before but report no match rather than a
+// guessed one.
+func TestOpen_MigratesFTSNameMapLabel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.sqlite")
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	if _, err := legacy.Exec(`
+		CREATE TABLE fts_name_map (rowid INTEGER PRIMARY KEY, concept_id TEXT NOT NULL, is_aggregate INTEGER NOT NULL DEFAULT 0);`); err != nil {
+		t.Fatalf("creating pre-migration fts_name_map table: %v", err)
+	}
+	_ = legacy.Close()
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open(legacy): %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	conceptID := seedCorynephorusConcept(t, db)
+	if _, err := db.sql.Exec(`UPDATE fts_name_map SET kind = '', label = ''`); err != nil {
+		t.Fatalf("resetting fts_name_map to its pre-migration contents: %v", err)
+	}
+
+	items, err := db.Suggest(context.Background(), "coryn", output.SuggestOpts{Limit: 10})
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if len(items) != 1 || items[0].ConceptID != conceptID {
+		t.Fatalf("Suggest(coryn) = %+v, want %s alone", items, conceptID)
+	}
+	if items[0].Match.Field != "" {
+		t.Errorf("Match = %+v, want none for an unlabelled row", items[0].Match)
+	}
+}
@@ -55,7 +55,7 @@ func seedTwoInAreaOneOut(t *testing.T, db *DB) {
 			mustTx(t, tx.UpsertConcept(c))
 			mustTx(t, tx.LinkName(c.ID, n.ID, "accepted", nil))
 			if s.inArea {
-				mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "ZZZ"}))
+				mustTx(t, tx.AddDistribution(c.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: "ZZZ"}, ""))
 			}
 		}
 	})
@@ -0,0 +1,46 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// TestSuggest_PublishedBeforeKeepsOlderAndUndatedConcepts: both WCVP
+// Corynephorus concepts carry only names from 1812 or later, so a cutoff of
+// 1812 drops them and 1813 keeps them; the CDM concept has no citation at
+// all and stays either way — an unknown year is not "too young".
+func TestSuggest_PublishedBeforeKeepsOlderAndUndatedConcepts(t *testing.T) {
+	ctx := context.Background()
+	db := ingestWCVPFixture(t)
+	concepts := []application.CDMConceptRow{{
+		ConceptUUID: "coryn-cdm", ScientificName: "Corynefake unica", Authorship: "L.",
+		Rank: "Species", Status: "Accepted", SecUUID: "sec-uno", SecTitle: "Flora Uno",
+	}}
+	if _, err := application.IngestCDM(ctx, db, concepts, nil,
+		domain.BackboneVersion{ID: "cdm", Version: "v1", Redistribution: domain.RedistributionUnknown}); err != nil {
+		t.Fatalf("IngestCDM: %v", err)
+	}
+
+	for _, tc := range []struct {
+		before int
+		want   map[string]bool
+	}{
+		{1812, map[string]bool{"wcvp:concept:451295": false, "wcvp:concept:405825": false, "cdm:concept:coryn-cdm": true}},
+		{1813, map[string]bool{"wcvp:concept:451295": true, "wcvp:concept:405825": true, "cdm:concept:coryn-cdm": true}},
+	} {
+		got, err := db.Suggest(ctx, "coryn", output.SuggestOpts{Limit: 50, PublishedBefore: tc.before})
+		if err != nil {
+			t.Fatalf("Suggest(published_before=%d): %v", tc.before, err)
+		}
+		ids := conceptIDs(got)
+		for id, want := range tc.want {
+			if _, ok := ids[id]; ok != want {
+				t.Errorf("Suggest(published_before=%d) has %s = %v, want %v", tc.before, id, ok, want)
+			}
+		}
+	}
+}
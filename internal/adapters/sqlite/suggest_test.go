@@ -2,11 +2,14 @@ package sqlite_test
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jobrunner/hostus/internal/adapters/manifest"
 	"github.com/jobrunner/hostus/internal/adapters/sqlite"
 	"github.com/jobrunner/hostus/internal/adapters/wcvp"
+	"github.com/jobrunner/hostus/internal/adapters/wgsrpd"
 	"github.com/jobrunner/hostus/internal/application"
 	"github.com/jobrunner/hostus/internal/domain"
 	"github.com/jobrunner/hostus/internal/ports/output"
@@ -50,7 +53,7 @@ func (s wcvpRowSource) Taxa() []application.TaxonRow {
 func (s wcvpRowSource) Distributions() []application.DistributionRow {
 	out := make([]application.DistributionRow, 0, len(s.ds.Distributions))
 	for _, d := range s.ds.Distributions {
-		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode()})
+		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode(), Introduced: d.Introduced()})
 	}
 	return out
 }
@@ -183,6 +186,14 @@ func TestSuggest_PrefixOnSynonymResolvesToAcceptedConcept(t *testing.T) {
 	if got[0].Canonical != "Corynephorus canescens" {
 		t.Errorf("Canonical = %q, want %q (Suggest always reports the accepted name, not the matched synonym text — see Finalize's doc comment)", got[0].Canonical, "Corynephorus canescens")
 	}
+	want := domain.SuggestMatch{
+		Field:      domain.MatchSynonym,
+		Name:       "Weingaertneria canescens var. pallida",
+		Highlights: []domain.Span{{Start: 0, End: 14}},
+	}
+	if !reflect.DeepEqual(got[0].Match, want) {
+		t.Errorf("Match = %+v, want %+v (the synonym the query matched)", got[0].Match, want)
+	}
 }
 
 // TestSuggest_RanksFiltersOutNonMatchingRanks proves the Ranks option
@@ -222,13 +233,25 @@ func TestSuggest_RanksFiltersOutNonMatchingRanks(t *testing.T) {
 
 // TestSuggest_InArea_ResolvesRawWGSRPDCodeAndAliasAndAbsentCode exercises
 // the area→L3 handling end to end: a raw WGSRPD L3 passthrough code that
-// IS in the concept's distribution, the "DE" alias that resolves to a code
-// that is NOT in this concept's distribution (the fixture's Corynephorus
-// canescens distribution rows are AUT/BLT/BLR/BGM/BRC/RUC/CNT/CZE/DEN —
-// GER is not among them), and a code that plainly is not present.
+// IS in the concept's distribution, the ingested ISO 3166 aliases — "DE"
+// resolves to a code that is NOT in this concept's distribution (the
+// fixture's Corynephorus canescens distribution rows are
+// AUT/BLT/BLR/BGM/BRC/RUC/CNT/CZE/DEN — GER is not among them) — and a
+// code that plainly is not present.
 func TestSuggest_InArea_ResolvesRawWGSRPDCodeAndAliasAndAbsentCode(t *testing.T) {
 	db := ingestWCVPFixture(t)
 	ctx := context.Background()
+	units, err := wgsrpd.Hierarchy()
+	if err != nil {
+		t.Fatalf("Hierarchy: %v", err)
+	}
+	aliases, err := wgsrpd.Aliases(units)
+	if err != nil {
+		t.Fatalf("Aliases: %v", err)
+	}
+	if _, err := application.IngestAreaHierarchy(ctx, db, units, aliases); err != nil {
+		t.Fatalf("IngestAreaHierarchy: %v", err)
+	}
 
 	cases := []struct {
 		name       string
@@ -239,6 +262,8 @@ func TestSuggest_InArea_ResolvesRawWGSRPDCodeAndAliasAndAbsentCode(t *testing.T)
 		{"DE alias resolves to GER, absent from this concept's distribution", "DE", false},
 		{"AT alias resolves to AUT, present in this concept's distribution", "AT", true},
 		{"CH alias resolves to SWI, absent from this concept's distribution", "CH", false},
+		{"alpha-3 alias BEL resolves to BGM, present in this concept's distribution", "BEL", true},
+		{"subdivision alias resolves to its country's area", "de-by", false},
 		{"raw L3 code absent from distribution", "ZZZ", false},
 	}
 	for _, tc := range cases {
@@ -282,7 +307,8 @@ func TestSuggest_EmptyAreaMeansInAreaAlwaysFalse(t *testing.T) {
 
 // TestSuggest_MatchSpecialCharactersDoNotErrorOrInject proves a q
 // containing FTS5 query-syntax special characters is treated as literal
-// text (via ftsPrefixToken's quoting), not as FTS5 operators/injection: it
+// text (via ftsPrefixToken's word splitting and quoting), not as FTS5
+// operators/injection: it
 // must not error, and it must not spuriously match everything.
 func TestSuggest_MatchSpecialCharactersDoNotErrorOrInject(t *testing.T) {
 	db := ingestWCVPFixture(t)
@@ -331,3 +357,79 @@ func TestSuggest_EmptyOrOneRuneQueryReturnsEmpty(t *testing.T) {
 		})
 	}
 }
+
+// TestSuggestPrefixes_ReturnsSameLengthPrefixesUnderTheFirstLetter pins the
+// prefilter the typo fallback scores: every prefix has the fragment's length
+// and initial, the misspelt fragment's intended prefix is among them, and a
+// name starting with another letter contributes nothing.
+func TestSuggestPrefixes_ReturnsSameLengthPrefixesUnderTheFirstLetter(t *testing.T) {
+	db := ingestWCVPFixture(t)
+
+	got, err := db.SuggestPrefixes(context.Background(), "corinephorus", 0)
+	if err != nil {
+		t.Fatalf("SuggestPrefixes: unexpected error: %v", err)
+	}
+	found := false
+	for _, p := range got {
+		if len([]rune(p)) != 12 || !strings.HasPrefix(p, "c") {
+			t.Errorf("prefix %q, want 12 runes starting with c", p)
+		}
+		found = found || p == "corynephorus"
+	}
+	if !found {
+		t.Errorf("prefixes = %v, want corynephorus among them", got)
+	}
+
+	if got, err := db.SuggestPrefixes(context.Background(), "c", 0); err != nil || len(got) != 0 {
+		t.Errorf("SuggestPrefixes(c) = %v, %v, want nothing", got, err)
+	}
+}
+
+// TestSuggest_PerWordPrefixes pins the field-botanist spellings: per-word
+// prefixes, an abbreviated genus and a bare epithet all reach Festuca ovina
+// (415853). Only the ones starting with the genus are prefix hits.
+func TestSuggest_PerWordPrefixes(t *testing.T) {
+	db := ingestWCVPFixture(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		q          string
+		wantPrefix bool
+	}{
+		{"fe ov", true},
+		{"F. ov", true},
+		{"F.ovina", true},
+		{"Festuca ovina", true},
+		{"ovina", false},
+	} {
+		t.Run(tc.q, func(t *testing.T) {
+			got, err := db.Suggest(ctx, tc.q, output.SuggestOpts{Limit: 10})
+			if err != nil {
+				t.Fatalf("Suggest(%q): unexpected error: %v", tc.q, err)
+			}
+			item, ok := conceptIDs(got)["wcvp:concept:415853"]
+			if !ok {
+				t.Fatalf("Suggest(%q) = %+v, want Festuca ovina", tc.q, got)
+			}
+			if item.PrefixHit != tc.wantPrefix {
+				t.Errorf("Suggest(%q) PrefixHit = %v, want %v", tc.q, item.PrefixHit, tc.wantPrefix)
+			}
+		})
+	}
+}
+
+// TestSuggest_WordOrderDecidesThePrefixHit pins that the words need not be
+// adjacent or in order to match, but a name only counts as a prefix hit when
+// its first word takes the query's first word.
+func TestSuggest_WordOrderDecidesThePrefixHit(t *testing.T) {
+	db := ingestWCVPFixture(t)
+
+	got, err := db.Suggest(context.Background(), "ovina festuca", output.SuggestOpts{Limit: 10})
+	if err != nil {
+		t.Fatalf("Suggest: unexpected error: %v", err)
+	}
+	item, ok := conceptIDs(got)["wcvp:concept:415853"]
+	if !ok || item.PrefixHit {
+		t.Errorf("Suggest(ovina festuca) = %+v, want Festuca ovina as a non-prefix hit", got)
+	}
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/jobrunner/hostus/internal/domain"
 )
 
-// synonymCandidateQuery reads one concept's synonyms in the shape UC5's
+// synonymCandidateQuery reads the synonyms of the concepts in a json_each
+// id list in the shape UC5's
 // relevance model needs. It differs from conceptSynonyms' query in exactly
 // two ways, and both are the point of the method existing:
 //
@@ -26,15 +27,15 @@ import (
 // have to scan into a bool. Comparing explicitly keeps the column a plain
 // 0/1 integer.
 const synonymCandidateQuery = `
-	SELECT n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.rank_verbatim, ''), COALESCE(n.nom_status, ''),
+	SELECT cn.concept_id, n.id, n.canonical, COALESCE(n.authorship, ''), n.rank, COALESCE(n.rank_verbatim, ''), COALESCE(n.nom_status, ''),
 	       cn.homotypic,
 	       (an.basionym_id IS NOT NULL AND an.basionym_id = n.id) AS is_basionym
 	FROM concept_name cn
 	JOIN name n ON n.id = cn.name_id
 	JOIN taxon_concept tc ON tc.id = cn.concept_id
 	JOIN name an ON an.id = tc.accepted_name
-	WHERE cn.concept_id = ? AND cn.role = 'synonym'
-	ORDER BY n.id`
+	WHERE cn.concept_id IN (SELECT value FROM json_each(?)) AND cn.role = 'synonym'
+	ORDER BY cn.concept_id, n.id`
 
 // SynonymCandidates returns conceptID's synonyms as domain.SynonymCandidates,
 // carrying nom_status, the tri-state homotypic flag and IsBasionym. See
@@ -53,25 +54,43 @@ func (db *DB) SynonymCandidates(ctx context.Context, conceptID string) ([]domain
 		return nil, fmt.Errorf("sqlite: concept %q: %w", conceptID, domain.ErrNotFound)
 	}
 
-	rows, err := db.sql.QueryContext(ctx, synonymCandidateQuery, conceptID)
+	byConcept, err := db.synonymCandidatesOf(ctx, []string{conceptID})
 	if err != nil {
-		return nil, fmt.Errorf("sqlite: querying synonym candidates of concept %q: %w", conceptID, err)
+		return nil, err
+	}
+	if candidates := byConcept[conceptID]; candidates != nil {
+		return candidates, nil
+	}
+	return []domain.SynonymCandidate{}, nil
+}
+
+// synonymCandidatesOf is SynonymCandidates for every concept in conceptIDs,
+// keyed by concept id, without the existence check: a concept without
+// synonyms is absent from the map.
+func (db *DB) synonymCandidatesOf(ctx context.Context, conceptIDs []string) (map[string][]domain.SynonymCandidate, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.sql.QueryContext(ctx, synonymCandidateQuery, idsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying synonym candidates of concepts %q: %w", conceptIDs, err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	out := []domain.SynonymCandidate{}
+	out := map[string][]domain.SynonymCandidate{}
 	for rows.Next() {
 		var (
 			c                  domain.SynonymCandidate
 			rank, rankVerbatim string
 			homotypic          sql.NullBool
 		)
-		if err := rows.Scan(&c.NameID, &c.Canonical, &c.Authorship, &rank, &rankVerbatim, &c.NomStatus, &homotypic, &c.IsBasionym); err != nil {
-			return nil, fmt.Errorf("sqlite: scanning synonym candidate of concept %q: %w", conceptID, err)
+		if err := rows.Scan(&c.ConceptID, &c.NameID, &c.Canonical, &c.Authorship, &rank, &rankVerbatim, &c.NomStatus, &homotypic, &c.IsBasionym); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning synonym candidate of concepts %q: %w", conceptIDs, err)
 		}
 		parsed, err := domain.ParseRank(rank)
 		if err != nil {
-			return nil, fmt.Errorf("sqlite: synonym %q of concept %q: %w", c.NameID, conceptID, err)
+			return nil, fmt.Errorf("sqlite: synonym %q of concept %q: %w", c.NameID, c.ConceptID, err)
 		}
 		c.Rank = parsed
 		// Only for RankOther, mirroring scanName/scanConcept: for every
@@ -80,7 +99,6 @@ func (db *DB) SynonymCandidates(ctx context.Context, conceptID string) ([]domain
 		if parsed == domain.RankOther {
 			c.RankVerbatim = rankVerbatim
 		}
-		c.ConceptID = conceptID
 		if homotypic.Valid {
 			// homotypic.Bool must be copied into a fresh variable: taking
 			// &homotypic.Bool would alias the loop's scan destination, so
@@ -88,10 +106,10 @@ func (db *DB) SynonymCandidates(ctx context.Context, conceptID string) ([]domain
 			value := homotypic.Bool
 			c.Homotypic = &value
 		}
-		out = append(out, c)
+		out[c.ConceptID] = append(out[c.ConceptID], c)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sqlite: iterating synonym candidates of concept %q: %w", conceptID, err)
+		return nil, fmt.Errorf("sqlite: iterating synonym candidates of concepts %q: %w", conceptIDs, err)
 	}
 	return out, nil
 }
@@ -75,6 +75,14 @@ INSERT INTO distribution (concept_id, area_scheme, area_code) VALUES
   ('c-corynephorus-canescens', 'wgsrpd_l3', 'GER'),
   ('c-corynephorus-canescens', 'wgsrpd_l3', 'FRA');
 
+-- A cut of the ingested ISO 3166 alias table (internal/adapters/wgsrpd's
+-- aliases.txt), so area filters can be exercised with "DE" as a caller
+-- would send it.
+INSERT INTO area_alias (alias, code) VALUES
+  ('DE', 'GER'),
+  ('AT', 'AUT'),
+  ('CH', 'SWI');
+
 -- SP6 Task 3 (GET /v1/concept/{id}/synonyms) additions.
 --
 -- c-uc5-corynephorus is a REDUCED, name-disambiguated replica of the real
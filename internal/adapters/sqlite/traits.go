@@ -9,10 +9,11 @@ import (
 	"github.com/jobrunner/hostus/internal/domain"
 )
 
-// traitSetKey identifies one (vocab, vocab_version) group within Traits'
-// result — the unit domain.TraitSet is built per, never merged across
-// vocabularies (PoC P10; see domain.TraitSet's doc comment).
+// traitSetKey identifies one concept's (vocab, vocab_version) group within
+// traitsOf's result — the unit domain.TraitSet is built per, never merged
+// across vocabularies (PoC P10; see domain.TraitSet's doc comment).
 type traitSetKey struct {
+	concept string
 	vocab   string
 	version string
 }
@@ -32,10 +33,27 @@ func (db *DB) Traits(ctx context.Context, conceptID string, vocabs []domain.Trai
 		return nil, fmt.Errorf("sqlite: concept %q: %w", conceptID, domain.ErrNotFound)
 	}
 
-	query, args := traitsQuery(conceptID, vocabs)
+	byConcept, err := db.traitsOf(ctx, []string{conceptID}, vocabs)
+	if err != nil {
+		return nil, err
+	}
+	if sets := byConcept[conceptID]; sets != nil {
+		return sets, nil
+	}
+	return []domain.TraitSet{}, nil
+}
+
+// traitsOf is Traits for every concept in conceptIDs, keyed by concept id,
+// without the existence check: a concept with no trait_value rows (known or
+// not) is simply absent from the map.
+func (db *DB) traitsOf(ctx context.Context, conceptIDs []string, vocabs []domain.TraitVocab) (map[string][]domain.TraitSet, error) {
+	query, args, err := traitsQuery(conceptIDs, vocabs)
+	if err != nil {
+		return nil, err
+	}
 	rows, err := db.sql.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("sqlite: querying trait values for concept %q: %w", conceptID, err)
+		return nil, fmt.Errorf("sqlite: querying trait values for concepts %q: %w", conceptIDs, err)
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -45,18 +63,18 @@ func (db *DB) Traits(ctx context.Context, conceptID string, vocabs []domain.Trai
 	)
 	for rows.Next() {
 		var (
-			vocab, vocabVersion, dim string
-			value                    float64
-			nicheWidth               sql.NullFloat64
-			nSystems                 sql.NullInt64
-			resolution               sql.NullString
-			taxonomy                 string
+			conceptID, vocab, vocabVersion, dim string
+			value                               float64
+			nicheWidth                          sql.NullFloat64
+			nSystems                            sql.NullInt64
+			resolution                          sql.NullString
+			taxonomy                            string
 		)
-		if err := rows.Scan(&vocab, &vocabVersion, &dim, &value, &nicheWidth, &nSystems, &resolution, &taxonomy); err != nil {
-			return nil, fmt.Errorf("sqlite: scanning trait value for concept %q: %w", conceptID, err)
+		if err := rows.Scan(&conceptID, &vocab, &vocabVersion, &dim, &value, &nicheWidth, &nSystems, &resolution, &taxonomy); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning trait value for concepts %q: %w", conceptIDs, err)
 		}
 
-		key := traitSetKey{vocab: vocab, version: vocabVersion}
+		key := traitSetKey{concept: conceptID, vocab: vocab, version: vocabVersion}
 		set, ok := sets[key]
 		if !ok {
 			set = &domain.TraitSet{
@@ -88,27 +106,33 @@ func (db *DB) Traits(ctx context.Context, conceptID string, vocabs []domain.Trai
 		set.Values = append(set.Values, tv)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sqlite: iterating trait values for concept %q: %w", conceptID, err)
+		return nil, fmt.Errorf("sqlite: iterating trait values for concepts %q: %w", conceptIDs, err)
 	}
 
-	out := make([]domain.TraitSet, 0, len(order))
+	out := map[string][]domain.TraitSet{}
 	for _, key := range order {
-		out = append(out, *sets[key])
+		out[key.concept] = append(out[key.concept], *sets[key])
 	}
 	return out, nil
 }
 
-// traitsQuery builds the query+args Traits runs: every trait_value row for
-// conceptID (optionally restricted to vocabs), joined onto trait_vocabulary
-// for Taxonomy, ordered by vocab then dim so both the grouping above and the
-// caller-visible Values order are deterministic.
-func traitsQuery(conceptID string, vocabs []domain.TraitVocab) (string, []any) {
+// traitsQuery builds the query+args traitsOf runs: every trait_value row for
+// conceptIDs (optionally restricted to vocabs), joined onto trait_vocabulary
+// for Taxonomy, ordered by concept, vocab then dim so both the grouping above
+// and the caller-visible Values order are deterministic. The concept ids are
+// one json_each parameter, as in bundle.go; the vocab list stays a bounded
+// placeholder list.
+func traitsQuery(conceptIDs []string, vocabs []domain.TraitVocab) (string, []any, error) {
+	idsJSON, err := marshalIDs(conceptIDs)
+	if err != nil {
+		return "", nil, err
+	}
 	query := `
-		SELECT tv.vocab, tv.vocab_version, tv.dim, tv.value, tv.niche_width, tv.n_systems, tv.resolution, COALESCE(vc.taxonomy, '')
+		SELECT tv.concept_id, tv.vocab, tv.vocab_version, tv.dim, tv.value, tv.niche_width, tv.n_systems, tv.resolution, COALESCE(vc.taxonomy, '')
 		FROM trait_value tv
 		LEFT JOIN trait_vocabulary vc ON vc.vocab = tv.vocab AND vc.version = tv.vocab_version
-		WHERE tv.concept_id = ?`
-	args := []any{conceptID}
+		WHERE tv.concept_id IN (SELECT value FROM json_each(?))`
+	args := []any{idsJSON}
 	if len(vocabs) > 0 {
 		placeholders := placeholdersFor(len(vocabs))
 		query += ` AND tv.vocab IN (` + placeholders + `)`
@@ -116,8 +140,8 @@ func traitsQuery(conceptID string, vocabs []domain.TraitVocab) (string, []any) {
 			args = append(args, string(v))
 		}
 	}
-	query += ` ORDER BY tv.vocab, tv.vocab_version, tv.dim`
-	return query, args
+	query += ` ORDER BY tv.concept_id, tv.vocab, tv.vocab_version, tv.dim`
+	return query, args, nil
 }
 
 // conceptExists reports whether id is a known taxon_concept, so Traits can
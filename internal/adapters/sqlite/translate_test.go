@@ -10,6 +10,7 @@ import (
 	"github.com/jobrunner/hostus/internal/adapters/sqlite"
 	"github.com/jobrunner/hostus/internal/application"
 	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
 )
 
 // The three sec. spaces the chain fixture below uses. A/B/C are three
@@ -334,6 +335,50 @@ func readCDMFixture(t *testing.T) ([]application.CDMConceptRow, []application.CD
 	return concepts, relations
 }
 
+// TestUsageTargetsOverTheCommittedCDMFixture reads back both usage edges the
+// fixture carries — the misapplication ingest keeps out of concept_relation
+// and the pro-parte concept relation — and follows the first one end to end
+// from an "auct." verbatim.
+func TestUsageTargetsOverTheCommittedCDMFixture(t *testing.T) {
+	const (
+		pinusAbies  = "cdm:concept:122053a6-abb7-4d4c-9f87-b7b8f6d1afef"
+		abiesAlba   = "cdm:concept:872088a4-95f4-472c-ae79-a29028bb3fbf"
+		sisymbrium  = "cdm:concept:2a9439bf-0cd9-4d49-a140-7ee0e695de06"
+		austriacum  = "cdm:concept:b50fb70f-d829-4ef6-b454-11fa9a864836"
+		noUsageEdge = "cdm:concept:b7a352aa-1f73-41f3-a4e3-b24fc1c2cd5f"
+	)
+	db, _ := openTempDB(t)
+	concepts, relations := readCDMFixture(t)
+	if _, err := application.IngestCDM(context.Background(), db, concepts, relations, cdmBackbone()); err != nil {
+		t.Fatalf("IngestCDM: unexpected error: %v", err)
+	}
+
+	got, err := db.UsageTargets(context.Background(), []string{sisymbrium, noUsageEdge, pinusAbies})
+	if err != nil {
+		t.Fatalf("UsageTargets: %v", err)
+	}
+	want := []output.UsageTarget{
+		{FromID: pinusAbies, ToID: abiesAlba, ToName: "Abies alba", Relation: domain.RelationMisapplied},
+		{FromID: sisymbrium, ToID: austriacum, ToName: "Sisymbrium austriacum", Relation: domain.RelationProParte},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("UsageTargets = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("UsageTargets[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	results, err := application.MatchNames(context.Background(), db, []application.MatchRequest{{ID: "1", Verbatim: "Pinus abies auct."}})
+	if err != nil {
+		t.Fatalf("MatchNames: %v", err)
+	}
+	if r := results[0]; r.ConceptID != abiesAlba || r.MatchType != domain.MatchMisapplied {
+		t.Errorf("Pinus abies auct. = %+v, want misapplied onto %s", r, abiesAlba)
+	}
+}
+
 // fixtureRows splits a pipe-separated fixture (one header line) into its
 // data rows, insisting on the exact field count so a reshaped fixture fails
 // loudly instead of ingesting shifted columns.
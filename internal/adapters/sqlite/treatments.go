@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// treatmentLinksQuery gathers TreatmentLinks' three links of the concept
+// bound to every parameter but the last, which is domain.RelationCongruent.
+// ident is the concept's IPNI identity: its accepted name's ipni_id plus its
+// powo/ipni xref ext_ids. The second column orders the links of one
+// concept: 1 xref, 2 name, 3 congruent.
+const treatmentLinksQuery = `
+	WITH ident(ipni) AS (
+		SELECT n.ipni_id FROM taxon_concept s JOIN name n ON n.id = s.accepted_name
+		WHERE s.id = ?1 AND n.ipni_id <> ''
+		UNION
+		SELECT ext_id FROM xref WHERE concept_id = ?1 AND authority IN ('powo', 'ipni')
+	),
+	links(id, via, ord) AS (
+		SELECT tc.id, 'xref', 1 FROM ident
+		JOIN name n ON n.ipni_id = ident.ipni
+		JOIN taxon_concept tc ON tc.accepted_name = n.id
+		UNION
+		SELECT x.concept_id, 'xref', 1 FROM ident
+		JOIN xref x ON x.authority IN ('powo', 'ipni') AND x.ext_id = ident.ipni
+		UNION
+		SELECT tc.id, 'name', 2 FROM taxon_concept s
+		JOIN name sn ON sn.id = s.accepted_name
+		JOIN name n ON n.canonical_fold = sn.canonical_fold
+		JOIN taxon_concept tc ON tc.accepted_name = n.id
+		WHERE s.id = ?1 AND sn.canonical_fold <> ''
+		UNION
+		SELECT CASE WHEN cr.from_concept = ?1 THEN cr.to_concept ELSE cr.from_concept END, 'congruent', 3
+		FROM concept_relation cr
+		WHERE (cr.from_concept = ?1 OR cr.to_concept = ?1) AND cr.relation = ?2
+	)
+	SELECT id, via FROM links
+	WHERE id <> ?1
+	ORDER BY id, ord`
+
+// TreatmentLinks reads conceptID's one-hop treatment links. See
+// output.Repository.TreatmentLinks for the contract.
+func (db *DB) TreatmentLinks(ctx context.Context, conceptID string) ([]output.TreatmentLink, error) {
+	exists, err := db.conceptExists(ctx, conceptID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: checking concept %q: %w", conceptID, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("sqlite: concept %q: %w", conceptID, domain.ErrNotFound)
+	}
+
+	rows, err := db.sql.QueryContext(ctx, treatmentLinksQuery, conceptID, string(domain.RelationCongruent))
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying treatment links of concept %q: %w", conceptID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []output.TreatmentLink{}
+	for rows.Next() {
+		var id, via string
+		if err := rows.Scan(&id, &via); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning treatment link of concept %q: %w", conceptID, err)
+		}
+		if n := len(out); n > 0 && out[n-1].ConceptID == id {
+			out[n-1].Via = append(out[n-1].Via, output.TreatmentVia(via))
+			continue
+		}
+		out = append(out, output.TreatmentLink{ConceptID: id, Via: []output.TreatmentVia{output.TreatmentVia(via)}})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating treatment links of concept %q: %w", conceptID, err)
+	}
+	return out, nil
+}
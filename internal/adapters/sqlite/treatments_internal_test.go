@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// seedTreatments adds to seedNames' WCVP concept a powo xref and a sec-bearing
+// backbone with one treatment per link: cdm:concept:ipni accepts a name
+// carrying that IPNI id, cdm:concept:x a homonym of the accepted "Festuca x",
+// cdm:concept:z an unrelated name congruent to the WCVP concept — the edge
+// stored from the WCVP side — and cdm:concept:poa nothing linked at all.
+func seedTreatments(t *testing.T) *DB {
+	t.Helper()
+	db := seedNames(t)
+	bv := domain.BackboneVersion{ID: "wcvp", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "x"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		mustTx(t, tx.AddXref("wcvp:concept:festuca", domain.Xref{Authority: "powo", ExtID: "123-1"}, ""))
+	})
+	bv = domain.BackboneVersion{ID: "cdm", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "y"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		mustTx(t, tx.UpsertSecReference(domain.SecReference{ID: "sec-x", Title: "Fl. X"}))
+		for _, c := range []struct {
+			id   string
+			name domain.Name
+		}{
+			{"cdm:concept:ipni", domain.Name{ID: "n-ipni", Canonical: "Festuca w", Rank: domain.RankSpecies, IPNIID: "123-1"}},
+			{"cdm:concept:x", domain.Name{ID: "n-homonym", Canonical: "Festuca x", Authorship: "Hack.", Rank: domain.RankSpecies}},
+			{"cdm:concept:z", domain.Name{ID: "n-z", Canonical: "Festuca z", Rank: domain.RankSpecies}},
+			{"cdm:concept:poa", domain.Name{ID: "n-poa", Canonical: "Poa q", Rank: domain.RankSpecies}},
+		} {
+			mustTx(t, tx.UpsertName(c.name))
+			mustTx(t, tx.UpsertConcept(domain.Concept{ID: c.id, BackboneID: "cdm", AcceptedName: c.name, Rank: domain.RankSpecies, SecReference: "sec-x", Status: domain.StatusAccepted}))
+			mustTx(t, tx.LinkName(c.id, c.name.ID, "accepted", nil))
+		}
+		mustTx(t, tx.AddConceptRelation("wcvp:concept:festuca", "cdm:concept:z", domain.RelationCongruent, "cdm"))
+		mustTx(t, tx.AddConceptRelation("cdm:concept:poa", "wcvp:concept:festuca", domain.RelationIncludes, "cdm"))
+	})
+	return db
+}
+
+func treatmentLines(links []output.TreatmentLink) string {
+	lines := make([]string, len(links))
+	for i, l := range links {
+		lines[i] = fmt.Sprintf("%s%v", l.ConceptID, l.Via)
+	}
+	return strings.Join(lines, " ")
+}
+
+// TestTreatmentLinks_EachLinkInBothDirections pins every link from the WCVP
+// concept's side and back from each treatment's: the IPNI identity matches
+// the powo xref against the accepted name's ipni_id, the congruent edge is
+// found from its stored end and its other end, and the includes edge is not
+// a treatment.
+func TestTreatmentLinks_EachLinkInBothDirections(t *testing.T) {
+	db := seedTreatments(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ id, want string }{
+		{"wcvp:concept:festuca", "cdm:concept:ipni[xref] cdm:concept:x[name] cdm:concept:z[congruent]"},
+		{"cdm:concept:ipni", "wcvp:concept:festuca[xref]"},
+		{"cdm:concept:x", "wcvp:concept:festuca[name]"},
+		{"cdm:concept:z", "wcvp:concept:festuca[congruent]"},
+		{"cdm:concept:poa", ""},
+	} {
+		links, err := db.TreatmentLinks(ctx, tc.id)
+		mustTx(t, err)
+		if got := treatmentLines(links); got != tc.want {
+			t.Errorf("TreatmentLinks(%s) = %q, want %q", tc.id, got, tc.want)
+		}
+	}
+}
+
+// TestTreatmentLinks_OneEntryPerConcept pins that a concept found by several
+// links is listed once, its links in the order xref, name, congruent.
+func TestTreatmentLinks_OneEntryPerConcept(t *testing.T) {
+	db := seedTreatments(t)
+	bv := domain.BackboneVersion{ID: "cdm", Version: "v1", IngestedAt: "2026-08-14T00:00:00Z", ManifestSHA: "y"}
+	ingestVia(t, db, bv, func(tx output.IngestTx) {
+		mustTx(t, tx.AddConceptRelation("cdm:concept:x", "wcvp:concept:festuca", domain.RelationCongruent, "cdm"))
+		mustTx(t, tx.AddXref("cdm:concept:x", domain.Xref{Authority: "ipni", ExtID: "123-1"}, ""))
+	})
+
+	links, err := db.TreatmentLinks(context.Background(), "wcvp:concept:festuca")
+	mustTx(t, err)
+	if got, want := treatmentLines(links), "cdm:concept:ipni[xref] cdm:concept:x[xref name congruent] cdm:concept:z[congruent]"; got != want {
+		t.Errorf("TreatmentLinks = %q, want %q", got, want)
+	}
+}
+
+func TestTreatmentLinks_UnknownConceptIsNotFound(t *testing.T) {
+	db := seedTreatments(t)
+	if _, err := db.TreatmentLinks(context.Background(), "cdm:concept:nope"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("TreatmentLinks(unknown) error = %v, want domain.ErrNotFound", err)
+	}
+}
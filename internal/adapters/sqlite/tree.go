@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// treeFilter renders opts' rank and area filters as a WHERE fragment over the
+// taxon_concept alias tc, with its args in placeholder order. The area test
+// reads the concept's whole subtree through concept_lineage (depth 0 is the
+// concept itself), so a genus with no distribution rows of its own is in an
+// area when any species below it is; like Suggest's in_area it is positive
+// evidence only, via distribution_effective. Built with literal-format
+// Sprintf so gosec sees untainted SQL.
+func (db *DB) treeFilter(ctx context.Context, opts output.TreeOpts) (string, []any, error) {
+	var where strings.Builder
+	var args []any
+	if len(opts.Ranks) > 0 {
+		ph := strings.TrimSuffix(strings.Repeat("?,", len(opts.Ranks)), ",")
+		fmt.Fprintf(&where, " AND tc.rank IN (%s)", ph)
+		for _, r := range opts.Ranks {
+			args = append(args, string(r))
+		}
+	}
+	scheme, codes, err := db.areaFilter(ctx, opts.Area)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(codes) != 0 {
+		ph := strings.TrimSuffix(strings.Repeat("?,", len(codes)), ",")
+		fmt.Fprintf(&where, ` AND EXISTS (
+			SELECT 1 FROM concept_lineage sub
+			JOIN distribution_effective de ON de.concept_id = sub.concept_id
+			WHERE sub.ancestor_id = tc.id AND de.area_scheme = ? AND de.area_code IN (%s)
+		)`, ph)
+		args = append(args, scheme)
+		for _, c := range codes {
+			args = append(args, c)
+		}
+	}
+	return where.String(), args, nil
+}
+
+// Children lists conceptID's direct children. See the
+// output.Repository.Children doc comment for the contract. The children are
+// concept_lineage's depth-1 rows, so a database whose closure was never built
+// (one not re-ingested since it existed) reports every concept as a leaf.
+func (db *DB) Children(ctx context.Context, conceptID string, opts output.TreeOpts) ([]domain.TaxonNode, int, error) {
+	exists, err := db.conceptExists(ctx, conceptID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("sqlite: checking concept %q exists: %w", conceptID, err)
+	}
+	if !exists {
+		return nil, 0, fmt.Errorf("sqlite: concept %q: %w", conceptID, domain.ErrNotFound)
+	}
+	filter, filterArgs, err := db.treeFilter(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	args := append([]any{conceptID}, filterArgs...)
+	var total int
+	if err := db.sql.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM concept_lineage cl
+		JOIN taxon_concept tc ON tc.id = cl.concept_id
+		WHERE cl.ancestor_id = ? AND cl.depth = 1`+filter, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("sqlite: counting children of concept %q: %w", conceptID, err)
+	}
+
+	// LIMIT -1 is SQLite's "no limit", which OFFSET still needs beside it.
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT tc.id, an.canonical, tc.rank, tc.status,
+		       (SELECT COUNT(*) FROM concept_lineage k WHERE k.ancestor_id = tc.id AND k.depth = 1)
+		FROM concept_lineage cl
+		JOIN taxon_concept tc ON tc.id = cl.concept_id
+		JOIN name an ON an.id = tc.accepted_name
+		WHERE cl.ancestor_id = ? AND cl.depth = 1`+filter+`
+		ORDER BY an.canonical, tc.id
+		LIMIT ? OFFSET ?`, append(args, limit, max(opts.Offset, 0))...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("sqlite: listing children of concept %q: %w", conceptID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	nodes := []domain.TaxonNode{}
+	for rows.Next() {
+		var n domain.TaxonNode
+		var rank, status string
+		if err := rows.Scan(&n.ConceptID, &n.Canonical, &rank, &status, &n.ChildCount); err != nil {
+			return nil, 0, fmt.Errorf("sqlite: scanning child of concept %q: %w", conceptID, err)
+		}
+		r, err := domain.ParseRank(rank)
+		if err != nil {
+			return nil, 0, fmt.Errorf("sqlite: child %q of concept %q: %w", n.ConceptID, conceptID, err)
+		}
+		n.Rank = r
+		n.Status = domain.ParseStatus(status)
+		nodes = append(nodes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("sqlite: iterating children of concept %q: %w", conceptID, err)
+	}
+	return nodes, total, nil
+}
+
+// DescendantCounts counts conceptID's descendants per rank. See the
+// output.Repository.DescendantCounts doc comment for the contract. It is one
+// GROUP BY over the concept's concept_lineage rows — a family's whole subtree
+// without walking it level by level.
+func (db *DB) DescendantCounts(ctx context.Context, conceptID string, opts output.TreeOpts) (map[domain.Rank]int, error) {
+	exists, err := db.conceptExists(ctx, conceptID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: checking concept %q exists: %w", conceptID, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("sqlite: concept %q: %w", conceptID, domain.ErrNotFound)
+	}
+	filter, filterArgs, err := db.treeFilter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.sql.QueryContext(ctx, `
+		SELECT tc.rank, COUNT(*) FROM concept_lineage cl
+		JOIN taxon_concept tc ON tc.id = cl.concept_id
+		WHERE cl.ancestor_id = ? AND cl.depth > 0`+filter+`
+		GROUP BY tc.rank`, append([]any{conceptID}, filterArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: counting descendants of concept %q: %w", conceptID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := map[domain.Rank]int{}
+	for rows.Next() {
+		var rank string
+		var n int
+		if err := rows.Scan(&rank, &n); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning descendant count of concept %q: %w", conceptID, err)
+		}
+		r, err := domain.ParseRank(rank)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: descendant count of concept %q: %w", conceptID, err)
+		}
+		counts[r] += n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite: iterating descendant counts of concept %q: %w", conceptID, err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// seedTree writes seedInfraspecificTree and builds both derived tables the
+// tree queries read, as an ingest would.
+func seedTree(t *testing.T) *DB {
+	t.Helper()
+	db := openTestDB(t)
+	seedInfraspecificTree(t, db)
+	ctx := context.Background()
+	mustTx(t, db.BuildDistributionClosure(ctx))
+	mustTx(t, db.BuildLineageClosure(ctx))
+	return db
+}
+
+// childIDs renders nodes as "id/child_count", the "wcvp:concept:" prefix
+// stripped.
+func childIDs(nodes []domain.TaxonNode) string {
+	out := make([]string, len(nodes))
+	for i, n := range nodes {
+		out[i] = strings.TrimPrefix(n.ConceptID, "wcvp:concept:") + "/" + string(rune('0'+n.ChildCount))
+	}
+	return strings.Join(out, ",")
+}
+
+// TestChildren_OrdersPagesAndFilters pins the children contract: canonical
+// order, a total that ignores paging, the rank filter, and the area filter
+// reaching through the subtree — the genus's species are in AUT only via a
+// subspecies, and the section's own ITA row keeps it.
+func TestChildren_OrdersPagesAndFilters(t *testing.T) {
+	db := seedTree(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name      string
+		opts      output.TreeOpts
+		want      string
+		wantTotal int
+	}{
+		{"all", output.TreeOpts{}, "sp/2,sp2/1,sect/0", 3},
+		{"page", output.TreeOpts{Limit: 1, Offset: 1}, "sp2/1", 3},
+		{"past the end", output.TreeOpts{Limit: 5, Offset: 3}, "", 3},
+		{"rank", output.TreeOpts{Ranks: []domain.Rank{domain.RankSpecies}}, "sp/2,sp2/1", 2},
+		{"area via descendant", output.TreeOpts{Area: "AUT"}, "sp/2", 1},
+		{"area own row", output.TreeOpts{Area: "ITA"}, "sect/0", 1},
+	} {
+		nodes, total, err := db.Children(ctx, "wcvp:concept:g", tc.opts)
+		mustTx(t, err)
+		if got := childIDs(nodes); got != tc.want || total != tc.wantTotal {
+			t.Errorf("%s: Children = %q (total %d), want %q (total %d)", tc.name, got, total, tc.want, tc.wantTotal)
+		}
+	}
+
+	nodes, total, err := db.Children(ctx, "wcvp:concept:var", output.TreeOpts{})
+	mustTx(t, err)
+	if nodes == nil || len(nodes) != 0 || total != 0 {
+		t.Errorf("Children(leaf) = %v (total %d), want an empty non-nil slice", nodes, total)
+	}
+}
+
+func TestDescendantCounts_CountsEveryDepthPerRank(t *testing.T) {
+	db := seedTree(t)
+	ctx := context.Background()
+
+	got, err := db.DescendantCounts(ctx, "wcvp:concept:g", output.TreeOpts{})
+	mustTx(t, err)
+	want := map[domain.Rank]int{
+		domain.RankOther: 1, domain.RankSpecies: 2, domain.RankSubspecies: 3, domain.RankVariety: 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DescendantCounts(g) = %v, want %v", got, want)
+	}
+
+	got, err = db.DescendantCounts(ctx, "wcvp:concept:g", output.TreeOpts{Area: "SWI"})
+	mustTx(t, err)
+	if want := (map[domain.Rank]int{domain.RankSpecies: 1, domain.RankSubspecies: 1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("DescendantCounts(g, area=SWI) = %v, want %v", got, want)
+	}
+}
+
+func TestTree_UnknownConceptIsNotFound(t *testing.T) {
+	db := seedTree(t)
+	ctx := context.Background()
+
+	if _, _, err := db.Children(ctx, "wcvp:concept:nope", output.TreeOpts{}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Children(unknown) err = %v, want ErrNotFound", err)
+	}
+	if _, err := db.DescendantCounts(ctx, "wcvp:concept:nope", output.TreeOpts{}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("DescendantCounts(unknown) err = %v, want ErrNotFound", err)
+	}
+}
@@ -69,6 +69,23 @@ func (t TaxonRow) POWOID() string {
 	return props.POWOID
 }
 
+// HybridFormula returns the "hybridformula" field of DynamicProperties —
+// for a nothotaxon the parents it arose from ("Salix alba × Salix
+// fragilis"), "" for every other row. Like POWOID it returns "" when
+// DynamicProperties is empty or not valid JSON.
+func (t TaxonRow) HybridFormula() string {
+	if t.DynamicProperties == "" {
+		return ""
+	}
+	var props struct {
+		HybridFormula string `json:"hybridformula"`
+	}
+	if err := json.Unmarshal([]byte(t.DynamicProperties), &props); err != nil {
+		return ""
+	}
+	return props.HybridFormula
+}
+
 // DistributionRow is one row of wcvp_distribution.csv (DwC-A extension,
 // GBIF Distribution rowType), joined to TaxonRow via CoreID == TaxonID.
 type DistributionRow struct {
@@ -86,6 +103,13 @@ func (d DistributionRow) AreaCode() string {
 	return strings.TrimPrefix(d.LocationID, "TDWG:")
 }
 
+// Introduced reports whether the row records the taxon as introduced to the
+// area (establishmentmeans "introduced"); WCVP leaves the column empty for
+// a native occurrence.
+func (d DistributionRow) Introduced() bool {
+	return strings.EqualFold(strings.TrimSpace(d.EstablishmentMeans), "introduced")
+}
+
 // ReplacementRow is one row of wcvp_replacementNames.csv (DwC-A extension;
 // its rowType is a ColDP NameRelation term despite the DwC-A container).
 type ReplacementRow struct {
@@ -74,6 +74,21 @@ func TestRead_AcceptedTaxon(t *testing.T) {
 	}
 }
 
+// TestTaxonRow_HybridFormula reads the formula out of dynamicproperties; the
+// sample carries it empty on every row, as WCVP does for non-hybrids.
+func TestTaxonRow_HybridFormula(t *testing.T) {
+	for _, tc := range []struct{ props, want string }{
+		{`{"powoid":"776300-1","hybridformula":"Salix alba × Salix fragilis"}`, "Salix alba × Salix fragilis"},
+		{`{"powoid":"396681-1","hybridformula":""}`, ""},
+		{`{"powoid":"396681-1"}`, ""},
+		{"", ""},
+	} {
+		if got := (wcvp.TaxonRow{DynamicProperties: tc.props}).HybridFormula(); got != tc.want {
+			t.Errorf("HybridFormula(%s) = %q, want %q", tc.props, got, tc.want)
+		}
+	}
+}
+
 func TestRead_SynonymTaxon(t *testing.T) {
 	ds := loadFixture(t)
 	synonym := findTaxon(t, ds, "543929")
@@ -111,6 +126,21 @@ func TestRead_DistributionAreaCode(t *testing.T) {
 	}
 }
 
+func TestDistributionRow_Introduced(t *testing.T) {
+	for _, tc := range []struct {
+		means string
+		want  bool
+	}{
+		{"introduced", true},
+		{" Introduced ", true},
+		{"", false},
+	} {
+		if got := (wcvp.DistributionRow{EstablishmentMeans: tc.means}).Introduced(); got != tc.want {
+			t.Errorf("Introduced(%q) = %v, want %v", tc.means, got, tc.want)
+		}
+	}
+}
+
 func TestRead_MissingDirectory(t *testing.T) {
 	if _, err := wcvp.Read("testdata/does-not-exist"); err == nil {
 		t.Fatal("Read(missing dir): expected error, got nil")
@@ -0,0 +1,79 @@
+package wgsrpd
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// aliasTable is the ISO 3166 alias table, one "alias|code[,code...]" line
+// per alias. Unlike hierarchy.txt it is curated by hand: ISO codes name
+// political units, and which WGSRPD areas cover one is a judgement the TDWG
+// tables do not record.
+//
+//go:embed aliases.txt
+var aliasTable []byte
+
+// Aliases parses the embedded alias table against units, the hierarchy it
+// points into (see ParseAliases).
+func Aliases(units []domain.Area) ([]domain.AreaAlias, error) {
+	return ParseAliases(bytes.NewReader(aliasTable), units)
+}
+
+// ParseAliases reads an alias table: pipe-separated alias and a
+// comma-separated list of WGSRPD codes, blank lines and "#" comments
+// ignored. Aliases are upper-cased, as an area filter value is before it is
+// looked up. Every code must be one of units, and no alias may itself be a
+// unit code: a raw WGSRPD code always means that unit, so an alias
+// shadowing one ("COL" for Colombia over Colorado) could never be reached
+// and is an error rather than a silent no-op. The result keeps the table's
+// order.
+func ParseAliases(r io.Reader, units []domain.Area) ([]domain.AreaAlias, error) {
+	known := make(map[string]bool, len(units))
+	for _, u := range units {
+		known[u.Code] = true
+	}
+	var aliases []domain.AreaAlias
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("wgsrpd: alias line %d: %d fields, want alias|codes", lineNo, len(fields))
+		}
+		a := domain.AreaAlias{Alias: strings.ToUpper(strings.TrimSpace(fields[0]))}
+		if a.Alias == "" {
+			return nil, fmt.Errorf("wgsrpd: alias line %d: empty alias", lineNo)
+		}
+		if known[a.Alias] {
+			return nil, fmt.Errorf("wgsrpd: alias line %d: alias %q is a WGSRPD code itself", lineNo, a.Alias)
+		}
+		if seen[a.Alias] {
+			return nil, fmt.Errorf("wgsrpd: alias line %d: duplicate alias %q", lineNo, a.Alias)
+		}
+		seen[a.Alias] = true
+		for _, code := range strings.Split(fields[1], ",") {
+			code = strings.TrimSpace(code)
+			if !known[code] {
+				return nil, fmt.Errorf("wgsrpd: alias line %d: alias %q names %q, which is not a WGSRPD unit", lineNo, a.Alias, code)
+			}
+			a.Codes = append(a.Codes, code)
+		}
+		aliases = append(aliases, a)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("wgsrpd: reading aliases: %w", err)
+	}
+	return aliases, nil
+}
@@ -0,0 +1,91 @@
+package wgsrpd_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/wgsrpd"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+var aliasUnits = []domain.Area{
+	{Scheme: domain.AreaSchemeWGSRPDL1, Code: "1", Name: "Europe"},
+	{Scheme: domain.AreaSchemeWGSRPDL2, Code: "12", Name: "Southwestern Europe", Parent: "1"},
+	{Scheme: domain.AreaSchemeWGSRPDL3, Code: "SPA", Name: "Spain", Parent: "12"},
+	{Scheme: domain.AreaSchemeWGSRPDL3, Code: "BAL", Name: "Baleares", Parent: "12"},
+}
+
+func TestParseAliases_ReadsCodeLists(t *testing.T) {
+	doc := `# comment
+es|SPA, BAL
+
+ES-IB|BAL
+`
+	aliases, err := wgsrpd.ParseAliases(strings.NewReader(doc), aliasUnits)
+	if err != nil {
+		t.Fatalf("ParseAliases: unexpected error: %v", err)
+	}
+	if len(aliases) != 2 {
+		t.Fatalf("aliases = %+v, want 2", aliases)
+	}
+	if a := aliases[0]; a.Alias != "ES" || strings.Join(a.Codes, ",") != "SPA,BAL" {
+		t.Errorf("aliases[0] = %+v, want ES -> SPA,BAL", a)
+	}
+	if a := aliases[1]; a.Alias != "ES-IB" || strings.Join(a.Codes, ",") != "BAL" {
+		t.Errorf("aliases[1] = %+v, want ES-IB -> BAL", a)
+	}
+}
+
+func TestParseAliases_RejectsBrokenTables(t *testing.T) {
+	cases := map[string]string{
+		"too few fields":   "ES\n",
+		"empty alias":      "|SPA\n",
+		"unknown code":     "ES|XYZ\n",
+		"empty code":       "ES|SPA,\n",
+		"shadows a code":   "BAL|SPA\n",
+		"duplicate alias":  "ES|SPA\nes|BAL\n",
+		"shadows a region": "12|SPA\n",
+	}
+	for name, doc := range cases {
+		if _, err := wgsrpd.ParseAliases(strings.NewReader(doc), aliasUnits); err == nil {
+			t.Errorf("%s: ParseAliases succeeded, want an error", name)
+		}
+	}
+}
+
+// TestAliases_EmbeddedTableCoversISO guards the checked-in table against the
+// embedded hierarchy and spot-checks the cases it exists for: the
+// alpha-2/alpha-3 pair, a country spanning several areas, a subdivision,
+// and an alpha-3 code left to the WGSRPD unit it collides with.
+func TestAliases_EmbeddedTableCoversISO(t *testing.T) {
+	units, err := wgsrpd.Hierarchy()
+	if err != nil {
+		t.Fatalf("Hierarchy: %v", err)
+	}
+	aliases, err := wgsrpd.Aliases(units)
+	if err != nil {
+		t.Fatalf("Aliases: %v", err)
+	}
+	byAlias := make(map[string]string, len(aliases))
+	for _, a := range aliases {
+		byAlias[a.Alias] = strings.Join(a.Codes, ",")
+	}
+	want := map[string]string{
+		"DE":    "GER",
+		"DEU":   "GER",
+		"CH":    "SWI",
+		"PL":    "POL",
+		"ES":    "SPA,BAL,CNY",
+		"DE-BY": "GER",
+		"BR":    "84",
+		"CO":    "CLM",
+	}
+	for alias, codes := range want {
+		if got := byAlias[alias]; got != codes {
+			t.Errorf("%s -> %q, want %q", alias, got, codes)
+		}
+	}
+	if got, ok := byAlias["COL"]; ok {
+		t.Errorf("COL -> %q, want no alias (it is the WGSRPD code for Colorado)", got)
+	}
+}
@@ -0,0 +1,89 @@
+package wgsrpd
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// hierarchyTable is the WGSRPD unit table, one "level|code|name|parent" line
+// per unit. The checked-in copy is a transcription of levels 1-3;
+// pipelines/wgsrpd/build.sh regenerates it from the pinned TDWG tables,
+// level 4 included.
+//
+//go:embed hierarchy.txt
+var hierarchyTable []byte
+
+// Hierarchy parses the embedded unit table.
+func Hierarchy() ([]domain.Area, error) {
+	return ParseHierarchy(bytes.NewReader(hierarchyTable))
+}
+
+// ParseHierarchy reads a unit table: pipe-separated level, code, name and
+// parent code, blank lines and "#" comments ignored. Every unit above level
+// 1 must name a parent that exists one level up, and level 1 must name none
+// — a dangling parent would silently drop a whole subtree from every
+// roll-up, so it is an error here rather than at query time. The result is
+// ordered by level, then code.
+func ParseHierarchy(r io.Reader) ([]domain.Area, error) {
+	var units []domain.Area
+	seen := make(map[string]bool) // scheme + "|" + code
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("wgsrpd: hierarchy line %d: %d fields, want level|code|name|parent", lineNo, len(fields))
+		}
+		level, err := strconv.Atoi(fields[0])
+		scheme := domain.WGSRPDScheme(level)
+		if err != nil || scheme == "" {
+			return nil, fmt.Errorf("wgsrpd: hierarchy line %d: level %q, want 1-4", lineNo, fields[0])
+		}
+		u := domain.Area{
+			Scheme: scheme,
+			Code:   strings.TrimSpace(fields[1]),
+			Name:   strings.TrimSpace(fields[2]),
+			Parent: strings.TrimSpace(fields[3]),
+		}
+		if u.Code == "" || u.Name == "" {
+			return nil, fmt.Errorf("wgsrpd: hierarchy line %d: unit without code or name", lineNo)
+		}
+		if (level == 1) != (u.Parent == "") {
+			return nil, fmt.Errorf("wgsrpd: hierarchy line %d: unit %q: only level 1 has no parent", lineNo, u.Code)
+		}
+		key := u.Scheme + "|" + u.Code
+		if seen[key] {
+			return nil, fmt.Errorf("wgsrpd: hierarchy line %d: duplicate unit %q", lineNo, u.Code)
+		}
+		seen[key] = true
+		units = append(units, u)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("wgsrpd: reading hierarchy: %w", err)
+	}
+	for _, u := range units {
+		if u.Parent != "" && !seen[domain.WGSRPDParentScheme(u.Scheme)+"|"+u.Parent] {
+			return nil, fmt.Errorf("wgsrpd: hierarchy: unit %q names parent %q, which is not a level-%d unit", u.Code, u.Parent, u.Level()-1)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		if li, lj := units[i].Level(), units[j].Level(); li != lj {
+			return li < lj
+		}
+		return units[i].Code < units[j].Code
+	})
+	return units, nil
+}
@@ -0,0 +1,81 @@
+package wgsrpd_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/wgsrpd"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+func TestParseHierarchy_OrdersByLevelThenCode(t *testing.T) {
+	doc := `# comment
+3|GER|Germany|11
+3|AUT|Austria|11
+
+2|11|Middle Europe|1
+1|1|Europe|
+4|GER-OO|Germany|GER
+`
+	units, err := wgsrpd.ParseHierarchy(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ParseHierarchy: unexpected error: %v", err)
+	}
+	want := []domain.Area{
+		{Scheme: domain.AreaSchemeWGSRPDL1, Code: "1", Name: "Europe"},
+		{Scheme: domain.AreaSchemeWGSRPDL2, Code: "11", Name: "Middle Europe", Parent: "1"},
+		{Scheme: domain.AreaSchemeWGSRPDL3, Code: "AUT", Name: "Austria", Parent: "11"},
+		{Scheme: domain.AreaSchemeWGSRPDL3, Code: "GER", Name: "Germany", Parent: "11"},
+		{Scheme: domain.AreaSchemeWGSRPDL4, Code: "GER-OO", Name: "Germany", Parent: "GER"},
+	}
+	if len(units) != len(want) {
+		t.Fatalf("units = %+v, want %+v", units, want)
+	}
+	for i := range want {
+		if units[i] != want[i] {
+			t.Errorf("units[%d] = %+v, want %+v", i, units[i], want[i])
+		}
+	}
+}
+
+func TestParseHierarchy_RejectsBrokenTables(t *testing.T) {
+	cases := map[string]string{
+		"too few fields":        "1|1|Europe\n",
+		"level out of range":    "5|X|X|1\n",
+		"no name":               "1|1||\n",
+		"level 1 with parent":   "1|1|Europe|0\n",
+		"level 2 without":       "2|11|Middle Europe|\n",
+		"duplicate unit":        "1|1|Europe|\n1|1|Europe|\n",
+		"dangling parent":       "1|1|Europe|\n2|11|Middle Europe|2\n",
+		"parent at wrong level": "1|1|Europe|\n2|11|Middle Europe|1\n3|GER|Germany|1\n",
+	}
+	for name, doc := range cases {
+		if _, err := wgsrpd.ParseHierarchy(strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: ParseHierarchy succeeded, want an error", name)
+		}
+	}
+}
+
+// TestHierarchy_EmbeddedTableIsComplete guards the checked-in table: it
+// parses (every parent resolves) and spans the nine continents, with
+// Germany under Middle Europe under Europe.
+func TestHierarchy_EmbeddedTableIsComplete(t *testing.T) {
+	units, err := wgsrpd.Hierarchy()
+	if err != nil {
+		t.Fatalf("Hierarchy: %v", err)
+	}
+	byCode := make(map[string]domain.Area, len(units))
+	level1 := 0
+	for _, u := range units {
+		byCode[u.Code] = u
+		if u.Level() == 1 {
+			level1++
+		}
+	}
+	if level1 != 9 {
+		t.Errorf("level-1 units = %d, want 9", level1)
+	}
+	if ger := byCode["GER"]; ger.Parent != "11" || byCode["11"].Parent != "1" {
+		t.Errorf("GER = %+v, 11 = %+v; want GER -> 11 -> 1", ger, byCode["11"])
+	}
+}
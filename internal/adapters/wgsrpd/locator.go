@@ -0,0 +1,197 @@
+// Package wgsrpd locates positions in the WGSRPD level-3 areas ("botanical
+// countries") from simplified geometries embedded in the binary. The asset,
+// level3.geojson, is generated ahead of time by pipelines/wgsrpd/build.sh
+// from the TDWG shapes (see pipelines/README.md for the contract); hostus
+// never touches the network for it at runtime.
+package wgsrpd
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// level3GeoJSON is the generated asset. A checkout that has not run the
+// pipeline carries an empty FeatureCollection here, which Load accepts: the
+// resulting Locator has Len 0 and the server reports position lookups as
+// unavailable instead of failing to start.
+//
+//go:embed level3.geojson
+var level3GeoJSON []byte
+
+// SnapDegrees is how far outside every area a position may lie and still be
+// placed in the nearest one. It equals the Douglas-Peucker tolerance the
+// pipeline simplifies with (pipelines/wgsrpd/convert.py TOLERANCE): a
+// simplified coastline is at most that far from the real one, so a phone on
+// a beach or in a harbour is still placed on land, while a ship at sea is
+// not.
+const SnapDegrees = 0.01
+
+// area is one level-3 feature with its precomputed bounding box.
+type area struct {
+	code, name     string
+	level2, level1 string
+	shape          domain.MultiPolygon
+	sw, ne         domain.Point
+}
+
+// Locator is an output.AreaLocator over a fixed set of level-3 areas. It is
+// immutable after Parse and safe for concurrent use.
+type Locator struct {
+	areas []area
+}
+
+// Load parses the embedded asset.
+func Load() (*Locator, error) {
+	return Parse(bytes.NewReader(level3GeoJSON))
+}
+
+// featureCollection is the asset's shape: a GeoJSON FeatureCollection whose
+// features carry the level-3 code and name and the codes of the level-2 and
+// level-1 units containing it — the pipeline renames TDWG's LEVEL3_COD,
+// LEVEL3_NAM, LEVEL2_COD and LEVEL1_COD to these and turns the numeric codes
+// into strings.
+type featureCollection struct {
+	Type     string `json:"type"`
+	Features []struct {
+		Properties struct {
+			Code   string `json:"code"`
+			Name   string `json:"name"`
+			Level2 string `json:"level2"`
+			Level1 string `json:"level1"`
+		} `json:"properties"`
+		Geometry struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"features"`
+}
+
+// Parse reads a level-3 FeatureCollection. Polygon and MultiPolygon
+// geometries are accepted; a feature without a code, or with any other
+// geometry type, is an error rather than a silently missing area.
+func Parse(r io.Reader) (*Locator, error) {
+	var fc featureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, fmt.Errorf("wgsrpd: decoding geometries: %w", err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("wgsrpd: geometries are a %q, want a FeatureCollection", fc.Type)
+	}
+	loc := &Locator{areas: make([]area, 0, len(fc.Features))}
+	for i, f := range fc.Features {
+		p := f.Properties
+		if p.Code == "" {
+			return nil, fmt.Errorf("wgsrpd: feature %d has no code", i)
+		}
+		shape, err := decodeShape(f.Geometry.Type, f.Geometry.Coordinates)
+		if err != nil {
+			return nil, fmt.Errorf("wgsrpd: area %q: %w", p.Code, err)
+		}
+		sw, ne := shape.Bounds()
+		loc.areas = append(loc.areas, area{
+			code: p.Code, name: p.Name, level2: p.Level2, level1: p.Level1,
+			shape: shape, sw: sw, ne: ne,
+		})
+	}
+	// Code order makes Locate's tie-break deterministic regardless of the
+	// order the pipeline wrote the features in.
+	sort.Slice(loc.areas, func(i, j int) bool { return loc.areas[i].code < loc.areas[j].code })
+	return loc, nil
+}
+
+// decodeShape turns GeoJSON coordinates ([lon, lat] pairs) into a
+// domain.MultiPolygon.
+func decodeShape(typ string, raw json.RawMessage) (domain.MultiPolygon, error) {
+	var polys [][][][2]float64
+	switch typ {
+	case "Polygon":
+		var poly [][][2]float64
+		if err := json.Unmarshal(raw, &poly); err != nil {
+			return nil, fmt.Errorf("decoding Polygon: %w", err)
+		}
+		polys = [][][][2]float64{poly}
+	case "MultiPolygon":
+		if err := json.Unmarshal(raw, &polys); err != nil {
+			return nil, fmt.Errorf("decoding MultiPolygon: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", typ)
+	}
+	shape := make(domain.MultiPolygon, 0, len(polys))
+	for _, poly := range polys {
+		rings := make(domain.Polygon, 0, len(poly))
+		for _, ring := range poly {
+			if len(ring) < 3 {
+				return nil, fmt.Errorf("ring with %d vertices", len(ring))
+			}
+			pts := make(domain.Ring, len(ring))
+			for i, c := range ring {
+				pts[i] = domain.Point{Lon: c[0], Lat: c[1]}
+			}
+			rings = append(rings, pts)
+		}
+		shape = append(shape, rings)
+	}
+	return shape, nil
+}
+
+// Len reports how many level-3 areas are loaded; 0 means the asset was never
+// generated.
+func (l *Locator) Len() int {
+	return len(l.areas)
+}
+
+// Locate implements output.AreaLocator. Simplified neighbours overlap by up
+// to SnapDegrees, so a point near a border can lie in two areas; the one it
+// lies deeper in wins (largest distance to its boundary). Outside every area,
+// the nearest one within SnapDegrees is taken. Ties go to the lower code.
+func (l *Locator) Locate(p domain.Point) []domain.Area {
+	var best *area
+	bestInside := false
+	bestDist := 0.0
+	for i := range l.areas {
+		a := &l.areas[i]
+		if p.Lat < a.sw.Lat-SnapDegrees || p.Lat > a.ne.Lat+SnapDegrees ||
+			p.Lon < a.sw.Lon-SnapDegrees || p.Lon > a.ne.Lon+SnapDegrees {
+			continue
+		}
+		inside := a.shape.Contains(p)
+		dist := a.shape.BoundaryDistance(p)
+		if !inside && dist > SnapDegrees {
+			continue
+		}
+		if best == nil || better(inside, dist, bestInside, bestDist) {
+			best, bestInside, bestDist = a, inside, dist
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	var out []domain.Area
+	if best.level1 != "" {
+		out = append(out, domain.Area{Scheme: domain.AreaSchemeWGSRPDL1, Code: best.level1})
+	}
+	if best.level2 != "" {
+		out = append(out, domain.Area{Scheme: domain.AreaSchemeWGSRPDL2, Code: best.level2})
+	}
+	return append(out, domain.Area{Scheme: domain.AreaSchemeWGSRPDL3, Code: best.code, Name: best.name})
+}
+
+// better reports whether a candidate (inside, dist) beats the current best:
+// containing beats snapping; among containing areas the deeper one wins,
+// among snapped ones the nearer.
+func better(inside bool, dist float64, bestInside bool, bestDist float64) bool {
+	if inside != bestInside {
+		return inside
+	}
+	if inside {
+		return dist > bestDist
+	}
+	return dist < bestDist
+}
@@ -13,6 +13,7 @@ import (
 	httpx "github.com/jobrunner/hostus/internal/adapters/http"
 	"github.com/jobrunner/hostus/internal/adapters/sqlite"
 	"github.com/jobrunner/hostus/internal/adapters/telemetry"
+	"github.com/jobrunner/hostus/internal/adapters/wgsrpd"
 	"github.com/jobrunner/hostus/internal/config"
 	"github.com/jobrunner/hostus/internal/ports/output"
 )
@@ -116,8 +117,11 @@ func New(cfg *config.Config, opts ...Option) (*App, error) {
 		Logger:             logger,
 		CORSAllowedOrigins: cfg.CORS.AllowedOrigins,
 		Repo:               repo,
+		Locator:            loadLocator(logger),
 		UIEnabled:          cfg.UI.Enabled,
 		Version:            o.version,
+
+		SuggestPopularityWeight: cfg.Suggest.PopularityWeight,
 	})
 
 	return &App{
@@ -131,6 +135,23 @@ func New(cfg *config.Config, opts ...Option) (*App, error) {
 	}, nil
 }
 
+// loadLocator parses the embedded WGSRPD geometries. Like openRepo it
+// degrades instead of failing New: with no usable asset (a build that never
+// ran pipelines/wgsrpd, or a broken one) it logs why and returns nil, and the
+// router answers position lookups with 503 while everything else is served.
+func loadLocator(logger *slog.Logger) output.AreaLocator {
+	loc, err := wgsrpd.Load()
+	if err != nil {
+		logger.Warn("loading WGSRPD geometries; lat/lon lookups will be unavailable", "error", err)
+		return nil
+	}
+	if loc.Len() == 0 {
+		logger.Warn("no WGSRPD geometries embedded (run pipelines/wgsrpd/build.sh before building); lat/lon lookups will be unavailable")
+		return nil
+	}
+	return loc
+}
+
 // openRepo opens cfg.SQLite.Path as the output.Repository the HTTP router
 // serves reads from. An empty path or an open failure degrades to (nil,
 // nil) rather than failing New outright: `hostus serve` must still start
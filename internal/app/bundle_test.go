@@ -33,7 +33,7 @@ func TestBundle_ExportsFromIngestedDatabase(t *testing.T) {
 	dbPath := filepath.Join(dir, "hostus.sqlite")
 	outPath := filepath.Join(dir, "bundle.sqlite")
 
-	if _, err := app.Ingest(ctx, "testdata/dataset-no-namespace.yaml", dbPath); err != nil {
+	if _, err := app.Ingest(ctx, "testdata/dataset-no-namespace.yaml", dbPath, app.IngestOpts{}); err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
 
@@ -84,7 +84,7 @@ func TestBundle_RefusesNameSpaceByDefault(t *testing.T) {
 	dir := t.TempDir()
 	dbPath := filepath.Join(dir, "hostus.sqlite")
 
-	if _, err := app.Ingest(ctx, "testdata/dataset.yaml", dbPath); err != nil {
+	if _, err := app.Ingest(ctx, "testdata/dataset.yaml", dbPath, app.IngestOpts{}); err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
 
@@ -16,7 +16,7 @@ import (
 // are the fixture's, and the full-scale run is Task 5's business.
 func TestIngestCDMFixtureEndToEnd(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
-	reports, err := app.Ingest(context.Background(), "testdata/dataset-cdm.yaml", dbPath)
+	reports, err := app.Ingest(context.Background(), "testdata/dataset-cdm.yaml", dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("Ingest: unexpected error: %v", err)
 	}
@@ -85,7 +85,7 @@ func assertCDMRelationTypes(t *testing.T, perType map[string]int) {
 
 func TestIngestCDMFailsOnAnUnreadableConceptCSV(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
-	if _, err := app.Ingest(context.Background(), "testdata/dataset-cdm-bad-path.yaml", dbPath); err == nil {
+	if _, err := app.Ingest(context.Background(), "testdata/dataset-cdm-bad-path.yaml", dbPath, app.IngestOpts{}); err == nil {
 		t.Fatal("want an error for a concept source whose CSV does not exist")
 	}
 }
@@ -96,7 +96,7 @@ func TestIngestCDMSurfacesReaderErrorsWithoutAborting(t *testing.T) {
 	// The count sums BOTH readers — a concepts-side error and a
 	// relations-side one.
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
-	reports, err := app.Ingest(context.Background(), "testdata/dataset-cdm-broken.yaml", dbPath)
+	reports, err := app.Ingest(context.Background(), "testdata/dataset-cdm-broken.yaml", dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("Ingest must not fail on malformed rows: %v", err)
 	}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/application"
+)
+
+// Checklist opens the SQLite database at dbPath and pages through the area
+// checklist req describes, calling visit once per page in order; req.Cursor
+// is where the first page starts. It is the entry point "hostus checklist"
+// calls, and reads the same pages GET /v1/checklist serves.
+func Checklist(ctx context.Context, dbPath string, req application.ChecklistRequest, visit func(application.ChecklistResult) error) error {
+	db, err := sqlite.Open(dbPath)
+	if err != nil {
+		return fmt.Errorf("app: opening database %q: %w", dbPath, err)
+	}
+	defer func() { _ = db.Close() }()
+
+	for {
+		page, err := application.Checklist(ctx, db, req)
+		if err != nil {
+			return err
+		}
+		if err := visit(page); err != nil {
+			return err
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		req.Cursor = page.NextCursor
+	}
+}
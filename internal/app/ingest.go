@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/jobrunner/hostus/internal/adapters/cdm"
+	"github.com/jobrunner/hostus/internal/adapters/distribution"
+	"github.com/jobrunner/hostus/internal/adapters/frequency"
 	"github.com/jobrunner/hostus/internal/adapters/manifest"
 	"github.com/jobrunner/hostus/internal/adapters/namelist"
 	"github.com/jobrunner/hostus/internal/adapters/sqlite"
 	"github.com/jobrunner/hostus/internal/adapters/traits"
 	"github.com/jobrunner/hostus/internal/adapters/wcvp"
+	"github.com/jobrunner/hostus/internal/adapters/wgsrpd"
 	"github.com/jobrunner/hostus/internal/adapters/xref"
 	"github.com/jobrunner/hostus/internal/application"
 	"github.com/jobrunner/hostus/internal/domain"
@@ -73,6 +76,8 @@ func (s wcvpRowSource) Taxa() []application.TaxonRow {
 			BasionymTaxonID: t.OriginalNameUsageID,
 			PublishedIn:     t.PublishedIn,
 			NomStatus:       t.NomenclaturalStatus,
+			Family:          t.Family,
+			HybridFormula:   t.HybridFormula(),
 		})
 	}
 	return out
@@ -81,7 +86,7 @@ func (s wcvpRowSource) Taxa() []application.TaxonRow {
 func (s wcvpRowSource) Distributions() []application.DistributionRow {
 	out := make([]application.DistributionRow, 0, len(s.ds.Distributions))
 	for _, d := range s.ds.Distributions {
-		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode(), AreaName: d.Locality})
+		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode(), AreaName: d.Locality, Introduced: d.Introduced()})
 	}
 	return out
 }
@@ -244,6 +249,94 @@ func ingestNameSpace(ctx context.Context, ns manifest.NameSpace, manifestSHA str
 	return report, err
 }
 
+// checklistRowSource adapts a *distribution.Dataset into
+// application.ChecklistRowSource, so application never imports
+// internal/adapters/distribution directly (depguard).
+type checklistRowSource struct{ ds *distribution.Dataset }
+
+func (s checklistRowSource) Rows() []application.ChecklistRow {
+	out := make([]application.ChecklistRow, 0, len(s.ds.Rows))
+	for _, r := range s.ds.Rows {
+		out = append(out, application.ChecklistRow{
+			Taxon:     r.Taxon,
+			Authority: r.Authority,
+			ExtID:     r.ExtID,
+			Scheme:    r.Scheme,
+			Code:      r.Code,
+			Status:    r.Status,
+		})
+	}
+	return out
+}
+
+// ingestDistributionSource opens ds's canonical distribution CSV and runs
+// application.IngestDistributions against repo. manifestSHA is recorded onto
+// the source's distribution_source row exactly as it is onto xref_source;
+// reader-level row errors are surfaced on the report like ingestNameSpace's.
+func ingestDistributionSource(ctx context.Context, src manifest.DistributionSource, manifestSHA string, repo *sqlite.DB) (application.DistributionIngestReport, error) {
+	ds, err := distribution.Read(src.Path)
+	if err != nil {
+		return application.DistributionIngestReport{}, fmt.Errorf("app: reading distribution source %q at %q: %w", src.ID, src.Path, err)
+	}
+	redistribution, err := domain.ParseRedistribution(src.Redistribution)
+	if err != nil {
+		return application.DistributionIngestReport{}, fmt.Errorf("app: distribution source %q: %w", src.ID, err)
+	}
+	meta := domain.DistributionSourceMeta{
+		ID:             src.ID,
+		Version:        src.Version,
+		License:        src.License,
+		SourceURL:      src.SourceURL,
+		ManifestSHA:    manifestSHA,
+		Redistribution: redistribution,
+	}
+	report, err := application.IngestDistributions(ctx, repo, checklistRowSource{ds: ds}, meta)
+	report.ReaderErrors = len(ds.Errors)
+	return report, err
+}
+
+// frequencyRowSource adapts a *frequency.Dataset into
+// application.FrequencyRowSource, like checklistRowSource (depguard).
+type frequencyRowSource struct{ ds *frequency.Dataset }
+
+func (s frequencyRowSource) Rows() []application.FrequencyRow {
+	out := make([]application.FrequencyRow, 0, len(s.ds.Rows))
+	for _, r := range s.ds.Rows {
+		out = append(out, application.FrequencyRow{
+			Taxon:     r.Taxon,
+			Authority: r.Authority,
+			ExtID:     r.ExtID,
+			Count:     r.Count,
+		})
+	}
+	return out
+}
+
+// ingestFrequencySource opens src's canonical frequency CSV and runs
+// application.IngestFrequencies against repo, exactly like
+// ingestDistributionSource.
+func ingestFrequencySource(ctx context.Context, src manifest.FrequencySource, manifestSHA string, repo *sqlite.DB) (application.FrequencyIngestReport, error) {
+	ds, err := frequency.Read(src.Path)
+	if err != nil {
+		return application.FrequencyIngestReport{}, fmt.Errorf("app: reading frequency source %q at %q: %w", src.ID, src.Path, err)
+	}
+	redistribution, err := domain.ParseRedistribution(src.Redistribution)
+	if err != nil {
+		return application.FrequencyIngestReport{}, fmt.Errorf("app: frequency source %q: %w", src.ID, err)
+	}
+	meta := domain.FrequencySourceMeta{
+		ID:             src.ID,
+		Version:        src.Version,
+		License:        src.License,
+		SourceURL:      src.SourceURL,
+		ManifestSHA:    manifestSHA,
+		Redistribution: redistribution,
+	}
+	report, err := application.IngestFrequencies(ctx, repo, frequencyRowSource{ds: ds}, meta)
+	report.ReaderErrors = len(ds.Errors)
+	return report, err
+}
+
 // ingestConceptSource reads cs's two canonical CDM CSVs and runs
 // application.IngestCDM against repo. This is the adapter -> application DTO
 // bridge for SP5: internal/application must not import
@@ -321,27 +414,52 @@ func adaptCDMRelations(rows []cdm.RelationRow) []application.CDMRelationRow {
 // results a positional tuple stops documenting itself, and every caller was
 // already discarding most of it with blank identifiers.
 type Reports struct {
+	Areas          application.AreaHierarchyReport
 	Backbone       application.IngestReport
 	Traits         []application.TraitIngestReport
 	Xrefs          []application.XrefIngestReport
+	Distributions  []application.DistributionIngestReport
+	Frequencies    []application.FrequencyIngestReport
 	ConceptSources []application.CDMIngestReport
 	NameSpaces     []application.NameSpaceIngestReport
 }
 
+// IngestOpts carries the choices "hostus ingest" leaves to its caller rather
+// than to the manifest.
+type IngestOpts struct {
+	// StrictParentChains fails the ingest when a backbone's parent chains
+	// are broken (see application.Dataset.StrictParentChains) instead of
+	// only reporting them.
+	StrictParentChains bool
+}
+
 // Ingest parses and validates the manifest at manifestPath, opens (or
-// creates) the SQLite database at dbPath, and runs application.Ingest
+// creates) the SQLite database at dbPath, records the embedded WGSRPD area
+// hierarchy and ISO 3166 alias table (application.IngestAreaHierarchy), and
+// runs application.Ingest
 // against every pinned backbone, then application.IngestTraits against every
 // pinned trait vocabulary, then application.IngestXrefs against every pinned
-// xref source, then application.IngestCDM against every pinned concept
-// source, then application.IngestNameSpace against every pinned name space.
-// It is the entry point "hostus ingest" calls.
+// xref source, then application.IngestDistributions against every pinned
+// distribution source, then application.IngestFrequencies against every
+// pinned frequency source, then application.IngestCDM against every pinned
+// concept source, then application.IngestNameSpace against every pinned name
+// space. It is the entry point "hostus ingest" calls.
+//
+// Distribution and frequency sources run BEFORE concept sources: their name
+// join must resolve to the WCVP concept alone, and a CDM twin ingested first
+// would turn every shared name into an ambiguity.
 //
 // Concept sources run LATE on purpose: their relation ends resolve against
 // taxon_concept, so anything an earlier phase wrote is already available to
 // them (see application.IngestCDM's phase 1). Name spaces run LAST for the
 // same reason — their crosswalk resolves against the name index, so every
 // concept any earlier phase contributed is a possible target.
-func Ingest(ctx context.Context, manifestPath, dbPath string) (Reports, error) {
+//
+// The area hierarchy runs FIRST: an area's first non-empty name wins, so the
+// WGSRPD unit names take precedence over whatever spelling a backbone's
+// distribution data carries for the same level-3 code. It is not part of
+// the manifest — it ships with the binary, like the area geometries.
+func Ingest(ctx context.Context, manifestPath, dbPath string, opts IngestOpts) (Reports, error) {
 	var reports Reports
 
 	manifestDS, err := manifest.Parse(manifestPath)
@@ -352,7 +470,7 @@ func Ingest(ctx context.Context, manifestPath, dbPath string) (Reports, error) {
 	if err != nil {
 		return reports, err
 	}
-	ds := &application.Dataset{Backbones: backbones, ManifestSHA: manifestDS.ManifestSHA}
+	ds := &application.Dataset{Backbones: backbones, ManifestSHA: manifestDS.ManifestSHA, StrictParentChains: opts.StrictParentChains}
 
 	repo, err := sqlite.Open(dbPath)
 	if err != nil {
@@ -360,6 +478,19 @@ func Ingest(ctx context.Context, manifestPath, dbPath string) (Reports, error) {
 	}
 	defer func() { _ = repo.Close() }()
 
+	units, err := wgsrpd.Hierarchy()
+	if err != nil {
+		return reports, fmt.Errorf("app: loading area hierarchy: %w", err)
+	}
+	aliases, err := wgsrpd.Aliases(units)
+	if err != nil {
+		return reports, fmt.Errorf("app: loading area aliases: %w", err)
+	}
+	reports.Areas, err = application.IngestAreaHierarchy(ctx, repo, units, aliases)
+	if err != nil {
+		return reports, err
+	}
+
 	reports.Backbone, err = application.Ingest(ctx, ds, readerFor, repo)
 	if err != nil {
 		return reports, err
@@ -383,6 +514,24 @@ func Ingest(ctx context.Context, manifestPath, dbPath string) (Reports, error) {
 		reports.Xrefs = append(reports.Xrefs, xr)
 	}
 
+	reports.Distributions = make([]application.DistributionIngestReport, 0, len(manifestDS.Distributions))
+	for _, src := range manifestDS.Distributions {
+		dr, err := ingestDistributionSource(ctx, src, manifestDS.ManifestSHA, repo)
+		if err != nil {
+			return reports, err
+		}
+		reports.Distributions = append(reports.Distributions, dr)
+	}
+
+	reports.Frequencies = make([]application.FrequencyIngestReport, 0, len(manifestDS.Frequencies))
+	for _, src := range manifestDS.Frequencies {
+		fr, err := ingestFrequencySource(ctx, src, manifestDS.ManifestSHA, repo)
+		if err != nil {
+			return reports, err
+		}
+		reports.Frequencies = append(reports.Frequencies, fr)
+	}
+
 	reports.ConceptSources = make([]application.CDMIngestReport, 0, len(manifestDS.ConceptSources))
 	for _, cs := range manifestDS.ConceptSources {
 		cr, err := ingestConceptSource(ctx, cs, manifestDS.ManifestSHA, repo)
@@ -407,6 +556,11 @@ func Ingest(ctx context.Context, manifestPath, dbPath string) (Reports, error) {
 	if err := repo.BuildDistributionClosure(ctx); err != nil {
 		return reports, fmt.Errorf("app: building distribution closure: %w", err)
 	}
+	// BuildLineageClosure likewise needs every backbone's parent chains in
+	// place; suggest's within filter reads nothing else.
+	if err := repo.BuildLineageClosure(ctx); err != nil {
+		return reports, fmt.Errorf("app: building lineage closure: %w", err)
+	}
 
 	return reports, nil
 }
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
 	"github.com/jobrunner/hostus/internal/app"
 	"github.com/jobrunner/hostus/internal/domain"
 )
@@ -19,7 +20,7 @@ import (
 func TestIngest_ReportsTraitVocabularies(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath)
+	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
@@ -51,6 +52,38 @@ func TestIngest_ReportsTraitVocabularies(t *testing.T) {
 	}
 }
 
+// TestIngest_RecordsAreaHierarchy pins the composition root's hierarchy leg:
+// the embedded WGSRPD and ISO 3166 alias tables are recorded, and the
+// fixture's AUT distribution comes back from Areas under Middle Europe under
+// Europe.
+func TestIngest_RecordsAreaHierarchy(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
+	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath, app.IngestOpts{})
+	if err != nil {
+		t.Fatalf("app.Ingest: unexpected error: %v", err)
+	}
+	if reports.Areas.Level1 != 9 || reports.Areas.Level3 == 0 || reports.Areas.Aliases == 0 {
+		t.Errorf("reports.Areas = %+v, want 9 continents, the level-3 areas and the aliases", reports.Areas)
+	}
+
+	db, err := sqlite.Open(dbPath)
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	areas, err := db.Areas(context.Background())
+	if err != nil {
+		t.Fatalf("Areas: %v", err)
+	}
+	parents := make(map[string]string, len(areas))
+	for _, a := range areas {
+		parents[a.Code] = a.Parent
+	}
+	if parents["AUT"] != "11" || parents["11"] != "1" {
+		t.Errorf("Areas = %+v, want AUT -> 11 -> 1", areas)
+	}
+}
+
 // TestIngest_ReportsXrefSources mirrors TestIngest_ReportsTraitVocabularies
 // for the xref-ingest leg of the same manifest (testdata/dataset.yaml now
 // also pins the wikidata xref-source fixture) — same REAL on-disk SQLite
@@ -59,7 +92,7 @@ func TestIngest_ReportsTraitVocabularies(t *testing.T) {
 func TestIngest_ReportsXrefSources(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath)
+	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
@@ -91,7 +124,7 @@ func TestIngest_ReportsXrefSources(t *testing.T) {
 func TestIngest_XrefSourceReadErrorPropagates(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	reports, err := app.Ingest(context.Background(), "testdata/dataset-bad-xref-path.yaml", dbPath)
+	reports, err := app.Ingest(context.Background(), "testdata/dataset-bad-xref-path.yaml", dbPath, app.IngestOpts{})
 	if err == nil {
 		t.Fatal("app.Ingest: expected an error for an unreadable xref CSV path, got nil")
 	}
@@ -103,14 +136,14 @@ func TestIngest_XrefSourceReadErrorPropagates(t *testing.T) {
 func TestIngest_ManifestParseErrorPropagates(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	if _, err := app.Ingest(context.Background(), "testdata/does-not-exist.yaml", dbPath); err == nil {
+	if _, err := app.Ingest(context.Background(), "testdata/does-not-exist.yaml", dbPath, app.IngestOpts{}); err == nil {
 		t.Fatal("app.Ingest: expected an error for a missing manifest, got nil")
 	}
 }
 
 func TestIngest_OpenDatabaseErrorPropagates(t *testing.T) {
 	// A directory is not a usable SQLite file path.
-	if _, err := app.Ingest(context.Background(), "testdata/dataset.yaml", t.TempDir()); err == nil {
+	if _, err := app.Ingest(context.Background(), "testdata/dataset.yaml", t.TempDir(), app.IngestOpts{}); err == nil {
 		t.Fatal("app.Ingest: expected an error for an unopenable database path, got nil")
 	}
 }
@@ -118,7 +151,7 @@ func TestIngest_OpenDatabaseErrorPropagates(t *testing.T) {
 func TestIngest_BackboneIngestErrorPropagates(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	reports, err := app.Ingest(context.Background(), "testdata/dataset-bad-backbone-path.yaml", dbPath)
+	reports, err := app.Ingest(context.Background(), "testdata/dataset-bad-backbone-path.yaml", dbPath, app.IngestOpts{})
 	if err == nil {
 		t.Fatal("app.Ingest: expected an error for an unreadable backbone path, got nil")
 	}
@@ -130,7 +163,7 @@ func TestIngest_BackboneIngestErrorPropagates(t *testing.T) {
 func TestIngest_TraitVocabularyReadErrorPropagates(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	if _, err := app.Ingest(context.Background(), "testdata/dataset-bad-trait-path.yaml", dbPath); err == nil {
+	if _, err := app.Ingest(context.Background(), "testdata/dataset-bad-trait-path.yaml", dbPath, app.IngestOpts{}); err == nil {
 		t.Fatal("app.Ingest: expected an error for an unreadable trait CSV path, got nil")
 	}
 }
@@ -138,11 +171,52 @@ func TestIngest_TraitVocabularyReadErrorPropagates(t *testing.T) {
 func TestIngest_UnknownTraitVocabularyIDErrors(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	if _, err := app.Ingest(context.Background(), "testdata/dataset-unknown-trait-vocab.yaml", dbPath); err == nil {
+	if _, err := app.Ingest(context.Background(), "testdata/dataset-unknown-trait-vocab.yaml", dbPath, app.IngestOpts{}); err == nil {
 		t.Fatal("app.Ingest: expected an error for a manifest pinning an unknown trait vocabulary id, got nil")
 	}
 }
 
+// TestIngest_ReportsDistributions drives the composition root against a
+// manifest pinning one distribution source, on an on-disk SQLite file for
+// the same single-connection reason as the name-space test below.
+func TestIngest_ReportsDistributions(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
+
+	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath, app.IngestOpts{})
+	if err != nil {
+		t.Fatalf("app.Ingest: unexpected error: %v", err)
+	}
+	if len(reports.Distributions) != 1 {
+		t.Fatalf("len(reports.Distributions) = %d, want 1 (the manifest pins one distribution source)", len(reports.Distributions))
+	}
+	dr := reports.Distributions[0]
+	if dr.Source != "euromed" || dr.Redistribution != string(domain.RedistributionAllowed) {
+		t.Errorf("reports.Distributions[0] source/redistribution = %q/%q, want euromed/allowed", dr.Source, dr.Redistribution)
+	}
+	if dr.Rows != 5 || dr.Matched != 5 || dr.ByXref != 2 {
+		t.Errorf("reports.Distributions[0] rows/matched/by xref = %d/%d/%d, want 5/5/2", dr.Rows, dr.Matched, dr.ByXref)
+	}
+}
+
+// TestIngest_ReportsFrequencies drives the composition root against a
+// manifest pinning one frequency source: both name-joined rows (a taxon and
+// its synonym) land on Festuca ovina, the id-joined one on Corynephorus.
+func TestIngest_ReportsFrequencies(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
+
+	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath, app.IngestOpts{})
+	if err != nil {
+		t.Fatalf("app.Ingest: unexpected error: %v", err)
+	}
+	if len(reports.Frequencies) != 1 {
+		t.Fatalf("len(reports.Frequencies) = %d, want 1 (the manifest pins one frequency source)", len(reports.Frequencies))
+	}
+	fr := reports.Frequencies[0]
+	if fr.Source != "veg_relevees" || fr.Rows != 3 || fr.Matched != 3 || fr.ByXref != 1 || fr.Concepts != 2 {
+		t.Errorf("reports.Frequencies[0] = %+v, want veg_relevees with 3 rows, 3 matched (1 by xref), 2 concepts", fr)
+	}
+}
+
 // TestIngest_ReportsNameSpaces drives the REAL composition root against a
 // manifest that pins the FloraVeg name space, on a REAL on-disk SQLite file
 // — the same combination that makes the trait/xref tests above meaningful:
@@ -152,7 +226,7 @@ func TestIngest_UnknownTraitVocabularyIDErrors(t *testing.T) {
 func TestIngest_ReportsNameSpaces(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "hostus.sqlite")
 
-	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath)
+	reports, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
@@ -109,7 +109,7 @@ func TestIntegration_MatchTargetSpaceFloraVeg(t *testing.T) {
 	dir := t.TempDir()
 	dbPath := filepath.Join(dir, "hostus.sqlite")
 	ctx := context.Background()
-	if _, err := app.Ingest(ctx, "testdata/dataset.yaml", dbPath); err != nil {
+	if _, err := app.Ingest(ctx, "testdata/dataset.yaml", dbPath, app.IngestOpts{}); err != nil {
 		t.Fatalf("app.Ingest: %v", err)
 	}
 
@@ -192,7 +192,7 @@ func TestIntegration_MatchUnknownTargetSpaceReturns400(t *testing.T) {
 	dir := t.TempDir()
 	dbPath := filepath.Join(dir, "hostus.sqlite")
 	ctx := context.Background()
-	if _, err := app.Ingest(ctx, "testdata/dataset.yaml", dbPath); err != nil {
+	if _, err := app.Ingest(ctx, "testdata/dataset.yaml", dbPath, app.IngestOpts{}); err != nil {
 		t.Fatalf("app.Ingest: %v", err)
 	}
 	cfg := testConfig()
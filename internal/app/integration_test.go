@@ -120,7 +120,7 @@ func TestIntegration_EndToEndIngestServeQuery(t *testing.T) {
 	manifestPath := "testdata/dataset.yaml"
 
 	ctx := context.Background()
-	reports, err := app.Ingest(ctx, manifestPath, dbPath)
+	reports, err := app.Ingest(ctx, manifestPath, dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
@@ -750,7 +750,7 @@ func TestIntegration_OfflineBundleServesSuggestOffline(t *testing.T) {
 	// redistribution=allowed. The FloraVeg name space (unknown) would be
 	// refused here by design — that refusal is pinned by
 	// internal/app's TestBundle_RefusesNameSpaceByDefault instead.
-	if _, err := app.Ingest(ctx, "testdata/dataset-no-namespace.yaml", dbPath); err != nil {
+	if _, err := app.Ingest(ctx, "testdata/dataset-no-namespace.yaml", dbPath, app.IngestOpts{}); err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
 
@@ -854,7 +854,7 @@ func TestIntegration_TraitsFuzzyClassification(t *testing.T) {
 	dbPath := filepath.Join(dir, "hostus.sqlite")
 
 	ctx := context.Background()
-	reports, err := app.Ingest(ctx, "testdata/dataset-traits.yaml", dbPath)
+	reports, err := app.Ingest(ctx, "testdata/dataset-traits.yaml", dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
@@ -1076,7 +1076,7 @@ func TestIntegration_OfflineBundleConceptSuggestTraitsOffline(t *testing.T) {
 	bundlePath := filepath.Join(dir, "bundle.sqlite")
 	genusConceptID := "wcvp:concept:451295"
 
-	if _, err := app.Ingest(ctx, "testdata/dataset-traits.yaml", dbPath); err != nil {
+	if _, err := app.Ingest(ctx, "testdata/dataset-traits.yaml", dbPath, app.IngestOpts{}); err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
 
@@ -1330,7 +1330,7 @@ func TestIntegration_TranslateBetweenSecSpaces(t *testing.T) {
 	dbPath := filepath.Join(dir, "hostus.sqlite")
 
 	ctx := context.Background()
-	reports, err := app.Ingest(ctx, "testdata/dataset-cdm.yaml", dbPath)
+	reports, err := app.Ingest(ctx, "testdata/dataset-cdm.yaml", dbPath, app.IngestOpts{})
 	if err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
@@ -1655,7 +1655,7 @@ func TestIntegration_TestConsoleToggle(t *testing.T) {
 	dir := t.TempDir()
 	dbPath := filepath.Join(dir, "hostus.sqlite")
 
-	if _, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath); err != nil {
+	if _, err := app.Ingest(context.Background(), "testdata/dataset.yaml", dbPath, app.IngestOpts{}); err != nil {
 		t.Fatalf("app.Ingest: unexpected error: %v", err)
 	}
 
@@ -35,6 +35,7 @@ func TestWCVPRowSource_Taxa_MapsEverySourceColumn(t *testing.T) {
 				OriginalNameUsageID: "basionym-id",
 				PublishedIn:         "published-in-val",
 				NomenclaturalStatus: "nom-status-val",
+				Family:              "family-val",
 				DynamicProperties:   `{"powoid":"powoid-val"}`,
 			},
 			// A self-referential accepted row, so the Accepted=true branch of
@@ -61,6 +62,7 @@ func TestWCVPRowSource_Taxa_MapsEverySourceColumn(t *testing.T) {
 		BasionymTaxonID: "basionym-id",
 		PublishedIn:     "published-in-val",
 		NomStatus:       "nom-status-val",
+		Family:          "family-val",
 	}
 	if got[0] != want {
 		t.Errorf("Taxa()[0] = %+v\nwant %+v\n(a mismatched/empty field means that source column was dropped by the mapper)", got[0], want)
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// AreaHierarchyReport summarizes one IngestAreaHierarchy run: how many units
+// of each WGSRPD level and how many ISO 3166 aliases were recorded. A Level4
+// of 0 is normal for the checked-in table, which transcribes levels 1-3 only.
+type AreaHierarchyReport struct {
+	Level1  int
+	Level2  int
+	Level3  int
+	Level4  int
+	Aliases int
+}
+
+// IngestAreaHierarchy records the WGSRPD unit hierarchy (levels 1-4 with
+// their parent codes) in the area table, which is what lets an area filter
+// name a continent or region: the repository expands it through these
+// parent links to the level-3 codes distribution is recorded at. aliases
+// are recorded in the same transaction; they are what lets the filter take
+// an ISO 3166 code ("PL", "DE-BY") instead of the WGSRPD one.
+//
+// It uses repo.BeginTraitIngest, NOT repo.BeginIngest, for the same reason
+// IngestNameSpace does: the hierarchy is reference data, not a backbone, and
+// must never appear in backbone_version. The units are written as given —
+// parent integrity is the reader's job (wgsrpd.ParseHierarchy rejects a
+// dangling parent, wgsrpd.ParseAliases an alias onto an unknown code) — but
+// a unit whose scheme is not a WGSRPD level is an error rather than a row
+// the roll-up could never reach.
+func IngestAreaHierarchy(ctx context.Context, repo output.Repository, units []domain.Area, aliases []domain.AreaAlias) (AreaHierarchyReport, error) {
+	var report AreaHierarchyReport
+	for _, u := range units {
+		if u.Level() == 0 {
+			return AreaHierarchyReport{}, fmt.Errorf("application: area hierarchy unit %q has non-WGSRPD scheme %q", u.Code, u.Scheme)
+		}
+	}
+
+	tx, err := repo.BeginTraitIngest(ctx)
+	if err != nil {
+		return report, fmt.Errorf("application: starting area hierarchy ingest: %w", err)
+	}
+	for _, u := range units {
+		if err := tx.UpsertArea(u); err != nil {
+			_ = tx.Rollback()
+			return AreaHierarchyReport{}, fmt.Errorf("application: recording area %s:%s: %w", u.Scheme, u.Code, err)
+		}
+		switch u.Level() {
+		case 1:
+			report.Level1++
+		case 2:
+			report.Level2++
+		case 3:
+			report.Level3++
+		case 4:
+			report.Level4++
+		}
+	}
+	for _, a := range aliases {
+		if err := tx.AddAreaAlias(a); err != nil {
+			_ = tx.Rollback()
+			return AreaHierarchyReport{}, fmt.Errorf("application: recording area alias %s: %w", a.Alias, err)
+		}
+		report.Aliases++
+	}
+	if err := tx.Finalize(); err != nil {
+		_ = tx.Rollback()
+		return AreaHierarchyReport{}, fmt.Errorf("application: finalizing area hierarchy ingest: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return AreaHierarchyReport{}, fmt.Errorf("application: committing area hierarchy ingest: %w", err)
+	}
+	return report, nil
+}
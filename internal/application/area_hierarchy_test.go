@@ -0,0 +1,71 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// europeUnits is a cut of the WGSRPD hierarchy: Europe, two of its regions,
+// and the level-3 areas seedRangedConcepts distributes to there.
+var europeUnits = []domain.Area{
+	{Scheme: domain.AreaSchemeWGSRPDL1, Code: "1", Name: "Europe"},
+	{Scheme: domain.AreaSchemeWGSRPDL2, Code: "11", Name: "Middle Europe", Parent: "1"},
+	{Scheme: domain.AreaSchemeWGSRPDL2, Code: "12", Name: "Southwestern Europe", Parent: "1"},
+	{Scheme: domain.AreaSchemeWGSRPDL3, Code: "AUT", Name: "Austria", Parent: "11"},
+	{Scheme: domain.AreaSchemeWGSRPDL3, Code: "GER", Name: "Germany", Parent: "11"},
+	{Scheme: domain.AreaSchemeWGSRPDL3, Code: "FRA", Name: "France", Parent: "12"},
+	{Scheme: domain.AreaSchemeWGSRPDL4, Code: "GER-OO", Name: "Germany", Parent: "GER"},
+}
+
+// europeAliases maps two ISO 3166 codes onto europeUnits: a country and a
+// region-sized alias that expands through the hierarchy.
+var europeAliases = []domain.AreaAlias{
+	{Alias: "AT", Codes: []string{"AUT"}},
+	{Alias: "DACH", Codes: []string{"11"}},
+}
+
+func TestIngestAreaHierarchy_CountsUnitsPerLevel(t *testing.T) {
+	repo := openMemoryRepo(t)
+	report, err := application.IngestAreaHierarchy(context.Background(), repo, europeUnits, europeAliases)
+	if err != nil {
+		t.Fatalf("IngestAreaHierarchy: unexpected error: %v", err)
+	}
+	want := application.AreaHierarchyReport{Level1: 1, Level2: 2, Level3: 3, Level4: 1, Aliases: 2}
+	if report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+}
+
+func TestIngestAreaHierarchy_RejectsNonWGSRPDUnit(t *testing.T) {
+	repo := openMemoryRepo(t)
+	_, err := application.IngestAreaHierarchy(context.Background(), repo,
+		[]domain.Area{{Scheme: "euromed", Code: "Ge", Name: "Germany"}}, nil)
+	if err == nil {
+		t.Fatal("IngestAreaHierarchy accepted a euromed unit, want an error")
+	}
+}
+
+// TestMatchInSpace_AreaRollsUpThroughHierarchy: with the hierarchy recorded,
+// a region, a continent, a level-4 unit and an alias onto a region all break
+// the homonym tie like the level-3 code they contain (or are contained in)
+// does.
+func TestMatchInSpace_AreaRollsUpThroughHierarchy(t *testing.T) {
+	repo, ids := seedRangedConcepts(t)
+	ctx := context.Background()
+	if _, err := application.IngestAreaHierarchy(ctx, repo, europeUnits, europeAliases); err != nil {
+		t.Fatalf("IngestAreaHierarchy: %v", err)
+	}
+	reqs := []application.MatchRequest{{ID: "1", Verbatim: "Homonymus rangeus L."}}
+	for _, area := range []string{"11", "1", "GER-OO", "dach"} {
+		results, err := application.MatchInSpace(ctx, repo, reqs, "", application.MatchFilter{Area: area})
+		if err != nil {
+			t.Fatalf("area %q: MatchInSpace: unexpected error: %v", area, err)
+		}
+		if r := results[0]; r.ConceptID != ids["a"] || r.Range == nil || !r.Range.InArea {
+			t.Errorf("area %q: result = %+v, want %s in area", area, r, ids["a"])
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	"errors"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// ErrNoAreaLocator is returned by LocateAreas and AreaAtPosition when no
+// geometries are loaded — the binary was built without the generated WGSRPD
+// asset. The HTTP adapter renders it as a 503: the question is fine, this
+// server cannot answer it.
+var ErrNoAreaLocator = errors.New("application: no area geometries loaded")
+
+// ErrNoAreaAtPosition is returned by AreaAtPosition for a position inside no
+// level-3 area (open sea, or beyond the snap tolerance of any coast). The
+// HTTP adapter renders it as a 400 INVALID_QUERY: filtering by "no area"
+// would silently answer a different question than the caller asked.
+var ErrNoAreaAtPosition = errors.New("application: no area at position")
+
+// LocateAreas resolves lat/lon to the WGSRPD areas containing it, broadest
+// level first (output.AreaLocator.Locate). An out-of-range coordinate is
+// domain.ErrInvalidCoordinate; a position in no area is an empty, non-error
+// result — "nothing here" is an answer when the areas themselves were asked
+// for.
+func LocateAreas(loc output.AreaLocator, lat, lon float64) ([]domain.Area, error) {
+	if loc == nil {
+		return nil, ErrNoAreaLocator
+	}
+	p, err := domain.NewPoint(lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	return loc.Locate(p), nil
+}
+
+// AreaAtPosition is LocateAreas for a caller that wants an area FILTER:
+// suggest's and match's lat/lon parameters stand in for area=, so what they
+// need is the one level-3 code, the level distribution data is recorded at.
+func AreaAtPosition(loc output.AreaLocator, lat, lon float64) (string, error) {
+	areas, err := LocateAreas(loc, lat, lon)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range areas {
+		if a.Scheme == domain.AreaSchemeWGSRPDL3 {
+			return a.Code, nil
+		}
+	}
+	return "", ErrNoAreaAtPosition
+}
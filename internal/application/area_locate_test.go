@@ -0,0 +1,47 @@
+package application_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// fakeLocator answers every position with areas, regardless of where it is.
+type fakeLocator struct{ areas []domain.Area }
+
+func (f fakeLocator) Locate(domain.Point) []domain.Area { return f.areas }
+
+func TestAreaAtPosition_ReturnsTheLevel3Code(t *testing.T) {
+	loc := fakeLocator{areas: []domain.Area{
+		{Scheme: domain.AreaSchemeWGSRPDL1, Code: "1"},
+		{Scheme: domain.AreaSchemeWGSRPDL2, Code: "11"},
+		{Scheme: domain.AreaSchemeWGSRPDL3, Code: "GER", Name: "Germany"},
+	}}
+	code, err := application.AreaAtPosition(loc, 52.5, 13.4)
+	if err != nil || code != "GER" {
+		t.Errorf("AreaAtPosition = %q, %v; want GER", code, err)
+	}
+}
+
+func TestAreaAtPosition_Errors(t *testing.T) {
+	if _, err := application.AreaAtPosition(nil, 52.5, 13.4); !errors.Is(err, application.ErrNoAreaLocator) {
+		t.Errorf("nil locator: error = %v, want ErrNoAreaLocator", err)
+	}
+	if _, err := application.AreaAtPosition(fakeLocator{}, 95, 13.4); !errors.Is(err, domain.ErrInvalidCoordinate) {
+		t.Errorf("lat 95: error = %v, want ErrInvalidCoordinate", err)
+	}
+	if _, err := application.AreaAtPosition(fakeLocator{}, 0, -30); !errors.Is(err, application.ErrNoAreaAtPosition) {
+		t.Errorf("open sea: error = %v, want ErrNoAreaAtPosition", err)
+	}
+}
+
+// TestLocateAreas_OpenSeaIsNotAnError: asked for the areas themselves, "none"
+// is the answer.
+func TestLocateAreas_OpenSeaIsNotAnError(t *testing.T) {
+	areas, err := application.LocateAreas(fakeLocator{}, 0, -30)
+	if err != nil || len(areas) != 0 {
+		t.Errorf("LocateAreas = %+v, %v; want no areas, no error", areas, err)
+	}
+}
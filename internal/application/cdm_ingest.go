@@ -79,10 +79,15 @@ type CDMIngestReport struct {
 	PerRelationType map[string]int
 	// NonConcept counts relation rows that are NOT Berendsohn concept
 	// relations — CDM's conceptRelationship=false rows (misapplied names).
-	// They are DROPPED, never written to concept_relation; see IngestCDM's
-	// doc comment for why.
+	// They are never written to concept_relation; see IngestCDM's doc
+	// comment for why.
 	NonConcept       int
 	NonConceptSample []string
+	// MisapplicationsWritten counts the NonConcept rows of type misapplied
+	// that were kept anyway, in their own table (IngestTx.AddMisapplication),
+	// for the matcher's "auct." resolution. A misapplied row with an
+	// unresolvable end is counted under UnresolvedEnds as well.
+	MisapplicationsWritten int
 	// UnknownFlag counts rows whose is_concept_relation column was EMPTY
 	// (an edge seen only from its to-end). Unknown is not false: such rows
 	// ARE written when their relation type is a concept relation, and this
@@ -139,16 +144,18 @@ func cdmNameID(uuid string) string    { return "cdm:name:" + uuid }
 //     measurement corrected (see domain.Relation) — the next correction must
 //     be loud, not silent.
 //
-//  2. MISAPPLIED NAMES ARE DROPPED. CDM flags every relation with a
-//     conceptRelationship boolean: true = a genuine concept relation,
-//     false = a misapplied-name relation. The latter says a NAME was used
-//     wrongly, not how two circumscriptions relate, so it is not written to
-//     concept_relation at all — counted and sampled instead. Keeping them
-//     would make /translate mix two kinds of claim under one column, and
-//     dropping them silently would hide 344 rows of the measured harvest.
-//     An EMPTY flag is unknown, not false: those rows are written if their
-//     TYPE is a concept relation (RelationMisapplied is the only type that
-//     is not), and counted under UnknownFlag.
+//  2. MISAPPLIED NAMES STAY OUT OF concept_relation. CDM flags every
+//     relation with a conceptRelationship boolean: true = a genuine concept
+//     relation, false = a misapplied-name relation. The latter says a NAME
+//     was used wrongly, not how two circumscriptions relate, so it is not
+//     written to concept_relation at all — counted and sampled instead.
+//     Keeping them there would make /translate mix two kinds of claim under
+//     one column. Rows whose TYPE is misapplied are nevertheless written to
+//     their own table (IngestTx.AddMisapplication): that assertion is
+//     exactly what resolves an "auct." verbatim to the concept its users
+//     meant. An EMPTY flag is unknown, not false: those rows are written if
+//     their TYPE is a concept relation (RelationMisapplied is the only type
+//     that is not), and counted under UnknownFlag.
 //
 //  3. DIRECTIONALITY — one canonical direction, no mirror rows. CDM only
 //     ever emits "Includes", never "Included in", and hostus stores each
@@ -201,6 +208,9 @@ type cdmPlan struct {
 	names     []domain.Name
 	conceptOf []domain.Concept
 	relations []cdmRelation
+	// misapplied are the misapplied-name rows with both ends resolvable,
+	// written to their own table rather than concept_relation.
+	misapplied []cdmRelation
 
 	otherRankCounts map[string]int
 	nonConcept      map[string]bool
@@ -373,6 +383,7 @@ func planCDMRelations(relations []CDMRelationRow, resolvable map[string]bool, pl
 		if !rel.IsConceptRelation() || (row.IsConceptRelation != nil && !*row.IsConceptRelation) {
 			report.NonConcept++
 			plan.nonConcept[cdmRelationKey(row)] = true
+			planCDMMisapplication(row, rel, resolvable, plan, report)
 			continue
 		}
 		if row.IsConceptRelation == nil {
@@ -392,6 +403,24 @@ func planCDMRelations(relations []CDMRelationRow, resolvable map[string]bool, pl
 	return nil
 }
 
+// planCDMMisapplication keeps a non-concept row for the misapplication table
+// when its type says it is one. A row merely FLAGGED non-concept but typed as
+// something else (congruent with conceptRelationship=false) is not kept: it is
+// no misapplication, and guessing what it is instead would be the coercion
+// domain.ParseRelation exists to refuse.
+func planCDMMisapplication(row CDMRelationRow, rel domain.Relation, resolvable map[string]bool, plan *cdmPlan, report *CDMIngestReport) {
+	if rel != domain.RelationMisapplied {
+		return
+	}
+	from, to := cdmConceptID(row.FromUUID), cdmConceptID(row.ToUUID)
+	if row.FromUUID == "" || row.ToUUID == "" || !resolvable[from] || !resolvable[to] {
+		report.UnresolvedEnds++
+		plan.unresolved[cdmRelationKey(row)] = true
+		return
+	}
+	plan.misapplied = append(plan.misapplied, cdmRelation{from: from, to: to, rel: rel})
+}
+
 // cdmRelationKey formats a relation row for a report sample: its
 // relationship uuid when it has one (the stable CDM identity), else the
 // endpoint pair, so a sampled row can actually be looked up again.
@@ -412,7 +441,7 @@ func cdmRelationKey(row CDMRelationRow) string {
 //	2a  sec_reference rows      — a concept's sec_reference id names one
 //	2b  names + concepts + the accepted link, WITHOUT parent_id
 //	2c  parent_id, re-upserted onto the concepts that have one
-//	2d  concept relations       — both ends FK onto taxon_concept
+//	2d  concept relations and misapplications — both ends FK onto taxon_concept
 //
 // Sub-pass 2c exists for exactly the reason internal/application/ingest.go
 // splits pass 1 into 1a/1b (see pass1AcceptedAndNames' comment): a concept's
@@ -458,5 +487,11 @@ func writeCDM(tx output.IngestTx, plan *cdmPlan, meta domain.BackboneVersion, re
 			return fmt.Errorf("application: writing concept relation %s -> %s (%s): %w", r.from, r.to, r.rel, err)
 		}
 	}
+	for _, r := range plan.misapplied {
+		if err := tx.AddMisapplication(r.from, r.to, meta.ID); err != nil {
+			return fmt.Errorf("application: writing misapplication %s -> %s: %w", r.from, r.to, err)
+		}
+		report.MisapplicationsWritten++
+	}
 	return nil
 }
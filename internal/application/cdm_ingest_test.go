@@ -21,14 +21,15 @@ type cdmRelationWrite struct {
 }
 
 type fakeCDMTx struct {
-	names     []domain.Name
-	concepts  []domain.Concept
-	secs      []domain.SecReference
-	relations []cdmRelationWrite
-	links     [][3]string
-	committed bool
-	rolled    bool
-	failOn    string
+	names      []domain.Name
+	concepts   []domain.Concept
+	secs       []domain.SecReference
+	relations  []cdmRelationWrite
+	misapplied []cdmRelationWrite
+	links      [][3]string
+	committed  bool
+	rolled     bool
+	failOn     string
 }
 
 func (t *fakeCDMTx) UpsertName(n domain.Name) error {
@@ -71,13 +72,26 @@ func (t *fakeCDMTx) AddConceptRelation(from, to string, rel domain.Relation, sou
 	return nil
 }
 
-func (t *fakeCDMTx) AddXref(string, domain.Xref, string) error         { return nil }
-func (t *fakeCDMTx) AddDistribution(string, domain.Distribution) error { return nil }
-func (t *fakeCDMTx) UpsertArea(domain.Area) error                      { return nil }
-func (t *fakeCDMTx) AddTraitValue(string, domain.TraitValue) error     { return nil }
-func (t *fakeCDMTx) UpsertTraitVocabulary(domain.TraitVocabMeta) error { return nil }
-func (t *fakeCDMTx) UpsertXrefSource(domain.XrefSourceMeta) error      { return nil }
-func (t *fakeCDMTx) UpsertNameSpace(domain.NameSpaceMeta) error        { return nil }
+func (t *fakeCDMTx) AddMisapplication(from, to, source string) error {
+	t.misapplied = append(t.misapplied, cdmRelationWrite{from: from, to: to, rel: domain.RelationMisapplied, source: source})
+	return nil
+}
+
+func (t *fakeCDMTx) AddHybridParent(string, int, string) error {
+	return nil
+}
+
+func (t *fakeCDMTx) AddXref(string, domain.Xref, string) error                    { return nil }
+func (t *fakeCDMTx) AddDistribution(string, domain.Distribution, string) error    { return nil }
+func (t *fakeCDMTx) UpsertArea(domain.Area) error                                 { return nil }
+func (t *fakeCDMTx) AddAreaAlias(domain.AreaAlias) error                          { return nil }
+func (t *fakeCDMTx) AddTraitValue(string, domain.TraitValue) error                { return nil }
+func (t *fakeCDMTx) UpsertTraitVocabulary(domain.TraitVocabMeta) error            { return nil }
+func (t *fakeCDMTx) UpsertXrefSource(domain.XrefSourceMeta) error                 { return nil }
+func (t *fakeCDMTx) UpsertDistributionSource(domain.DistributionSourceMeta) error { return nil }
+func (t *fakeCDMTx) UpsertFrequencySource(domain.FrequencySourceMeta) error       { return nil }
+func (t *fakeCDMTx) SetFrequency(string, string, int64) error                     { return nil }
+func (t *fakeCDMTx) UpsertNameSpace(domain.NameSpaceMeta) error                   { return nil }
 func (t *fakeCDMTx) AddNameSpaceEntry(string, domain.NameSpaceEntry) error {
 	return nil
 }
@@ -131,8 +145,28 @@ func (r *fakeCDMRepo) SynonymCandidates(context.Context, string) ([]domain.Synon
 	return nil, nil
 }
 
+func (r *fakeCDMRepo) NameRecord(context.Context, string) (output.NameRecord, error) {
+	return output.NameRecord{}, nil
+}
+
+func (r *fakeCDMRepo) NameUsages(context.Context, string) ([]domain.Name, []output.NameUsage, error) {
+	return nil, nil, nil
+}
+
 func (r *fakeCDMRepo) Areas(context.Context) ([]domain.Area, error) { return nil, nil }
 
+func (r *fakeCDMRepo) AreaPresence(context.Context, string, []string) (map[string]output.AreaPresence, error) {
+	return nil, nil
+}
+
+func (r *fakeCDMRepo) UsageTargets(context.Context, []string) ([]output.UsageTarget, error) {
+	return nil, nil
+}
+
+func (r *fakeCDMRepo) MatchHybridParents(context.Context, []string) ([]output.MatchCandidate, error) {
+	return nil, nil
+}
+
 func (r *fakeCDMRepo) SecReferences(context.Context) ([]domain.SecReference, error) {
 	return nil, nil
 }
@@ -141,10 +175,34 @@ func (r *fakeCDMRepo) Concept(context.Context, string) (*domain.Concept, []outpu
 	return nil, nil, nil, nil, nil
 }
 
+func (r *fakeCDMRepo) Concepts(context.Context, []string, output.ConceptInclude) ([]output.ConceptDocument, error) {
+	return nil, nil
+}
+
+func (r *fakeCDMRepo) TreatmentLinks(context.Context, string) ([]output.TreatmentLink, error) {
+	return nil, nil
+}
+
 func (r *fakeCDMRepo) Classification(context.Context, string) ([]domain.ClassificationEntry, error) {
 	return nil, nil
 }
 
+func (r *fakeCDMRepo) HigherTaxa(context.Context, []string) (map[string]domain.HigherTaxa, error) {
+	return nil, nil
+}
+
+func (r *fakeCDMRepo) Children(context.Context, string, output.TreeOpts) ([]domain.TaxonNode, int, error) {
+	return nil, 0, nil
+}
+
+func (r *fakeCDMRepo) DescendantCounts(context.Context, string, output.TreeOpts) (map[domain.Rank]int, error) {
+	return nil, nil
+}
+
+func (r *fakeCDMRepo) Checklist(context.Context, output.ChecklistOpts) ([]domain.ChecklistEntry, error) {
+	return nil, nil
+}
+
 func (r *fakeCDMRepo) ConceptByXref(context.Context, string, string) (*domain.Concept, error) {
 	return nil, nil
 }
@@ -161,6 +219,10 @@ func (r *fakeCDMRepo) MatchFuzzyCandidates(context.Context, string, int, string,
 	return nil, nil
 }
 
+func (r *fakeCDMRepo) SuggestPrefixes(context.Context, string, int) ([]string, error) {
+	return nil, nil
+}
+
 func (r *fakeCDMRepo) BackboneVersions(context.Context) ([]domain.BackboneVersion, error) {
 	return nil, nil
 }
@@ -169,6 +231,10 @@ func (r *fakeCDMRepo) BuildDistributionClosure(context.Context) error {
 	return nil
 }
 
+func (r *fakeCDMRepo) BuildLineageClosure(context.Context) error {
+	return nil
+}
+
 func (r *fakeCDMRepo) Traits(context.Context, string, []domain.TraitVocab) ([]domain.TraitSet, error) {
 	return nil, nil
 }
@@ -388,6 +454,13 @@ func TestIngestCDMDropsMisappliedNameRowsAndSamplesThem(t *testing.T) {
 	if repo.tx.relations[0].rel == domain.RelationMisapplied {
 		t.Error("a misapplied-name row must never reach concept_relation")
 	}
+	// Kept apart instead, for matching "auct." verbatims.
+	if rep.MisapplicationsWritten != 1 || len(repo.tx.misapplied) != 1 {
+		t.Fatalf("MisapplicationsWritten = %d, misapplied = %+v, want the one row", rep.MisapplicationsWritten, repo.tx.misapplied)
+	}
+	if m := repo.tx.misapplied[0]; m.from != "cdm:concept:aaa" || m.to != "cdm:concept:bbb" {
+		t.Errorf("misapplication = %+v, want aaa -> bbb", m)
+	}
 }
 
 func TestIngestCDMDropsMisappliedTypeEvenWhenFlagIsUnknown(t *testing.T) {
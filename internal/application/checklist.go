@@ -0,0 +1,173 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// DefaultChecklistLimit is the page size of an area checklist when the
+// caller names none, and MaxChecklistLimit the largest it accepts. A
+// country's vascular flora runs to a few thousand species, so the largest
+// page holds one whole, and "hostus checklist" pages through the rest.
+const (
+	DefaultChecklistLimit = 500
+	MaxChecklistLimit     = 5000
+)
+
+// Errors the checklist distinguishes; each maps onto INVALID_QUERY.
+var (
+	// ErrEmptyArea reports a checklist request without an area: a
+	// checklist of "everywhere" is the whole index, which the bundle
+	// export already is.
+	ErrEmptyArea = errors.New("application: area is required")
+	// ErrInvalidCursor reports a cursor Checklist did not issue.
+	ErrInvalidCursor = errors.New("application: invalid cursor")
+	// ErrInvalidEstablishment reports an establishment filter other than
+	// native, introduced or doubtful — absent is never stored, so asking
+	// for it would always answer "nothing".
+	ErrInvalidEstablishment = errors.New("application: invalid establishment")
+)
+
+// ChecklistRequest is one page of an area checklist.
+type ChecklistRequest struct {
+	Area          string
+	Ranks         []domain.Rank
+	Establishment domain.DistributionStatus
+	// EntryBackbone restricts the checklist to one backbone, like
+	// SuggestRequest.EntryBackbone; an un-ingested one is
+	// ErrUnknownBackbone. Without it a species ingested from two backbones
+	// is listed once per backbone.
+	EntryBackbone string
+	// Cursor is a previous ChecklistResult.NextCursor; empty starts at the
+	// first page.
+	Cursor string
+	// Limit is the page size; 0 means DefaultChecklistLimit.
+	Limit int
+}
+
+// ChecklistResult is one page of an area checklist.
+type ChecklistResult struct {
+	Area  string
+	Limit int
+	// Entries is the page in checklist order: by family, the family-less
+	// last, then by canonical name.
+	Entries []domain.ChecklistEntry
+	// NextCursor continues after this page; empty on the last one.
+	NextCursor string
+}
+
+// ChecklistFamily is one family's run of entries on a checklist page.
+type ChecklistFamily struct {
+	// Family is "" for the entries whose family is unknown.
+	Family  string
+	Entries []domain.ChecklistEntry
+}
+
+// Families groups the page's entries by family, in page order. A family cut
+// by a page boundary continues at the top of the next page.
+func (r ChecklistResult) Families() []ChecklistFamily {
+	families := []ChecklistFamily{}
+	for _, e := range r.Entries {
+		if n := len(families); n > 0 && families[n-1].Family == e.Family {
+			families[n-1].Entries = append(families[n-1].Entries, e)
+			continue
+		}
+		families = append(families, ChecklistFamily{Family: e.Family, Entries: []domain.ChecklistEntry{e}})
+	}
+	return families
+}
+
+// Checklist returns one page of the accepted concepts occurring in
+// req.Area (output.Repository.Checklist), after validating the request:
+// ErrEmptyArea, ErrInvalidLimit, ErrInvalidEstablishment, ErrInvalidCursor
+// and ErrUnknownBackbone name what was wrong with it.
+func Checklist(ctx context.Context, repo output.Repository, req ChecklistRequest) (ChecklistResult, error) {
+	area := strings.TrimSpace(req.Area)
+	if area == "" {
+		return ChecklistResult{}, ErrEmptyArea
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultChecklistLimit
+	}
+	if limit < 0 || limit > MaxChecklistLimit {
+		return ChecklistResult{}, fmt.Errorf("%w: %d", ErrInvalidLimit, req.Limit)
+	}
+	switch req.Establishment {
+	case "", domain.DistributionNative, domain.DistributionIntroduced, domain.DistributionDoubtful:
+	default:
+		return ChecklistResult{}, fmt.Errorf("%w: %q", ErrInvalidEstablishment, req.Establishment)
+	}
+	after, err := decodeChecklistCursor(req.Cursor)
+	if err != nil {
+		return ChecklistResult{}, err
+	}
+	if err := validateBackbone(ctx, repo, req.EntryBackbone); err != nil {
+		return ChecklistResult{}, err
+	}
+
+	// One entry past the page says whether there is a next one.
+	entries, err := repo.Checklist(ctx, output.ChecklistOpts{
+		Area:          area,
+		Ranks:         req.Ranks,
+		Establishment: req.Establishment,
+		Backbone:      req.EntryBackbone,
+		After:         after,
+		Limit:         limit + 1,
+	})
+	if err != nil {
+		return ChecklistResult{}, fmt.Errorf("application: checklist for area %q: %w", area, err)
+	}
+	res := ChecklistResult{Area: area, Limit: limit, Entries: entries}
+	if len(entries) > limit {
+		res.Entries = entries[:limit]
+		last := res.Entries[limit-1]
+		res.NextCursor = encodeChecklistCursor(output.ChecklistKey{Family: last.Family, Canonical: last.Canonical, ConceptID: last.ConceptID})
+	}
+	return res, nil
+}
+
+// encodeChecklistCursor renders key as an opaque, URL-safe token. It is
+// opaque so the order it encodes can change without breaking a contract,
+// not to hide anything: a client holds on to it, it never builds one.
+func encodeChecklistCursor(key output.ChecklistKey) string {
+	b, _ := json.Marshal([]string{key.Family, key.Canonical, key.ConceptID})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeChecklistCursor reverses encodeChecklistCursor; empty is the zero
+// key.
+func decodeChecklistCursor(cursor string) (output.ChecklistKey, error) {
+	if cursor == "" {
+		return output.ChecklistKey{}, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return output.ChecklistKey{}, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	var parts []string
+	if err := json.Unmarshal(b, &parts); err != nil || len(parts) != 3 || parts[2] == "" {
+		return output.ChecklistKey{}, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	return output.ChecklistKey{Family: parts[0], Canonical: parts[1], ConceptID: parts[2]}, nil
+}
+
+// ChecklistCSVColumns is the header row of a checklist rendered as CSV, by
+// GET /v1/checklist and "hostus checklist" alike.
+var ChecklistCSVColumns = []string{"family", "concept_id", "backbone", "canonical", "authorship", "rank", "establishment", "derived"}
+
+// ChecklistCSVRecord renders e as one CSV row under ChecklistCSVColumns.
+func ChecklistCSVRecord(e domain.ChecklistEntry) []string {
+	return []string{
+		e.Family, e.ConceptID, e.BackboneID, e.Canonical, e.Authorship,
+		string(e.Rank), string(e.Establishment), strconv.FormatBool(e.Derived),
+	}
+}
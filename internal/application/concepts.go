@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// MaxBulkConcepts bounds the ids of one POST /v1/concepts call. A result
+// page or a checklist family rarely holds more than a few hundred concepts,
+// and every part is one query over the whole list, so the bound caps the
+// size of those queries rather than their number.
+const MaxBulkConcepts = 500
+
+// ErrInvalidConceptCount reports a POST /v1/concepts id list that is empty
+// or longer than MaxBulkConcepts; it maps onto INVALID_QUERY.
+var ErrInvalidConceptCount = errors.New("application: concept id count out of range")
+
+// ConceptDocument is one compound concept: the repository's document plus,
+// when synonyms were included, its synonym candidates ranked and summarized
+// exactly as GET /v1/concept/{id}/synonyms does without parameters.
+type ConceptDocument struct {
+	output.ConceptDocument
+	// RankedSynonyms is nil unless the synonyms part was included.
+	RankedSynonyms *SynonymsResult
+}
+
+// ConceptsResult is POST /v1/concepts' answer: one document per known id in
+// request order, a repeated id once, and the ids that resolved to nothing.
+type ConceptsResult struct {
+	Documents []ConceptDocument
+	NotFound  []string
+}
+
+// Concepts reads the compound documents of ids through one
+// output.Repository.Concepts call. An id list that is empty or longer than
+// MaxBulkConcepts is ErrInvalidConceptCount; unknown ids are not an error
+// but listed in NotFound.
+func Concepts(ctx context.Context, repo output.Repository, ids []string, include output.ConceptInclude) (ConceptsResult, error) {
+	if len(ids) == 0 || len(ids) > MaxBulkConcepts {
+		return ConceptsResult{}, fmt.Errorf("%w: %d is not in [1, %d]", ErrInvalidConceptCount, len(ids), MaxBulkConcepts)
+	}
+	docs, err := repo.Concepts(ctx, ids, include)
+	if err != nil {
+		return ConceptsResult{}, fmt.Errorf("application: concepts: %w", err)
+	}
+
+	res := ConceptsResult{Documents: make([]ConceptDocument, len(docs)), NotFound: []string{}}
+	found := make(map[string]bool, len(docs))
+	for i, doc := range docs {
+		found[doc.Concept.ID] = true
+		res.Documents[i] = ConceptDocument{ConceptDocument: doc}
+		if include.SynonymCandidates {
+			ranked := domain.RankSynonyms(doc.SynonymCandidates, domain.SynonymOptions{})
+			res.Documents[i].RankedSynonyms = &SynonymsResult{
+				ConceptID: doc.Concept.ID,
+				Relevance: RelevanceAll,
+				Synonyms:  ranked,
+				Summary:   domain.SummarizeSynonyms(ranked),
+			}
+		}
+	}
+	for _, id := range ids {
+		if !found[id] {
+			found[id] = true
+			res.NotFound = append(res.NotFound, id)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,327 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// ChecklistRow is the minimal shape of one canonical distribution CSV row
+// IngestDistributions needs. A concrete reader's row type (distribution.Row)
+// is adapted into this DTO by the caller, the same RowSource-bridge pattern
+// NameRowSource/XrefRowSource use (depguard).
+//
+// The concept is named by Authority/ExtID when both are set — an id hostus
+// already holds as an xref, joined exactly like IngestXrefs' join key — and
+// by Taxon otherwise.
+type ChecklistRow struct {
+	Taxon     string
+	Authority string
+	ExtID     string
+	Scheme    string
+	Code      string
+	Status    string
+}
+
+// ChecklistRowSource streams one distribution source's rows for
+// IngestDistributions.
+type ChecklistRowSource interface {
+	Rows() []ChecklistRow
+}
+
+// DistributionIngestReport summarizes one distribution source's run.
+// Matched+Unmatched+Ambiguous+Invalid+Absent always sums to Rows: like every
+// crosswalk in this package it reports loss rather than absorbing it.
+type DistributionIngestReport struct {
+	Source    string
+	Rows      int
+	Matched   int
+	Unmatched int
+	Ambiguous int
+	// ByXref counts the Matched rows that resolved through their
+	// authority/ext_id rather than their name.
+	ByXref int
+	// Invalid counts rows whose scheme is not a valid scheme id
+	// (domain.ValidAreaScheme, or a WGSRPD level other than 3 — distribution
+	// is filtered at level 3 only) or whose status is not a known
+	// domain.DistributionStatus. Checked before resolution, so an invalid
+	// row is never also counted as unmatched.
+	Invalid int
+	// Absent counts rows with an explicit domain.DistributionAbsent verdict
+	// that resolved: distribution is a presence table, so they are counted
+	// and skipped, never written.
+	Absent int
+	// Concepts is the number of DISTINCT concepts that gained at least one
+	// row; Areas the number of distinct (scheme, code) pairs written.
+	Concepts int
+	Areas    int
+	// ReaderErrors counts rows the reader rejected before this use case saw
+	// them, so Rows + ReaderErrors accounts for every line of the artifact.
+	ReaderErrors int
+	// UnmatchedSample, AmbiguousSample and InvalidSample are bounded,
+	// deterministic samples (sortedSample). An id-joined row is sampled as
+	// "authority:ext_id", a name-joined one by its name.
+	UnmatchedSample []string
+	AmbiguousSample []string
+	InvalidSample   []string
+	// Redistribution is this source's manifest-pinned redistribution value.
+	// Local ingest is never gated by it; EXPORT is (see ExportBundle).
+	Redistribution string
+}
+
+// IngestDistributions resolves every row src provides to a concept and
+// writes its (scheme, code) as a distribution row attributed to meta.ID,
+// then records meta as the source's provenance.
+//
+// It runs RESOLVE first and WRITE second, for the reason IngestTraits
+// documents (the sqlite adapter's single connection). A row carrying
+// authority/ext_id is resolved through the existing xref table —
+// resolveXrefJoinKeys, the very lookup IngestXrefs' join uses — and an id
+// that does not resolve is Unmatched: the source named a specific taxon,
+// and retrying by name would guess. Every other row resolves its Taxon
+// through resolveTraitName, the SP3 crosswalk ladder, with its three
+// outcomes and its refusal to pick among several concepts.
+//
+// A concept may be named by several rows for one area (a checklist listing
+// a species and its synonym separately); distribution's key is (concept,
+// scheme, code), so the first row written wins and the rest are no-ops.
+//
+// Phase 2 uses repo.BeginTraitIngest: a distribution source is not a
+// backbone and must never leave a backbone_version row. The source metadata
+// is recorded regardless of match outcome, so a source that resolved nothing
+// is still visible as ingested and to the redistribution gate.
+func IngestDistributions(ctx context.Context, repo output.Repository, src ChecklistRowSource, meta domain.DistributionSourceMeta) (DistributionIngestReport, error) {
+	report := DistributionIngestReport{Source: meta.ID, Redistribution: string(meta.Redistribution)}
+	rows := src.Rows()
+	report.Rows = len(rows)
+
+	byID, byName, err := resolveDistributionRows(ctx, repo, rows)
+	if err != nil {
+		return report, fmt.Errorf("application: resolving distribution source %q: %w", meta.ID, err)
+	}
+
+	tx, err := repo.BeginTraitIngest(ctx)
+	if err != nil {
+		return report, fmt.Errorf("application: starting distribution ingest for %q: %w", meta.ID, err)
+	}
+	if err := tx.UpsertDistributionSource(meta); err != nil {
+		_ = tx.Rollback()
+		return report, fmt.Errorf("application: recording distribution source %q: %w", meta.ID, err)
+	}
+
+	tally := newDistributionTally()
+	for _, row := range rows {
+		if err := writeDistributionRow(tx, row, byID, byName, meta.ID, &report, tally); err != nil {
+			_ = tx.Rollback()
+			return report, err
+		}
+	}
+
+	if err := tx.Finalize(); err != nil {
+		_ = tx.Rollback()
+		return report, fmt.Errorf("application: finalizing distribution ingest for %q: %w", meta.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return report, fmt.Errorf("application: committing distribution ingest for %q: %w", meta.ID, err)
+	}
+
+	tally.report(&report)
+	return report, nil
+}
+
+// writeDistributionRow classifies and (if it resolved to a present area)
+// writes ONE row. Split out of IngestDistributions' loop for the same
+// cognitive-complexity reason as writeNameSpaceRow.
+func writeDistributionRow(
+	tx output.IngestTx,
+	row ChecklistRow,
+	byID map[xrefJoinKey]string,
+	byName map[string]traitResolution,
+	source string,
+	report *DistributionIngestReport,
+	tally *distributionTally,
+) error {
+	status, err := domain.ParseDistributionStatus(row.Status)
+	if err != nil || !validDistributionScheme(row.Scheme) {
+		report.Invalid++
+		tally.invalid[row.Scheme+":"+row.Code+" "+row.Status] = true
+		return nil
+	}
+
+	ref := sourceRowRef{taxon: row.Taxon, authority: row.Authority, extID: row.ExtID}
+	conceptID, outcome := ref.lookup(byID, byName)
+	switch outcome {
+	case refAmbiguous:
+		report.Ambiguous++
+		tally.ambiguous[ref.label()] = true
+		return nil
+	case refUnmatched:
+		report.Unmatched++
+		tally.unmatched[ref.label()] = true
+		return nil
+	}
+
+	if status == domain.DistributionAbsent {
+		report.Absent++
+		return nil
+	}
+	report.Matched++
+	if row.ExtID != "" {
+		report.ByXref++
+	}
+	key := distributionKey{conceptID: conceptID, scheme: row.Scheme, code: row.Code}
+	if tally.written[key] {
+		return nil
+	}
+	d := domain.Distribution{AreaScheme: row.Scheme, AreaCode: row.Code, Status: status}
+	if err := tx.AddDistribution(conceptID, d, source); err != nil {
+		return fmt.Errorf("application: writing distribution %s:%s for concept %q, source %q: %w", row.Scheme, row.Code, conceptID, source, err)
+	}
+	tally.written[key] = true
+	tally.concepts[conceptID] = true
+	tally.areas[d.AreaScheme+":"+d.AreaCode] = true
+	return nil
+}
+
+// validDistributionScheme reports whether a source row's scheme can be
+// stored and later filtered on: a valid scheme id that is not a WGSRPD level
+// other than 3. distribution holds WGSRPD data at level 3 only (the area
+// filter expands every other level to it), so a "wgsrpd_l2" row would be
+// stored and never matched.
+func validDistributionScheme(scheme string) bool {
+	if !domain.ValidAreaScheme(scheme) {
+		return false
+	}
+	level := domain.WGSRPDLevel(scheme)
+	return level == 0 || scheme == domain.AreaSchemeWGSRPDL3
+}
+
+// resolveDistributionRows is IngestDistributions' phase 1 (resolveSourceRowRefs
+// over every row). It must be called with no ingest transaction open.
+func resolveDistributionRows(ctx context.Context, repo output.Repository, rows []ChecklistRow) (map[xrefJoinKey]string, map[string]traitResolution, error) {
+	refs := make([]sourceRowRef, len(rows))
+	for i, row := range rows {
+		refs[i] = sourceRowRef{taxon: row.Taxon, authority: row.Authority, extID: row.ExtID}
+	}
+	return resolveSourceRowRefs(ctx, repo, refs)
+}
+
+// sourceRowRef is how a per-taxon source row (a checklist's, a frequency
+// list's) names its concept: by authority/ext_id when both are set, else by
+// taxon name.
+type sourceRowRef struct {
+	taxon     string
+	authority string
+	extID     string
+}
+
+// refOutcome is sourceRowRef.lookup's verdict.
+type refOutcome int
+
+const (
+	refMatched refOutcome = iota
+	refUnmatched
+	refAmbiguous
+)
+
+// lookup returns the concept r resolved to in resolveSourceRowRefs' maps. An
+// id that did not resolve is unmatched and never retried by name: the
+// source named a specific taxon, and retrying would guess.
+func (r sourceRowRef) lookup(byID map[xrefJoinKey]string, byName map[string]traitResolution) (string, refOutcome) {
+	if r.extID != "" {
+		id, ok := byID[xrefJoinKey{joinAuthority: r.authority, joinID: r.extID}]
+		if !ok {
+			return "", refUnmatched
+		}
+		return id, refMatched
+	}
+	res := byName[domain.Canonicalize(r.taxon)]
+	switch {
+	case res.ambiguous:
+		return "", refAmbiguous
+	case !res.matched:
+		return "", refUnmatched
+	}
+	return res.conceptID, refMatched
+}
+
+// label is how r appears in a report sample: "authority:ext_id" for an
+// id-joined row, its name otherwise.
+func (r sourceRowRef) label() string {
+	if r.extID != "" {
+		return r.authority + ":" + r.extID
+	}
+	return r.taxon
+}
+
+// resolveSourceRowRefs resolves the id-carrying refs' join keys through
+// resolveXrefJoinKeys — the very lookup IngestXrefs' join uses — and every
+// DISTINCT canonical name of the remaining refs through resolveTraitName,
+// the SP3 crosswalk ladder. It must be called with no ingest transaction
+// open (the sqlite adapter's single connection).
+func resolveSourceRowRefs(ctx context.Context, repo output.Repository, refs []sourceRowRef) (map[xrefJoinKey]string, map[string]traitResolution, error) {
+	var joins []XrefRow
+	byName := make(map[string]traitResolution)
+	for _, ref := range refs {
+		if ref.extID != "" {
+			joins = append(joins, XrefRow{JoinAuthority: ref.authority, JoinID: ref.extID})
+			continue
+		}
+		canon := domain.Canonicalize(ref.taxon)
+		if _, seen := byName[canon]; seen {
+			continue
+		}
+		res, err := resolveTraitName(ctx, repo, canon)
+		if err != nil {
+			return nil, nil, fmt.Errorf("name %q: %w", ref.taxon, err)
+		}
+		byName[canon] = res
+	}
+	byID, err := resolveXrefJoinKeys(ctx, repo, joins)
+	if err != nil {
+		return nil, nil, err
+	}
+	return byID, byName, nil
+}
+
+// distributionKey is distribution's primary key, tracked per run so a second
+// row for an already-written (concept, scheme, code) is a no-op rather than
+// a silent overwrite of the first row's status.
+type distributionKey struct {
+	conceptID string
+	scheme    string
+	code      string
+}
+
+// distributionTally accumulates the bookkeeping DistributionIngestReport
+// needs beyond its plain counters.
+type distributionTally struct {
+	unmatched map[string]bool
+	ambiguous map[string]bool
+	invalid   map[string]bool
+	concepts  map[string]bool
+	areas     map[string]bool
+	written   map[distributionKey]bool
+}
+
+func newDistributionTally() *distributionTally {
+	return &distributionTally{
+		unmatched: map[string]bool{},
+		ambiguous: map[string]bool{},
+		invalid:   map[string]bool{},
+		concepts:  map[string]bool{},
+		areas:     map[string]bool{},
+		written:   map[distributionKey]bool{},
+	}
+}
+
+// report writes the accumulated samples and coverage counts onto r.
+func (t *distributionTally) report(r *DistributionIngestReport) {
+	r.UnmatchedSample = sortedSample(t.unmatched)
+	r.AmbiguousSample = sortedSample(t.ambiguous)
+	r.InvalidSample = sortedSample(t.invalid)
+	r.Concepts = len(t.concepts)
+	r.Areas = len(t.areas)
+}
@@ -0,0 +1,140 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/distribution"
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// checklistRowSource adapts a *distribution.Dataset into
+// application.ChecklistRowSource — application never imports
+// internal/adapters/distribution directly (depguard).
+type checklistRowSource struct{ ds *distribution.Dataset }
+
+func (s checklistRowSource) Rows() []application.ChecklistRow {
+	out := make([]application.ChecklistRow, 0, len(s.ds.Rows))
+	for _, r := range s.ds.Rows {
+		out = append(out, application.ChecklistRow{
+			Taxon: r.Taxon, Authority: r.Authority, ExtID: r.ExtID,
+			Scheme: r.Scheme, Code: r.Code, Status: r.Status,
+		})
+	}
+	return out
+}
+
+// sliceChecklistSource is the minimal ChecklistRowSource for the cases the
+// CSV fixture does not carry (invalid schemes, absent verdicts).
+type sliceChecklistSource []application.ChecklistRow
+
+func (s sliceChecklistSource) Rows() []application.ChecklistRow { return s }
+
+var euromedMeta = domain.DistributionSourceMeta{
+	ID:             "euromed",
+	Version:        "2026-03",
+	License:        "CC BY-SA 4.0",
+	SourceURL:      "https://europlusmed.org",
+	Redistribution: domain.RedistributionUnknown,
+}
+
+func loadEuroMedFixture(t *testing.T) checklistRowSource {
+	t.Helper()
+	ds, err := distribution.Read("../adapters/distribution/testdata/euromed-sample.csv")
+	if err != nil {
+		t.Fatalf("distribution.Read(euromed-sample.csv): unexpected error: %v", err)
+	}
+	return checklistRowSource{ds: ds}
+}
+
+// statusesIn returns the concept's distribution rows of one scheme as
+// code -> status.
+func statusesIn(t *testing.T, repo *sqlite.DB, conceptID, scheme string) map[string]domain.DistributionStatus {
+	t.Helper()
+	_, _, _, dists, err := repo.Concept(context.Background(), conceptID)
+	if err != nil {
+		t.Fatalf("Concept(%s): unexpected error: %v", conceptID, err)
+	}
+	out := map[string]domain.DistributionStatus{}
+	for _, d := range dists {
+		if d.AreaScheme == scheme {
+			out[d.AreaCode] = d.Status
+		}
+	}
+	return out
+}
+
+// TestIngestDistributions_NameAndXrefJoins is the core round-trip: name-joined
+// and id-joined rows land on their concepts in the checklist's own scheme,
+// with the status normalized and the WCVP rows left untouched.
+func TestIngestDistributions_NameAndXrefJoins(t *testing.T) {
+	repo := seededMatchRepo(t)
+	ctx := context.Background()
+
+	report, err := application.IngestDistributions(ctx, repo, loadEuroMedFixture(t), euromedMeta)
+	if err != nil {
+		t.Fatalf("IngestDistributions: unexpected error: %v", err)
+	}
+	if report.Rows != 5 || report.Matched != 5 || report.ByXref != 2 || report.Unmatched != 0 || report.Ambiguous != 0 {
+		t.Errorf("report = %+v, want 5 rows, 5 matched (2 by xref)", report)
+	}
+	if report.Concepts != 3 || report.Areas != 4 {
+		t.Errorf("report.Concepts/Areas = %d/%d, want 3/4", report.Concepts, report.Areas)
+	}
+
+	got := statusesIn(t, repo, festucaOvinaConceptID, "euromed")
+	want := map[string]domain.DistributionStatus{"Ge": domain.DistributionNative, "Br": domain.DistributionIntroduced}
+	if len(got) != len(want) || got["Ge"] != want["Ge"] || got["Br"] != want["Br"] {
+		t.Errorf("Festuca ovina euromed rows = %v, want %v", got, want)
+	}
+	// "Native" in the source is normalized; the id-only row needs no name.
+	got = statusesIn(t, repo, "wcvp:concept:405825", "euromed")
+	if got["Ge"] != domain.DistributionNative || got["Au"] != domain.DistributionDoubtful {
+		t.Errorf("Corynephorus canescens euromed rows = %v, want Ge native, Au doubtful", got)
+	}
+	if wcvp := statusesIn(t, repo, festucaOvinaConceptID, domain.AreaSchemeWGSRPDL3); len(wcvp) == 0 {
+		t.Error("Festuca ovina lost its WCVP wgsrpd_l3 rows")
+	}
+}
+
+// TestIngestDistributions_LossIsReported pins that every row is accounted
+// for: an unknown status or unusable scheme is Invalid, an unresolved id is
+// Unmatched (never retried by name), and an absent verdict is counted but
+// never stored.
+func TestIngestDistributions_LossIsReported(t *testing.T) {
+	repo := seededMatchRepo(t)
+	ctx := context.Background()
+
+	src := sliceChecklistSource{
+		{Taxon: "Festuca ovina", Scheme: "euromed", Code: "Ge", Status: "extinct"},
+		{Taxon: "Festuca ovina", Scheme: "wgsrpd_l2", Code: "10", Status: "native"},
+		{Taxon: "Festuca ovina", Scheme: "Euro Med", Code: "Ge"},
+		{Taxon: "Festuca ovina", Authority: "powo", ExtID: "999999-9", Scheme: "euromed", Code: "Ge"},
+		{Taxon: "Festuca ovina", Scheme: "euromed", Code: "Sa", Status: "absent"},
+		{Taxon: "Nonexistia fictiva", Scheme: "euromed", Code: "Ge"},
+		{Taxon: "Festuca ovina", Scheme: "euromed", Code: "Ga", Status: "native"},
+		{Taxon: "Festuca duriuscula", Scheme: "euromed", Code: "Ga", Status: "introduced"},
+	}
+	report, err := application.IngestDistributions(ctx, repo, src, euromedMeta)
+	if err != nil {
+		t.Fatalf("IngestDistributions: unexpected error: %v", err)
+	}
+	if report.Invalid != 3 || report.Unmatched != 2 || report.Absent != 1 || report.Matched != 2 {
+		t.Errorf("report = %+v, want 3 invalid, 2 unmatched, 1 absent, 2 matched", report)
+	}
+	if sum := report.Matched + report.Unmatched + report.Ambiguous + report.Invalid + report.Absent; sum != report.Rows {
+		t.Errorf("outcomes sum to %d, want Rows = %d", sum, report.Rows)
+	}
+	if !containsString(report.UnmatchedSample, "powo:999999-9") || !containsString(report.UnmatchedSample, "Nonexistia fictiva") {
+		t.Errorf("UnmatchedSample = %v, want powo:999999-9 and Nonexistia fictiva", report.UnmatchedSample)
+	}
+
+	// The synonym's row for Ga is a no-op: the accepted name's row, written
+	// first, keeps its status.
+	got := statusesIn(t, repo, festucaOvinaConceptID, "euromed")
+	if len(got) != 1 || got["Ga"] != domain.DistributionNative {
+		t.Errorf("Festuca ovina euromed rows = %v, want only Ga native", got)
+	}
+}
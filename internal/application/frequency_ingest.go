@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// FrequencyRow is the minimal shape of one canonical frequency CSV row
+// IngestFrequencies needs, adapted from the reader's row type by the caller
+// (the ChecklistRow bridge pattern, depguard). The concept is named by
+// Authority/ExtID when both are set, by Taxon otherwise; Count is verbatim.
+type FrequencyRow struct {
+	Taxon     string
+	Authority string
+	ExtID     string
+	Count     string
+}
+
+// FrequencyRowSource streams one frequency source's rows for
+// IngestFrequencies.
+type FrequencyRowSource interface {
+	Rows() []FrequencyRow
+}
+
+// FrequencyIngestReport summarizes one frequency source's run.
+// Matched+Unmatched+Ambiguous+Invalid always sums to Rows.
+type FrequencyIngestReport struct {
+	Source    string
+	Rows      int
+	Matched   int
+	Unmatched int
+	Ambiguous int
+	// ByXref counts the Matched rows that resolved through their
+	// authority/ext_id rather than their name.
+	ByXref int
+	// Invalid counts rows whose count is not a non-negative integer. Checked
+	// before resolution, so an invalid row is never also counted as
+	// unmatched.
+	Invalid int
+	// Concepts is the number of distinct concepts that received a count.
+	// Fewer than Matched when several rows — a taxon and its synonyms —
+	// resolved to one concept; their counts are summed.
+	Concepts int
+	// ReaderErrors counts rows the reader rejected before this use case saw
+	// them, so Rows + ReaderErrors accounts for every line of the artifact.
+	ReaderErrors int
+	// UnmatchedSample, AmbiguousSample and InvalidSample are bounded,
+	// deterministic samples (sortedSample), labelled like
+	// DistributionIngestReport's.
+	UnmatchedSample []string
+	AmbiguousSample []string
+	InvalidSample   []string
+	// Redistribution is this source's manifest-pinned redistribution value.
+	// Local ingest is never gated by it; EXPORT is (see ExportBundle).
+	Redistribution string
+}
+
+// IngestFrequencies resolves every row src provides to a concept, sums the
+// counts per concept, and records each total as meta.ID's frequency for that
+// concept, then records meta as the source's provenance. Suggest reads the
+// totals as a popularity signal (domain.RankSuggestionsWeighted).
+//
+// Resolution is IngestDistributions' exactly (resolveSourceRowRefs): RESOLVE
+// first and WRITE second, an id that does not resolve is Unmatched, and a
+// name resolving to several concepts is Ambiguous rather than guessed.
+// Summing is what a count filed under a synonym needs — its records are
+// records of the accepted taxon.
+//
+// Phase 2 uses repo.BeginTraitIngest: a frequency source is not a backbone
+// and must never leave a backbone_version row.
+func IngestFrequencies(ctx context.Context, repo output.Repository, src FrequencyRowSource, meta domain.FrequencySourceMeta) (FrequencyIngestReport, error) {
+	report := FrequencyIngestReport{Source: meta.ID, Redistribution: string(meta.Redistribution)}
+	rows := src.Rows()
+	report.Rows = len(rows)
+
+	refs := make([]sourceRowRef, len(rows))
+	for i, row := range rows {
+		refs[i] = sourceRowRef{taxon: row.Taxon, authority: row.Authority, extID: row.ExtID}
+	}
+	byID, byName, err := resolveSourceRowRefs(ctx, repo, refs)
+	if err != nil {
+		return report, fmt.Errorf("application: resolving frequency source %q: %w", meta.ID, err)
+	}
+
+	unmatched := map[string]bool{}
+	ambiguous := map[string]bool{}
+	invalid := map[string]bool{}
+	totals := map[string]int64{}
+	var order []string
+	for i, row := range rows {
+		count, err := strconv.ParseInt(row.Count, 10, 64)
+		if err != nil || count < 0 {
+			report.Invalid++
+			invalid[refs[i].label()+" "+row.Count] = true
+			continue
+		}
+		conceptID, outcome := refs[i].lookup(byID, byName)
+		switch outcome {
+		case refAmbiguous:
+			report.Ambiguous++
+			ambiguous[refs[i].label()] = true
+			continue
+		case refUnmatched:
+			report.Unmatched++
+			unmatched[refs[i].label()] = true
+			continue
+		}
+		report.Matched++
+		if row.ExtID != "" {
+			report.ByXref++
+		}
+		if _, seen := totals[conceptID]; !seen {
+			order = append(order, conceptID)
+		}
+		totals[conceptID] += count
+	}
+
+	tx, err := repo.BeginTraitIngest(ctx)
+	if err != nil {
+		return report, fmt.Errorf("application: starting frequency ingest for %q: %w", meta.ID, err)
+	}
+	if err := tx.UpsertFrequencySource(meta); err != nil {
+		_ = tx.Rollback()
+		return report, fmt.Errorf("application: recording frequency source %q: %w", meta.ID, err)
+	}
+	for _, conceptID := range order {
+		if err := tx.SetFrequency(conceptID, meta.ID, totals[conceptID]); err != nil {
+			_ = tx.Rollback()
+			return report, fmt.Errorf("application: writing frequency for concept %q, source %q: %w", conceptID, meta.ID, err)
+		}
+	}
+	if err := tx.Finalize(); err != nil {
+		_ = tx.Rollback()
+		return report, fmt.Errorf("application: finalizing frequency ingest for %q: %w", meta.ID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return report, fmt.Errorf("application: committing frequency ingest for %q: %w", meta.ID, err)
+	}
+
+	report.Concepts = len(order)
+	report.UnmatchedSample = sortedSample(unmatched)
+	report.AmbiguousSample = sortedSample(ambiguous)
+	report.InvalidSample = sortedSample(invalid)
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/frequency"
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// frequencyRowSource adapts a *frequency.Dataset into
+// application.FrequencyRowSource, like checklistRowSource.
+type frequencyRowSource struct{ ds *frequency.Dataset }
+
+func (s frequencyRowSource) Rows() []application.FrequencyRow {
+	out := make([]application.FrequencyRow, 0, len(s.ds.Rows))
+	for _, r := range s.ds.Rows {
+		out = append(out, application.FrequencyRow{Taxon: r.Taxon, Authority: r.Authority, ExtID: r.ExtID, Count: r.Count})
+	}
+	return out
+}
+
+// sliceFrequencySource is the minimal FrequencyRowSource for the loss cases.
+type sliceFrequencySource []application.FrequencyRow
+
+func (s sliceFrequencySource) Rows() []application.FrequencyRow { return s }
+
+var releveeMeta = domain.FrequencySourceMeta{
+	ID:             "veg_relevees",
+	Version:        "2026-01",
+	Redistribution: domain.RedistributionUnknown,
+}
+
+// suggestFrequency returns the Frequency Suggest reports for conceptID on
+// query q, failing when the concept is not among the results.
+func suggestFrequency(t *testing.T, repo *sqlite.DB, q, conceptID string) int64 {
+	t.Helper()
+	items, err := repo.Suggest(context.Background(), q, output.SuggestOpts{Limit: 20})
+	if err != nil {
+		t.Fatalf("Suggest(%q): unexpected error: %v", q, err)
+	}
+	for _, it := range items {
+		if it.ConceptID == conceptID {
+			return it.Frequency
+		}
+	}
+	t.Fatalf("Suggest(%q) = %+v, want %s among the results", q, items, conceptID)
+	return 0
+}
+
+// TestIngestFrequencies_SumsPerConcept is the core round-trip: the synonym's
+// count adds to its accepted concept's, and an id-joined row lands on its
+// concept.
+func TestIngestFrequencies_SumsPerConcept(t *testing.T) {
+	repo := seededMatchRepo(t)
+	ds, err := frequency.Read("../adapters/frequency/testdata/relevees-sample.csv")
+	if err != nil {
+		t.Fatalf("frequency.Read: unexpected error: %v", err)
+	}
+
+	report, err := application.IngestFrequencies(context.Background(), repo, frequencyRowSource{ds: ds}, releveeMeta)
+	if err != nil {
+		t.Fatalf("IngestFrequencies: unexpected error: %v", err)
+	}
+	if report.Rows != 3 || report.Matched != 3 || report.ByXref != 1 || report.Concepts != 2 {
+		t.Errorf("report = %+v, want 3 rows, 3 matched (1 by xref), 2 concepts", report)
+	}
+	if got := suggestFrequency(t, repo, "festuca ovina", festucaOvinaConceptID); got != 1235 {
+		t.Errorf("Festuca ovina frequency = %d, want 1235 (1200 + the synonym's 35)", got)
+	}
+	if got := suggestFrequency(t, repo, "coryn", "wcvp:concept:405825"); got != 340 {
+		t.Errorf("Corynephorus canescens frequency = %d, want 340", got)
+	}
+}
+
+// TestIngestFrequencies_LossIsReported pins that every row is accounted for:
+// a count that is not a non-negative integer is Invalid, and an unresolved id
+// or name is Unmatched.
+func TestIngestFrequencies_LossIsReported(t *testing.T) {
+	repo := seededMatchRepo(t)
+
+	src := sliceFrequencySource{
+		{Taxon: "Festuca ovina", Count: "-3"},
+		{Taxon: "Festuca ovina", Count: "many"},
+		{Authority: "powo", ExtID: "999999-9", Count: "4"},
+		{Taxon: "Nonexistia fictiva", Count: "4"},
+		{Taxon: "Festuca ovina", Count: "12"},
+	}
+	report, err := application.IngestFrequencies(context.Background(), repo, src, releveeMeta)
+	if err != nil {
+		t.Fatalf("IngestFrequencies: unexpected error: %v", err)
+	}
+	if report.Invalid != 2 || report.Unmatched != 2 || report.Matched != 1 {
+		t.Errorf("report = %+v, want 2 invalid, 2 unmatched, 1 matched", report)
+	}
+	if sum := report.Matched + report.Unmatched + report.Ambiguous + report.Invalid; sum != report.Rows {
+		t.Errorf("outcomes sum to %d, want Rows = %d", sum, report.Rows)
+	}
+	if !containsString(report.UnmatchedSample, "powo:999999-9") || !containsString(report.UnmatchedSample, "Nonexistia fictiva") {
+		t.Errorf("UnmatchedSample = %v, want powo:999999-9 and Nonexistia fictiva", report.UnmatchedSample)
+	}
+	if got := suggestFrequency(t, repo, "festuca ovina", festucaOvinaConceptID); got != 12 {
+		t.Errorf("Festuca ovina frequency = %d, want 12", got)
+	}
+}
@@ -35,6 +35,11 @@ type Backbone struct {
 type Dataset struct {
 	Backbones   []Backbone
 	ManifestSHA string
+	// StrictParentChains is not read from the manifest but set by whoever
+	// runs the ingest ("hostus ingest --strict-parents"): it makes a backbone
+	// whose parent chains are broken (BackboneReport.ParentChains) fail with
+	// ErrBrokenParentChains instead of being committed and reported.
+	StrictParentChains bool
 }
 
 // TaxonRow is the minimal, backbone-agnostic shape of one taxon record
@@ -74,6 +79,14 @@ type TaxonRow struct {
 	// defaulted or dropped here — an empty source value stays empty.
 	PublishedIn string
 	NomStatus   string
+	// Family is the source row's family label (WCVP family), carried onto
+	// the concept as domain.Concept.Family; "" if the source has none.
+	Family string
+	// HybridFormula is the source row's hybrid formula (WCVP
+	// dynamicproperties "hybridformula"), or "" if none. An accepted row
+	// whose formula domain.ParseHybridFormula reads gets its parents stored
+	// as hybrid parents of its concept; a formula it cannot read is dropped.
+	HybridFormula string
 }
 
 // DistributionRow is one area assignment, joined to a TaxonRow by TaxonID.
@@ -84,6 +97,11 @@ type DistributionRow struct {
 	// source carried none. Captured once per area into the area lookup table so
 	// GET /v1/areas can offer "Germany (GER)".
 	AreaName string
+	// Introduced marks an area the taxon reached by human agency (WCVP
+	// establishmentmeans "introduced"). The row is stored with
+	// domain.DistributionIntroduced, every other backbone row with
+	// domain.DistributionNative.
+	Introduced bool
 }
 
 // RowSource streams one backbone's rows for Ingest. The caller adapts a
@@ -131,6 +149,10 @@ type BackboneReport struct {
 	// sample of the verbatim rank spellings counted in OtherRanks, most
 	// frequent first (ties broken alphabetically) — see sortedRankCounts.
 	OtherRankSample []RankVerbatimCount
+	// ParentChains is what the parent-chain check after pass 2 found: parent
+	// cycles, dangling parents, synonym parents and rank inversions among
+	// the accepted rows (see ParentChainReport).
+	ParentChains ParentChainReport
 	// Redistribution is this backbone's manifest-pinned redistribution
 	// value (see domain.Redistribution), surfaced here so "hostus ingest"
 	// can print a notice for anything that is not "allowed" — the local
@@ -166,7 +188,8 @@ type IngestReport struct {
 //  1. Every taxon row's Name is upserted. Rows that are the accepted name
 //     for their group (TaxonRow.Accepted) additionally get a Concept,
 //     an "accepted" concept_name link, a powo xref (if POWOID is set),
-//     and their own distribution rows.
+//     their own distribution rows and, for a nothotaxon with a readable
+//     HybridFormula, its hybrid parents.
 //  2. Every non-accepted (synonym) row is linked to its accepted Concept
 //     (resolved via AcceptedTaxonID), grouping synonyms under the accepted
 //     taxon rather than giving them their own concept. Rows whose accepted
@@ -175,6 +198,12 @@ type IngestReport struct {
 //     mirrors the source backbone readers' tolerance of dirty real-world
 //     data.
 //
+// After pass 2 the parent links of the accepted rows are checked (see
+// ParentChainReport) and the findings reported. In strict mode
+// (ds.StrictParentChains) a backbone with any finding is rolled back and
+// Ingest fails with ErrBrokenParentChains; otherwise it is committed as
+// before — a broken link already degrades to a root concept in pass 1.
+//
 // Each backbone_version record ds.ManifestSHA binds the ingest to the exact
 // manifest revision that was validated.
 func Ingest(ctx context.Context, ds *Dataset, readerFor func(Backbone) (RowSource, error), repo output.Repository) (IngestReport, error) {
@@ -184,7 +213,7 @@ func Ingest(ctx context.Context, ds *Dataset, readerFor func(Backbone) (RowSourc
 		if err != nil {
 			return report, fmt.Errorf("application: opening reader for backbone %q: %w", b.ID, err)
 		}
-		br, err := ingestBackbone(ctx, b, ds.ManifestSHA, rs, repo)
+		br, err := ingestBackbone(ctx, b, ds.ManifestSHA, ds.StrictParentChains, rs, repo)
 		if err != nil {
 			return report, err
 		}
@@ -280,7 +309,7 @@ func basionymIDsByTaxon(b Backbone, taxa []TaxonRow, present map[string]bool) ma
 	return m
 }
 
-func ingestBackbone(ctx context.Context, b Backbone, manifestSHA string, rs RowSource, repo output.Repository) (BackboneReport, error) {
+func ingestBackbone(ctx context.Context, b Backbone, manifestSHA string, strict bool, rs RowSource, repo output.Repository) (BackboneReport, error) {
 	report := BackboneReport{ID: b.ID, Redistribution: b.Redistribution}
 
 	tx, err := repo.BeginIngest(ctx, domain.BackboneVersion{
@@ -327,6 +356,11 @@ func ingestBackbone(ctx context.Context, b Backbone, manifestSHA string, rs RowS
 		_ = tx.Rollback()
 		return report, err
 	}
+	st.checkParentChains(taxa, present, &report)
+	if strict && report.ParentChains.Broken() {
+		_ = tx.Rollback()
+		return report, parentChainsError(b.ID, report.ParentChains)
+	}
 	st.finalizeOtherRanksReport(&report)
 	// Record each distribution area's name once (INSERT OR IGNORE) so
 	// GET /v1/areas can offer "Germany (GER)". Scheme matches AddDistribution's
@@ -382,14 +416,17 @@ func (st *ingestState) pass1AcceptedAndNames(taxa []TaxonRow, report *BackboneRe
 		// namespace, e.g. "396681-1") — spec §A.1's nomenclatural anchor —
 		// so every name's ipni_id is populated straight from it, not just
 		// the accepted row's powo xref.
+		// PublishedIn stays verbatim; only the year is read out of it,
+		// for the published-before filters.
 		name := domain.Name{
-			ID:          nameID(b.ID, row.TaxonID),
-			Canonical:   row.Canonical,
-			Authorship:  row.Authorship,
-			Rank:        rank,
-			IPNIID:      row.POWOID,
-			PublishedIn: row.PublishedIn,
-			NomStatus:   row.NomStatus,
+			ID:            nameID(b.ID, row.TaxonID),
+			Canonical:     row.Canonical,
+			Authorship:    row.Authorship,
+			Rank:          rank,
+			IPNIID:        row.POWOID,
+			PublishedIn:   row.PublishedIn,
+			PublishedYear: domain.ParsePublication(row.PublishedIn).Year,
+			NomStatus:     row.NomStatus,
 		}
 		if rank == domain.RankOther {
 			name.RankVerbatim = verbatim
@@ -450,6 +487,7 @@ func (st *ingestState) upsertAcceptedConcept(row TaxonRow, name domain.Name, ran
 		// they're always identical (see domain.Concept.RankVerbatim's
 		// doc comment for why it's still its own field).
 		RankVerbatim: name.RankVerbatim,
+		Family:       row.Family,
 	}
 	if err := st.tx.UpsertConcept(concept); err != nil {
 		return domain.Concept{}, fmt.Errorf("application: backbone %q: %w", b.ID, err)
@@ -466,11 +504,24 @@ func (st *ingestState) upsertAcceptedConcept(row TaxonRow, name domain.Name, ran
 			return domain.Concept{}, fmt.Errorf("application: backbone %q: %w", b.ID, err)
 		}
 	}
+	// Source "" for the same reason as the powo xref above: the backbone's
+	// own range is gated by the backbone's redistribution value.
 	for _, d := range st.distByTaxon[row.TaxonID] {
-		if err := st.tx.AddDistribution(cID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: d.AreaCode}); err != nil {
+		status := domain.DistributionNative
+		if d.Introduced {
+			status = domain.DistributionIntroduced
+		}
+		if err := st.tx.AddDistribution(cID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: d.AreaCode, Status: status}, ""); err != nil {
 			return domain.Concept{}, fmt.Errorf("application: backbone %q: %w", b.ID, err)
 		}
 	}
+	if parents, ok := domain.ParseHybridFormula(row.HybridFormula); ok {
+		for i, p := range parents {
+			if err := st.tx.AddHybridParent(cID, i, p); err != nil {
+				return domain.Concept{}, fmt.Errorf("application: backbone %q: %w", b.ID, err)
+			}
+		}
+	}
 	return concept, nil
 }
 
@@ -3,6 +3,7 @@ package application_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sort"
 	"testing"
 
@@ -37,6 +38,8 @@ func (s wcvpRowSource) Taxa() []application.TaxonRow {
 			BasionymTaxonID: t.OriginalNameUsageID,
 			PublishedIn:     t.PublishedIn,
 			NomStatus:       t.NomenclaturalStatus,
+			Family:          t.Family,
+			HybridFormula:   t.HybridFormula(),
 		})
 	}
 	return out
@@ -45,7 +48,7 @@ func (s wcvpRowSource) Taxa() []application.TaxonRow {
 func (s wcvpRowSource) Distributions() []application.DistributionRow {
 	out := make([]application.DistributionRow, 0, len(s.ds.Distributions))
 	for _, d := range s.ds.Distributions {
-		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode()})
+		out = append(out, application.DistributionRow{TaxonID: d.CoreID, AreaCode: d.AreaCode(), Introduced: d.Introduced()})
 	}
 	return out
 }
@@ -306,6 +309,9 @@ func TestIngest_WCVPFixture_CarriesNomStatusAndPublishedIn(t *testing.T) {
 	if got, want := concept.AcceptedName.PublishedIn, "Sp. Pl.: 73 (1753)"; got != want {
 		t.Errorf("Festuca ovina.PublishedIn = %q, want %q", got, want)
 	}
+	if got := concept.AcceptedName.PublishedYear; got != 1753 {
+		t.Errorf("Festuca ovina.PublishedYear = %d, want 1753 (parsed from the citation)", got)
+	}
 	if got := concept.AcceptedName.NomStatus; got != "" {
 		t.Errorf("Festuca ovina.NomStatus = %q, want empty (the fixture row's nomenclaturalstatus is empty and must not gain a placeholder)", got)
 	}
@@ -340,6 +346,9 @@ func TestIngest_WCVPFixture_CarriesNomStatusAndPublishedIn(t *testing.T) {
 	if got, want := bromus.PublishedIn, "Fl. Carniol., ed. 2, 1: 77 (1771)"; got != want {
 		t.Errorf("Bromus ovinus.PublishedIn = %q, want %q", got, want)
 	}
+	if got := bromus.PublishedYear; got != 1771 {
+		t.Errorf("Bromus ovinus.PublishedYear = %d, want 1771", got)
+	}
 }
 
 // TestIngest_WCVPFixture_HomotypicRule proves T7's conservative homotypic
@@ -846,6 +855,88 @@ func TestIngest_ReaderErrorPropagates(t *testing.T) {
 	}
 }
 
+// brokenParentTaxa is one accepted row per parent-chain finding, next to an
+// intact genus/species pair: "c1" and "c2" parent each other, "d" names an
+// absent parent, "s" a synonym row, and the species "r" sits under the
+// variety "v". "hy" (SUBVARIETY under the NOTHOVARIETY "nv") and "x" (an
+// exotic rank under the species) are not inversions.
+func brokenParentTaxa() []application.TaxonRow {
+	acc := func(id, rank, parent string) application.TaxonRow {
+		return application.TaxonRow{TaxonID: id, AcceptedTaxonID: id, Accepted: true, Canonical: "Taxon " + id, Rank: rank, ParentTaxonID: parent}
+	}
+	return []application.TaxonRow{
+		acc("g", "GENUS", ""),
+		acc("sp", "SPECIES", "g"),
+		acc("nv", "NOTHOVARIETY", "sp"),
+		acc("hy", "SUBVARIETY", "nv"),
+		acc("x", "proles", "sp"),
+		acc("c1", "SPECIES", "c2"),
+		acc("c2", "GENUS", "c1"),
+		acc("d", "SPECIES", "absent"),
+		{TaxonID: "syn", AcceptedTaxonID: "g", Canonical: "Synonym", Rank: "GENUS"},
+		acc("s", "SPECIES", "syn"),
+		acc("v", "VARIETY", "sp"),
+		acc("r", "SPECIES", "v"),
+	}
+}
+
+// TestIngest_ReportsBrokenParentChains pins each finding of the parent-chain
+// check and that, outside strict mode, the backbone is committed anyway.
+// "hy" parented to "nv" is the nothotaxon case; "c2" (GENUS) under "c1"
+// (SPECIES) is both on the cycle and an inversion.
+func TestIngest_ReportsBrokenParentChains(t *testing.T) {
+	ds := &application.Dataset{Backbones: []application.Backbone{{ID: "bb", Version: "v1"}}, ManifestSHA: "deadbeef"}
+	repo := openMemoryRepo(t)
+	ctx := context.Background()
+	readerFor := func(application.Backbone) (application.RowSource, error) {
+		return fakeRowSource{taxa: brokenParentTaxa()}, nil
+	}
+
+	report, err := application.Ingest(ctx, ds, readerFor, repo)
+	if err != nil {
+		t.Fatalf("Ingest: unexpected error: %v", err)
+	}
+	got := report.Backbones[0].ParentChains
+	want := application.ParentChainReport{
+		Cycles: 1, CycleSample: []string{"c1"},
+		DanglingParents: 1, DanglingParentSample: []string{"d"},
+		SynonymParents: 1, SynonymParentSample: []string{"s"},
+		RankInversions: 2, RankInversionSample: []string{"c2", "r"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParentChains = %+v, want %+v", got, want)
+	}
+	if c := mustConcept(ctx, t, repo, "bb:concept:s"); c.ParentID != "" {
+		t.Errorf("synonym-parented concept.ParentID = %q, want empty (written as a root)", c.ParentID)
+	}
+}
+
+// TestIngest_StrictParentChainsRollsBack pins strict mode: the broken
+// backbone fails with ErrBrokenParentChains and leaves nothing behind.
+func TestIngest_StrictParentChainsRollsBack(t *testing.T) {
+	ds := &application.Dataset{Backbones: []application.Backbone{{ID: "bb", Version: "v1"}}, ManifestSHA: "deadbeef", StrictParentChains: true}
+	repo := openMemoryRepo(t)
+	ctx := context.Background()
+	readerFor := func(application.Backbone) (application.RowSource, error) {
+		return fakeRowSource{taxa: brokenParentTaxa()}, nil
+	}
+
+	if _, err := application.Ingest(ctx, ds, readerFor, repo); !errors.Is(err, application.ErrBrokenParentChains) {
+		t.Fatalf("Ingest error = %v, want application.ErrBrokenParentChains", err)
+	}
+	if _, _, _, _, err := repo.Concept(ctx, "bb:concept:g"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Concept(bb:concept:g) error = %v, want domain.ErrNotFound (the backbone rolled back)", err)
+	}
+
+	ds.Backbones[0].ID = "ok"
+	readerFor = func(application.Backbone) (application.RowSource, error) {
+		return fakeRowSource{taxa: brokenParentTaxa()[:4]}, nil
+	}
+	if _, err := application.Ingest(ctx, ds, readerFor, repo); err != nil {
+		t.Errorf("Ingest(intact chains, strict) error = %v, want nil", err)
+	}
+}
+
 type fakeRowSource struct {
 	taxa  []application.TaxonRow
 	dists []application.DistributionRow
@@ -875,9 +966,27 @@ func (f *fakeCapturingRepo) BeginIngest(context.Context, domain.BackboneVersion)
 func (f *fakeCapturingRepo) Concept(context.Context, string) (*domain.Concept, []output.SynonymName, []domain.Xref, []domain.Distribution, error) {
 	panic("not needed by Ingest")
 }
+func (f *fakeCapturingRepo) Concepts(context.Context, []string, output.ConceptInclude) ([]output.ConceptDocument, error) {
+	panic("not needed by Ingest")
+}
+func (f *fakeCapturingRepo) TreatmentLinks(context.Context, string) ([]output.TreatmentLink, error) {
+	panic("not needed by Ingest")
+}
 func (f *fakeCapturingRepo) Classification(context.Context, string) ([]domain.ClassificationEntry, error) {
 	panic("not needed by Ingest")
 }
+func (f *fakeCapturingRepo) HigherTaxa(context.Context, []string) (map[string]domain.HigherTaxa, error) {
+	panic("not needed by Ingest")
+}
+func (f *fakeCapturingRepo) Children(context.Context, string, output.TreeOpts) ([]domain.TaxonNode, int, error) {
+	panic("not needed by Ingest")
+}
+func (f *fakeCapturingRepo) DescendantCounts(context.Context, string, output.TreeOpts) (map[domain.Rank]int, error) {
+	panic("not needed by Ingest")
+}
+func (f *fakeCapturingRepo) Checklist(context.Context, output.ChecklistOpts) ([]domain.ChecklistEntry, error) {
+	panic("not needed by Ingest")
+}
 func (f *fakeCapturingRepo) ConceptByXref(context.Context, string, string) (*domain.Concept, error) {
 	panic("not needed by Ingest")
 }
@@ -890,12 +999,19 @@ func (f *fakeCapturingRepo) MatchExact(context.Context, string) ([]output.MatchC
 func (f *fakeCapturingRepo) MatchFuzzyCandidates(context.Context, string, int, string, string) ([]output.MatchCandidate, error) {
 	panic("not needed by Ingest")
 }
+func (f *fakeCapturingRepo) SuggestPrefixes(context.Context, string, int) ([]string, error) {
+	panic("not needed by Ingest")
+}
 func (f *fakeCapturingRepo) BackboneVersions(context.Context) ([]domain.BackboneVersion, error) {
 	panic("not needed by Ingest")
 }
 func (f *fakeCapturingRepo) BuildDistributionClosure(context.Context) error {
 	panic("not needed by Ingest")
 }
+
+func (f *fakeCapturingRepo) BuildLineageClosure(context.Context) error {
+	panic("not needed by Ingest")
+}
 func (f *fakeCapturingRepo) Traits(context.Context, string, []domain.TraitVocab) ([]domain.TraitSet, error) {
 	panic("not needed by Ingest")
 }
@@ -914,12 +1030,30 @@ func (f *fakeCapturingRepo) Suggest(context.Context, string, output.SuggestOpts)
 func (f *fakeCapturingRepo) SynonymCandidates(context.Context, string) ([]domain.SynonymCandidate, error) {
 	panic("not needed by Ingest")
 }
+func (f *fakeCapturingRepo) NameRecord(context.Context, string) (output.NameRecord, error) {
+	panic("not needed by Ingest")
+}
+func (f *fakeCapturingRepo) NameUsages(context.Context, string) ([]domain.Name, []output.NameUsage, error) {
+	panic("not needed by Ingest")
+}
 func (f *fakeCapturingRepo) ExistingConceptIDs(context.Context, []string) (map[string]bool, error) {
 	return nil, nil
 }
 
 func (f *fakeCapturingRepo) Areas(context.Context) ([]domain.Area, error) { return nil, nil }
 
+func (f *fakeCapturingRepo) AreaPresence(context.Context, string, []string) (map[string]output.AreaPresence, error) {
+	return nil, nil
+}
+
+func (f *fakeCapturingRepo) UsageTargets(context.Context, []string) ([]output.UsageTarget, error) {
+	return nil, nil
+}
+
+func (f *fakeCapturingRepo) MatchHybridParents(context.Context, []string) ([]output.MatchCandidate, error) {
+	panic("not needed by Ingest")
+}
+
 func (f *fakeCapturingRepo) SecReferences(context.Context) ([]domain.SecReference, error) {
 	return nil, nil
 }
@@ -953,15 +1087,19 @@ func (t *fakeCapturingTx) UpsertName(n domain.Name) error {
 	t.names[n.ID] = n
 	return nil
 }
-func (t *fakeCapturingTx) UpsertConcept(domain.Concept) error                { return nil }
-func (t *fakeCapturingTx) LinkName(string, string, string, *bool) error      { return nil }
-func (t *fakeCapturingTx) AddXref(string, domain.Xref, string) error         { return nil }
-func (t *fakeCapturingTx) AddDistribution(string, domain.Distribution) error { return nil }
-func (t *fakeCapturingTx) UpsertArea(domain.Area) error                      { return nil }
-func (t *fakeCapturingTx) AddTraitValue(string, domain.TraitValue) error     { return nil }
-func (t *fakeCapturingTx) UpsertTraitVocabulary(domain.TraitVocabMeta) error { return nil }
-func (t *fakeCapturingTx) UpsertXrefSource(domain.XrefSourceMeta) error      { return nil }
-func (t *fakeCapturingTx) UpsertNameSpace(domain.NameSpaceMeta) error        { return nil }
+func (t *fakeCapturingTx) UpsertConcept(domain.Concept) error                           { return nil }
+func (t *fakeCapturingTx) LinkName(string, string, string, *bool) error                 { return nil }
+func (t *fakeCapturingTx) AddXref(string, domain.Xref, string) error                    { return nil }
+func (t *fakeCapturingTx) AddDistribution(string, domain.Distribution, string) error    { return nil }
+func (t *fakeCapturingTx) UpsertArea(domain.Area) error                                 { return nil }
+func (t *fakeCapturingTx) AddAreaAlias(domain.AreaAlias) error                          { return nil }
+func (t *fakeCapturingTx) AddTraitValue(string, domain.TraitValue) error                { return nil }
+func (t *fakeCapturingTx) UpsertTraitVocabulary(domain.TraitVocabMeta) error            { return nil }
+func (t *fakeCapturingTx) UpsertXrefSource(domain.XrefSourceMeta) error                 { return nil }
+func (t *fakeCapturingTx) UpsertDistributionSource(domain.DistributionSourceMeta) error { return nil }
+func (t *fakeCapturingTx) UpsertFrequencySource(domain.FrequencySourceMeta) error       { return nil }
+func (t *fakeCapturingTx) SetFrequency(string, string, int64) error                     { return nil }
+func (t *fakeCapturingTx) UpsertNameSpace(domain.NameSpaceMeta) error                   { return nil }
 func (t *fakeCapturingTx) AddNameSpaceEntry(string, domain.NameSpaceEntry) error {
 	return nil
 }
@@ -969,6 +1107,9 @@ func (t *fakeCapturingTx) UpsertSecReference(domain.SecReference) error { return
 func (t *fakeCapturingTx) AddConceptRelation(string, string, domain.Relation, string) error {
 	return nil
 }
+func (t *fakeCapturingTx) AddMisapplication(string, string, string) error { return nil }
+func (t *fakeCapturingTx) AddHybridParent(string, int, string) error      { return nil }
+
 func (t *fakeCapturingTx) Finalize() error { return nil }
 func (t *fakeCapturingTx) Commit() error   { return nil }
 func (t *fakeCapturingTx) Rollback() error { return nil }
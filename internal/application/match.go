@@ -32,12 +32,30 @@ var (
 // The zero value is no filter: resolution is then byte-for-byte the unfiltered
 // match. Backbone and Sec compose with AND; Sec alone implies a sec-bearing
 // concept (WCVP candidates, whose SecReference is empty, are then dropped).
+//
+// Area is different in kind: it never drops a candidate. It names the region
+// the names were recorded in (resolved like output.SuggestOpts.Area) and is
+// used twice — as the last tie-break (see areaWinner) and to flag each
+// resolved result's plausibility (MatchResult.Range). apply and empty ignore
+// it on purpose: narrowing by range would silently resolve a misidentified
+// name to whatever local taxon shares it, which is the very error the flag
+// exists to surface.
+//
+// PublishedBefore, when > 0, drops a candidate whose MATCHED name — the
+// spelling the verbatim resolved to, not the concept's accepted name — is
+// known to be published in or after that year: a label written in 1850
+// cannot carry a name first published in 1900. A name of unknown year
+// (domain.Name.PublishedYear 0) is kept, since nothing says it is younger.
 type MatchFilter struct {
-	Backbone string
-	Sec      string
+	Backbone        string
+	Sec             string
+	Area            string
+	PublishedBefore int
 }
 
-func (f MatchFilter) empty() bool { return f.Backbone == "" && f.Sec == "" }
+func (f MatchFilter) empty() bool {
+	return f.Backbone == "" && f.Sec == "" && f.PublishedBefore <= 0
+}
 
 // apply drops the candidates that do not match the filter. A zero filter
 // returns the slice unchanged (the byte-identical unfiltered path).
@@ -53,6 +71,9 @@ func (f MatchFilter) apply(cands []output.MatchCandidate) []output.MatchCandidat
 		if f.Sec != "" && c.Concept.SecReference != f.Sec {
 			continue
 		}
+		if f.PublishedBefore > 0 && c.MatchedName.PublishedYear >= f.PublishedBefore {
+			continue
+		}
 		kept = append(kept, c)
 	}
 	return kept
@@ -137,6 +158,22 @@ const (
 	// 0.8 would take the guesses and reject the certainties. The first draft
 	// used 0.75 and did exactly that.
 	confidenceAggregateNominate = 0.88
+	// One step below the nominate fallback, for the same reason that one sits
+	// below exact: the concept is certain, but a genus answers a relevé row
+	// far more coarsely than a nominate species answers an aggregate. Still
+	// above domain.FuzzyThreshold, by the argument above — it is not a guess.
+	confidenceHigherRank = 0.87
+	// One more step down: the concept is the one a stored misapplied /
+	// pro-parte edge names, i.e. certain as far as the source is, but reached
+	// by following a usage statement rather than the name itself. Still above
+	// domain.FuzzyThreshold — it is a curated edge, not a guess.
+	confidenceMisapplied = 0.86
+	// Between exact and the nominate fallback: the nothotaxon is the one the
+	// backbone itself records as arising from exactly these parents, so the
+	// answer covers what was asked — neither less (nominate) nor more
+	// (genus). It still sits below exact, since the name was never written;
+	// the hybrid was identified by its parentage.
+	confidenceHybridFormula = 0.89
 )
 
 // fuzzyCandidateLimit bounds how many repo.MatchFuzzyCandidates rows
@@ -164,14 +201,30 @@ const fuzzyReviewFloor = 0.70
 
 // Notes attached to results that need a human's attention.
 const (
-	noteAggregateResolved   = "Aggregat, keine Kleinartauflösung"
-	noteAggregateUnresolved = "Aggregat ohne aufgelöstes Sammelart-Konzept"
-	noteAggregateNominate   = "Aggregat: keine Sammelart im Index, aufgelöst auf das Nominal-Konzept — deckt weniger ab als die Anfrage"
-	noteUnresolvable        = "Kein eindeutiger Treffer, keine Fuzzy-Auflösung in dieser SP"
-	noteNearMiss            = "Nicht aufgelöst: kein Treffer über der Ähnlichkeitsschwelle. Die gelisteten Kandidaten sind die nächstliegenden Namen im Index, zur manuellen Prüfung — KEINE Auflösung"
-	noteAmbiguous           = "Mehrdeutiger Treffer: mehrere Konzepte mit gleicher Übereinstimmungsstärke, manuelle Prüfung nötig"
-	noteFuzzy               = "Fuzzy-Treffer: Ähnlichkeit über Schwellenwert, manuelle Prüfung erforderlich"
-	noteFuzzyAmbiguous      = "Mehrdeutiger Fuzzy-Treffer: mehrere Konzepte mit gleicher Ähnlichkeit, manuelle Prüfung nötig"
+	noteAggregateResolved      = "Aggregat, keine Kleinartauflösung"
+	noteAggregateUnresolved    = "Aggregat ohne aufgelöstes Sammelart-Konzept"
+	noteAggregateNominate      = "Aggregat: keine Sammelart im Index, aufgelöst auf das Nominal-Konzept — deckt weniger ab als die Anfrage"
+	noteUnresolvable           = "Kein eindeutiger Treffer, keine Fuzzy-Auflösung in dieser SP"
+	noteNearMiss               = "Nicht aufgelöst: kein Treffer über der Ähnlichkeitsschwelle. Die gelisteten Kandidaten sind die nächstliegenden Namen im Index, zur manuellen Prüfung — KEINE Auflösung"
+	noteAmbiguous              = "Mehrdeutiger Treffer: mehrere Konzepte mit gleicher Übereinstimmungsstärke, manuelle Prüfung nötig"
+	noteFuzzy                  = "Fuzzy-Treffer: Ähnlichkeit über Schwellenwert, manuelle Prüfung erforderlich"
+	noteFuzzyAmbiguous         = "Mehrdeutiger Fuzzy-Treffer: mehrere Konzepte mit gleicher Ähnlichkeit, manuelle Prüfung nötig"
+	noteHigherRank             = "Offene Nomenklatur: nur bis zu diesem Rang bestimmt, aufgelöst auf das höherrangige Konzept"
+	noteHigherRankFallback     = "Offene Nomenklatur: infragenerische Gruppe nicht im Index, aufgelöst auf die Gattung"
+	noteHigherRankMissing      = "Offene Nomenklatur: höherrangiges Taxon nicht im Index"
+	noteAreaTieBreak           = "Mehrdeutiger Name: aufgelöst auf das einzige Konzept mit Vorkommen im angegebenen Gebiet"
+	noteOutsideKnownRange      = "Außerhalb des bekannten Areals: keine Verbreitungsangabe im angegebenen Gebiet, manuelle Prüfung nötig"
+	noteMisapplied             = "Fehlanwendung (auct./sensu): aufgelöst auf das gemeinte Konzept, nicht auf den nomenklatorischen Namensträger"
+	noteProParte               = "Pro-parte-Verwendung (auct./sensu): aufgelöst auf das Konzept, auf das der Name nur teilweise zutrifft, manuelle Prüfung nötig"
+	noteUsageAmbiguous         = "Fehlanwendung (auct./sensu) mit mehreren gemeinten Konzepten, manuelle Prüfung nötig"
+	noteUsageUnresolved        = "auct.-Verwendung ohne gespeicherte Fehlanwendung im Index: der nomenklatorische Namensträger wird bewusst nicht zurückgegeben, manuelle Prüfung nötig"
+	noteSensu                  = "sensu-Angabe: auf das Konzept der zitierten Referenz aufgelöst"
+	noteSensuUnknown           = "sensu-Angabe keiner eindeutigen Referenz im Index zuordenbar: ohne Referenzbezug aufgelöst, manuelle Prüfung nötig"
+	noteSensuNotInReference    = "sensu-Angabe: Name in der zitierten Referenz nicht geführt"
+	noteNonExcluded            = "non-Angabe: Homonym des ausgeschlossenen Autors verworfen"
+	noteHybridFormula          = "Hybridformel: aufgelöst auf das Nothotaxon mit genau diesen Eltern"
+	noteHybridFormulaMissing   = "Hybridformel: kein Nothotaxon mit diesen Eltern im Index"
+	noteHybridFormulaAmbiguous = "Hybridformel: mehrere Nothotaxa mit diesen Eltern, manuelle Prüfung nötig"
 	// noteAggregatePrefix is prepended to whatever matchFuzzy's Note already
 	// says (noteFuzzy or noteFuzzyAmbiguous) when a fuzzy hit resolves an
 	// aggregate/collective-species query — see matchAggregate's fuzzy
@@ -215,6 +268,27 @@ type MatchResult struct {
 	// for a plain species). See MatchInSpace.
 	TargetSpaceName string
 	AggregatePolicy domain.AggregatePolicy
+
+	// Range is set only when the filter named an Area and the result resolved
+	// to a concept; see annotateRange.
+	Range *AreaRange
+
+	// HigherTaxa is the resolved concept's family and genus, zero for a
+	// result without a concept; see annotateHigherTaxa.
+	HigherTaxa domain.HigherTaxa
+
+	// Trace is set only by ExplainMatches and nil everywhere else.
+	Trace *MatchTrace
+}
+
+// AreaRange is a resolved result's plausibility against the request's area.
+// InArea is positive evidence only: the concept's effective distribution
+// reaches the area. OutsideKnownRange is the suspicious case — a range IS
+// known and the area is not in it. A concept without any distribution data
+// has both false: nothing is known, so nothing is flagged.
+type AreaRange struct {
+	InArea            bool
+	OutsideKnownRange bool
 }
 
 // MatchNames resolves every req against repo, in order, per §B.2:
@@ -242,24 +316,138 @@ type MatchResult struct {
 //     spec §B.2's own wording ("wenn exact/exact_author/aggregate nichts
 //     liefert"), fuzzy is the catch-all for exact, exact_author, AND
 //     aggregate all coming up empty — not just the first two.
+//
+// Open nomenclature ("Festuca sp.", "Taraxacum sect. Ruderalia") never enters
+// that ladder at all: it is recognized on the raw verbatim first and answered
+// by matchHigherRank with domain.MatchHigherRank.
 func MatchNames(ctx context.Context, repo output.Repository, reqs []MatchRequest) ([]MatchResult, error) {
-	return matchNamesFiltered(ctx, repo, reqs, MatchFilter{})
+	results, err := matchNamesFiltered(ctx, repo, reqs, MatchFilter{}, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := annotateHigherTaxa(ctx, repo, results); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
 
 // matchNamesFiltered is MatchNames with an optional resolution filter applied
-// to every entry. A zero filter makes it byte-for-byte MatchNames.
-func matchNamesFiltered(ctx context.Context, repo output.Repository, reqs []MatchRequest, filter MatchFilter) ([]MatchResult, error) {
+// to every entry. A zero filter makes it byte-for-byte MatchNames. explain
+// attaches a MatchTrace to every result (see ExplainMatches).
+func matchNamesFiltered(ctx context.Context, repo output.Repository, reqs []MatchRequest, filter MatchFilter, explain bool) ([]MatchResult, error) {
 	results := make([]MatchResult, 0, len(reqs))
 	for _, req := range reqs {
-		res, err := matchOne(ctx, repo, req, filter)
+		var tr *MatchTrace
+		if explain {
+			tr = &MatchTrace{Verbatim: req.Verbatim, Filter: filter}
+		}
+		res, err := matchOne(ctx, repo, req, filter, tr)
 		if err != nil {
 			return nil, err
 		}
+		res.Trace = tr
 		results = append(results, res)
 	}
+	if err := annotateRange(ctx, repo, results, filter.Area); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
+// annotateHigherTaxa sets HigherTaxa on every resolved result, in one
+// HigherTaxa call for the whole batch. The public match paths call it;
+// Translate, which reuses matchNamesFiltered only for the concept id, does
+// not.
+func annotateHigherTaxa(ctx context.Context, repo output.Repository, results []MatchResult) error {
+	var ids []string
+	for _, r := range results {
+		if r.ConceptID != "" {
+			ids = append(ids, r.ConceptID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	higher, err := repo.HigherTaxa(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		results[i].HigherTaxa = higher[results[i].ConceptID]
+	}
+	return nil
+}
+
+// annotateRange sets Range on every resolved result for a non-empty area, in
+// one AreaPresence call for the whole batch. A result outside its concept's
+// known range is put up for review — that is the checklist row a curator has
+// to see — and keeps any note it already had, which explains more than this
+// one would.
+func annotateRange(ctx context.Context, repo output.Repository, results []MatchResult, area string) error {
+	if area == "" {
+		return nil
+	}
+	var ids []string
+	for _, r := range results {
+		if r.ConceptID != "" {
+			ids = append(ids, r.ConceptID)
+		}
+	}
+	presence, err := repo.AreaPresence(ctx, area, ids)
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		if results[i].ConceptID == "" {
+			continue
+		}
+		p, known := presence[results[i].ConceptID]
+		rng := &AreaRange{InArea: p.InArea, OutsideKnownRange: known && !p.InArea}
+		results[i].Range = rng
+		if !rng.OutsideKnownRange {
+			continue
+		}
+		results[i].RequiresReview = true
+		if results[i].Note == "" {
+			results[i].Note = noteOutsideKnownRange
+		}
+	}
+	return nil
+}
+
+// areaLookup reports which of conceptIDs have an effective distribution
+// reaching the request's area — the tie-break input for filter.Area.
+type areaLookup func(conceptIDs []string) (map[string]bool, error)
+
+// inAreaLookup returns the areaLookup for area, nil without one. It is
+// handed to classifyTraced unevaluated: the AreaPresence query runs only
+// when a tie actually reaches areaWinner, so a batch whose names resolve on
+// their own pays nothing for the area beyond annotateRange's one query.
+func inAreaLookup(ctx context.Context, repo output.Repository, area string) areaLookup {
+	if area == "" {
+		return nil
+	}
+	return func(conceptIDs []string) (map[string]bool, error) {
+		return conceptsInArea(ctx, repo, area, conceptIDs)
+	}
+}
+
+// conceptsInArea is inAreaLookup's query: the set of conceptIDs whose
+// effective distribution reaches area.
+func conceptsInArea(ctx context.Context, repo output.Repository, area string, conceptIDs []string) (map[string]bool, error) {
+	presence, err := repo.AreaPresence(ctx, area, conceptIDs)
+	if err != nil {
+		return nil, err
+	}
+	in := make(map[string]bool, len(presence))
+	for id, p := range presence {
+		if p.InArea {
+			in[id] = true
+		}
+	}
+	return in, nil
+}
+
 // MatchInSpace resolves reqs exactly as MatchNames and then, for the ingested
 // target space `space`, annotates each result with the space's ESy-compatible
 // spelling (MatchResult.TargetSpaceName) and its AggregatePolicy — the
@@ -278,19 +466,44 @@ func matchNamesFiltered(ctx context.Context, repo output.Repository, reqs []Matc
 // UNRESOLVABLE match) carry no name-space annotation, since there is no concept
 // to look one up for.
 func MatchInSpace(ctx context.Context, repo output.Repository, reqs []MatchRequest, space string, filter MatchFilter) ([]MatchResult, error) {
+	return matchInSpace(ctx, repo, reqs, space, filter, false)
+}
+
+// matchInSpace is MatchInSpace and ExplainMatches, which differ only in
+// whether every result carries a MatchTrace.
+func matchInSpace(ctx context.Context, repo output.Repository, reqs []MatchRequest, space string, filter MatchFilter, explain bool) ([]MatchResult, error) {
 	if err := validateFilter(ctx, repo, filter); err != nil {
 		return nil, err
 	}
+	if space != "" {
+		if err := validateTargetSpace(ctx, repo, space); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := matchNamesFiltered(ctx, repo, reqs, filter, explain)
+	if err != nil {
+		return nil, err
+	}
+	if err := annotateHigherTaxa(ctx, repo, results); err != nil {
+		return nil, err
+	}
 	if space == "" {
-		return matchNamesFiltered(ctx, repo, reqs, filter)
+		return results, nil
 	}
-	if err := validateTargetSpace(ctx, repo, space); err != nil {
+	if err := annotateTargetSpace(ctx, repo, results, reqs, space); err != nil {
 		return nil, err
 	}
+	return results, nil
+}
 
-	results, err := matchNamesFiltered(ctx, repo, reqs, filter)
-	if err != nil {
-		return nil, err
+// annotateTargetSpace sets TargetSpaceName and AggregatePolicy on every
+// resolved result (results[i] answers reqs[i]) for a non-empty, already
+// validated space. Shared by the batch and the streamed path so both derive
+// the policy identically.
+func annotateTargetSpace(ctx context.Context, repo output.Repository, results []MatchResult, reqs []MatchRequest, space string) error {
+	if space == "" {
+		return nil
 	}
 	for i := range results {
 		if results[i].ConceptID == "" {
@@ -298,32 +511,58 @@ func MatchInSpace(ctx context.Context, repo output.Repository, reqs []MatchReque
 		}
 		entries, err := repo.NameSpaceEntries(ctx, results[i].ConceptID, []string{space})
 		if err != nil {
-			return nil, err
+			return err
 		}
 		canonical, _ := splitVerbatim(reqs[i].Verbatim)
 		name, policy := domain.ResolveTargetSpace(isAggregate(canonical), entries)
 		results[i].TargetSpaceName = name
 		results[i].AggregatePolicy = policy
 	}
-	return results, nil
+	return nil
 }
 
-func matchOne(ctx context.Context, repo output.Repository, req MatchRequest, filter MatchFilter) (MatchResult, error) {
+func matchOne(ctx context.Context, repo output.Repository, req MatchRequest, filter MatchFilter, tr *MatchTrace) (MatchResult, error) {
+	// Checked on the RAW verbatim, before splitVerbatim: an infrageneric
+	// epithet is capitalized ("Taraxacum sect. Ruderalia"), so the split would
+	// hand it to the author and the name would never reach this path.
+	if on, ok := domain.ParseOpenNomenclature(req.Verbatim); ok {
+		tr.parsed(on.Key, "", MatchPathHigherRank)
+		return matchHigherRank(ctx, repo, req, on, filter, tr)
+	}
+
+	// Also on the raw verbatim, for the same reason: "auct."/"sensu"/"non"
+	// are lower-case and would otherwise be read as epithets.
+	if uq, ok := domain.ParseUsageQualifier(req.Verbatim); ok {
+		return matchUsage(ctx, repo, req, uq, filter, tr)
+	}
+
+	// And the hybrid formula: its marker and second parent would otherwise
+	// be read as an author ("S. fragilis") or as epithets ("x spicata").
+	if parents, ok := domain.ParseHybridFormula(req.Verbatim); ok {
+		return matchHybridFormula(ctx, repo, req, parents, filter, tr)
+	}
+
 	canonical, author := splitVerbatim(req.Verbatim)
 
 	if isAggregate(canonical) {
-		return matchAggregate(ctx, repo, req, canonical, filter)
+		tr.parsed(canonical, author, MatchPathAggregate)
+		return matchAggregate(ctx, repo, req, canonical, filter, tr)
 	}
 
 	queryCanon := domain.Canonicalize(canonical)
 	queryAuthor := domain.NormalizeAuthor(author)
+	tr.parsed(canonical, author, MatchPathSpecies)
 
-	candidates, err := repo.MatchExact(ctx, queryCanon)
+	raw, err := repo.MatchExact(ctx, queryCanon)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	candidates := filter.apply(raw)
+	tr.lookup(queryCanon, LookupExact, raw, candidates)
+	res, unresolved, err := classifyTraced(req, queryCanon, queryAuthor, candidates, inAreaLookup(ctx, repo, filter.Area), tr)
 	if err != nil {
 		return MatchResult{}, err
 	}
-	candidates = filter.apply(candidates)
-	res, unresolved := classify(req, queryCanon, queryAuthor, candidates)
 	if !unresolved {
 		return res, nil
 	}
@@ -338,7 +577,7 @@ func matchOne(ctx context.Context, repo output.Repository, req MatchRequest, fil
 		return res, nil
 	}
 
-	fuzzy, err := matchFuzzy(ctx, repo, req, queryCanon, filter)
+	fuzzy, err := matchFuzzy(ctx, repo, req, queryCanon, filter, tr)
 	if err != nil {
 		return MatchResult{}, err
 	}
@@ -418,7 +657,7 @@ func nearMissNames(candidates []output.MatchCandidate, scores []float64) []strin
 //     Candidates lists the tied names — silently picking one would hide a
 //     genuine ambiguity from the caller, same principle as classify's own
 //     ambiguity handling.
-func matchFuzzy(ctx context.Context, repo output.Repository, req MatchRequest, queryCanon string, filter MatchFilter) (*MatchResult, error) {
+func matchFuzzy(ctx context.Context, repo output.Repository, req MatchRequest, queryCanon string, filter MatchFilter, tr *MatchTrace) (*MatchResult, error) {
 	candidates, err := repo.MatchFuzzyCandidates(ctx, queryCanon, fuzzyCandidateLimit, filter.Backbone, filter.Sec)
 	if err != nil {
 		return nil, err
@@ -442,6 +681,7 @@ func matchFuzzy(ctx context.Context, repo output.Repository, req MatchRequest, q
 			best = s
 		}
 	}
+	tr.fuzzy(candidates, scores)
 	// best < domain.FuzzyThreshold is NOT a boundary equivalent: a
 	// similarity landing EXACTLY on FuzzyThreshold must still resolve (the
 	// threshold is inclusive, per FuzzyThreshold's doc comment and the spec
@@ -543,27 +783,28 @@ func matchFuzzy(ctx context.Context, repo output.Repository, req MatchRequest, q
 // is invented, RequiresReview is set and every candidate name is listed.
 // Picking candidates[0] there would silently answer a question hostus
 // cannot answer, which is precisely what the other two paths refuse to do.
-func matchAggregate(ctx context.Context, repo output.Repository, req MatchRequest, canonical string, filter MatchFilter) (MatchResult, error) {
+func matchAggregate(ctx context.Context, repo output.Repository, req MatchRequest, canonical string, filter MatchFilter, tr *MatchTrace) (MatchResult, error) {
 	queryCanon := domain.Canonicalize(canonical)
-	candidates, err := repo.MatchExact(ctx, queryCanon)
+	raw, err := repo.MatchExact(ctx, queryCanon)
 	if err != nil {
 		return MatchResult{}, err
 	}
-	candidates = filter.apply(candidates)
+	candidates := filter.apply(raw)
+	tr.lookup(queryCanon, LookupAggregate, raw, candidates)
 	if len(candidates) == 0 {
 		// No aggregate taxon for the marked name. Before giving up, try the
 		// name WITHOUT the marker: a data set writing "X aggr." against a
 		// backbone that carries only X used to lose the whole row (issue #67,
 		// 96 names), even though X itself resolves exactly. The nominate
 		// concept plus a type saying it is coarser beats no answer at all.
-		nominate, err := matchAggregateNominate(ctx, repo, req, queryCanon, filter)
+		nominate, err := matchAggregateNominate(ctx, repo, req, queryCanon, filter, tr)
 		if err != nil {
 			return MatchResult{}, err
 		}
 		if nominate != nil {
 			return *nominate, nil
 		}
-		fuzzy, err := matchFuzzy(ctx, repo, req, queryCanon, filter)
+		fuzzy, err := matchFuzzy(ctx, repo, req, queryCanon, filter, tr)
 		if err != nil {
 			return MatchResult{}, err
 		}
@@ -584,6 +825,7 @@ func matchAggregate(ctx context.Context, repo output.Repository, req MatchReques
 		names = append(names, c.MatchedName.Canonical)
 	}
 	if len(distinctConcepts) > 1 {
+		tr.decide(DecisionAmbiguous)
 		return MatchResult{
 			ID:             req.ID,
 			RequiresReview: true,
@@ -591,6 +833,7 @@ func matchAggregate(ctx context.Context, repo output.Repository, req MatchReques
 			Candidates:     names,
 		}, nil
 	}
+	tr.decide(DecisionSingleConcept)
 	return MatchResult{
 		ID:         req.ID,
 		MatchType:  domain.MatchAggregateAlias,
@@ -625,20 +868,28 @@ func matchAggregate(ctx context.Context, repo output.Repository, req MatchReques
 // ConceptID — so a resolved concept is the condition to check. Stamping a
 // match type onto an ambiguous tie would produce the worst possible answer: a
 // confident-looking type and confidence with no concept behind them.
-func matchAggregateNominate(ctx context.Context, repo output.Repository, req MatchRequest, queryCanon string, filter MatchFilter) (*MatchResult, error) {
+func matchAggregateNominate(ctx context.Context, repo output.Repository, req MatchRequest, queryCanon string, filter MatchFilter, tr *MatchTrace) (*MatchResult, error) {
 	for _, base := range domain.AggregateBases(queryCanon) {
 		if base == "" {
 			continue
 		}
-		candidates, err := repo.MatchExact(ctx, base)
+		raw, err := repo.MatchExact(ctx, base)
 		if err != nil {
 			return nil, err
 		}
-		candidates = filter.apply(candidates)
+		candidates := filter.apply(raw)
+		rule := LookupAggregateToNominate
+		if domain.IsAggregateName(base) {
+			rule = LookupAggregate
+		}
+		tr.lookup(base, rule, raw, candidates)
 		if len(candidates) == 0 {
 			continue
 		}
-		res, noCandidates := classify(req, base, "", candidates)
+		res, noCandidates, err := classifyTraced(req, base, "", candidates, inAreaLookup(ctx, repo, filter.Area), tr)
+		if err != nil {
+			return nil, err
+		}
 		if noCandidates || res.ConceptID == "" {
 			continue
 		}
@@ -656,6 +907,69 @@ func matchAggregateNominate(ctx context.Context, repo output.Repository, req Mat
 	return nil, nil
 }
 
+// matchHigherRank resolves an open-nomenclature query (see
+// domain.ParseOpenNomenclature) to the concept of the rank it was identified
+// to: on.Key first, then — for an infrageneric group the index does not carry —
+// the genus in on.Fallback.
+//
+// Every outcome sets RequiresReview, resolved or not. A relevé row that says
+// "Festuca sp." must survive an import as a genus-level record rather than be
+// dropped, but it must also never pass silently as though it were a species
+// determination.
+//
+// Resolution goes through classify for the same reason matchAggregateNominate
+// does: one tie-break, not two. An ambiguous tie is handed back as classify
+// reported it. There is deliberately NO fuzzy fallback: a one-word key has so
+// few runes that one edit already drops Similarity to the threshold, and a
+// fuzzy "genus" would be a guess presented under this type's certainty.
+func matchHigherRank(ctx context.Context, repo output.Repository, req MatchRequest, on domain.OpenNomenclature, filter MatchFilter, tr *MatchTrace) (MatchResult, error) {
+	keys := []string{on.Key}
+	if on.Fallback != "" {
+		keys = append(keys, on.Fallback)
+	}
+	var seen []string
+	for i, key := range keys {
+		raw, err := repo.MatchExact(ctx, key)
+		if err != nil {
+			return MatchResult{}, err
+		}
+		candidates := filter.apply(raw)
+		rule := LookupHigherRank
+		if i > 0 {
+			rule = LookupHigherRankFallback
+		}
+		tr.lookup(key, rule, raw, candidates)
+		if len(candidates) == 0 {
+			continue
+		}
+		res, noCandidates, err := classifyTraced(req, key, "", candidates, inAreaLookup(ctx, repo, filter.Area), tr)
+		if err != nil {
+			return MatchResult{}, err
+		}
+		if noCandidates {
+			seen = append(seen, res.Candidates...)
+			continue
+		}
+		if res.ConceptID == "" {
+			return res, nil // an ambiguous tie, already noted as such
+		}
+		res.MatchType = domain.MatchHigherRank
+		res.Confidence = confidenceHigherRank
+		res.RequiresReview = true
+		res.Note = noteHigherRank
+		if i > 0 {
+			res.Note = noteHigherRankFallback
+		}
+		return res, nil
+	}
+	return MatchResult{
+		ID:             req.ID,
+		RequiresReview: true,
+		Note:           noteHigherRankMissing,
+		Candidates:     seen,
+	}, nil
+}
+
 // classifiedHit is one candidate that classified as a match, carrying just
 // enough to detect ambiguity (does the winning strength resolve to more
 // than one distinct concept?) and to report it (the matched name).
@@ -691,6 +1005,17 @@ type classifiedHit struct {
 // (e.g. a synonym and its accepted name both classifying exact_author) are
 // NOT ambiguous — they still resolve normally to that one concept.
 func classify(req MatchRequest, queryCanon, queryAuthor string, candidates []output.MatchCandidate) (MatchResult, bool) {
+	// Without an areaLookup classifyTraced has nothing that can fail.
+	res, unresolved, _ := classifyTraced(req, queryCanon, queryAuthor, candidates, nil, nil)
+	return res, unresolved
+}
+
+// classifyTraced is classify, additionally recording its decision on tr (see
+// MatchTrace.decide) and, when inArea is non-nil, breaking a tie the bearer
+// tiers leave standing by area presence (see areaWinner). inArea is called
+// only for such a tie, with the tied concepts; its error is the only one
+// classifyTraced returns. A nil tr and a nil inArea make it exactly classify.
+func classifyTraced(req MatchRequest, queryCanon, queryAuthor string, candidates []output.MatchCandidate, inArea areaLookup, tr *MatchTrace) (MatchResult, bool, error) {
 	var (
 		names              []string
 		exactAuthorMatches []classifiedHit
@@ -710,10 +1035,11 @@ func classify(req MatchRequest, queryCanon, queryAuthor string, candidates []out
 			exactAuthorMatches = append(exactAuthorMatches, hit)
 		case domain.MatchExact:
 			exactMatches = append(exactMatches, hit)
-		case domain.MatchAggregateAlias, domain.MatchAggregateNominate, domain.MatchFuzzy:
+		case domain.MatchAggregateAlias, domain.MatchAggregateNominate, domain.MatchFuzzy, domain.MatchHigherRank, domain.MatchMisapplied, domain.MatchHybridFormula:
 			// ClassifyMatch never produces any of these — they are assigned
 			// by separate code paths (matchAggregate,
-			// matchAggregateNominate, matchFuzzy) — unreachable here.
+			// matchAggregateNominate, matchFuzzy, matchHigherRank,
+			// matchUsage, matchHybridFormula) — unreachable here.
 		}
 	}
 
@@ -725,12 +1051,15 @@ func classify(req MatchRequest, queryCanon, queryAuthor string, candidates []out
 	}
 
 	if len(winners) == 0 {
+		if len(candidates) > 0 {
+			tr.decide(DecisionNoneClassified)
+		}
 		return MatchResult{
 			ID:             req.ID,
 			RequiresReview: true,
 			Note:           noteUnresolvable,
 			Candidates:     names,
-		}, true
+		}, true, nil
 	}
 
 	distinctConcepts := make(map[string]bool, len(winners))
@@ -742,6 +1071,7 @@ func classify(req MatchRequest, queryCanon, queryAuthor string, candidates []out
 		// (if any) for which the queried name is the genuine name-bearer — see
 		// genuineBearerWinner.
 		if cid, ok := genuineBearerWinner(winners); ok {
+			tr.decide(bearerDecision(winners))
 			conf := confidenceExact
 			if bestType == domain.MatchExactAuthor {
 				conf = confidenceExactAuthor
@@ -751,8 +1081,35 @@ func classify(req MatchRequest, queryCanon, queryAuthor string, candidates []out
 				MatchType:  bestType,
 				Confidence: conf,
 				ConceptID:  cid,
-			}, false
+			}, false, nil
+		}
+		var present map[string]bool
+		if inArea != nil {
+			ids := make([]string, 0, len(distinctConcepts))
+			for id := range distinctConcepts {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			var err error
+			if present, err = inArea(ids); err != nil {
+				return MatchResult{}, false, err
+			}
 		}
+		if cid, ok := areaWinner(winners, present); ok {
+			tr.decide(DecisionInArea)
+			conf := confidenceExact
+			if bestType == domain.MatchExactAuthor {
+				conf = confidenceExactAuthor
+			}
+			return MatchResult{
+				ID:         req.ID,
+				MatchType:  bestType,
+				Confidence: conf,
+				ConceptID:  cid,
+				Note:       noteAreaTieBreak,
+			}, false, nil
+		}
+		tr.decide(DecisionAmbiguous)
 		tiedNames := make([]string, 0, len(winners))
 		for _, w := range winners {
 			tiedNames = append(tiedNames, w.name)
@@ -762,9 +1119,10 @@ func classify(req MatchRequest, queryCanon, queryAuthor string, candidates []out
 			RequiresReview: true,
 			Note:           noteAmbiguous,
 			Candidates:     tiedNames,
-		}, false
+		}, false, nil
 	}
 
+	tr.decide(DecisionSingleConcept)
 	conf := confidenceExact
 	if bestType == domain.MatchExactAuthor {
 		conf = confidenceExactAuthor
@@ -774,12 +1132,31 @@ func classify(req MatchRequest, queryCanon, queryAuthor string, candidates []out
 		MatchType:  bestType,
 		Confidence: conf,
 		ConceptID:  winners[0].conceptID,
-	}, false
+	}, false, nil
+}
+
+// bearerDecision names which genuineBearerWinner tier decided a tie it did
+// break: the accepted tier whenever any winner holds the name as accepted —
+// that tier decides as soon as it holds anyone — else the homotypic one.
+func bearerDecision(winners []classifiedHit) TraceDecision {
+	for _, w := range winners {
+		if w.role == roleAccepted {
+			return DecisionAcceptedBearer
+		}
+	}
+	return DecisionHomotypicBearer
 }
 
 // roleAccepted is the concept_name.role value for a concept's accepted name.
 const roleAccepted = "accepted"
 
+// bearerTiers are genuineBearerWinner's claims, strongest first: the queried
+// name is the concept's accepted name, else a homotypic synonym of it.
+var bearerTiers = []func(classifiedHit) bool{
+	func(w classifiedHit) bool { return w.role == roleAccepted },
+	func(w classifiedHit) bool { return w.homotypic != nil && *w.homotypic },
+}
+
 // genuineBearerWinner breaks a match tie by nomenclatural type, or reports
 // that the tie stands.
 //
@@ -815,11 +1192,7 @@ func genuineBearerWinner(winners []classifiedHit) (string, bool) {
 	// holds the name as accepted — which is the Inula hirta case the tier below
 	// exists for (homotypic under Pentanema hirtum, heterotypic under
 	// P. britannica, accepted in neither).
-	tiers := []func(classifiedHit) bool{
-		func(w classifiedHit) bool { return w.role == roleAccepted },
-		func(w classifiedHit) bool { return w.homotypic != nil && *w.homotypic },
-	}
-	for _, qualifies := range tiers {
+	for _, qualifies := range bearerTiers {
 		id, present := soleConcept(winners, qualifies)
 		if !present {
 			continue // nothing at this tier — the next one may decide
@@ -833,6 +1206,38 @@ func genuineBearerWinner(winners []classifiedHit) (string, bool) {
 	return "", false
 }
 
+// areaWinner breaks a tie genuineBearerWinner left standing by geography: the
+// sole concept present in the request's area wins. It is strictly the LAST
+// tier, and it only chooses WITHIN the contest the bearer tiers left — among
+// the accepted holders if any, else among the homotypic ones, else among all
+// winners. The same rule as above applies: a weaker claim is never rescued by
+// being local, so a concept holding the name only heterotypically does not
+// beat two floras that accept it just because it occurs in Germany.
+//
+// Several in-area concepts, or none, leave the tie standing — nothing here
+// picks between two local homonyms. A nil inArea (no area requested) never
+// decides.
+func areaWinner(winners []classifiedHit, inArea map[string]bool) (string, bool) {
+	if inArea == nil {
+		return "", false
+	}
+	contest := winners
+	for _, qualifies := range bearerTiers {
+		var held []classifiedHit
+		for _, w := range winners {
+			if qualifies(w) {
+				held = append(held, w)
+			}
+		}
+		if len(held) > 0 {
+			contest = held
+			break
+		}
+	}
+	id, _ := soleConcept(contest, func(w classifiedHit) bool { return inArea[w.conceptID] })
+	return id, id != ""
+}
+
 // soleConcept reports whether any winner qualifies (present) and, if exactly
 // one CONCEPT does, which. present with an empty id means "several qualified"
 // — the caller must treat that as ambiguous rather than looking further.
@@ -872,9 +1277,13 @@ func isAggregate(canonical string) bool {
 // the string is the (untouched) author.
 //
 // This is a purposefully small heuristic, not a full nomenclatural parser:
-// it does not recognize hybrid markers, "sensu"/"auct." qualifiers, or
-// author names that happen to start with a lowercase particle (e.g. "de
-// Candolle" abbreviated oddly). Those are out of scope for this SP.
+// it does not recognize hybrid markers or author names that happen to start
+// with a lowercase particle (e.g. "de Candolle" abbreviated oddly). Those are
+// out of scope for this SP. "sensu"/"auct."/"non" qualifiers never reach it:
+// matchOne splits them off first (domain.ParseUsageQualifier), since their
+// lower-case markers would otherwise read as further epithets. Nor does a
+// hybrid formula ("Salix alba × S. fragilis"): matchOne hands it to
+// matchHybridFormula before the split.
 func splitVerbatim(verbatim string) (canonical, author string) {
 	fields := strings.Fields(verbatim)
 	if len(fields) == 0 {
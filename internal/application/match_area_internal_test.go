@@ -0,0 +1,23 @@
+package application
+
+import "testing"
+
+// TestAreaWinner_NeverRescuesAWeakerClaim pins that geography only decides
+// within the strongest contest in play: two concepts accepting the name stay
+// tied even when a third, holding it only as a synonym, is the sole local one.
+func TestAreaWinner_NeverRescuesAWeakerClaim(t *testing.T) {
+	winners := []classifiedHit{
+		{conceptID: "flora-a", role: roleAccepted},
+		{conceptID: "flora-b", role: roleAccepted},
+		{conceptID: "local", role: "synonym"},
+	}
+	if id, ok := areaWinner(winners, map[string]bool{"local": true}); ok {
+		t.Errorf("areaWinner = %q, want the accepted tie to stand", id)
+	}
+	if id, ok := areaWinner(winners, map[string]bool{"flora-b": true, "local": true}); !ok || id != "flora-b" {
+		t.Errorf("areaWinner = (%q, %v), want flora-b — the only local accepted holder", id, ok)
+	}
+	if _, ok := areaWinner(winners, nil); ok {
+		t.Error("areaWinner decided without an area")
+	}
+}
@@ -0,0 +1,208 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// seedRangedConcepts ingests, under backbone "test-range", a homonym pair
+// ("Homonymus rangeus L.", concept a in GER, concept b in BZN), a species
+// known only from BZN, a species without any distribution, and a second
+// homonym pair both present in GER — then builds distribution_effective,
+// which the area checks read. Returns the concept ids by short key.
+func seedRangedConcepts(t *testing.T) (*sqlite.DB, map[string]string) {
+	t.Helper()
+	repo := openMemoryRepo(t)
+	ctx := context.Background()
+	tx, err := repo.BeginIngest(ctx, domain.BackboneVersion{ID: "test-range", Version: "v1"})
+	if err != nil {
+		t.Fatalf("BeginIngest: %v", err)
+	}
+	species := []struct {
+		key, canonical string
+		areas          []string
+	}{
+		{"a", "Homonymus rangeus", []string{"GER", "FRA"}},
+		{"b", "Homonymus rangeus", []string{"BZN"}},
+		{"tropical", "Tropicus remotus", []string{"BZN"}},
+		{"unknown", "Ignotus nusquam", nil},
+		{"local1", "Localis duplex", []string{"GER"}},
+		{"local2", "Localis duplex", []string{"AUT", "GER"}},
+	}
+	ids := make(map[string]string, len(species))
+	for _, sp := range species {
+		name := domain.Name{ID: "test-range:name:" + sp.key, Canonical: sp.canonical, Authorship: "L.", Rank: domain.RankSpecies}
+		concept := domain.Concept{ID: "test-range:concept:" + sp.key, BackboneID: "test-range", AcceptedName: name, Rank: domain.RankSpecies, Status: domain.StatusAccepted}
+		if err := tx.UpsertName(name); err != nil {
+			t.Fatalf("UpsertName(%s): %v", sp.key, err)
+		}
+		if err := tx.UpsertConcept(concept); err != nil {
+			t.Fatalf("UpsertConcept(%s): %v", sp.key, err)
+		}
+		if err := tx.LinkName(concept.ID, name.ID, "accepted", nil); err != nil {
+			t.Fatalf("LinkName(%s): %v", sp.key, err)
+		}
+		for _, code := range sp.areas {
+			if err := tx.AddDistribution(concept.ID, domain.Distribution{AreaScheme: "wgsrpd_l3", AreaCode: code}, ""); err != nil {
+				t.Fatalf("AddDistribution(%s, %s): %v", sp.key, code, err)
+			}
+		}
+		ids[sp.key] = concept.ID
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if err := repo.BuildDistributionClosure(ctx); err != nil {
+		t.Fatalf("BuildDistributionClosure: %v", err)
+	}
+	if _, err := application.IngestAreaHierarchy(ctx, repo, nil,
+		[]domain.AreaAlias{{Alias: "DE", Codes: []string{"GER"}}}); err != nil {
+		t.Fatalf("IngestAreaHierarchy: %v", err)
+	}
+	return repo, ids
+}
+
+// TestMatchInSpace_AreaBreaksHomonymTie is the relevé case: a homonym pair is
+// ambiguous without context, and the area picks the one that grows there.
+func TestMatchInSpace_AreaBreaksHomonymTie(t *testing.T) {
+	repo, ids := seedRangedConcepts(t)
+	ctx := context.Background()
+	reqs := []application.MatchRequest{{ID: "1", Verbatim: "Homonymus rangeus L."}}
+
+	plain, err := application.MatchInSpace(ctx, repo, reqs, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+	if plain[0].ConceptID != "" || plain[0].Range != nil {
+		t.Fatalf("without area = %+v, want an ambiguous tie without range flags", plain[0])
+	}
+
+	results, err := application.MatchInSpace(ctx, repo, reqs, "", application.MatchFilter{Area: "DE"})
+	if err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+	r := results[0]
+	if r.ConceptID != ids["a"] || r.MatchType != domain.MatchExactAuthor || r.RequiresReview {
+		t.Errorf("result = %+v, want %s as exact_author without review", r, ids["a"])
+	}
+	if r.Range == nil || !r.Range.InArea || r.Range.OutsideKnownRange {
+		t.Errorf("Range = %+v, want in area", r.Range)
+	}
+}
+
+// TestMatchInSpace_AreaLeavesLocalHomonymsAmbiguous pins that the area only
+// ever decides a SOLE local concept: two homonyms both in the area remain a
+// tie for a human.
+func TestMatchInSpace_AreaLeavesLocalHomonymsAmbiguous(t *testing.T) {
+	repo, _ := seedRangedConcepts(t)
+
+	results, err := application.MatchInSpace(context.Background(), repo,
+		[]application.MatchRequest{{ID: "1", Verbatim: "Localis duplex L."}}, "", application.MatchFilter{Area: "GER"})
+	if err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+	if r := results[0]; r.ConceptID != "" || !r.RequiresReview || len(r.Candidates) != 2 {
+		t.Errorf("result = %+v, want the tie to stand", r)
+	}
+}
+
+// countingAreaRepo counts AreaPresence calls on the repository it wraps.
+type countingAreaRepo struct {
+	output.Repository
+	calls int
+}
+
+func (r *countingAreaRepo) AreaPresence(ctx context.Context, area string, conceptIDs []string) (map[string]output.AreaPresence, error) {
+	r.calls++
+	return r.Repository.AreaPresence(ctx, area, conceptIDs)
+}
+
+// TestMatchInSpace_AreaQueriedOnlyForATie pins the cost of an area: names
+// that resolve on their own share annotateRange's one AreaPresence query,
+// and only a tie left to the area adds one of its own.
+func TestMatchInSpace_AreaQueriedOnlyForATie(t *testing.T) {
+	db, _ := seedRangedConcepts(t)
+	filter := application.MatchFilter{Area: "DE"}
+
+	repo := &countingAreaRepo{Repository: db}
+	if _, err := application.MatchInSpace(context.Background(), repo, []application.MatchRequest{
+		{ID: "1", Verbatim: "Tropicus remotus L."},
+		{ID: "2", Verbatim: "Ignotus nusquam L."},
+	}, "", filter); err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+	if repo.calls != 1 {
+		t.Errorf("AreaPresence calls without a tie = %d, want 1 (the range flags)", repo.calls)
+	}
+
+	repo = &countingAreaRepo{Repository: db}
+	if _, err := application.MatchInSpace(context.Background(), repo,
+		[]application.MatchRequest{{ID: "1", Verbatim: "Homonymus rangeus L."}}, "", filter); err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+	if repo.calls != 2 {
+		t.Errorf("AreaPresence calls with a tie = %d, want 2 (the tie-break and the range flags)", repo.calls)
+	}
+}
+
+// TestMatchInSpace_FlagsOutsideKnownRange pins the plausibility flags: a
+// species known only from elsewhere is put up for review, one without any
+// distribution data is not — absence of data is not evidence of absence.
+func TestMatchInSpace_FlagsOutsideKnownRange(t *testing.T) {
+	repo, ids := seedRangedConcepts(t)
+
+	results, err := application.MatchInSpace(context.Background(), repo, []application.MatchRequest{
+		{ID: "1", Verbatim: "Tropicus remotus L."},
+		{ID: "2", Verbatim: "Ignotus nusquam L."},
+		{ID: "3", Verbatim: "Nonexistentus bogus"},
+	}, "", application.MatchFilter{Area: "DE"})
+	if err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+
+	tropical := results[0]
+	if tropical.ConceptID != ids["tropical"] {
+		t.Fatalf("tropical ConceptID = %q, want %q", tropical.ConceptID, ids["tropical"])
+	}
+	if tropical.Range == nil || tropical.Range.InArea || !tropical.Range.OutsideKnownRange {
+		t.Errorf("tropical Range = %+v, want outside known range", tropical.Range)
+	}
+	if !tropical.RequiresReview || tropical.Note == "" {
+		t.Errorf("tropical = %+v, want review with a note", tropical)
+	}
+
+	unknown := results[1]
+	if unknown.Range == nil || unknown.Range.InArea || unknown.Range.OutsideKnownRange {
+		t.Errorf("unknown Range = %+v, want both flags false", unknown.Range)
+	}
+	if unknown.RequiresReview {
+		t.Errorf("unknown RequiresReview = true, want false for a concept without range data")
+	}
+
+	if results[2].Range != nil {
+		t.Errorf("unresolved Range = %+v, want nil", results[2].Range)
+	}
+}
+
+// TestExplainMatches_RecordsAreaDecision pins that a tie the area broke is
+// named as such in the trace, not as a bearer decision.
+func TestExplainMatches_RecordsAreaDecision(t *testing.T) {
+	repo, _ := seedRangedConcepts(t)
+
+	results, err := application.ExplainMatches(context.Background(), repo,
+		[]application.MatchRequest{{ID: "1", Verbatim: "Homonymus rangeus"}}, "", application.MatchFilter{Area: "DE"})
+	if err != nil {
+		t.Fatalf("ExplainMatches: unexpected error: %v", err)
+	}
+	if got := results[0].Trace.Lookups[0].Decision; got != application.DecisionInArea {
+		t.Errorf("Decision = %q, want %q", got, application.DecisionInArea)
+	}
+	if got := results[0].Trace.Filter.Area; got != "DE" {
+		t.Errorf("Trace.Filter.Area = %q, want DE", got)
+	}
+}
@@ -0,0 +1,186 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// MatchPath names which branch of the matcher an entry took. The branch is
+// decided once, from the verbatim alone, before any lookup — so it is the
+// first thing a curator needs to know about a surprising outcome.
+type MatchPath string
+
+const (
+	// MatchPathSpecies is the ordinary ladder: exact/exact_author, then fuzzy.
+	MatchPathSpecies MatchPath = "species"
+	// MatchPathAggregate is matchAggregate: the marked name, its shorter
+	// spellings (matchAggregateNominate), then fuzzy.
+	MatchPathAggregate MatchPath = "aggregate"
+	// MatchPathHigherRank is matchHigherRank (open nomenclature).
+	MatchPathHigherRank MatchPath = "higher_rank"
+	// MatchPathUsage is matchUsage: a verbatim qualified by "auct.",
+	// "sensu …" or "non …".
+	MatchPathUsage MatchPath = "usage"
+	// MatchPathHybridFormula is matchHybridFormula: a verbatim naming a
+	// hybrid by its parents ("Salix alba × S. fragilis").
+	MatchPathHybridFormula MatchPath = "hybrid_formula"
+)
+
+// LookupRule labels why a key was looked up. The values shared with
+// domain.NormalizationRule (exact, aggregate, aggregate_to_nominate) are
+// spelled identically on purpose: a curator comparing a match trace with a
+// trait-ingest resolution should see the same word for the same rewrite.
+type LookupRule string
+
+const (
+	LookupExact               LookupRule = LookupRule(domain.RuleExact)
+	LookupAggregate           LookupRule = LookupRule(domain.RuleAggregate)
+	LookupAggregateToNominate LookupRule = LookupRule(domain.RuleAggregateToNominate)
+	LookupHigherRank          LookupRule = "higher_rank"
+	LookupHigherRankFallback  LookupRule = "higher_rank_fallback"
+	// LookupSensu is the exact lookup, scoped to the sec. reference a
+	// "sensu" citation named; FilteredOut then includes what the scope
+	// removed.
+	LookupSensu LookupRule = "sensu"
+	// LookupHybridFormula is Repository.MatchHybridParents on a formula's
+	// parents; the key is the parents joined by " × ".
+	LookupHybridFormula LookupRule = "hybrid_formula"
+)
+
+// TraceDecision is how one lookup's candidates were decided — the step a
+// curator otherwise has to reconstruct from classify and genuineBearerWinner.
+type TraceDecision string
+
+const (
+	// DecisionNoCandidates: the lookup returned nothing (after the filter).
+	DecisionNoCandidates TraceDecision = "no_candidates"
+	// DecisionNoneClassified: candidates came back, but none classified —
+	// in practice an author mismatch.
+	DecisionNoneClassified TraceDecision = "none_classified"
+	// DecisionSingleConcept: every winning candidate named one concept.
+	DecisionSingleConcept TraceDecision = "single_concept"
+	// DecisionAcceptedBearer: a tie broken by genuineBearerWinner's first
+	// tier — exactly one concept holds the name as accepted.
+	DecisionAcceptedBearer TraceDecision = "accepted_bearer"
+	// DecisionHomotypicBearer: a tie broken by the second tier — exactly one
+	// concept holds the name as a homotypic synonym, none as accepted.
+	DecisionHomotypicBearer TraceDecision = "homotypic_bearer"
+	// DecisionInArea: a tie the bearer tiers left standing, broken by the
+	// request's area — exactly one contender occurs there (see areaWinner).
+	DecisionInArea TraceDecision = "in_area"
+	// DecisionUsageRelation: the candidates were not classified at all — a
+	// stored misapplied/pro-parte edge named the meant concept (see
+	// matchUsage).
+	DecisionUsageRelation TraceDecision = "usage_relation"
+	// DecisionAmbiguous: the tie stands.
+	DecisionAmbiguous TraceDecision = "ambiguous"
+)
+
+// MatchTrace is the explain-mode record of how one MatchRequest was resolved:
+// what the verbatim was parsed into, every key the ladder looked up and what
+// came back for it, the fuzzy pool with its scores, and the filter that was in
+// force. It describes; it never influences — the result of an explained match
+// is byte-for-byte the result of the same match unexplained.
+//
+// Only keys the matcher ACTUALLY looks up appear in Lookups. The wider set of
+// domain.NameCandidates rewrites (hybrid spacing, autonym, genitive) belongs
+// to trait and name-space ingest and is deliberately not tried by the
+// matcher; listing it here would explain a path the name never took.
+type MatchTrace struct {
+	Verbatim  string
+	Canonical string
+	Author    string
+	Path      MatchPath
+	Filter    MatchFilter
+	Lookups   []MatchLookup
+	// Fuzzy is nil when fuzzy matching was never reached, and empty when it
+	// was reached but the pool came back empty — the difference is the
+	// answer to "did it even try?".
+	Fuzzy []FuzzyScore
+}
+
+// MatchLookup is one repo.MatchExact call: the key, why it was tried, the
+// candidates that survived the filter, how many the filter removed, and how
+// they were decided.
+type MatchLookup struct {
+	Key         string
+	Rule        LookupRule
+	Candidates  []TracedCandidate
+	FilteredOut int
+	Decision    TraceDecision
+}
+
+// TracedCandidate is one MatchExact candidate as the tie-break sees it.
+type TracedCandidate struct {
+	ConceptID  string
+	BackboneID string
+	Name       string
+	Authorship string
+	Role       string
+	Homotypic  *bool
+}
+
+// FuzzyScore is one fuzzy-pool candidate with its domain.Similarity to the
+// query canonical.
+type FuzzyScore struct {
+	ConceptID  string
+	Name       string
+	Similarity float64
+}
+
+// ExplainMatches is MatchInSpace with a MatchTrace attached to every result.
+// It validates and resolves exactly as MatchInSpace does; the only difference
+// is MatchResult.Trace.
+func ExplainMatches(ctx context.Context, repo output.Repository, reqs []MatchRequest, space string, filter MatchFilter) ([]MatchResult, error) {
+	return matchInSpace(ctx, repo, reqs, space, filter, true)
+}
+
+// The recording methods below are all nil-safe, so the matcher threads a
+// *MatchTrace through unconditionally and an unexplained match (nil) pays
+// nothing but the nil checks.
+
+func (tr *MatchTrace) parsed(canonical, author string, path MatchPath) {
+	if tr == nil {
+		return
+	}
+	tr.Canonical, tr.Author, tr.Path = canonical, author, path
+}
+
+func (tr *MatchTrace) lookup(key string, rule LookupRule, raw, kept []output.MatchCandidate) {
+	if tr == nil {
+		return
+	}
+	l := MatchLookup{Key: key, Rule: rule, FilteredOut: len(raw) - len(kept), Decision: DecisionNoCandidates}
+	for _, c := range kept {
+		l.Candidates = append(l.Candidates, TracedCandidate{
+			ConceptID:  c.Concept.ID,
+			BackboneID: c.Concept.BackboneID,
+			Name:       c.MatchedName.Canonical,
+			Authorship: c.MatchedName.Authorship,
+			Role:       c.Role,
+			Homotypic:  c.Homotypic,
+		})
+	}
+	tr.Lookups = append(tr.Lookups, l)
+}
+
+// decide records d on the most recent lookup — classify always runs on the
+// candidates the lookup just before it returned.
+func (tr *MatchTrace) decide(d TraceDecision) {
+	if tr == nil || len(tr.Lookups) == 0 {
+		return
+	}
+	tr.Lookups[len(tr.Lookups)-1].Decision = d
+}
+
+func (tr *MatchTrace) fuzzy(candidates []output.MatchCandidate, scores []float64) {
+	if tr == nil {
+		return
+	}
+	tr.Fuzzy = make([]FuzzyScore, 0, len(candidates))
+	for i, c := range candidates {
+		tr.Fuzzy = append(tr.Fuzzy, FuzzyScore{ConceptID: c.Concept.ID, Name: c.MatchedName.Canonical, Similarity: scores[i]})
+	}
+}
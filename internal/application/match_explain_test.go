@@ -0,0 +1,145 @@
+package application_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// TestExplainMatches_DescribesWithoutChanging pins the explain contract's
+// first half: the trace is an attachment, never an influence. Every result of
+// an explained batch, with its Trace removed, must equal the unexplained one.
+func TestExplainMatches_DescribesWithoutChanging(t *testing.T) {
+	repo := seededMatchRepo(t)
+	seedFestucaOvinaAggregate(t, repo)
+	seedSileneOtites(t, repo)
+	reqs := []application.MatchRequest{
+		{ID: "1", Verbatim: "Corynephorus canescens (L.) P.Beauv."},
+		{ID: "2", Verbatim: "Corynephorus canescens aggr."},
+		{ID: "3", Verbatim: "Festuca ovina agg."},
+		{ID: "4", Verbatim: "Silene otitis"},
+		{ID: "5", Verbatim: "Nonexistentus bogus"},
+	}
+	ctx := context.Background()
+
+	plain, err := application.MatchInSpace(ctx, repo, reqs, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+	explained, err := application.ExplainMatches(ctx, repo, reqs, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("ExplainMatches: unexpected error: %v", err)
+	}
+	for i := range reqs {
+		if plain[i].Trace != nil {
+			t.Errorf("%s: MatchInSpace attached a trace, want nil", reqs[i].Verbatim)
+		}
+		if explained[i].Trace == nil {
+			t.Fatalf("%s: ExplainMatches attached no trace", reqs[i].Verbatim)
+		}
+		stripped := explained[i]
+		stripped.Trace = nil
+		if !reflect.DeepEqual(stripped, plain[i]) {
+			t.Errorf("%s: explained result %+v differs from plain %+v", reqs[i].Verbatim, stripped, plain[i])
+		}
+		if explained[i].Trace.Verbatim != reqs[i].Verbatim {
+			t.Errorf("Trace.Verbatim = %q, want %q", explained[i].Trace.Verbatim, reqs[i].Verbatim)
+		}
+	}
+}
+
+// TestExplainMatches_TracesTheAggregateLadder is the case explain exists for:
+// "why did my aggregate come back as the nominate species?" The trace must
+// show the marked lookup finding nothing and the bare one deciding.
+func TestExplainMatches_TracesTheAggregateLadder(t *testing.T) {
+	repo := seededMatchRepo(t)
+
+	results, err := application.ExplainMatches(context.Background(), repo,
+		[]application.MatchRequest{{ID: "1", Verbatim: "Corynephorus canescens aggr."}}, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("ExplainMatches: unexpected error: %v", err)
+	}
+	tr := results[0].Trace
+	if tr.Path != application.MatchPathAggregate {
+		t.Errorf("Path = %q, want %q", tr.Path, application.MatchPathAggregate)
+	}
+	if len(tr.Lookups) != 2 {
+		t.Fatalf("Lookups = %+v, want the marked and the bare key", tr.Lookups)
+	}
+	marked, bare := tr.Lookups[0], tr.Lookups[1]
+	if marked.Key != "corynephorus canescens aggr." || marked.Rule != application.LookupAggregate || marked.Decision != application.DecisionNoCandidates {
+		t.Errorf("first lookup = %+v, want the marked key with no candidates", marked)
+	}
+	if bare.Key != "corynephorus canescens" || bare.Rule != application.LookupAggregateToNominate || bare.Decision != application.DecisionSingleConcept {
+		t.Errorf("second lookup = %+v, want the bare key deciding a single concept", bare)
+	}
+	if len(bare.Candidates) == 0 || bare.Candidates[0].ConceptID != results[0].ConceptID {
+		t.Errorf("bare lookup candidates = %+v, want the resolved concept %q among them", bare.Candidates, results[0].ConceptID)
+	}
+	if tr.Fuzzy != nil {
+		t.Errorf("Fuzzy = %+v, want nil — the ladder resolved before fuzzy was reached", tr.Fuzzy)
+	}
+}
+
+// TestExplainMatches_RecordsTheFuzzyPool pins that the scores fuzzy computed
+// are handed back, the winner's among them, at the confidence it resolved with.
+func TestExplainMatches_RecordsTheFuzzyPool(t *testing.T) {
+	repo := seededMatchRepo(t)
+	conceptID := seedSileneOtites(t, repo)
+
+	results, err := application.ExplainMatches(context.Background(), repo,
+		[]application.MatchRequest{{ID: "1", Verbatim: "Silene otitis"}}, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("ExplainMatches: unexpected error: %v", err)
+	}
+	r := results[0]
+	if r.MatchType != domain.MatchFuzzy {
+		t.Fatalf("MatchType = %q, want %q", r.MatchType, domain.MatchFuzzy)
+	}
+	if got := r.Trace.Lookups[0].Decision; got != application.DecisionNoCandidates {
+		t.Errorf("exact lookup Decision = %q, want %q", got, application.DecisionNoCandidates)
+	}
+	found := false
+	for _, f := range r.Trace.Fuzzy {
+		if f.ConceptID == conceptID && f.Similarity == r.Confidence {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Fuzzy = %+v, want %q scored at %v", r.Trace.Fuzzy, conceptID, r.Confidence)
+	}
+}
+
+// TestExplainMatches_RecordsAmbiguityAndFilter pins the tie-break record and
+// the filter echo: a homonym tie is named as such, and a filter that removes
+// one side is visible as FilteredOut plus the decision it allowed.
+func TestExplainMatches_RecordsAmbiguityAndFilter(t *testing.T) {
+	repo := seededMatchRepo(t)
+	seedHomonymPair(t, repo)
+	reqs := []application.MatchRequest{{ID: "1", Verbatim: "Homonymus testicus L."}}
+	ctx := context.Background()
+
+	results, err := application.ExplainMatches(ctx, repo, reqs, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("ExplainMatches: unexpected error: %v", err)
+	}
+	if got := results[0].Trace.Lookups[0].Decision; got != application.DecisionAmbiguous {
+		t.Errorf("Decision = %q, want %q", got, application.DecisionAmbiguous)
+	}
+
+	filter := application.MatchFilter{Backbone: "wcvp"}
+	filtered, err := application.ExplainMatches(ctx, repo, reqs, "", filter)
+	if err != nil {
+		t.Fatalf("ExplainMatches: unexpected error: %v", err)
+	}
+	tr := filtered[0].Trace
+	if tr.Filter != filter {
+		t.Errorf("Filter = %+v, want %+v", tr.Filter, filter)
+	}
+	if l := tr.Lookups[0]; l.FilteredOut != 2 || len(l.Candidates) != 0 {
+		t.Errorf("lookup = %+v, want both homonyms filtered out", l)
+	}
+}
@@ -227,3 +227,28 @@ func TestMatchFilter_UnknownBackboneAndSecAreRejected(t *testing.T) {
 		t.Errorf("entry_sec=nope err = %v, want ErrUnknownSec", err)
 	}
 }
+
+// TestMatchFilter_PublishedBeforeDropsYoungerNames: Corynephorus canescens f.
+// pallidus was published in 1972 (the fixture's "(1971 publ. 1972)"), so a
+// label from before 1900 cannot carry it and the name is unresolvable; a
+// cutoff after 1972 resolves it as usual.
+func TestMatchFilter_PublishedBeforeDropsYoungerNames(t *testing.T) {
+	repo := seededMatchRepo(t)
+	reqs := []application.MatchRequest{{ID: "1", Verbatim: "Corynephorus canescens f. pallidus"}}
+
+	old, err := application.MatchInSpace(context.Background(), repo, reqs, "", application.MatchFilter{PublishedBefore: 1900})
+	if err != nil {
+		t.Fatalf("published_before=1900: %v", err)
+	}
+	if old[0].ConceptID != "" {
+		t.Errorf("published_before=1900 = %+v, want no concept", old[0])
+	}
+
+	young, err := application.MatchInSpace(context.Background(), repo, reqs, "", application.MatchFilter{PublishedBefore: 1973})
+	if err != nil {
+		t.Fatalf("published_before=1973: %v", err)
+	}
+	if young[0].ConceptID != "wcvp:concept:405825" {
+		t.Errorf("published_before=1973 = %+v, want wcvp:concept:405825", young[0])
+	}
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// matchHybridFormula resolves a verbatim that names a hybrid by its parents
+// (domain.ParseHybridFormula): "Salix alba × S. fragilis" is the
+// nothospecies the backbone records with exactly those parents, "Salix ×
+// rubens". The parents are looked up as a set (Repository.MatchHybridParents),
+// so the order a cross is written in does not matter.
+//
+// One concept resolves as domain.MatchHybridFormula; several (two
+// nothotaxa sharing a parentage, or one per backbone without a filter) are
+// an ambiguous tie. None leaves the entry unresolved. Like matchUsage there
+// is no fallback to the ordinary ladder or to fuzzy: a formula's nearest
+// NAME is one of its parents, which is exactly what the writer did not mean.
+func matchHybridFormula(ctx context.Context, repo output.Repository, req MatchRequest, parents []string, filter MatchFilter, tr *MatchTrace) (MatchResult, error) {
+	key := strings.Join(parents, " × ")
+	tr.parsed(key, "", MatchPathHybridFormula)
+
+	raw, err := repo.MatchHybridParents(ctx, parents)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	candidates := filter.apply(raw)
+	tr.lookup(key, LookupHybridFormula, raw, candidates)
+
+	if len(candidates) == 0 {
+		return MatchResult{
+			ID:             req.ID,
+			RequiresReview: true,
+			Note:           noteHybridFormulaMissing,
+		}, nil
+	}
+	concepts := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		concepts[c.Concept.ID] = true
+	}
+	if len(concepts) > 1 {
+		tr.decide(DecisionAmbiguous)
+		return MatchResult{
+			ID:             req.ID,
+			RequiresReview: true,
+			Note:           noteHybridFormulaAmbiguous,
+			Candidates:     candidateNames(candidates),
+		}, nil
+	}
+	tr.decide(DecisionSingleConcept)
+	return MatchResult{
+		ID:         req.ID,
+		MatchType:  domain.MatchHybridFormula,
+		Confidence: confidenceHybridFormula,
+		ConceptID:  candidates[0].Concept.ID,
+		Note:       noteHybridFormula,
+	}, nil
+}
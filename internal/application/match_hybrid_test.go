@@ -0,0 +1,117 @@
+package application_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// seedHybrids ingests, under backbone "test", the willows "Salix alba" and
+// "Salix fragilis" with their hybrid "Salix × rubens", whose source row
+// carries the formula with an abbreviated genus and authors, and two Mentha
+// nothospecies recorded with one and the same parentage.
+func seedHybrids(t *testing.T) *sqlite.DB {
+	t.Helper()
+	ds := &application.Dataset{Backbones: []application.Backbone{{ID: "test", Version: "v1"}}, ManifestSHA: "x"}
+	repo := openMemoryRepo(t)
+	taxa := []application.TaxonRow{
+		{TaxonID: "alba", AcceptedTaxonID: "alba", Accepted: true, Canonical: "Salix alba", Rank: "SPECIES"},
+		{TaxonID: "fragilis", AcceptedTaxonID: "fragilis", Accepted: true, Canonical: "Salix fragilis", Rank: "SPECIES"},
+		{TaxonID: "rubens", AcceptedTaxonID: "rubens", Accepted: true, Canonical: "Salix × rubens", Rank: "SPECIES", HybridFormula: "Salix alba L. × S. fragilis L."},
+		{TaxonID: "piperita", AcceptedTaxonID: "piperita", Accepted: true, Canonical: "Mentha × piperita", Rank: "SPECIES", HybridFormula: "Mentha aquatica × M. spicata"},
+		{TaxonID: "other", AcceptedTaxonID: "other", Accepted: true, Canonical: "Mentha × other", Rank: "SPECIES", HybridFormula: "Mentha spicata × M. aquatica"},
+		{TaxonID: "nested", AcceptedTaxonID: "nested", Accepted: true, Canonical: "Salix × nested", Rank: "SPECIES", HybridFormula: "(Salix alba × S. fragilis) × S. purpurea"},
+	}
+	readerFor := func(application.Backbone) (application.RowSource, error) {
+		return fakeRowSource{taxa: taxa}, nil
+	}
+	if _, err := application.Ingest(context.Background(), ds, readerFor, repo); err != nil {
+		t.Fatalf("Ingest: unexpected error: %v", err)
+	}
+	return repo
+}
+
+// TestIngest_StoresHybridParents pins what Ingest makes of a formula: the
+// parents in formula order, genus written out, authors dropped, each
+// resolved to its concept — and nothing for a nested formula.
+func TestIngest_StoresHybridParents(t *testing.T) {
+	repo := seedHybrids(t)
+	docs, err := repo.Concepts(context.Background(), []string{"test:concept:rubens", "test:concept:nested", "test:concept:alba"}, output.ConceptInclude{})
+	if err != nil {
+		t.Fatalf("Concepts: unexpected error: %v", err)
+	}
+	want := []domain.HybridParent{
+		{Canonical: "Salix alba", ConceptID: "test:concept:alba"},
+		{Canonical: "Salix fragilis", ConceptID: "test:concept:fragilis"},
+	}
+	if got := docs[0].HybridParents; !reflect.DeepEqual(got, want) {
+		t.Errorf("rubens HybridParents = %+v, want %+v", got, want)
+	}
+	for _, doc := range docs[1:] {
+		if doc.HybridParents != nil {
+			t.Errorf("%s HybridParents = %+v, want none", doc.Concept.ID, doc.HybridParents)
+		}
+	}
+}
+
+// TestMatchNames_HybridFormulaResolvesToNothotaxon is the case the path
+// exists for: the formula, in either order and either marker, answers with
+// the nothospecies — while the nothospecies' own name still goes the
+// ordinary ladder.
+func TestMatchNames_HybridFormulaResolvesToNothotaxon(t *testing.T) {
+	repo := seedHybrids(t)
+
+	for _, verbatim := range []string{"Salix alba × S. fragilis", "Salix fragilis x Salix alba"} {
+		r := matchOneName(t, repo, verbatim)
+		if r.ConceptID != "test:concept:rubens" || r.MatchType != domain.MatchHybridFormula || r.RequiresReview || r.Note == "" {
+			t.Errorf("%q: result = %+v, want hybrid_formula onto test:concept:rubens with a note and without review", verbatim, r)
+		}
+		if r.Confidence <= domain.FuzzyThreshold {
+			t.Errorf("%q: Confidence = %v, want above the fuzzy threshold %v", verbatim, r.Confidence, domain.FuzzyThreshold)
+		}
+	}
+
+	if r := matchOneName(t, repo, "Salix × rubens"); r.ConceptID != "test:concept:rubens" || r.MatchType != domain.MatchExact {
+		t.Errorf("nothospecies name: result = %+v, want an exact match", r)
+	}
+}
+
+// TestMatchNames_HybridFormulaUnresolved pins the two non-answers: a
+// parentage two nothotaxa share is a tie, one no nothotaxon has stays
+// unresolved rather than falling back to a parent's name.
+func TestMatchNames_HybridFormulaUnresolved(t *testing.T) {
+	repo := seedHybrids(t)
+
+	r := matchOneName(t, repo, "Mentha aquatica × M. spicata")
+	if r.ConceptID != "" || !r.RequiresReview || len(r.Candidates) != 2 {
+		t.Errorf("shared parentage: result = %+v, want an unresolved tie listing both nothotaxa", r)
+	}
+
+	r = matchOneName(t, repo, "Salix alba × S. purpurea")
+	if r.ConceptID != "" || !r.RequiresReview || r.Note == "" {
+		t.Errorf("unknown parentage: result = %+v, want unresolved with a note", r)
+	}
+}
+
+// TestExplainMatches_HybridFormulaPath pins the trace: the hybrid_formula
+// path, one lookup keyed by the written-out parents, decided on one concept.
+func TestExplainMatches_HybridFormulaPath(t *testing.T) {
+	repo := seedHybrids(t)
+	results, err := application.ExplainMatches(context.Background(), repo,
+		[]application.MatchRequest{{ID: "1", Verbatim: "Salix alba × S. fragilis"}}, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("ExplainMatches: unexpected error: %v", err)
+	}
+	tr := results[0].Trace
+	if tr.Path != application.MatchPathHybridFormula || tr.Canonical != "Salix alba × Salix fragilis" {
+		t.Errorf("trace = %+v, want path hybrid_formula on the written-out formula", tr)
+	}
+	if len(tr.Lookups) != 1 || tr.Lookups[0].Rule != application.LookupHybridFormula || tr.Lookups[0].Decision != application.DecisionSingleConcept {
+		t.Errorf("lookups = %+v, want one hybrid_formula lookup decided on a single concept", tr.Lookups)
+	}
+}
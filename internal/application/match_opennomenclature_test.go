@@ -0,0 +1,169 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// seedHigherRanks ingests a small backbone carrying only ranks above species
+// — a family, three genera and one section — plus one species, so each open-
+// nomenclature shape has exactly one concept to land on. An empty repo rather
+// than the WCVP fixture keeps the genus keys unambiguous.
+func seedHigherRanks(t *testing.T) (*sqlite.DB, map[string]string) {
+	t.Helper()
+	repo := openMemoryRepo(t)
+	tx, err := repo.BeginIngest(context.Background(), domain.BackboneVersion{ID: "test-hr", Version: "v1"})
+	if err != nil {
+		t.Fatalf("BeginIngest: unexpected error: %v", err)
+	}
+	taxa := []struct {
+		key, canonical string
+		rank           domain.Rank
+	}{
+		{"poaceae", "Poaceae", domain.RankFamily},
+		{"festuca", "Festuca", domain.RankGenus},
+		{"carex", "Carex", domain.RankGenus},
+		{"taraxacum", "Taraxacum", domain.RankGenus},
+		{"ruderalia", "Taraxacum sect. Ruderalia", domain.RankOther},
+		{"festuca ovina", "Festuca ovina", domain.RankSpecies},
+	}
+	ids := make(map[string]string, len(taxa))
+	for _, taxon := range taxa {
+		name := domain.Name{ID: "test-hr:name:" + taxon.key, Canonical: taxon.canonical, Rank: taxon.rank}
+		concept := domain.Concept{ID: "test-hr:concept:" + taxon.key, BackboneID: "test-hr", AcceptedName: name, Rank: taxon.rank, Status: domain.StatusAccepted}
+		if err := tx.UpsertName(name); err != nil {
+			t.Fatalf("UpsertName: unexpected error: %v", err)
+		}
+		if err := tx.UpsertConcept(concept); err != nil {
+			t.Fatalf("UpsertConcept: unexpected error: %v", err)
+		}
+		if err := tx.LinkName(concept.ID, name.ID, "accepted", nil); err != nil {
+			t.Fatalf("LinkName: unexpected error: %v", err)
+		}
+		ids[taxon.key] = concept.ID
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: unexpected error: %v", err)
+	}
+	return repo, ids
+}
+
+// TestMatchNames_OpenNomenclatureResolvesToHigherRank is the relevé-import
+// case: genus- and family-level records used to run the species ladder and
+// come back UNRESOLVABLE (or, worse, fuzzy-matched onto some species). They
+// must resolve to the concept they actually name, under a type of their own,
+// and always flagged for review.
+func TestMatchNames_OpenNomenclatureResolvesToHigherRank(t *testing.T) {
+	repo, ids := seedHigherRanks(t)
+
+	cases := []struct {
+		verbatim, wantConcept string
+	}{
+		{"Festuca sp.", ids["festuca"]},
+		{"Carex spec.", ids["carex"]},
+		{"Poaceae indet.", ids["poaceae"]},
+		{"Taraxacum sect. Ruderalia", ids["ruderalia"]},
+	}
+	for _, c := range cases {
+		t.Run(c.verbatim, func(t *testing.T) {
+			results, err := application.MatchNames(context.Background(), repo, []application.MatchRequest{{ID: "1", Verbatim: c.verbatim}})
+			if err != nil {
+				t.Fatalf("MatchNames: unexpected error: %v", err)
+			}
+			assertMatchResult(t, results[0], wantMatch{
+				matchType:      domain.MatchHigherRank,
+				confidence:     0.87,
+				conceptID:      c.wantConcept,
+				requiresReview: true,
+				noteNonEmpty:   true,
+			})
+		})
+	}
+}
+
+// TestMatchNames_SectionFallsBackToGenus pins the fallback: WCVP carries no
+// sections, and a section record is still a true genus-level statement. The
+// note must say that the section itself was not found.
+func TestMatchNames_SectionFallsBackToGenus(t *testing.T) {
+	repo, ids := seedHigherRanks(t)
+
+	results, err := application.MatchNames(context.Background(), repo, []application.MatchRequest{{ID: "1", Verbatim: "Taraxacum sect. Erythrosperma"}})
+	if err != nil {
+		t.Fatalf("MatchNames: unexpected error: %v", err)
+	}
+	r := results[0]
+	if r.MatchType != domain.MatchHigherRank || r.ConceptID != ids["taraxacum"] {
+		t.Errorf("got (%q, %q), want (%q, %q)", r.MatchType, r.ConceptID, domain.MatchHigherRank, ids["taraxacum"])
+	}
+	direct, err := application.MatchNames(context.Background(), repo, []application.MatchRequest{{ID: "1", Verbatim: "Taraxacum sect. Ruderalia"}})
+	if err != nil {
+		t.Fatalf("MatchNames: unexpected error: %v", err)
+	}
+	if r.Note == direct[0].Note {
+		t.Errorf("Note = %q for both the fallback and a direct section hit, want the fallback told apart", r.Note)
+	}
+}
+
+// TestMatchNames_OpenNomenclatureMissingGenusStaysUnresolvable pins that
+// nothing is invented: an absent genus is UNRESOLVABLE — and in particular is
+// NOT fuzzy-matched onto a similar-looking genus.
+func TestMatchNames_OpenNomenclatureMissingGenusStaysUnresolvable(t *testing.T) {
+	repo, _ := seedHigherRanks(t)
+
+	results, err := application.MatchNames(context.Background(), repo, []application.MatchRequest{{ID: "1", Verbatim: "Festuka sp."}})
+	if err != nil {
+		t.Fatalf("MatchNames: unexpected error: %v", err)
+	}
+	r := results[0]
+	if r.ConceptID != "" || r.MatchType != "" || !r.RequiresReview {
+		t.Errorf("got ConceptID=%q MatchType=%q RequiresReview=%v, want unresolvable", r.ConceptID, r.MatchType, r.RequiresReview)
+	}
+}
+
+// TestMatchNames_OpenNomenclatureHonoursFilter pins that the higher-rank path
+// applies the resolution filter like every other path: a genus carried by two
+// backbones is an ambiguous tie unfiltered and resolves once entry_backbone
+// picks one of them.
+func TestMatchNames_OpenNomenclatureHonoursFilter(t *testing.T) {
+	repo, ids := seedHigherRanks(t)
+	ctx := context.Background()
+	tx, err := repo.BeginIngest(ctx, domain.BackboneVersion{ID: "test-hr2", Version: "v1"})
+	if err != nil {
+		t.Fatalf("BeginIngest: unexpected error: %v", err)
+	}
+	name := domain.Name{ID: "test-hr2:name:festuca", Canonical: "Festuca", Rank: domain.RankGenus}
+	concept := domain.Concept{ID: "test-hr2:concept:festuca", BackboneID: "test-hr2", AcceptedName: name, Rank: domain.RankGenus, Status: domain.StatusAccepted}
+	if err := tx.UpsertName(name); err != nil {
+		t.Fatalf("UpsertName: unexpected error: %v", err)
+	}
+	if err := tx.UpsertConcept(concept); err != nil {
+		t.Fatalf("UpsertConcept: unexpected error: %v", err)
+	}
+	if err := tx.LinkName(concept.ID, name.ID, "accepted", nil); err != nil {
+		t.Fatalf("LinkName: unexpected error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: unexpected error: %v", err)
+	}
+	reqs := []application.MatchRequest{{ID: "1", Verbatim: "Festuca sp."}}
+
+	unfiltered, err := application.MatchNames(ctx, repo, reqs)
+	if err != nil {
+		t.Fatalf("MatchNames: unexpected error: %v", err)
+	}
+	if r := unfiltered[0]; r.ConceptID != "" || len(r.Candidates) != 2 {
+		t.Errorf("unfiltered: got ConceptID=%q Candidates=%v, want an ambiguous tie over both genera", r.ConceptID, r.Candidates)
+	}
+
+	filtered, err := application.MatchInSpace(ctx, repo, reqs, "", application.MatchFilter{Backbone: "test-hr"})
+	if err != nil {
+		t.Fatalf("MatchInSpace: unexpected error: %v", err)
+	}
+	if r := filtered[0]; r.MatchType != domain.MatchHigherRank || r.ConceptID != ids["festuca"] {
+		t.Errorf("filtered: got (%q, %q), want (%q, %q)", r.MatchType, r.ConceptID, domain.MatchHigherRank, ids["festuca"])
+	}
+}
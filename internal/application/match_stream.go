@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// MatchSource yields the entries of a streamed match one at a time. It
+// returns io.EOF (unwrapped or wrapped) once the input is exhausted; any other
+// error ends the stream with that error.
+type MatchSource func() (MatchRequest, error)
+
+// StreamMatches is MatchInSpace (or, with explain, ExplainMatches) for input
+// that should never be held in memory as a whole: it pulls one entry from
+// next, resolves it, and hands the result to emit before pulling the next.
+// Memory stays flat in the batch size; results come out in input order.
+//
+// Filter and target space are validated before next is called for the first
+// time, so an unknown value fails exactly as it does on the batch path —
+// before any entry is read and, for an HTTP caller, before any byte of the
+// response is written.
+//
+// Each result is the one MatchInSpace would produce for the same entry. The
+// only cost is the annotations: the batch path asks AreaPresence and
+// HigherTaxa once for the whole batch, this path once per resolved entry.
+func StreamMatches(ctx context.Context, repo output.Repository, next MatchSource, emit func(MatchResult) error, space string, filter MatchFilter, explain bool) error {
+	if err := validateFilter(ctx, repo, filter); err != nil {
+		return err
+	}
+	if space != "" {
+		if err := validateTargetSpace(ctx, repo, space); err != nil {
+			return err
+		}
+	}
+	for {
+		req, err := next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var tr *MatchTrace
+		if explain {
+			tr = &MatchTrace{Verbatim: req.Verbatim, Filter: filter}
+		}
+		res, err := matchOne(ctx, repo, req, filter, tr)
+		if err != nil {
+			return err
+		}
+		res.Trace = tr
+		one := []MatchResult{res}
+		if err := annotateRange(ctx, repo, one, filter.Area); err != nil {
+			return err
+		}
+		if err := annotateHigherTaxa(ctx, repo, one); err != nil {
+			return err
+		}
+		if err := annotateTargetSpace(ctx, repo, one, []MatchRequest{req}, space); err != nil {
+			return err
+		}
+		if err := emit(one[0]); err != nil {
+			return err
+		}
+	}
+}
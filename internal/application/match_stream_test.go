@@ -0,0 +1,73 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/application"
+)
+
+// sliceSource feeds reqs to StreamMatches one at a time.
+func sliceSource(reqs []application.MatchRequest) application.MatchSource {
+	return func() (application.MatchRequest, error) {
+		if len(reqs) == 0 {
+			return application.MatchRequest{}, io.EOF
+		}
+		req := reqs[0]
+		reqs = reqs[1:]
+		return req, nil
+	}
+}
+
+// TestStreamMatches_EqualsTheBatch pins that streaming changes only when a
+// result is produced, never what it is — area flags and tie-breaks included.
+func TestStreamMatches_EqualsTheBatch(t *testing.T) {
+	repo, _ := seedRangedConcepts(t)
+	ctx := context.Background()
+	reqs := []application.MatchRequest{
+		{ID: "1", Verbatim: "Homonymus rangeus L."},
+		{ID: "2", Verbatim: "Tropicus remotus"},
+		{ID: "3", Verbatim: "Ignotus nusquam"},
+		{ID: "4", Verbatim: "Localis duplex"},
+		{ID: "5", Verbatim: "Nonexistus bogus"},
+	}
+	filter := application.MatchFilter{Area: "DE"}
+
+	want, err := application.MatchInSpace(ctx, repo, reqs, "", filter)
+	if err != nil {
+		t.Fatalf("MatchInSpace: %v", err)
+	}
+	var got []application.MatchResult
+	emit := func(r application.MatchResult) error {
+		got = append(got, r)
+		return nil
+	}
+	if err := application.StreamMatches(ctx, repo, sliceSource(reqs), emit, "", filter, false); err != nil {
+		t.Fatalf("StreamMatches: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed = %+v\nbatch    = %+v", got, want)
+	}
+}
+
+// TestStreamMatches_ValidatesBeforeReading pins that an unknown filter fails
+// before the source is touched — for HTTP, before any byte is written.
+func TestStreamMatches_ValidatesBeforeReading(t *testing.T) {
+	repo, _ := seedRangedConcepts(t)
+	read := false
+	next := func() (application.MatchRequest, error) {
+		read = true
+		return application.MatchRequest{}, io.EOF
+	}
+	err := application.StreamMatches(context.Background(), repo, next, func(application.MatchResult) error { return nil },
+		"", application.MatchFilter{Backbone: "nope"}, false)
+	if !errors.Is(err, application.ErrUnknownBackbone) {
+		t.Errorf("err = %v, want ErrUnknownBackbone", err)
+	}
+	if read {
+		t.Error("source was read before the filter was validated")
+	}
+}
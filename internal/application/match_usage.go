@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// matchUsage resolves a verbatim that says whose USE of a name it means
+// (domain.ParseUsageQualifier): "Pinus abies auct.", "Abies alba sensu
+// Oberdorfer", "Festuca ovina Hack. non L.". The ordinary ladder would drop
+// the qualifier and answer with the nomenclatural bearer of the canonical —
+// for "auct." exactly the concept the writer did NOT mean.
+//
+//  1. The unqualified name is looked up exactly. A "sensu" citation that
+//     names exactly one ingested sec. reference (domain.SecReferencesCiting)
+//     scopes the candidates to that reference; one naming none or several is
+//     recorded and otherwise ignored. A bare "non" (no auct., no sensu) drops
+//     the candidates whose author is the excluded one — the later-homonym
+//     case. Alongside auct./sensu the "non" part only says which name was
+//     misapplied, and a misapplication concept carries that very author, so
+//     it is not used to exclude anything there.
+//  2. For auct./sensu, the stored usage edges of the candidates
+//     (Repository.UsageTargets) decide: misapplied edges over pro-parte ones,
+//     one meant concept resolves as domain.MatchMisapplied, several are an
+//     ambiguous tie.
+//  3. "auct." without any edge is left unresolved with the candidates listed.
+//     Falling back to classify would return the very homonym the qualifier
+//     excludes, now looking certain.
+//  4. Everything else is classified like an ordinary name, with a note
+//     saying what the qualifier did.
+//
+// Like matchHigherRank there is NO fuzzy fallback: a qualified name is a
+// deliberate statement, and a guessed neighbour of it is not what was stated.
+func matchUsage(ctx context.Context, repo output.Repository, req MatchRequest, uq domain.UsageQualifier, filter MatchFilter, tr *MatchTrace) (MatchResult, error) {
+	canonical, author := splitVerbatim(uq.Name)
+	queryCanon := domain.Canonicalize(canonical)
+	queryAuthor := domain.NormalizeAuthor(author)
+	tr.parsed(canonical, author, MatchPathUsage)
+
+	raw, err := repo.MatchExact(ctx, queryCanon)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	candidates := filter.apply(raw)
+	rule := LookupExact
+	sensuKnown := true
+	if uq.Sensu != "" {
+		refs, err := repo.SecReferences(ctx)
+		if err != nil {
+			return MatchResult{}, err
+		}
+		cited := domain.SecReferencesCiting(refs, uq.Sensu)
+		sensuKnown = len(cited) == 1
+		if sensuKnown {
+			candidates = MatchFilter{Sec: cited[0].ID}.apply(candidates)
+			rule = LookupSensu
+		}
+	}
+	excluded := 0
+	if uq.Non != "" && !uq.Misapplication() {
+		kept := candidatesNotBy(candidates, uq.Non)
+		excluded = len(candidates) - len(kept)
+		candidates = kept
+	}
+	tr.lookup(queryCanon, rule, raw, candidates)
+
+	if uq.Misapplication() {
+		res, found, err := followUsage(ctx, repo, req, candidates, tr)
+		if err != nil || found {
+			return res, err
+		}
+	}
+	if uq.Auct {
+		if len(candidates) > 0 {
+			tr.decide(DecisionNoneClassified)
+		}
+		return MatchResult{
+			ID:             req.ID,
+			RequiresReview: true,
+			Note:           noteUsageUnresolved,
+			Candidates:     candidateNames(candidates),
+		}, nil
+	}
+
+	res, unresolved, err := classifyTraced(req, queryCanon, queryAuthor, candidates, inAreaLookup(ctx, repo, filter.Area), tr)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	if unresolved && rule == LookupSensu && len(raw) > 0 {
+		res.Note = noteSensuNotInReference
+	}
+	if unresolved || res.ConceptID == "" {
+		return res, nil
+	}
+	// A tie-break note (noteAreaTieBreak) says more about how the concept was
+	// picked than these do; it is kept.
+	if res.Note != "" {
+		return res, nil
+	}
+	if !sensuKnown {
+		res.RequiresReview = true
+		res.Note = noteSensuUnknown
+		return res, nil
+	}
+	if rule == LookupSensu {
+		res.Note = noteSensu
+		return res, nil
+	}
+	if excluded > 0 {
+		res.Note = noteNonExcluded
+	}
+	return res, nil
+}
+
+// followUsage resolves candidates through their stored usage edges. found is
+// false when none of them has one, leaving the caller to decide.
+func followUsage(ctx context.Context, repo output.Repository, req MatchRequest, candidates []output.MatchCandidate, tr *MatchTrace) (res MatchResult, found bool, err error) {
+	if len(candidates) == 0 {
+		return MatchResult{}, false, nil
+	}
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.Concept.ID)
+	}
+	edges, err := repo.UsageTargets(ctx, ids)
+	if err != nil {
+		return MatchResult{}, false, err
+	}
+	if len(edges) == 0 {
+		return MatchResult{}, false, nil
+	}
+
+	// A misapplication names what the author meant; a pro-parte edge only
+	// where the name partly belongs. The stronger statement wins outright.
+	relation := domain.RelationProParte
+	for _, e := range edges {
+		if e.Relation == domain.RelationMisapplied {
+			relation = domain.RelationMisapplied
+			break
+		}
+	}
+	seen := make(map[string]bool)
+	var targets []output.UsageTarget
+	for _, e := range edges {
+		if e.Relation != relation || seen[e.ToID] {
+			continue
+		}
+		seen[e.ToID] = true
+		targets = append(targets, e)
+	}
+
+	if len(targets) > 1 {
+		tr.decide(DecisionAmbiguous)
+		names := make([]string, 0, len(targets))
+		for _, t := range targets {
+			names = append(names, t.ToName)
+		}
+		return MatchResult{
+			ID:             req.ID,
+			RequiresReview: true,
+			Note:           noteUsageAmbiguous,
+			Candidates:     names,
+		}, true, nil
+	}
+
+	tr.decide(DecisionUsageRelation)
+	res = MatchResult{
+		ID:         req.ID,
+		MatchType:  domain.MatchMisapplied,
+		Confidence: confidenceMisapplied,
+		ConceptID:  targets[0].ToID,
+		Note:       noteMisapplied,
+	}
+	if relation == domain.RelationProParte {
+		res.RequiresReview = true
+		res.Note = noteProParte
+	}
+	return res, true, nil
+}
+
+// candidatesNotBy drops the candidates whose name is authored by author,
+// compared as domain.ClassifyMatch compares authors.
+func candidatesNotBy(candidates []output.MatchCandidate, author string) []output.MatchCandidate {
+	excluded := domain.NormalizeAuthor(author)
+	kept := make([]output.MatchCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if domain.NormalizeAuthor(c.MatchedName.Authorship) == excluded {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+func candidateNames(candidates []output.MatchCandidate) []string {
+	var names []string
+	for _, c := range candidates {
+		names = append(names, c.MatchedName.Canonical)
+	}
+	return names
+}
@@ -0,0 +1,174 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/adapters/sqlite"
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// seedUsageConcepts ingests, under backbone "test-usage", the shapes a usage
+// qualifier has to tell apart: "Pinus abies L." as its own concept AND as a
+// misapplication of "Abies alba Mill." (two sec. spaces carry Abies alba), a
+// pro-parte synonym, an auct. concept without any stored edge, and a
+// "Festuca ovina" homonym pair differing only in author. Returns the concept
+// ids by short key.
+func seedUsageConcepts(t *testing.T) (*sqlite.DB, map[string]string) {
+	t.Helper()
+	repo := openMemoryRepo(t)
+	tx, err := repo.BeginIngest(context.Background(), domain.BackboneVersion{ID: "test-usage", Version: "v1"})
+	if err != nil {
+		t.Fatalf("BeginIngest: %v", err)
+	}
+	for _, ref := range []domain.SecReference{
+		{ID: "sec:wisskirchen", Title: "Wisskirchen & Haeupler 1998: Standardliste der Farn- und Blütenpflanzen Deutschlands"},
+		{ID: "sec:oberdorfer", Title: "Oberdorfer 2001: Pflanzensoziologische Exkursionsflora"},
+		{ID: "sec:auct", Title: "Andere Referenzen (fuer auct. Synonyme)"},
+	} {
+		if err := tx.UpsertSecReference(ref); err != nil {
+			t.Fatalf("UpsertSecReference(%s): %v", ref.ID, err)
+		}
+	}
+	concepts := []struct {
+		key, canonical, author, sec string
+	}{
+		{"pinus", "Pinus abies", "L.", ""},
+		{"pinus-auct", "Pinus abies", "L.", "sec:auct"},
+		{"abies", "Abies alba", "Mill.", "sec:wisskirchen"},
+		{"abies-ober", "Abies alba", "Mill.", "sec:oberdorfer"},
+		{"sisymbrium-pp", "Sisymbrium pyrenaicum", "Vill.", ""},
+		{"sisymbrium", "Sisymbrium austriacum", "Jacq.", "sec:wisskirchen"},
+		{"carex-auct", "Carex nuda", "L.", "sec:auct"},
+		{"festuca-l", "Festuca ovina", "L.", ""},
+		{"festuca-hack", "Festuca ovina", "Hack.", ""},
+	}
+	ids := make(map[string]string, len(concepts))
+	for _, c := range concepts {
+		name := domain.Name{ID: "test-usage:name:" + c.key, Canonical: c.canonical, Authorship: c.author, Rank: domain.RankSpecies}
+		concept := domain.Concept{ID: "test-usage:concept:" + c.key, BackboneID: "test-usage", AcceptedName: name, Rank: domain.RankSpecies, SecReference: c.sec, Status: domain.StatusAccepted}
+		if err := tx.UpsertName(name); err != nil {
+			t.Fatalf("UpsertName(%s): %v", c.key, err)
+		}
+		if err := tx.UpsertConcept(concept); err != nil {
+			t.Fatalf("UpsertConcept(%s): %v", c.key, err)
+		}
+		if err := tx.LinkName(concept.ID, name.ID, "accepted", nil); err != nil {
+			t.Fatalf("LinkName(%s): %v", c.key, err)
+		}
+		ids[c.key] = concept.ID
+	}
+	if err := tx.AddMisapplication(ids["pinus-auct"], ids["abies"], "test-usage"); err != nil {
+		t.Fatalf("AddMisapplication: %v", err)
+	}
+	if err := tx.AddConceptRelation(ids["sisymbrium-pp"], ids["sisymbrium"], domain.RelationProParte, "test-usage"); err != nil {
+		t.Fatalf("AddConceptRelation: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	return repo, ids
+}
+
+func matchOneName(t *testing.T, repo *sqlite.DB, verbatim string) application.MatchResult {
+	t.Helper()
+	results, err := application.MatchNames(context.Background(), repo, []application.MatchRequest{{ID: "1", Verbatim: verbatim}})
+	if err != nil {
+		t.Fatalf("MatchNames(%q): unexpected error: %v", verbatim, err)
+	}
+	return results[0]
+}
+
+// TestMatchNames_AuctFollowsTheMisapplication is the case the qualifier
+// exists for: "auct." means the concept the name was misapplied to, not the
+// Linnaean one the canonical names.
+func TestMatchNames_AuctFollowsTheMisapplication(t *testing.T) {
+	repo, ids := seedUsageConcepts(t)
+
+	r := matchOneName(t, repo, "Pinus abies auct. non L.")
+	if r.ConceptID != ids["abies"] || r.MatchType != domain.MatchMisapplied || r.RequiresReview || r.Note == "" {
+		t.Errorf("result = %+v, want misapplied onto %s with a note and without review", r, ids["abies"])
+	}
+	if r.Confidence <= domain.FuzzyThreshold {
+		t.Errorf("Confidence = %v, want above the fuzzy threshold %v", r.Confidence, domain.FuzzyThreshold)
+	}
+
+	if plain := matchOneName(t, repo, "Pinus abies L."); plain.ConceptID == ids["abies"] {
+		t.Errorf("unqualified name followed the misapplication: %+v", plain)
+	}
+}
+
+// TestMatchNames_AuctFollowsProParteForReview pins that a pro-parte edge
+// resolves too, but never without review: the name covers the target only in
+// part.
+func TestMatchNames_AuctFollowsProParteForReview(t *testing.T) {
+	repo, ids := seedUsageConcepts(t)
+
+	r := matchOneName(t, repo, "Sisymbrium pyrenaicum auct.")
+	if r.ConceptID != ids["sisymbrium"] || r.MatchType != domain.MatchMisapplied || !r.RequiresReview {
+		t.Errorf("result = %+v, want misapplied onto %s with review", r, ids["sisymbrium"])
+	}
+}
+
+// TestMatchNames_AuctWithoutEdgeStaysUnresolved pins the guard: no stored
+// edge means no answer, not the nomenclatural homonym.
+func TestMatchNames_AuctWithoutEdgeStaysUnresolved(t *testing.T) {
+	repo, _ := seedUsageConcepts(t)
+
+	r := matchOneName(t, repo, "Carex nuda auct.")
+	if r.ConceptID != "" || r.MatchType != "" || !r.RequiresReview {
+		t.Errorf("result = %+v, want unresolved with review", r)
+	}
+	if len(r.Candidates) != 1 || r.Candidates[0] != "Carex nuda" {
+		t.Errorf("Candidates = %v, want the one name seen", r.Candidates)
+	}
+}
+
+// TestMatchNames_SensuScopesToTheCitedReference resolves one name carried by
+// two sec. spaces: the citation picks one, an unknown citation leaves the tie.
+func TestMatchNames_SensuScopesToTheCitedReference(t *testing.T) {
+	repo, ids := seedUsageConcepts(t)
+
+	r := matchOneName(t, repo, "Abies alba Mill. sensu Oberdorfer")
+	if r.ConceptID != ids["abies-ober"] || r.MatchType != domain.MatchExactAuthor || r.RequiresReview || r.Note == "" {
+		t.Errorf("result = %+v, want exact_author on %s with a note", r, ids["abies-ober"])
+	}
+
+	r = matchOneName(t, repo, "Abies alba sensu Hegi")
+	if r.ConceptID != "" || !r.RequiresReview || len(r.Candidates) != 2 {
+		t.Errorf("result = %+v, want the unscoped ambiguous tie", r)
+	}
+}
+
+// TestMatchNames_NonExcludesTheHomonym is the later-homonym form: without the
+// qualifier the author-less name is a tie, with it only Hackel's remains.
+func TestMatchNames_NonExcludesTheHomonym(t *testing.T) {
+	repo, ids := seedUsageConcepts(t)
+
+	if tie := matchOneName(t, repo, "Festuca ovina"); tie.ConceptID != "" {
+		t.Fatalf("unqualified = %+v, want an ambiguous tie", tie)
+	}
+	r := matchOneName(t, repo, "Festuca ovina non L.")
+	if r.ConceptID != ids["festuca-hack"] || r.MatchType != domain.MatchExact || r.Note == "" {
+		t.Errorf("result = %+v, want exact on %s with a note", r, ids["festuca-hack"])
+	}
+}
+
+// TestExplainMatches_UsagePath pins what the trace shows for a followed edge.
+func TestExplainMatches_UsagePath(t *testing.T) {
+	repo, _ := seedUsageConcepts(t)
+
+	results, err := application.ExplainMatches(context.Background(), repo,
+		[]application.MatchRequest{{ID: "1", Verbatim: "Pinus abies auct."}}, "", application.MatchFilter{})
+	if err != nil {
+		t.Fatalf("ExplainMatches: %v", err)
+	}
+	tr := results[0].Trace
+	if tr == nil || tr.Path != application.MatchPathUsage || tr.Canonical != "Pinus abies" {
+		t.Fatalf("trace = %+v, want the usage path on Pinus abies", tr)
+	}
+	if len(tr.Lookups) != 1 || tr.Lookups[0].Decision != application.DecisionUsageRelation || tr.Fuzzy != nil {
+		t.Errorf("lookups = %+v, fuzzy = %v, want one usage_relation lookup and no fuzzy pool", tr.Lookups, tr.Fuzzy)
+	}
+}
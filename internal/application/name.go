@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// ClassifiedName is a name together with domain.ClassifyNomStatus' verdict
+// on its nom_status cell.
+type ClassifiedName struct {
+	domain.Name
+	Status domain.NomStatusVerdict
+}
+
+// NameResult is GET /v1/name/{id}'s answer: the name's nomenclatural story
+// (output.NameRecord), every name in it classified.
+type NameResult struct {
+	Name           ClassifiedName
+	BasionymChain  []ClassifiedName
+	HomotypicGroup []ClassifiedName
+	Usages         []output.NameUsage
+}
+
+// Name reads nameID's record and classifies the nom_status of every name in
+// it, so the chain and the group say which of their members are defective
+// the same way a synonym list does. domain.ErrNotFound (wrapped) reports an
+// unknown name.
+func Name(ctx context.Context, repo output.Repository, nameID string) (NameResult, error) {
+	rec, err := repo.NameRecord(ctx, nameID)
+	if err != nil {
+		return NameResult{}, fmt.Errorf("application: name %q: %w", nameID, err)
+	}
+	return NameResult{
+		Name:           classifyName(rec.Name),
+		BasionymChain:  classifyNames(rec.BasionymChain),
+		HomotypicGroup: classifyNames(rec.HomotypicGroup),
+		Usages:         rec.Usages,
+	}, nil
+}
+
+func classifyName(n domain.Name) ClassifiedName {
+	return ClassifiedName{Name: n, Status: domain.ClassifyNomStatus(n.NomStatus)}
+}
+
+func classifyNames(names []domain.Name) []ClassifiedName {
+	out := make([]ClassifiedName, len(names))
+	for i, n := range names {
+		out[i] = classifyName(n)
+	}
+	return out
+}
+
+// UsageGroup is the usages of a name within one backbone and sec. reference
+// space — the unit an editor checks a name against: two concepts in one
+// group claim the same name in the same frame.
+type UsageGroup struct {
+	BackboneID   string
+	SecReference domain.SecReference
+	Usages       []output.NameUsage
+}
+
+// NameUsagesResult is GET /v1/names/{name}/usages' answer: the names the
+// key resolved to and their usages, grouped.
+type NameUsagesResult struct {
+	Key    string
+	Names  []ClassifiedName
+	Groups []UsageGroup
+}
+
+// NameUsages lists every concept using the name key resolves to (see
+// output.Repository.NameUsages), grouped by backbone and sec. reference in
+// the repository's order. domain.ErrNotFound (wrapped) reports a key that
+// resolves to no name.
+func NameUsages(ctx context.Context, repo output.Repository, key string) (NameUsagesResult, error) {
+	names, usages, err := repo.NameUsages(ctx, key)
+	if err != nil {
+		return NameUsagesResult{}, fmt.Errorf("application: usages of name %q: %w", key, err)
+	}
+	res := NameUsagesResult{Key: key, Names: classifyNames(names), Groups: []UsageGroup{}}
+	for _, u := range usages {
+		if n := len(res.Groups); n > 0 && res.Groups[n-1].BackboneID == u.BackboneID && res.Groups[n-1].SecReference.ID == u.SecReference.ID {
+			res.Groups[n-1].Usages = append(res.Groups[n-1].Usages, u)
+			continue
+		}
+		res.Groups = append(res.Groups, UsageGroup{BackboneID: u.BackboneID, SecReference: u.SecReference, Usages: []output.NameUsage{u}})
+	}
+	return res, nil
+}
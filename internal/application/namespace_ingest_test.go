@@ -483,19 +483,25 @@ func (t *fakeNameSpaceTx) Finalize() error {
 func (t *fakeNameSpaceTx) Commit() error   { t.committed = true; return nil }
 func (t *fakeNameSpaceTx) Rollback() error { t.rolled = true; return nil }
 
-func (t *fakeNameSpaceTx) UpsertName(domain.Name) error                      { return nil }
-func (t *fakeNameSpaceTx) UpsertConcept(domain.Concept) error                { return nil }
-func (t *fakeNameSpaceTx) LinkName(string, string, string, *bool) error      { return nil }
-func (t *fakeNameSpaceTx) AddXref(string, domain.Xref, string) error         { return nil }
-func (t *fakeNameSpaceTx) AddDistribution(string, domain.Distribution) error { return nil }
-func (t *fakeNameSpaceTx) UpsertArea(domain.Area) error                      { return nil }
-func (t *fakeNameSpaceTx) AddTraitValue(string, domain.TraitValue) error     { return nil }
-func (t *fakeNameSpaceTx) UpsertTraitVocabulary(domain.TraitVocabMeta) error { return nil }
-func (t *fakeNameSpaceTx) UpsertSecReference(domain.SecReference) error      { return nil }
-func (t *fakeNameSpaceTx) UpsertXrefSource(domain.XrefSourceMeta) error      { return nil }
+func (t *fakeNameSpaceTx) UpsertName(domain.Name) error                                 { return nil }
+func (t *fakeNameSpaceTx) UpsertConcept(domain.Concept) error                           { return nil }
+func (t *fakeNameSpaceTx) LinkName(string, string, string, *bool) error                 { return nil }
+func (t *fakeNameSpaceTx) AddXref(string, domain.Xref, string) error                    { return nil }
+func (t *fakeNameSpaceTx) AddDistribution(string, domain.Distribution, string) error    { return nil }
+func (t *fakeNameSpaceTx) UpsertArea(domain.Area) error                                 { return nil }
+func (t *fakeNameSpaceTx) AddAreaAlias(domain.AreaAlias) error                          { return nil }
+func (t *fakeNameSpaceTx) AddTraitValue(string, domain.TraitValue) error                { return nil }
+func (t *fakeNameSpaceTx) UpsertTraitVocabulary(domain.TraitVocabMeta) error            { return nil }
+func (t *fakeNameSpaceTx) UpsertSecReference(domain.SecReference) error                 { return nil }
+func (t *fakeNameSpaceTx) UpsertXrefSource(domain.XrefSourceMeta) error                 { return nil }
+func (t *fakeNameSpaceTx) UpsertDistributionSource(domain.DistributionSourceMeta) error { return nil }
+func (t *fakeNameSpaceTx) UpsertFrequencySource(domain.FrequencySourceMeta) error       { return nil }
+func (t *fakeNameSpaceTx) SetFrequency(string, string, int64) error                     { return nil }
 func (t *fakeNameSpaceTx) AddConceptRelation(string, string, domain.Relation, string) error {
 	return nil
 }
+func (t *fakeNameSpaceTx) AddMisapplication(string, string, string) error { return nil }
+func (t *fakeNameSpaceTx) AddHybridParent(string, int, string) error      { return nil }
 
 // fakeNameSpaceRepo answers MatchExact from a canned map and counts both how
 // many lookups happened and how many of them happened while the ingest
@@ -540,12 +546,36 @@ func (r *fakeNameSpaceRepo) BeginIngest(context.Context, domain.BackboneVersion)
 func (r *fakeNameSpaceRepo) Concept(context.Context, string) (*domain.Concept, []output.SynonymName, []domain.Xref, []domain.Distribution, error) {
 	return nil, nil, nil, nil, nil
 }
+func (r *fakeNameSpaceRepo) Concepts(context.Context, []string, output.ConceptInclude) ([]output.ConceptDocument, error) {
+	return nil, nil
+}
+func (r *fakeNameSpaceRepo) TreatmentLinks(context.Context, string) ([]output.TreatmentLink, error) {
+	return nil, nil
+}
 func (r *fakeNameSpaceRepo) SynonymCandidates(context.Context, string) ([]domain.SynonymCandidate, error) {
 	return nil, nil
 }
+func (r *fakeNameSpaceRepo) NameRecord(context.Context, string) (output.NameRecord, error) {
+	return output.NameRecord{}, nil
+}
+func (r *fakeNameSpaceRepo) NameUsages(context.Context, string) ([]domain.Name, []output.NameUsage, error) {
+	return nil, nil, nil
+}
 func (r *fakeNameSpaceRepo) Classification(context.Context, string) ([]domain.ClassificationEntry, error) {
 	return nil, nil
 }
+func (r *fakeNameSpaceRepo) HigherTaxa(context.Context, []string) (map[string]domain.HigherTaxa, error) {
+	return nil, nil
+}
+func (r *fakeNameSpaceRepo) Children(context.Context, string, output.TreeOpts) ([]domain.TaxonNode, int, error) {
+	return nil, 0, nil
+}
+func (r *fakeNameSpaceRepo) DescendantCounts(context.Context, string, output.TreeOpts) (map[domain.Rank]int, error) {
+	return nil, nil
+}
+func (r *fakeNameSpaceRepo) Checklist(context.Context, output.ChecklistOpts) ([]domain.ChecklistEntry, error) {
+	return nil, nil
+}
 func (r *fakeNameSpaceRepo) ConceptByXref(context.Context, string, string) (*domain.Concept, error) {
 	return nil, nil
 }
@@ -557,6 +587,18 @@ func (r *fakeNameSpaceRepo) ExistingConceptIDs(context.Context, []string) (map[s
 }
 func (r *fakeNameSpaceRepo) Areas(context.Context) ([]domain.Area, error) { return nil, nil }
 
+func (r *fakeNameSpaceRepo) AreaPresence(context.Context, string, []string) (map[string]output.AreaPresence, error) {
+	return nil, nil
+}
+
+func (r *fakeNameSpaceRepo) UsageTargets(context.Context, []string) ([]output.UsageTarget, error) {
+	return nil, nil
+}
+
+func (r *fakeNameSpaceRepo) MatchHybridParents(context.Context, []string) ([]output.MatchCandidate, error) {
+	return nil, nil
+}
+
 func (r *fakeNameSpaceRepo) SecReferences(context.Context) ([]domain.SecReference, error) {
 	return nil, nil
 }
@@ -569,12 +611,19 @@ func (r *fakeNameSpaceRepo) ConceptRelationsInSec(context.Context, string, strin
 func (r *fakeNameSpaceRepo) MatchFuzzyCandidates(context.Context, string, int, string, string) ([]output.MatchCandidate, error) {
 	return nil, nil
 }
+func (r *fakeNameSpaceRepo) SuggestPrefixes(context.Context, string, int) ([]string, error) {
+	return nil, nil
+}
 func (r *fakeNameSpaceRepo) BackboneVersions(context.Context) ([]domain.BackboneVersion, error) {
 	return nil, nil
 }
 func (r *fakeNameSpaceRepo) BuildDistributionClosure(context.Context) error {
 	return nil
 }
+
+func (r *fakeNameSpaceRepo) BuildLineageClosure(context.Context) error {
+	return nil
+}
 func (r *fakeNameSpaceRepo) Traits(context.Context, string, []domain.TraitVocab) ([]domain.TraitSet, error) {
 	return nil, nil
 }
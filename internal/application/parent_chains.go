@@ -0,0 +1,152 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// ErrBrokenParentChains is returned (wrapped, naming the backbone and its
+// counts) by Ingest in strict mode (Dataset.StrictParentChains) when a
+// backbone's parent chains are not intact. The backbone's transaction is
+// rolled back, so the database keeps whatever it held before.
+var ErrBrokenParentChains = errors.New("application: broken parent chains")
+
+// ParentChainReport is BackboneReport.ParentChains: what the parent-chain
+// check after pass 2 found wrong with one backbone's taxonomy graph. Every
+// count has a bounded (see sortedSample), sorted sample of the source
+// taxon ids involved, so an operator can look the rows up in the source.
+//
+// The check reads the source rows, not the written concepts: a dangling
+// parent or one that is a synonym never becomes a taxon_concept.parent_id
+// (see TaxonRow.ParentTaxonID), so the database alone could not tell these
+// rows from roots.
+type ParentChainReport struct {
+	// Cycles counts the parent cycles among accepted rows; CycleSample
+	// names each by its smallest taxon id. A cycle is what
+	// maxClassificationDepth in internal/adapters/sqlite guards walks
+	// against — the concepts on it have no root.
+	Cycles      int
+	CycleSample []string
+	// DanglingParents counts accepted rows whose parent taxon id is not in
+	// the source at all. Their concepts are written as roots.
+	DanglingParents      int
+	DanglingParentSample []string
+	// SynonymParents counts accepted rows whose parent is a synonym row of
+	// the same source. Their concepts are written as roots too: a synonym
+	// has no concept to point at.
+	SynonymParents      int
+	SynonymParentSample []string
+	// RankInversions counts accepted rows that do not rank below their
+	// parent, e.g. a species parented to a variety, or a species to another
+	// species. Rows whose rank (or whose parent's) is domain.RankOther are
+	// not judged: an exotic rank has no place in the order. A
+	// nothotaxon counts as its plain rank (NOTHOSUBSPECIES as SUBSPECIES).
+	RankInversions      int
+	RankInversionSample []string
+}
+
+// Broken reports whether the check found anything at all.
+func (r ParentChainReport) Broken() bool {
+	return r.Cycles+r.DanglingParents+r.SynonymParents+r.RankInversions > 0
+}
+
+// parentRankLevel is the depth of each canonical rank in a parent chain, the
+// nothotaxa sharing their plain rank's level — unlike domain.RankOrder,
+// which interleaves them for suggest ranking, so that a subvariety under a
+// nothovariety would look like an inversion.
+var parentRankLevel = map[domain.Rank]int{
+	domain.RankFamily:          0,
+	domain.RankGenus:           1,
+	domain.RankSpecies:         2,
+	domain.RankSubspecies:      3,
+	domain.RankNothosubspecies: 3,
+	domain.RankVariety:         4,
+	domain.RankNothovariety:    4,
+	domain.RankSubvariety:      5,
+	domain.RankForm:            6,
+	domain.RankNothoform:       6,
+	domain.RankSubform:         7,
+}
+
+// checkParentChains validates the parent links of every accepted row of
+// taxa — the graph pass 1 wrote — and fills report.ParentChains. present
+// is presentTaxonIDs(taxa); st.accepted tells an accepted parent from a
+// synonym one.
+func (st *ingestState) checkParentChains(taxa []TaxonRow, present map[string]bool, report *BackboneReport) {
+	var pc ParentChainReport
+	parentOf := make(map[string]string)
+	rankOf := make(map[string]domain.Rank)
+	dangling := make(map[string]bool)
+	synonymParents := make(map[string]bool)
+	inversions := make(map[string]bool)
+	for _, row := range taxa {
+		if !row.Accepted {
+			continue
+		}
+		rank, _ := domain.ParseRankLenient(row.Rank)
+		rankOf[row.TaxonID] = rank
+		switch {
+		case row.ParentTaxonID == "":
+		case !present[row.ParentTaxonID]:
+			dangling[row.TaxonID] = true
+		case !st.accepted[row.ParentTaxonID]:
+			synonymParents[row.TaxonID] = true
+		default:
+			parentOf[row.TaxonID] = row.ParentTaxonID
+		}
+	}
+	for child, parent := range parentOf {
+		cl, cok := parentRankLevel[rankOf[child]]
+		pl, pok := parentRankLevel[rankOf[parent]]
+		if cok && pok && cl <= pl {
+			inversions[child] = true
+		}
+	}
+	cycles := parentCycles(parentOf)
+
+	pc.DanglingParents, pc.DanglingParentSample = len(dangling), sortedSample(dangling)
+	pc.SynonymParents, pc.SynonymParentSample = len(synonymParents), sortedSample(synonymParents)
+	pc.RankInversions, pc.RankInversionSample = len(inversions), sortedSample(inversions)
+	pc.Cycles, pc.CycleSample = len(cycles), sortedSample(cycles)
+	report.ParentChains = pc
+}
+
+// parentCycles returns the cycles of the parent graph, each keyed by its
+// smallest member. Every node is walked once: a walk stops at a node an
+// earlier walk finished, and a node met again on the current walk closes a
+// cycle — the walk's tail from that node on.
+func parentCycles(parentOf map[string]string) map[string]bool {
+	const (
+		onPath = 1
+		done   = 2
+	)
+	state := make(map[string]int, len(parentOf))
+	cycles := make(map[string]bool)
+	for start := range parentOf {
+		var path []string
+		for id, ok := start, true; ok && state[id] != done; id, ok = parentOf[id] {
+			if state[id] == onPath {
+				smallest := id
+				for i := len(path) - 1; path[i] != id; i-- {
+					smallest = min(smallest, path[i])
+				}
+				cycles[smallest] = true
+				break
+			}
+			state[id] = onPath
+			path = append(path, id)
+		}
+		for _, p := range path {
+			state[p] = done
+		}
+	}
+	return cycles
+}
+
+// parentChainsError is the strict-mode failure for backbone id.
+func parentChainsError(id string, r ParentChainReport) error {
+	return fmt.Errorf("%w in backbone %q: cycles=%d dangling=%d synonym parents=%d rank inversions=%d",
+		ErrBrokenParentChains, id, r.Cycles, r.DanglingParents, r.SynonymParents, r.RankInversions)
+}
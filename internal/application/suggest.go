@@ -13,6 +13,11 @@ import (
 // whitespace-only. Handlers map it to the INVALID_QUERY error code.
 var ErrEmptyQuery = errors.New("application: empty query")
 
+// ErrUnknownWithin is returned by Suggest when SuggestRequest.Within names no
+// concept. Handlers map it to an INVALID_QUERY naming the value: an empty
+// page would read as "nothing in that group matches".
+var ErrUnknownWithin = errors.New("unknown within")
+
 // defaultSuggestLimit and maxSuggestLimit bound SuggestRequest.Limit: a
 // value <= 0 falls back to defaultSuggestLimit, and any value above
 // maxSuggestLimit is capped there — protecting the repo's fetch budget
@@ -38,6 +43,19 @@ type SuggestRequest struct {
 	// backbone. Naming an un-ingested backbone is ErrUnknownBackbone, not an
 	// empty result: silence would read as "no such plant".
 	EntryBackbone string
+	// Within restricts results to one concept and its descendants (e.g. a
+	// family's concept id for "only Poaceae"), read from the materialised
+	// lineage closure. Empty means no restriction; an id naming no concept is
+	// ErrUnknownWithin.
+	Within string
+	// PublishedBefore keeps only concepts that carried a name before that
+	// year (see output.SuggestOpts.PublishedBefore) — the names a historical
+	// label can have been written with. 0 means no filter.
+	PublishedBefore int
+	// PopularityWeight weights the popularity term of the final ranking key
+	// (domain.RankSuggestionsWeighted): 0 ranks equal candidates by bm25
+	// alone. It is server configuration, not a caller's choice.
+	PopularityWeight float64
 }
 
 // SuggestResponse is the ranked, truncated result of Suggest, plus the
@@ -50,8 +68,12 @@ type SuggestResponse struct {
 // Suggest resolves req against repo: it validates req.Q, calls
 // repo.Suggest with an effective limit (defaulted/capped from req.Limit)
 // so the adapter's own fetch budget isn't truncated, ranks the (unranked)
-// results via domain.RankSuggestions, truncates to the effective limit, and
-// attaches the repo's BackboneVersions.
+// results via domain.RankSuggestionsWeighted — whose last key is each item's
+// Relevance, its bm25 score lowered by req.PopularityWeight times its
+// recorded Frequency on a log scale — truncates to the effective limit, and
+// attaches the repo's BackboneVersions. Only when the prefix query finds
+// nothing are near-miss spellings of req.Q tried instead (suggestCorrected):
+// a query that prefixes any name is spelt as written.
 func Suggest(ctx context.Context, repo output.Repository, req SuggestRequest) (SuggestResponse, error) {
 	if strings.TrimSpace(req.Q) == "" {
 		return SuggestResponse{}, ErrEmptyQuery
@@ -63,21 +85,33 @@ func Suggest(ctx context.Context, repo output.Repository, req SuggestRequest) (S
 	if err := validateTargetSpace(ctx, repo, req.TargetSpace); err != nil {
 		return SuggestResponse{}, err
 	}
+	if err := validateWithin(ctx, repo, req.Within); err != nil {
+		return SuggestResponse{}, err
+	}
 
 	limit := effectiveLimit(req.Limit)
 
-	items, err := repo.Suggest(ctx, req.Q, output.SuggestOpts{
+	opts := output.SuggestOpts{
 		Area:        req.Area,
 		Ranks:       req.Ranks,
 		Limit:       limit,
 		Backbone:    req.EntryBackbone,
 		TargetSpace: req.TargetSpace,
-	})
+		Within:      req.Within,
+
+		PublishedBefore: req.PublishedBefore,
+	}
+	items, err := repo.Suggest(ctx, req.Q, opts)
 	if err != nil {
 		return SuggestResponse{}, err
 	}
+	if len(items) == 0 {
+		if items, err = suggestCorrected(ctx, repo, req.Q, opts); err != nil {
+			return SuggestResponse{}, err
+		}
+	}
 
-	ranked := domain.RankSuggestions(items)
+	ranked := domain.RankSuggestionsWeighted(items, req.PopularityWeight)
 	// len(ranked) > limit is a genuinely equivalent mutant at
 	// CONDITIONALS_BOUNDARY (>=): when len(ranked) == limit exactly,
 	// ranked[:limit] reproduces the same slice content either branch
@@ -88,6 +122,9 @@ func Suggest(ctx context.Context, repo output.Repository, req SuggestRequest) (S
 	if len(ranked) > limit {
 		ranked = ranked[:limit]
 	}
+	if err := annotateSuggestHigherTaxa(ctx, repo, ranked); err != nil {
+		return SuggestResponse{}, err
+	}
 
 	versions, err := repo.BackboneVersions(ctx)
 	if err != nil {
@@ -101,6 +138,83 @@ func Suggest(ctx context.Context, repo output.Repository, req SuggestRequest) (S
 	return SuggestResponse{BackboneVersions: backboneVersions, Results: ranked}, nil
 }
 
+// annotateSuggestHigherTaxa sets HigherTaxa on every item of the final page
+// in one repo.HigherTaxa call, so the labels cost one query per request
+// whatever the limit.
+func annotateSuggestHigherTaxa(ctx context.Context, repo output.Repository, items []domain.SuggestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]string, len(items))
+	for i, it := range items {
+		ids[i] = it.ConceptID
+	}
+	higher, err := repo.HigherTaxa(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].HigherTaxa = higher[items[i].ConceptID]
+	}
+	return nil
+}
+
+// validateWithin reports ErrUnknownWithin unless within names a concept. An
+// empty within is "no restriction" and always valid.
+func validateWithin(ctx context.Context, repo output.Repository, within string) error {
+	if within == "" {
+		return nil
+	}
+	_, _, _, _, err := repo.Concept(ctx, within)
+	if errors.Is(err, domain.ErrNotFound) {
+		return ErrUnknownWithin
+	}
+	return err
+}
+
+// maxSuggestCorrections caps how many corrected spellings suggestCorrected
+// queries. Each costs one more repo.Suggest call, and a fragment with more
+// than a few equally near neighbours is too vague for a guess to help.
+const maxSuggestCorrections = 3
+
+// suggestCorrected is Suggest's typo fallback: "Festuka" or "Quercis" match no
+// name as a prefix, so the page would be empty. It asks repo for same-length
+// prefixes of names sharing q's first letter, keeps the near misses
+// (domain.SuggestCorrections), and runs the ordinary prefix query for each with
+// the caller's options. The items it returns carry CorrectedFrom = q and
+// PrefixHit false; domain.RankSuggestionsWeighted orders them like any page,
+// with frequency weighting feeding their Relevance, so a common taxon leads
+// among equally near guesses. A concept two corrections both reach is listed
+// once.
+//
+// The comparison is on the canonical form without aggregate markers, as
+// repo.Suggest searches it, so "Festuka agg." is corrected like "Festuka".
+func suggestCorrected(ctx context.Context, repo output.Repository, q string, opts output.SuggestOpts) ([]domain.SuggestItem, error) {
+	canon := domain.StripAggregateMarkers(domain.Canonicalize(q))
+	prefixes, err := repo.SuggestPrefixes(ctx, canon, 0)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var out []domain.SuggestItem
+	for _, p := range domain.SuggestCorrections(canon, prefixes, maxSuggestCorrections) {
+		items, err := repo.Suggest(ctx, p, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range items {
+			if seen[it.ConceptID] {
+				continue
+			}
+			seen[it.ConceptID] = true
+			it.PrefixHit = false
+			it.CorrectedFrom = q
+			out = append(out, it)
+		}
+	}
+	return out, nil
+}
+
 // effectiveLimit applies Suggest's default/cap policy to a caller-supplied
 // limit: <= 0 defaults to defaultSuggestLimit, and anything above
 // maxSuggestLimit is capped there.
@@ -75,3 +75,7 @@ func (r *suggestBackboneRepo) Suggest(_ context.Context, _ string, opts output.S
 func (r *suggestBackboneRepo) BackboneVersions(context.Context) ([]domain.BackboneVersion, error) {
 	return []domain.BackboneVersion{{ID: "wcvp", Version: "2026-06-15"}}, nil
 }
+
+func (r *suggestBackboneRepo) SuggestPrefixes(context.Context, string, int) ([]string, error) {
+	return nil, nil
+}
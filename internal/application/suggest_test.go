@@ -3,6 +3,7 @@ package application_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/jobrunner/hostus/internal/application"
@@ -26,18 +27,37 @@ type fakeSuggestRepo struct {
 	versions     []domain.BackboneVersion
 	versionsErr  error
 
+	// byQ, when set, answers repo.Suggest per query instead of
+	// suggestItems, and prefixes is what SuggestPrefixes returns — together
+	// they drive the typo fallback. prefixCalls counts SuggestPrefixes
+	// calls, so a test can pin that the fallback never ran.
+	byQ         map[string][]domain.SuggestItem
+	prefixes    []string
+	prefixCalls int
+
 	gotQ    string
 	gotOpts output.SuggestOpts
 	called  bool
 }
 
 func (f *fakeSuggestRepo) Suggest(_ context.Context, q string, opts output.SuggestOpts) ([]domain.SuggestItem, error) {
+	if f.called {
+		return f.byQ[q], f.suggestErr
+	}
 	f.called = true
 	f.gotQ = q
 	f.gotOpts = opts
+	if f.byQ != nil {
+		return f.byQ[q], f.suggestErr
+	}
 	return f.suggestItems, f.suggestErr
 }
 
+func (f *fakeSuggestRepo) SuggestPrefixes(context.Context, string, int) ([]string, error) {
+	f.prefixCalls++
+	return f.prefixes, nil
+}
+
 func (f *fakeSuggestRepo) BackboneVersions(context.Context) ([]domain.BackboneVersion, error) {
 	return f.versions, f.versionsErr
 }
@@ -46,6 +66,14 @@ func (f *fakeSuggestRepo) BuildDistributionClosure(context.Context) error {
 	return nil
 }
 
+func (f *fakeSuggestRepo) BuildLineageClosure(context.Context) error {
+	return nil
+}
+
+func (f *fakeSuggestRepo) HigherTaxa(context.Context, []string) (map[string]domain.HigherTaxa, error) {
+	return nil, nil
+}
+
 func TestSuggest_EmptyQueryReturnsErrEmptyQuery(t *testing.T) {
 	cases := []string{"", "   ", "\t\n"}
 	for _, q := range cases {
@@ -128,6 +156,28 @@ func TestSuggest_RanksAndTruncatesRepoResults(t *testing.T) {
 	}
 }
 
+// TestSuggest_PopularityWeightReordersNearTies pins that the request's
+// PopularityWeight reaches the ranking: with it the commonly recorded
+// concept leads, without it bm25 alone decides.
+func TestSuggest_PopularityWeightReordersNearTies(t *testing.T) {
+	rare := domain.SuggestItem{ConceptID: "rare", Rank: domain.RankSpecies, Status: domain.StatusAccepted, PrefixHit: true, Score: -5.2}
+	common := domain.SuggestItem{ConceptID: "common", Rank: domain.RankSpecies, Status: domain.StatusAccepted, PrefixHit: true, Score: -5.0, Frequency: 5000}
+
+	for _, tc := range []struct {
+		weight float64
+		want   string
+	}{{domain.DefaultPopularityWeight, "common"}, {0, "rare"}} {
+		repo := &fakeSuggestRepo{suggestItems: []domain.SuggestItem{rare, common}}
+		resp, err := application.Suggest(context.Background(), repo, application.SuggestRequest{Q: "carex", Limit: 10, PopularityWeight: tc.weight})
+		if err != nil {
+			t.Fatalf("Suggest: unexpected error: %v", err)
+		}
+		if resp.Results[0].ConceptID != tc.want {
+			t.Errorf("weight %v: first = %q, want %q", tc.weight, resp.Results[0].ConceptID, tc.want)
+		}
+	}
+}
+
 // TestSuggest_FewerResultsThanLimitAreNotPadded checks the truncate step
 // tolerates a repo returning fewer items than the effective limit (no
 // out-of-range slice, no panic).
@@ -145,6 +195,97 @@ func TestSuggest_FewerResultsThanLimitAreNotPadded(t *testing.T) {
 	}
 }
 
+// TestSuggest_CorrectionsReplaceAnEmptyPage pins the typo fallback's
+// contract: a near-miss prefix answers a query with no prefix hit, its items
+// carry CorrectedFrom and are no prefix hits, a concept two corrections reach
+// is listed once, and a prefix too far from the query is not queried at all.
+func TestSuggest_CorrectionsReplaceAnEmptyPage(t *testing.T) {
+	near := domain.SuggestItem{ConceptID: "near", Rank: domain.RankGenus, Status: domain.StatusAccepted, InArea: true, PrefixHit: true, Score: 1}
+	shared := domain.SuggestItem{ConceptID: "shared", Rank: domain.RankSpecies, Status: domain.StatusAccepted, PrefixHit: true, Score: 2}
+	repo := &fakeSuggestRepo{
+		byQ: map[string][]domain.SuggestItem{
+			"festuca": {near, shared},
+			"festuka": nil,
+			"fesluka": {shared},
+			"fertile": {{ConceptID: "far", PrefixHit: true}},
+		},
+		prefixes: []string{"festuca", "fesluka", "fertile"},
+	}
+
+	resp, err := application.Suggest(context.Background(), repo, application.SuggestRequest{Q: "festuka", Limit: 5})
+	if err != nil {
+		t.Fatalf("Suggest: unexpected error: %v", err)
+	}
+	var ids []string
+	for _, r := range resp.Results {
+		ids = append(ids, r.ConceptID)
+		if r.CorrectedFrom != "festuka" || r.PrefixHit {
+			t.Errorf("correction = %+v, want CorrectedFrom festuka and no prefix hit", r)
+		}
+	}
+	if strings.Join(ids, ",") != "near,shared" {
+		t.Errorf("Results = %v, want near and shared once each, never far", ids)
+	}
+}
+
+// TestSuggest_PrefixHitSkipsCorrections pins that the fallback only runs when
+// the prefix query finds nothing — not merely when it leaves the page short.
+// "carex acuta" is a correct spelling; the near prefix "carex acuti" belongs
+// to another plant and must not be appended.
+func TestSuggest_PrefixHitSkipsCorrections(t *testing.T) {
+	repo := &fakeSuggestRepo{
+		byQ: map[string][]domain.SuggestItem{
+			"carex acuta": {{ConceptID: "acuta", PrefixHit: true}},
+			"carex acuti": {{ConceptID: "acutiformis", PrefixHit: true}},
+		},
+		prefixes: []string{"carex acuti"},
+	}
+
+	resp, err := application.Suggest(context.Background(), repo, application.SuggestRequest{Q: "carex acuta", Limit: 10})
+	if err != nil {
+		t.Fatalf("Suggest: unexpected error: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].ConceptID != "acuta" {
+		t.Errorf("Results = %+v, want only the prefix hit", resp.Results)
+	}
+	if repo.prefixCalls != 0 {
+		t.Errorf("SuggestPrefixes called %d times, want 0 for a query with a prefix hit", repo.prefixCalls)
+	}
+}
+
+// TestSuggest_CorrectSpellingGetsNoCorrections: a correctly spelled name with
+// prefix hits gets no corrected items. "Carex acuta" hits its own concept and
+// nothing else, although "carex acuti" — the same-length prefix of Carex
+// acutiformis — is well within the correction threshold.
+func TestSuggest_CorrectSpellingGetsNoCorrections(t *testing.T) {
+	ds := &application.Dataset{Backbones: []application.Backbone{{ID: "test", Version: "v1"}}, ManifestSHA: "x"}
+	repo := openMemoryRepo(t)
+	ctx := context.Background()
+	taxa := []application.TaxonRow{
+		{TaxonID: "acuta", AcceptedTaxonID: "acuta", Accepted: true, Canonical: "Carex acuta", Rank: "SPECIES"},
+		{TaxonID: "acutiformis", AcceptedTaxonID: "acutiformis", Accepted: true, Canonical: "Carex acutiformis", Rank: "SPECIES"},
+	}
+	readerFor := func(application.Backbone) (application.RowSource, error) {
+		return fakeRowSource{taxa: taxa}, nil
+	}
+	if _, err := application.Ingest(ctx, ds, readerFor, repo); err != nil {
+		t.Fatalf("Ingest: unexpected error: %v", err)
+	}
+
+	resp, err := application.Suggest(ctx, repo, application.SuggestRequest{Q: "Carex acuta", Limit: 10})
+	if err != nil {
+		t.Fatalf("Suggest: unexpected error: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].ConceptID != "test:concept:acuta" {
+		t.Errorf("Results = %+v, want only Carex acuta", resp.Results)
+	}
+	for _, r := range resp.Results {
+		if r.CorrectedFrom != "" {
+			t.Errorf("result %s carries CorrectedFrom %q, want none for a correctly spelled query", r.ConceptID, r.CorrectedFrom)
+		}
+	}
+}
+
 func TestSuggest_BackboneVersionsPopulatedFromRepo(t *testing.T) {
 	repo := &fakeSuggestRepo{versions: []domain.BackboneVersion{
 		{ID: "wcvp", Version: "2026-06-15"},
@@ -249,3 +390,64 @@ func TestSuggest_WCVPFixture_AreaRankedAndTruncated(t *testing.T) {
 		t.Errorf("Corynephorus canescens (InArea) at index %d, genus (not InArea) at %d; want species ranked first", speciesIdx, genusIdx)
 	}
 }
+
+// TestSuggest_WCVPFixture_CorrectsATypo runs the fallback against a real
+// repo: "Corinephorus" is no prefix of any name, "corynephoru" is one edit
+// away.
+func TestSuggest_WCVPFixture_CorrectsATypo(t *testing.T) {
+	ds := loadDataset(t)
+	repo := openMemoryRepo(t)
+	ctx := context.Background()
+	if _, err := application.Ingest(ctx, ds, wcvpReaderFor, repo); err != nil {
+		t.Fatalf("Ingest: unexpected error: %v", err)
+	}
+
+	resp, err := application.Suggest(ctx, repo, application.SuggestRequest{Q: "Corinephorus", Limit: 5})
+	if err != nil {
+		t.Fatalf("Suggest: unexpected error: %v", err)
+	}
+	if len(resp.Results) == 0 {
+		t.Fatal("Results = empty, want the Corynephorus concepts as corrections")
+	}
+	for _, r := range resp.Results {
+		if r.CorrectedFrom != "Corinephorus" || !strings.HasPrefix(r.Canonical, "Corynephorus") {
+			t.Errorf("result = %+v, want a Corynephorus concept corrected from the query", r)
+		}
+	}
+}
+
+// TestSuggest_GenusPrefixHitsRankBeforeEpithetHits runs the §B.1 ordering
+// over a real repo: "abies" is the genus of Abies alba and the epithet of
+// Pinus abies, and the genus must come first.
+func TestSuggest_GenusPrefixHitsRankBeforeEpithetHits(t *testing.T) {
+	ds := &application.Dataset{Backbones: []application.Backbone{{ID: "test", Version: "v1"}}, ManifestSHA: "x"}
+	repo := openMemoryRepo(t)
+	ctx := context.Background()
+	taxa := []application.TaxonRow{
+		{TaxonID: "pinus", AcceptedTaxonID: "pinus", Accepted: true, Canonical: "Pinus abies", Rank: "SPECIES"},
+		{TaxonID: "abies", AcceptedTaxonID: "abies", Accepted: true, Canonical: "Abies alba", Rank: "SPECIES"},
+	}
+	readerFor := func(application.Backbone) (application.RowSource, error) {
+		return fakeRowSource{taxa: taxa}, nil
+	}
+	if _, err := application.Ingest(ctx, ds, readerFor, repo); err != nil {
+		t.Fatalf("Ingest: unexpected error: %v", err)
+	}
+
+	resp, err := application.Suggest(ctx, repo, application.SuggestRequest{Q: "abies", Limit: 10})
+	if err != nil {
+		t.Fatalf("Suggest: unexpected error: %v", err)
+	}
+	pos := make(map[string]int, len(resp.Results))
+	for i, r := range resp.Results {
+		pos[r.ConceptID] = i
+	}
+	abies, okA := pos["test:concept:abies"]
+	pinus, okP := pos["test:concept:pinus"]
+	if !okA || !okP {
+		t.Fatalf("Results = %+v, want both Abies alba and Pinus abies", resp.Results)
+	}
+	if abies > pinus {
+		t.Errorf("Abies alba at %d, Pinus abies at %d; want the genus-prefix hit first", abies, pinus)
+	}
+}
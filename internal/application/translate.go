@@ -316,7 +316,7 @@ func resolveTranslateEntry(ctx context.Context, repo output.Repository, req Tran
 	if err := validateFilter(ctx, repo, req.Filter); err != nil {
 		return "", TranslateEntry{}, err
 	}
-	results, err := matchNamesFiltered(ctx, repo, []MatchRequest{{ID: "source", Verbatim: req.Verbatim}}, req.Filter)
+	results, err := matchNamesFiltered(ctx, repo, []MatchRequest{{ID: "source", Verbatim: req.Verbatim}}, req.Filter, false)
 	if err != nil {
 		return "", TranslateEntry{}, err
 	}
@@ -0,0 +1,162 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// SynonymDiff compares a treatment's synonyms with the source concept's by
+// folded canonical (domain.Canonicalize), so an authorship spelled
+// differently by two backbones does not count as a difference.
+type SynonymDiff struct {
+	// Shared counts the folded canonicals both concepts list.
+	Shared int
+	// OnlyHere and OnlySource are the synonyms only the treatment or only
+	// the source lists, each in its repository order.
+	OnlyHere   []output.SynonymName
+	OnlySource []output.SynonymName
+}
+
+// DistributionChange is one area both concepts record with different
+// statuses.
+type DistributionChange struct {
+	Here   domain.Distribution
+	Source domain.Distribution
+}
+
+// DistributionDiff compares a treatment's distribution with the source
+// concept's by (scheme, code).
+type DistributionDiff struct {
+	// Shared counts the areas both record with the same status.
+	Shared     int
+	OnlyHere   []domain.Distribution
+	OnlySource []domain.Distribution
+	Changed    []DistributionChange
+}
+
+// Treatment is one other concept treating the source's taxon, with the
+// links that found it and how it differs from the source.
+type Treatment struct {
+	output.ConceptDocument
+	Via          []output.TreatmentVia
+	Synonyms     SynonymDiff
+	Distribution DistributionDiff
+}
+
+// TreatmentsResult is GET /v1/concept/{id}/treatments' answer: the source
+// concept and every concept linked to it, ordered by backbone, sec.
+// reference id, then concept id.
+type TreatmentsResult struct {
+	Source     output.ConceptDocument
+	Treatments []Treatment
+}
+
+// Treatments gathers conceptID's treatments in other backbones and sec.
+// reference spaces (output.Repository.TreatmentLinks, one hop) and reads
+// them together with the source through one output.Repository.Concepts
+// call. domain.ErrNotFound (wrapped) reports an unknown concept.
+func Treatments(ctx context.Context, repo output.Repository, conceptID string) (TreatmentsResult, error) {
+	links, err := repo.TreatmentLinks(ctx, conceptID)
+	if err != nil {
+		return TreatmentsResult{}, fmt.Errorf("application: treatments of concept %q: %w", conceptID, err)
+	}
+	ids := make([]string, 0, len(links)+1)
+	ids = append(ids, conceptID)
+	for _, l := range links {
+		ids = append(ids, l.ConceptID)
+	}
+	docs, err := repo.Concepts(ctx, ids, output.ConceptInclude{})
+	if err != nil {
+		return TreatmentsResult{}, fmt.Errorf("application: treatments of concept %q: %w", conceptID, err)
+	}
+	if len(docs) == 0 || docs[0].Concept.ID != conceptID {
+		// Deleted between the two reads.
+		return TreatmentsResult{}, fmt.Errorf("application: treatments of concept %q: %w", conceptID, domain.ErrNotFound)
+	}
+
+	source := docs[0]
+	via := make(map[string][]output.TreatmentVia, len(links))
+	for _, l := range links {
+		via[l.ConceptID] = l.Via
+	}
+	res := TreatmentsResult{Source: source, Treatments: make([]Treatment, 0, len(docs)-1)}
+	for _, doc := range docs[1:] {
+		res.Treatments = append(res.Treatments, Treatment{
+			ConceptDocument: doc,
+			Via:             via[doc.Concept.ID],
+			Synonyms:        diffSynonyms(doc.Synonyms, source.Synonyms),
+			Distribution:    diffDistributions(doc.Distribution, source.Distribution),
+		})
+	}
+	sort.SliceStable(res.Treatments, func(i, j int) bool {
+		a, b := res.Treatments[i].Concept, res.Treatments[j].Concept
+		if a.BackboneID != b.BackboneID {
+			return a.BackboneID < b.BackboneID
+		}
+		if a.SecReference != b.SecReference {
+			return a.SecReference < b.SecReference
+		}
+		return a.ID < b.ID
+	})
+	return res, nil
+}
+
+func diffSynonyms(here, source []output.SynonymName) SynonymDiff {
+	inSource := make(map[string]bool, len(source))
+	for _, s := range source {
+		inSource[domain.Canonicalize(s.Canonical)] = true
+	}
+	inHere := make(map[string]bool, len(here))
+	d := SynonymDiff{OnlyHere: []output.SynonymName{}, OnlySource: []output.SynonymName{}}
+	for _, s := range here {
+		key := domain.Canonicalize(s.Canonical)
+		switch {
+		case inHere[key]:
+			// A second name of the same canonical: counted once.
+		case inSource[key]:
+			d.Shared++
+		default:
+			d.OnlyHere = append(d.OnlyHere, s)
+		}
+		inHere[key] = true
+	}
+	for _, s := range source {
+		if !inHere[domain.Canonicalize(s.Canonical)] {
+			d.OnlySource = append(d.OnlySource, s)
+		}
+	}
+	return d
+}
+
+func diffDistributions(here, source []domain.Distribution) DistributionDiff {
+	type area struct{ scheme, code string }
+	inSource := make(map[area]domain.Distribution, len(source))
+	for _, s := range source {
+		inSource[area{s.AreaScheme, s.AreaCode}] = s
+	}
+	inHere := make(map[area]bool, len(here))
+	d := DistributionDiff{OnlyHere: []domain.Distribution{}, OnlySource: []domain.Distribution{}, Changed: []DistributionChange{}}
+	for _, h := range here {
+		key := area{h.AreaScheme, h.AreaCode}
+		inHere[key] = true
+		s, ok := inSource[key]
+		switch {
+		case !ok:
+			d.OnlyHere = append(d.OnlyHere, h)
+		case s.Status == h.Status:
+			d.Shared++
+		default:
+			d.Changed = append(d.Changed, DistributionChange{Here: h, Source: s})
+		}
+	}
+	for _, s := range source {
+		if !inHere[area{s.AreaScheme, s.AreaCode}] {
+			d.OnlySource = append(d.OnlySource, s)
+		}
+	}
+	return d
+}
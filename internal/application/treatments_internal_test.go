@@ -0,0 +1,41 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// TestDiffSynonyms_ComparesFoldedCanonicals pins that authorship and case do
+// not make a difference, and that a canonical listed twice counts once.
+func TestDiffSynonyms_ComparesFoldedCanonicals(t *testing.T) {
+	syn := func(canonical, authorship string) output.SynonymName {
+		return output.SynonymName{Name: domain.Name{Canonical: canonical, Authorship: authorship}}
+	}
+	here := []output.SynonymName{syn("Festuca duriuscula", "L."), syn("festuca  duriuscula", "auct."), syn("Festuca capillata", "")}
+	source := []output.SynonymName{syn("Festuca duriuscula", "Lam."), syn("Festuca guestfalica", "")}
+
+	d := diffSynonyms(here, source)
+	if d.Shared != 1 || len(d.OnlyHere) != 1 || d.OnlyHere[0].Canonical != "Festuca capillata" || len(d.OnlySource) != 1 || d.OnlySource[0].Canonical != "Festuca guestfalica" {
+		t.Errorf("diffSynonyms = %+v, want 1 shared, capillata only here, guestfalica only in the source", d)
+	}
+}
+
+// TestDiffDistributions_SeparatesStatusChanges pins that an area recorded by
+// both with another status is a change, not a shared area.
+func TestDiffDistributions_SeparatesStatusChanges(t *testing.T) {
+	area := func(code string, status domain.DistributionStatus) domain.Distribution {
+		return domain.Distribution{AreaScheme: "euromed", AreaCode: code, Status: status}
+	}
+	here := []domain.Distribution{area("Ge", domain.DistributionNative), area("Au", domain.DistributionIntroduced), area("Br", "")}
+	source := []domain.Distribution{area("Ge", domain.DistributionNative), area("Au", domain.DistributionNative), area("Ga", "")}
+
+	d := diffDistributions(here, source)
+	if d.Shared != 1 || len(d.OnlyHere) != 1 || d.OnlyHere[0].AreaCode != "Br" || len(d.OnlySource) != 1 || d.OnlySource[0].AreaCode != "Ga" {
+		t.Errorf("diffDistributions = %+v, want Ge shared, Br only here, Ga only in the source", d)
+	}
+	if len(d.Changed) != 1 || d.Changed[0].Here.Status != domain.DistributionIntroduced || d.Changed[0].Source.Status != domain.DistributionNative {
+		t.Errorf("Changed = %+v, want Au introduced here, native in the source", d.Changed)
+	}
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jobrunner/hostus/internal/domain"
+	"github.com/jobrunner/hostus/internal/ports/output"
+)
+
+// DefaultChildrenLimit is the page size of GET /v1/concept/{id}/children
+// when the caller names none, and MaxChildrenLimit the largest it accepts.
+// The largest families hold some 1,700 genera, so "all children of one
+// node" always fits in a request, while an absurd value is refused before
+// anything is read for it.
+const (
+	DefaultChildrenLimit = 100
+	MaxChildrenLimit     = 2000
+)
+
+// Errors the tree endpoints distinguish; both map onto INVALID_QUERY.
+var (
+	// ErrInvalidLimit reports a `limit` outside [0, MaxChildrenLimit].
+	ErrInvalidLimit = errors.New("application: limit out of range")
+	// ErrInvalidOffset reports a negative `offset`.
+	ErrInvalidOffset = errors.New("application: negative offset")
+)
+
+// ChildrenRequest is one GET /v1/concept/{id}/children call.
+type ChildrenRequest struct {
+	ConceptID string
+	Ranks     []domain.Rank
+	Area      string
+	// Limit is the page size; 0 means DefaultChildrenLimit.
+	Limit  int
+	Offset int
+}
+
+// ChildrenResult is one page of a concept's direct children.
+type ChildrenResult struct {
+	ConceptID string
+	// Total counts every child matching the request's filters, not just
+	// this page, so a caller can tell the last page from a short one.
+	Total  int
+	Limit  int
+	Offset int
+	Nodes  []domain.TaxonNode
+}
+
+// Children pages through req.ConceptID's direct children, filtered by rank
+// and area (output.TreeOpts). Limit and Offset are validated here, so the
+// bound is stated once; the repository does the ordering and paging.
+// domain.ErrNotFound (wrapped) reports an unknown concept.
+func Children(ctx context.Context, repo output.Repository, req ChildrenRequest) (ChildrenResult, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultChildrenLimit
+	}
+	if limit < 0 || limit > MaxChildrenLimit {
+		return ChildrenResult{}, fmt.Errorf("%w: %d", ErrInvalidLimit, req.Limit)
+	}
+	if req.Offset < 0 {
+		return ChildrenResult{}, fmt.Errorf("%w: %d", ErrInvalidOffset, req.Offset)
+	}
+	nodes, total, err := repo.Children(ctx, req.ConceptID, output.TreeOpts{
+		Ranks: req.Ranks, Area: req.Area, Limit: limit, Offset: req.Offset,
+	})
+	if err != nil {
+		return ChildrenResult{}, fmt.Errorf("application: children of %q: %w", req.ConceptID, err)
+	}
+	return ChildrenResult{ConceptID: req.ConceptID, Total: total, Limit: limit, Offset: req.Offset, Nodes: nodes}, nil
+}
+
+// RankCount is one rank's number of descendants.
+type RankCount struct {
+	Rank  domain.Rank
+	Count int
+}
+
+// DescendantsResult is a concept's descendant counts per rank.
+type DescendantsResult struct {
+	ConceptID string
+	// Total is the sum of ByRank.
+	Total int
+	// ByRank is ordered broad to fine (domain.RankOrder), the order a
+	// checklist summary reads in: genera before species before subspecies.
+	ByRank []RankCount
+}
+
+// Descendants counts req.ConceptID's descendants per rank, filtered like
+// Children; req.Limit and req.Offset are ignored. domain.ErrNotFound
+// (wrapped) reports an unknown concept.
+func Descendants(ctx context.Context, repo output.Repository, req ChildrenRequest) (DescendantsResult, error) {
+	counts, err := repo.DescendantCounts(ctx, req.ConceptID, output.TreeOpts{Ranks: req.Ranks, Area: req.Area})
+	if err != nil {
+		return DescendantsResult{}, fmt.Errorf("application: descendants of %q: %w", req.ConceptID, err)
+	}
+	res := DescendantsResult{ConceptID: req.ConceptID, ByRank: []RankCount{}}
+	for rank, n := range counts {
+		res.ByRank = append(res.ByRank, RankCount{Rank: rank, Count: n})
+		res.Total += n
+	}
+	sortRankCounts(res.ByRank)
+	return res, nil
+}
+
+// sortRankCounts orders counts by domain.RankOrder, then by rank spelling so
+// two ranks sharing the unknown-rank ordinal still sort deterministically.
+func sortRankCounts(counts []RankCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		oi, oj := domain.RankOrder(counts[i].Rank), domain.RankOrder(counts[j].Rank)
+		if oi != oj {
+			return oi < oj
+		}
+		return counts[i].Rank < counts[j].Rank
+	})
+}
@@ -27,6 +27,9 @@ const (
 	defaultTelemetrySampleRatio = 1.0
 	defaultSQLitePath           = "./data/hostus.db"
 	defaultUIEnabled            = true
+	// defaultSuggestPopularityWeight mirrors domain.DefaultPopularityWeight;
+	// config does not import domain.
+	defaultSuggestPopularityWeight = 1.0
 )
 
 // Config holds all application configuration for hostus 2.0.
@@ -39,6 +42,7 @@ type Config struct {
 	SQLite    SQLiteConfig    `mapstructure:"sqlite"`
 	CORS      CORSConfig      `mapstructure:"cors"`
 	UI        UIConfig        `mapstructure:"ui"`
+	Suggest   SuggestConfig   `mapstructure:"suggest"`
 }
 
 // ServerConfig holds HTTP server configuration.
@@ -98,6 +102,14 @@ type UIConfig struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+// SuggestConfig tunes GET /v1/suggest's ranking. PopularityWeight weights
+// the popularity term ingested `frequencies:` sources feed (env
+// HOSTUS_SUGGEST_POPULARITY_WEIGHT); 0 switches it off. Without a frequency
+// source the term is inert whatever the weight.
+type SuggestConfig struct {
+	PopularityWeight float64 `mapstructure:"popularity_weight"`
+}
+
 // Defaults sets viper's default configuration values.
 func Defaults() {
 	// The multiplication runs here (inside a function body covered by the
@@ -129,6 +141,8 @@ func Defaults() {
 	viper.SetDefault("cors.allowed_origins", []string{})
 
 	viper.SetDefault("ui.enabled", defaultUIEnabled)
+
+	viper.SetDefault("suggest.popularity_weight", defaultSuggestPopularityWeight)
 }
 
 // Load loads configuration from defaults, an optional config file, and
@@ -185,7 +199,10 @@ func (c *Config) Validate() error {
 	if err := c.validateTLS(); err != nil {
 		return err
 	}
-	return c.validateTelemetry()
+	if err := c.validateTelemetry(); err != nil {
+		return err
+	}
+	return c.validateSuggest()
 }
 
 func (c *Config) validateServer() error {
@@ -217,3 +234,10 @@ func (c *Config) validateTelemetry() error {
 	}
 	return nil
 }
+
+func (c *Config) validateSuggest() error {
+	if c.Suggest.PopularityWeight < 0 {
+		return fmt.Errorf("suggest.popularity_weight must be >= 0, got %f", c.Suggest.PopularityWeight)
+	}
+	return nil
+}
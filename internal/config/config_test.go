@@ -339,3 +339,29 @@ func TestServerConfigAddress(t *testing.T) {
 func writeFile(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0o600)
 }
+
+// TestLoadSuggestPopularityWeight pins the default, the env spelling
+// (HOSTUS_SUGGEST_POPULARITY_WEIGHT) and the rejection of a negative weight,
+// which would rank rare taxa first.
+func TestLoadSuggestPopularityWeight(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Suggest.PopularityWeight != defaultSuggestPopularityWeight {
+		t.Fatalf("got %v, want default %v", cfg.Suggest.PopularityWeight, defaultSuggestPopularityWeight)
+	}
+
+	t.Setenv("HOSTUS_SUGGEST_POPULARITY_WEIGHT", "0")
+	if cfg, err = Load(""); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Suggest.PopularityWeight != 0 {
+		t.Fatalf("got %v, want 0 from HOSTUS_SUGGEST_POPULARITY_WEIGHT=0", cfg.Suggest.PopularityWeight)
+	}
+
+	t.Setenv("HOSTUS_SUGGEST_POPULARITY_WEIGHT", "-1")
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "popularity_weight") {
+		t.Fatalf("err = %v, want a validation error naming suggest.popularity_weight", err)
+	}
+}
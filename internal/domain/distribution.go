@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DistributionStatus is a source's verdict on one taxon in one area. WCVP's
+// own distribution rows are native or, where WCVP says so, introduced; the
+// empty status is presence without a verdict.
+type DistributionStatus string
+
+const (
+	// DistributionNative marks a taxon indigenous to the area.
+	DistributionNative DistributionStatus = "native"
+	// DistributionIntroduced marks a taxon present in the area by human
+	// agency (archaeophytes, neophytes, casuals alike).
+	DistributionIntroduced DistributionStatus = "introduced"
+	// DistributionDoubtful marks a record the checklist itself does not
+	// vouch for. It is still a record, so it is stored and filters like
+	// any other.
+	DistributionDoubtful DistributionStatus = "doubtful"
+	// DistributionAbsent marks an explicit "not in this area" statement.
+	// It is never stored: distribution is a presence table, and an area
+	// filter treats every row as positive evidence.
+	DistributionAbsent DistributionStatus = "absent"
+)
+
+// ParseDistributionStatus maps a status spelling (case-insensitive,
+// leading/trailing whitespace ignored) to a DistributionStatus. Empty input
+// is the empty status — a presence record with no verdict — and anything
+// else unknown returns an error, so a checklist's "extinct" or "cultivated"
+// is reported rather than silently read as present.
+func ParseDistributionStatus(s string) (DistributionStatus, error) {
+	switch v := DistributionStatus(strings.ToLower(strings.TrimSpace(s))); v {
+	case "", DistributionNative, DistributionIntroduced, DistributionDoubtful, DistributionAbsent:
+		return v, nil
+	default:
+		return "", fmt.Errorf("domain: unknown distribution status %q", s)
+	}
+}
+
+// ValidAreaScheme reports whether scheme may name a distribution area
+// scheme: non-empty lower-case ASCII letters, digits and underscores
+// ("wgsrpd_l3", "euromed", "bayern"). The restriction is what lets an area
+// filter write "euromed:Ge" — a scheme can never itself contain the colon.
+func ValidAreaScheme(scheme string) bool {
+	if scheme == "" {
+		return false
+	}
+	for _, r := range scheme {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// DistributionSourceMeta is one ingested regional distribution source's
+// provenance row (a `distributions:` manifest entry, e.g. a Euro+Med or
+// Bavarian county checklist) — the distribution counterpart of
+// XrefSourceMeta. IngestedAt is stamped by the repository adapter.
+type DistributionSourceMeta struct {
+	ID        string
+	Version   string
+	License   string
+	SourceURL string
+	// ManifestSHA binds this ingest to the exact manifest revision that was
+	// validated, like BackboneVersion.ManifestSHA.
+	ManifestSHA string
+	// Redistribution gates ExportBundle, never local ingest: the bundle
+	// refuses to carry this source's distribution rows unless it is
+	// RedistributionAllowed (see findRestrictedSources).
+	Redistribution Redistribution
+}
+
+// ChecklistEntry is one accepted concept on an area checklist: which taxon
+// occurs in the area, filed under its family, with the checklist verdict
+// for the area when one was ingested.
+type ChecklistEntry struct {
+	ConceptID  string
+	BackboneID string
+	Canonical  string
+	Authorship string
+	Rank       Rank
+	// Family is the concept's family label (Concept.Family), or the
+	// canonical name of its nearest FAMILY ancestor when the source gave
+	// none; "" when neither exists.
+	Family string
+	// Establishment is the concept's own verdict for the area:
+	// DistributionNative when any of its rows there says so, else
+	// DistributionIntroduced, else DistributionDoubtful. It is empty for
+	// presence without a verdict — every area the concept holds only
+	// through a twin or an infraspecific taxon.
+	Establishment DistributionStatus
+	// Derived reports that the concept is in the area only through an
+	// accepted infraspecific taxon (Distribution.Derived), never by a row
+	// asserted for itself.
+	Derived bool
+}
@@ -0,0 +1,48 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+func TestParseDistributionStatus(t *testing.T) {
+	cases := []struct {
+		in   string
+		want domain.DistributionStatus
+	}{
+		{"", ""},
+		{"native", domain.DistributionNative},
+		{" Introduced ", domain.DistributionIntroduced},
+		{"DOUBTFUL", domain.DistributionDoubtful},
+		{"absent", domain.DistributionAbsent},
+	}
+	for _, c := range cases {
+		got, err := domain.ParseDistributionStatus(c.in)
+		if err != nil {
+			t.Errorf("ParseDistributionStatus(%q): unexpected error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseDistributionStatus(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+	for _, in := range []string{"extinct", "cultivated", "present?"} {
+		if _, err := domain.ParseDistributionStatus(in); err == nil {
+			t.Errorf("ParseDistributionStatus(%q): want error, got nil", in)
+		}
+	}
+}
+
+func TestValidAreaScheme(t *testing.T) {
+	for _, s := range []string{"wgsrpd_l3", "euromed", "bayern", "tk25"} {
+		if !domain.ValidAreaScheme(s) {
+			t.Errorf("ValidAreaScheme(%q) = false, want true", s)
+		}
+	}
+	for _, s := range []string{"", "Euromed", "euro med", "euromed:ge", "bayern-lk"} {
+		if domain.ValidAreaScheme(s) {
+			t.Errorf("ValidAreaScheme(%q) = true, want false", s)
+		}
+	}
+}
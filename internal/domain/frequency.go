@@ -0,0 +1,20 @@
+package domain
+
+// FrequencySourceMeta is one ingested taxon-frequency source's provenance
+// row (a `frequencies:` manifest entry, e.g. a national relevé database's
+// per-taxon relevé counts or a locally dumped GBIF occurrence count) — the
+// frequency counterpart of DistributionSourceMeta. IngestedAt is stamped by
+// the repository adapter.
+type FrequencySourceMeta struct {
+	ID        string
+	Version   string
+	License   string
+	SourceURL string
+	// ManifestSHA binds this ingest to the exact manifest revision that was
+	// validated, like BackboneVersion.ManifestSHA.
+	ManifestSHA string
+	// Redistribution gates ExportBundle, never local ingest: the bundle
+	// refuses to carry this source's counts unless it is
+	// RedistributionAllowed (see findRestrictedSources).
+	Redistribution Redistribution
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+// The WGSRPD area schemes: level 1 (continent, "1" Europe), level 2 (region,
+// "11" Middle Europe), level 3 (botanical country, "GER") and level 4 (basic
+// recording unit, "GER-OO"). distribution.area_scheme uses the level-3
+// spelling, so a resolved level-3 Area's Code can be used directly wherever
+// an area code is accepted. A position resolves to levels 1 to 3 only; level
+// 4 exists in the hierarchy, not in the embedded geometries.
+const (
+	AreaSchemeWGSRPDL1 = "wgsrpd_l1"
+	AreaSchemeWGSRPDL2 = "wgsrpd_l2"
+	AreaSchemeWGSRPDL3 = "wgsrpd_l3"
+	AreaSchemeWGSRPDL4 = "wgsrpd_l4"
+)
+
+// wgsrpdSchemes lists the WGSRPD schemes by level; index 0 is unused.
+var wgsrpdSchemes = [...]string{"", AreaSchemeWGSRPDL1, AreaSchemeWGSRPDL2, AreaSchemeWGSRPDL3, AreaSchemeWGSRPDL4}
+
+// WGSRPDLevel returns the level (1-4) of a WGSRPD area scheme, or 0 for any
+// other scheme.
+func WGSRPDLevel(scheme string) int {
+	for level := 1; level < len(wgsrpdSchemes); level++ {
+		if wgsrpdSchemes[level] == scheme {
+			return level
+		}
+	}
+	return 0
+}
+
+// WGSRPDScheme returns the area scheme of a WGSRPD level, or "" for a level
+// outside 1-4.
+func WGSRPDScheme(level int) string {
+	if level < 1 || level >= len(wgsrpdSchemes) {
+		return ""
+	}
+	return wgsrpdSchemes[level]
+}
+
+// WGSRPDParentScheme returns the scheme an Area.Parent of the given scheme is
+// a code in — "wgsrpd_l2" for "wgsrpd_l3" — or "" for level 1 and for every
+// scheme outside the hierarchy.
+func WGSRPDParentScheme(scheme string) string {
+	return WGSRPDScheme(WGSRPDLevel(scheme) - 1)
+}
+
+// ErrInvalidCoordinate is returned by NewPoint for a latitude outside
+// [-90, 90], a longitude outside [-180, 180], or either being NaN.
+var ErrInvalidCoordinate = errors.New("domain: invalid coordinate")
+
+// Point is a WGS84 position in decimal degrees.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// NewPoint validates lat/lon and returns them as a Point. Swapped values are
+// the common mistake (GeoJSON orders lon, lat); they are caught only when the
+// swapped latitude falls outside [-90, 90], so a caller should name the
+// parameters rather than rely on this.
+func NewPoint(lat, lon float64) (Point, error) {
+	if math.IsNaN(lat) || math.IsNaN(lon) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return Point{}, ErrInvalidCoordinate
+	}
+	return Point{Lat: lat, Lon: lon}, nil
+}
+
+// Ring is a closed linear ring; the closing vertex may be repeated or
+// omitted, both are treated the same.
+type Ring []Point
+
+// Polygon is an outer ring followed by zero or more holes, as in GeoJSON.
+type Polygon []Ring
+
+// MultiPolygon is a set of polygons. WGSRPD areas are mostly several: a
+// botanical country with islands, or one split by the antimeridian.
+type MultiPolygon []Polygon
+
+// Contains reports whether p lies inside m: inside an outer ring and outside
+// every hole of the same polygon. Coordinates are treated as planar degrees,
+// which is exact for the even-odd test itself — it only asks on which side of
+// each edge p lies — and WGSRPD geometries are split at the antimeridian, so
+// no ring wraps around it.
+func (m MultiPolygon) Contains(p Point) bool {
+	for _, poly := range m {
+		if poly.contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (poly Polygon) contains(p Point) bool {
+	if len(poly) == 0 || !poly[0].contains(p) {
+		return false
+	}
+	for _, hole := range poly[1:] {
+		if hole.contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains is the even-odd ray test: a ray from p towards +lon crosses the
+// ring's boundary an odd number of times iff p is inside.
+func (r Ring) contains(p Point) bool {
+	inside := false
+	n := len(r)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Lat > p.Lat) == (b.Lat > p.Lat) {
+			continue
+		}
+		crossLon := a.Lon + (p.Lat-a.Lat)*(b.Lon-a.Lon)/(b.Lat-a.Lat)
+		if p.Lon < crossLon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// BoundaryDistance returns the planar distance in degrees from p to the
+// nearest edge of m, whether p is inside or not. A resolver uses it twice:
+// outside every area, to accept the nearest one within the simplification
+// tolerance (a coastline simplified to kilometres leaves beaches and harbours
+// "in the sea"); inside several — simplified neighbours overlap slightly —
+// to prefer the one p is deepest in. Degrees are not a distance on the
+// ground, but both uses compare small distances near one point, where the
+// distortion is the same for every candidate.
+func (m MultiPolygon) BoundaryDistance(p Point) float64 {
+	best := math.Inf(1)
+	for _, poly := range m {
+		for _, r := range poly {
+			n := len(r)
+			for i, j := 0, n-1; i < n; j, i = i, i+1 {
+				if d := segmentDistance(p, r[j], r[i]); d < best {
+					best = d
+				}
+			}
+		}
+	}
+	return best
+}
+
+func segmentDistance(p, a, b Point) float64 {
+	dx, dy := b.Lon-a.Lon, b.Lat-a.Lat
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = ((p.Lon-a.Lon)*dx + (p.Lat-a.Lat)*dy) / l
+		t = math.Max(0, math.Min(1, t))
+	}
+	return math.Hypot(p.Lon-(a.Lon+t*dx), p.Lat-(a.Lat+t*dy))
+}
+
+// Bounds returns m's bounding box as its south-west and north-east corners,
+// for a cheap reject before Contains. An empty m returns an inverted box that
+// contains nothing.
+func (m MultiPolygon) Bounds() (sw, ne Point) {
+	sw = Point{Lat: math.Inf(1), Lon: math.Inf(1)}
+	ne = Point{Lat: math.Inf(-1), Lon: math.Inf(-1)}
+	for _, poly := range m {
+		for _, r := range poly {
+			for _, v := range r {
+				sw.Lat, sw.Lon = math.Min(sw.Lat, v.Lat), math.Min(sw.Lon, v.Lon)
+				ne.Lat, ne.Lon = math.Max(ne.Lat, v.Lat), math.Max(ne.Lon, v.Lon)
+			}
+		}
+	}
+	return sw, ne
+}
@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// square returns the axis-aligned ring [lat0,lat1] x [lon0,lon1], unclosed.
+func square(lat0, lon0, lat1, lon1 float64) domain.Ring {
+	return domain.Ring{{Lat: lat0, Lon: lon0}, {Lat: lat0, Lon: lon1}, {Lat: lat1, Lon: lon1}, {Lat: lat1, Lon: lon0}}
+}
+
+func TestNewPoint_RejectsOutOfRangeAndNaN(t *testing.T) {
+	for _, c := range []struct{ lat, lon float64 }{
+		{91, 0}, {-90.5, 0}, {0, 180.1}, {0, -181}, {math.NaN(), 0}, {0, math.NaN()},
+	} {
+		if _, err := domain.NewPoint(c.lat, c.lon); !errors.Is(err, domain.ErrInvalidCoordinate) {
+			t.Errorf("NewPoint(%v, %v) error = %v, want ErrInvalidCoordinate", c.lat, c.lon, err)
+		}
+	}
+	if p, err := domain.NewPoint(-90, 180); err != nil || p.Lat != -90 || p.Lon != 180 {
+		t.Errorf("NewPoint(-90, 180) = %+v, %v, want the corner accepted", p, err)
+	}
+}
+
+// TestMultiPolygon_ContainsRespectsHolesAndIslands pins the even-odd rule over
+// the shapes WGSRPD has: a hole (an enclave) is outside, a second polygon (an
+// island) is inside.
+func TestMultiPolygon_ContainsRespectsHolesAndIslands(t *testing.T) {
+	m := domain.MultiPolygon{
+		{square(0, 0, 10, 10), square(4, 4, 6, 6)},
+		{square(20, 20, 21, 21)},
+	}
+	cases := []struct {
+		name string
+		p    domain.Point
+		want bool
+	}{
+		{"mainland", domain.Point{Lat: 2, Lon: 2}, true},
+		{"enclave", domain.Point{Lat: 5, Lon: 5}, false},
+		{"island", domain.Point{Lat: 20.5, Lon: 20.5}, true},
+		{"sea", domain.Point{Lat: 15, Lon: 15}, false},
+		{"level with a vertex", domain.Point{Lat: 10, Lon: -1}, false},
+	}
+	for _, c := range cases {
+		if got := m.Contains(c.p); got != c.want {
+			t.Errorf("%s: Contains(%+v) = %v, want %v", c.name, c.p, got, c.want)
+		}
+	}
+}
+
+func TestMultiPolygon_BoundaryDistanceAndBounds(t *testing.T) {
+	m := domain.MultiPolygon{{square(0, 0, 10, 10)}}
+	if d := m.BoundaryDistance(domain.Point{Lat: 5, Lon: 12}); math.Abs(d-2) > 1e-9 {
+		t.Errorf("BoundaryDistance outside = %v, want 2", d)
+	}
+	if d := m.BoundaryDistance(domain.Point{Lat: 5, Lon: 9}); math.Abs(d-1) > 1e-9 {
+		t.Errorf("BoundaryDistance inside = %v, want 1", d)
+	}
+	sw, ne := m.Bounds()
+	if sw != (domain.Point{Lat: 0, Lon: 0}) || ne != (domain.Point{Lat: 10, Lon: 10}) {
+		t.Errorf("Bounds = %+v, %+v, want the square's corners", sw, ne)
+	}
+}
+
+func TestWGSRPDLevels(t *testing.T) {
+	cases := []struct {
+		scheme string
+		level  int
+		parent string
+	}{
+		{domain.AreaSchemeWGSRPDL1, 1, ""},
+		{domain.AreaSchemeWGSRPDL2, 2, domain.AreaSchemeWGSRPDL1},
+		{domain.AreaSchemeWGSRPDL3, 3, domain.AreaSchemeWGSRPDL2},
+		{domain.AreaSchemeWGSRPDL4, 4, domain.AreaSchemeWGSRPDL3},
+		{"euromed", 0, ""},
+	}
+	for _, c := range cases {
+		if got := (domain.Area{Scheme: c.scheme}).Level(); got != c.level {
+			t.Errorf("Level(%q) = %d, want %d", c.scheme, got, c.level)
+		}
+		if got := domain.WGSRPDParentScheme(c.scheme); got != c.parent {
+			t.Errorf("WGSRPDParentScheme(%q) = %q, want %q", c.scheme, got, c.parent)
+		}
+	}
+	if got := domain.WGSRPDScheme(5); got != "" {
+		t.Errorf("WGSRPDScheme(5) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MatchField says which of a concept's indexed names a suggest query matched.
+type MatchField string
+
+const (
+	// MatchAccepted is the concept's own accepted name: the name the
+	// suggestion displays.
+	MatchAccepted MatchField = "accepted"
+	// MatchSynonym is one of the concept's synonyms, so the suggestion
+	// displays a different name from the one typed.
+	MatchSynonym MatchField = "synonym"
+	// MatchAlias is a name-space aggregate alias ("Achillea millefolium
+	// aggr."), indexed for the concept it resolved to.
+	MatchAlias MatchField = "alias"
+)
+
+// matchFieldOrder is BestSuggestMatch's preference among equally anchored
+// candidates: the displayed name first, since highlighting it needs no
+// further explanation, then a synonym, then an alias.
+var matchFieldOrder = map[MatchField]int{
+	MatchAccepted: 0,
+	MatchSynonym:  1,
+	MatchAlias:    2,
+}
+
+// Span is a half-open [Start, End) range of rune offsets into a name.
+type Span struct {
+	Start int
+	End   int
+}
+
+// SuggestMatch is the name a suggestion was found by and the parts of it the
+// query matched. Highlights index into Name, which equals the item's Display
+// when Field is MatchAccepted.
+type SuggestMatch struct {
+	Field      MatchField
+	Name       string
+	Highlights []Span
+}
+
+// SuggestQueryWords splits q into the words a suggest query searches for:
+// q's Canonicalize'd form with any trailing aggregate marker stripped, cut
+// at every rune that is neither a letter nor a digit — where FTS5's
+// unicode61 tokenizer cuts the indexed names. Each word must prefix some
+// word of a matching name.
+func SuggestQueryWords(q string) []string {
+	return strings.FieldsFunc(StripAggregateMarkers(Canonicalize(q)), isWordSeparator)
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// HighlightPrefixes returns the spans of name that the query words prefix,
+// in name order: for each word of name, the longest query word prefixing its
+// folded form marks that many runes from the word's start. A name word no
+// query word prefixes is not marked, and nil means none is.
+//
+// name is folded rune by rune with Canonicalize's own fold, so "Senecio"
+// typed as "sene" and "Sénécio" typed as "sene" mark the same four runes,
+// and the offsets count runes of name as written — the display string a
+// client renders — not of its folded key.
+func HighlightPrefixes(name string, words []string) []Span {
+	var spans []Span
+	runes := []rune(name)
+	for start := 0; start < len(runes); {
+		if isWordSeparator(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && !isWordSeparator(runes[end]) {
+			end++
+		}
+		folded := make([]rune, end-start)
+		for i, r := range runes[start:end] {
+			folded[i] = foldRune(r)
+		}
+		best := 0
+		for _, w := range words {
+			if n := len([]rune(w)); n > best && strings.HasPrefix(string(folded), w) {
+				best = n
+			}
+		}
+		if best > 0 {
+			spans = append(spans, Span{Start: start, End: start + best})
+		}
+		start = end
+	}
+	return spans
+}
+
+// BestSuggestMatch picks, among the names a concept was matched by, the one
+// to report for query q, with its highlights filled in. A name whose first
+// word the query's first word prefixes — a genus-prefix hit
+// (SuggestItem.PrefixHit) — wins over one matched only by a later word;
+// among those, MatchAccepted before MatchSynonym before MatchAlias, then the
+// name itself for a deterministic choice. The zero SuggestMatch is returned
+// when candidates is empty.
+func BestSuggestMatch(q string, candidates []SuggestMatch) SuggestMatch {
+	if len(candidates) == 0 {
+		return SuggestMatch{}
+	}
+	words := SuggestQueryWords(q)
+	scored := make([]SuggestMatch, len(candidates))
+	for i, c := range candidates {
+		c.Highlights = HighlightPrefixes(c.Name, words)
+		scored[i] = c
+	}
+	anchored := func(m SuggestMatch) bool {
+		if len(words) == 0 {
+			return false
+		}
+		nameWords := strings.FieldsFunc(Canonicalize(m.Name), isWordSeparator)
+		return len(nameWords) > 0 && strings.HasPrefix(nameWords[0], words[0])
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		a, b := scored[i], scored[j]
+		if anchored(a) != anchored(b) {
+			return anchored(a)
+		}
+		if ao, bo := matchFieldOrder[a.Field], matchFieldOrder[b.Field]; ao != bo {
+			return ao < bo
+		}
+		return a.Name < b.Name
+	})
+	return scored[0]
+}
@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+func TestHighlightPrefixes(t *testing.T) {
+	for _, tc := range []struct {
+		name, q string
+		want    []domain.Span
+	}{
+		{"Festuca ovina", "fe ov", []domain.Span{{0, 2}, {8, 10}}},
+		{"Festuca ovina", "ovina", []domain.Span{{8, 13}}},
+		// Folded like the query: the offsets count the name's own runes, so
+		// the accented letters are inside the span, not shifted by it.
+		{"Sénécio vulgaris", "SENE", []domain.Span{{0, 4}}},
+		// The longer of two query words prefixing one name word wins.
+		{"Poa pratensis", "p pra", []domain.Span{{0, 1}, {4, 7}}},
+		// An aggregate marker is stripped from the query as Suggest strips it.
+		{"Achillea millefolium aggr.", "achillea millefolium agg.", []domain.Span{{0, 8}, {9, 20}}},
+		// The hybrid sign is a separator, like every non-letter.
+		{"Acer × coriaceum", "cor", []domain.Span{{7, 10}}},
+		{"Festuca ovina", "carex", nil},
+	} {
+		got := domain.HighlightPrefixes(tc.name, domain.SuggestQueryWords(tc.q))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("HighlightPrefixes(%q, %q) = %v, want %v", tc.name, tc.q, got, tc.want)
+		}
+	}
+}
+
+// TestBestSuggestMatch_Preference pins the choice among a concept's matched
+// names: a genus-prefix hit first, then accepted before synonym before alias.
+func TestBestSuggestMatch_Preference(t *testing.T) {
+	accepted := domain.SuggestMatch{Field: domain.MatchAccepted, Name: "Festuca ovina"}
+	synonym := domain.SuggestMatch{Field: domain.MatchSynonym, Name: "Festuca duriuscula"}
+	epithetSyn := domain.SuggestMatch{Field: domain.MatchSynonym, Name: "Bromus festucoides"}
+	alias := domain.SuggestMatch{Field: domain.MatchAlias, Name: "Festuca ovina aggr."}
+
+	for _, tc := range []struct {
+		q          string
+		candidates []domain.SuggestMatch
+		want       string
+	}{
+		{"fest", []domain.SuggestMatch{alias, synonym, accepted}, "Festuca ovina"},
+		{"fest", []domain.SuggestMatch{alias, synonym}, "Festuca duriuscula"},
+		{"fest", []domain.SuggestMatch{epithetSyn, alias}, "Festuca ovina aggr."},
+		{"fest", []domain.SuggestMatch{epithetSyn}, "Bromus festucoides"},
+	} {
+		got := domain.BestSuggestMatch(tc.q, tc.candidates)
+		if got.Name != tc.want {
+			t.Errorf("BestSuggestMatch(%q, %v) = %q, want %q", tc.q, tc.candidates, got.Name, tc.want)
+		}
+		if len(got.Highlights) == 0 {
+			t.Errorf("BestSuggestMatch(%q) = %+v, want highlights filled in", tc.q, got)
+		}
+	}
+
+	if got := domain.BestSuggestMatch("fest", nil); got.Field != "" {
+		t.Errorf("BestSuggestMatch(no candidates) = %+v, want the zero match", got)
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// HybridParent is one parent of a nothotaxon as its hybrid formula names it
+// ("Salix alba × S. fragilis" has the parents "Salix alba" and "Salix
+// fragilis"). ConceptID is the concept of the hybrid's own backbone that
+// name resolves to, or "" when it resolves to none or to several — a
+// formula names its parents by name, and the backbone need not carry them.
+type HybridParent struct {
+	Canonical string
+	ConceptID string
+}
+
+// ParseHybridFormula reads a hybrid formula — parent names joined by the
+// hybrid marker — into the canonicals of its parents, in formula order:
+//
+//	"Salix alba × S. fragilis"     ["Salix alba", "Salix fragilis"]
+//	"Mentha aquatica x spicata"    ["Mentha aquatica", "Mentha spicata"]
+//	"Salix alba L. × S. fragilis L." authors dropped, same parents
+//	"Festuca × Lolium"             ["Festuca", "Lolium"], intergeneric
+//
+// An abbreviated genus ("S.") or a bare epithet ("spicata") in a later parent
+// takes the first parent's genus. The marker is "×" or a standalone ASCII
+// "x", as NormalizeHybridMarker reads it.
+//
+// The NAME of a nothotaxon is not a formula and reports false: in "Salix ×
+// rubens" or "Mentha × piperita nothosubsp. citrata" the marker follows a
+// bare genus and precedes an epithet, and "× Festulolium" opens with it. A
+// nested formula ("(A × B) × C") reports false as well — its parents are
+// not names.
+func ParseHybridFormula(s string) ([]string, bool) {
+	if strings.ContainsAny(s, "()") {
+		return nil, false
+	}
+	var groups [][]string
+	var cur []string
+	for i, f := range strings.Fields(s) {
+		rest, marked := strings.CutPrefix(f, hybridMarker)
+		if f == "x" && i > 0 {
+			marked, rest = true, ""
+		}
+		if marked {
+			groups = append(groups, cur)
+			cur = nil
+			if rest == "" {
+				continue
+			}
+			f = rest
+		}
+		cur = append(cur, f)
+	}
+	groups = append(groups, cur)
+	if len(groups) < 2 {
+		return nil, false
+	}
+
+	names := make([][]string, len(groups))
+	for i, g := range groups {
+		if names[i] = nameTokens(g); len(names[i]) == 0 {
+			return nil, false
+		}
+	}
+	genus := names[0][0]
+	if !startsUpper(genus) || strings.HasSuffix(genus, ".") {
+		return nil, false
+	}
+	if len(names[0]) == 1 && !startsUpper(names[1][0]) {
+		return nil, false
+	}
+
+	parents := make([]string, len(names))
+	for i, n := range names {
+		switch {
+		case !startsUpper(n[0]):
+			n = append([]string{genus}, n...)
+		case isGenusAbbreviation(n[0], genus):
+			n = append([]string{genus}, n[1:]...)
+		}
+		parents[i] = strings.Join(n, " ")
+	}
+	return parents, true
+}
+
+// nameTokens is the name part of one formula parent: its first token, then
+// every token up to the first that starts upper-case — the author, as
+// splitVerbatim in internal/application cuts it.
+func nameTokens(tokens []string) []string {
+	for i, t := range tokens {
+		if i > 0 && startsUpper(t) {
+			return tokens[:i]
+		}
+	}
+	return tokens
+}
+
+// isGenusAbbreviation reports whether t abbreviates genus: a capitalized
+// prefix of it followed by a period ("S." or "Sal." for "Salix").
+func isGenusAbbreviation(t, genus string) bool {
+	prefix, ok := strings.CutSuffix(t, ".")
+	return ok && prefix != "" && len(prefix) < len(genus) && strings.HasPrefix(genus, prefix)
+}
+
+func startsUpper(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+func TestParseHybridFormula(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"Salix alba × S. fragilis", []string{"Salix alba", "Salix fragilis"}},
+		{"Salix alba × Salix fragilis", []string{"Salix alba", "Salix fragilis"}},
+		{"Salix alba L. × S. fragilis L.", []string{"Salix alba", "Salix fragilis"}},
+		{"Mentha aquatica x spicata", []string{"Mentha aquatica", "Mentha spicata"}},
+		{"Mentha aquatica ×spicata", []string{"Mentha aquatica", "Mentha spicata"}},
+		{"Festuca × Lolium", []string{"Festuca", "Lolium"}},
+		{"Festuca pratensis × Lolium perenne", []string{"Festuca pratensis", "Lolium perenne"}},
+		{"Salix alba subsp. vitellina × S. babylonica", []string{"Salix alba subsp. vitellina", "Salix babylonica"}},
+		{"Salix aurita × S. caprea × S. cinerea", []string{"Salix aurita", "Salix caprea", "Salix cinerea"}},
+		// Nothotaxon names, not formulas.
+		{"Salix × rubens", nil},
+		{"Salix ×rubens Schrank", nil},
+		{"Salix x rubens", nil},
+		{"Mentha × piperita nothosubsp. citrata", nil},
+		{"× Festulolium loliaceum", nil},
+		// Neither.
+		{"Salix alba", nil},
+		{"(Salix alba × S. fragilis) × S. pentandra", nil},
+		{"Salix alba ×", nil},
+		{"S. alba × S. fragilis", nil},
+		{"", nil},
+	}
+	for _, tc := range tests {
+		got, ok := domain.ParseHybridFormula(tc.in)
+		if ok != (tc.want != nil) || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseHybridFormula(%q) = %q, %v, want %q", tc.in, got, ok, tc.want)
+		}
+	}
+}
@@ -29,6 +29,29 @@ const (
 	// ClassifyMatch — assigned by the application layer once fuzzy scoring
 	// clears the threshold.
 	MatchFuzzy MatchType = "fuzzy"
+	// MatchHigherRank: the query was open nomenclature ("Festuca sp.",
+	// "Poaceae indet.", "Taraxacum sect. Ruderalia" — see
+	// ParseOpenNomenclature) and the answer is the genus, infrageneric group
+	// or family concept it names. Its own type because the record was
+	// identified only that far: a consumer must not read it as a species
+	// determination, and must be able to keep it apart from one without
+	// parsing the verbatim again. Never produced by ClassifyMatch.
+	MatchHigherRank MatchType = "higher_rank"
+	// MatchMisapplied: the query qualified the name as a usage ("auct.",
+	// "sensu …" — see ParseUsageQualifier) and the index holds a stored
+	// misapplied or pro-parte edge from that usage to the concept the author
+	// actually meant. Its own type because the answer is, by construction, NOT
+	// the concept the name nomenclaturally belongs to: a consumer re-deriving
+	// it from the canonical alone would land on the homonym the qualifier was
+	// written to exclude. Never produced by ClassifyMatch.
+	MatchMisapplied MatchType = "misapplied"
+	// MatchHybridFormula: the query was a hybrid formula ("Salix alba × S.
+	// fragilis" — see ParseHybridFormula) and the answer is the nothotaxon
+	// whose stored hybrid parents are exactly the ones it names. Its own type
+	// because the verbatim named no taxon at all, only a cross: a consumer
+	// must be able to tell that the name it now holds was supplied by the
+	// index. Never produced by ClassifyMatch.
+	MatchHybridFormula MatchType = "hybrid_formula"
 )
 
 // FuzzyThreshold is the minimum Similarity score for a fuzzy candidate to be
@@ -0,0 +1,93 @@
+package domain
+
+import "strings"
+
+// openNomenclatureMarkers are the trailing qualifiers with which a record
+// says "identified to THIS rank and no further": "Festuca sp.", "Carex
+// spec.", "Poaceae indet.". They follow the uninomial directly; whatever
+// comes after them ("sp. 1", "spec. (steril)") is a field worker's own
+// label for an unnamed morphotype and carries no nomenclature at all.
+//
+// Matched case-insensitively and with or without the trailing dot, because
+// relevé exports spell them every way ("SP", "spec", "Spec.") — unlike an
+// aggregate marker, none of these is ever a legitimate epithet, so being
+// lenient here cannot turn a real species name into a genus query.
+//
+// "cf." and "aff." are deliberately ABSENT: they qualify an identification
+// that DID reach species ("Carex cf. flacca"), and answering those with the
+// genus would throw away the part the observer was reasonably sure of.
+var openNomenclatureMarkers = map[string]bool{
+	"sp":     true,
+	"sp.":    true,
+	"spp":    true,
+	"spp.":   true,
+	"spec":   true,
+	"spec.":  true,
+	"indet":  true,
+	"indet.": true,
+}
+
+// infragenericMarkers are the rank markers that sit between a genus and an
+// infrageneric epithet ("Taraxacum sect. Ruderalia"). Unlike an
+// infraspecific epithet, the infrageneric one is capitalized, which is why
+// a plain split on the first uppercase token would mistake it for an author
+// — see ParseOpenNomenclature.
+var infragenericMarkers = map[string]bool{
+	"subg.":    true,
+	"sect.":    true,
+	"subsect.": true,
+	"ser.":     true,
+}
+
+// OpenNomenclature is a verbatim that names a taxon only ABOVE species — a
+// genus or family left open ("Festuca sp.", "Poaceae indet.") or an
+// infrageneric group ("Taraxacum sect. Ruderalia").
+//
+// Key is the canonicalized name to resolve: the uninomial itself, or the
+// full "genus sect. epithet" for an infrageneric group. Fallback is the
+// genus to resolve to when an infrageneric Key is absent from the index —
+// most backbones (WCVP among them) carry no sections at all, and the genus is
+// still a true statement about the record. It is empty for a plain
+// uninomial, which has nothing coarser worth falling back to.
+type OpenNomenclature struct {
+	Key      string
+	Fallback string
+}
+
+// ParseOpenNomenclature reports whether verbatim is open nomenclature and,
+// if so, what to look up for it.
+//
+// Recognized shapes, all on the raw verbatim (case preserved on input,
+// canonicalized on output):
+//
+//   - "<Uninomial> <marker> [anything]" with a marker from
+//     openNomenclatureMarkers — "Festuca sp.", "Carex spec. 2";
+//   - "<Genus> <sect.|subsect.|ser.|subg.> <Epithet> [author]" —
+//     "Taraxacum sect. Ruderalia Kirschner & al.".
+//
+// A bare uninomial ("Festuca") is NOT reported: it already resolves
+// through the ordinary exact ladder, exactly like any one-word name, and
+// routing it here would only change its match type. Aggregate names ("Rubus
+// fruticosus agg.") are not reported either — they name a collective
+// SPECIES, which the aggregate path answers more precisely than a genus
+// ever could.
+func ParseOpenNomenclature(verbatim string) (OpenNomenclature, bool) {
+	fields := strings.Fields(verbatim)
+	if len(fields) < 2 {
+		return OpenNomenclature{}, false
+	}
+	marker := strings.ToLower(fields[1])
+	// Guarded early-return rather than a tagless switch or an if/else chain
+	// — see AggregateBases.
+	if openNomenclatureMarkers[marker] {
+		return OpenNomenclature{Key: Canonicalize(fields[0])}, true
+	}
+	if len(fields) >= 3 && infragenericMarkers[marker] {
+		genus := Canonicalize(fields[0])
+		return OpenNomenclature{
+			Key:      genus + " " + marker + " " + Canonicalize(fields[2]),
+			Fallback: genus,
+		}, true
+	}
+	return OpenNomenclature{}, false
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+func TestParseOpenNomenclature(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		want   domain.OpenNomenclature
+		wantOK bool
+	}{
+		{name: "genus sp.", in: "Festuca sp.", want: domain.OpenNomenclature{Key: "festuca"}, wantOK: true},
+		{name: "genus spec.", in: "Carex spec.", want: domain.OpenNomenclature{Key: "carex"}, wantOK: true},
+		{name: "genus spp.", in: "Carex spp.", want: domain.OpenNomenclature{Key: "carex"}, wantOK: true},
+		{name: "family indet.", in: "Poaceae indet.", want: domain.OpenNomenclature{Key: "poaceae"}, wantOK: true},
+		{
+			// relevé exports spell the marker without a dot and in capitals.
+			name: "marker case and dot are irrelevant", in: "Festuca SP",
+			want: domain.OpenNomenclature{Key: "festuca"}, wantOK: true,
+		},
+		{
+			// a field worker's morphotype label after the marker is not nomenclature.
+			name: "trailing morphotype label is ignored", in: "Carex spec. 2 (steril)",
+			want: domain.OpenNomenclature{Key: "carex"}, wantOK: true,
+		},
+		{
+			name: "section with genus fallback", in: "Taraxacum sect. Ruderalia",
+			want:   domain.OpenNomenclature{Key: "taraxacum sect. ruderalia", Fallback: "taraxacum"},
+			wantOK: true,
+		},
+		{
+			name: "section with an author", in: "Taraxacum sect. Ruderalia Kirschner, H.Øllg. & Štěpánek",
+			want:   domain.OpenNomenclature{Key: "taraxacum sect. ruderalia", Fallback: "taraxacum"},
+			wantOK: true,
+		},
+		{
+			name: "subgenus", in: "Rubus subg. Rubus",
+			want:   domain.OpenNomenclature{Key: "rubus subg. rubus", Fallback: "rubus"},
+			wantOK: true,
+		},
+		{name: "section marker without an epithet", in: "Taraxacum sect.", wantOK: false},
+		{name: "bare uninomial stays on the exact ladder", in: "Festuca", wantOK: false},
+		{name: "binomial", in: "Festuca ovina", wantOK: false},
+		{name: "aggregate stays on the aggregate path", in: "Rubus fruticosus agg.", wantOK: false},
+		{name: "cf. keeps its species", in: "Carex cf. flacca", wantOK: false},
+		{name: "empty", in: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := domain.ParseOpenNomenclature(tt.in)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseOpenNomenclature(%q) = (%+v, %v), want (%+v, %v)", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Publication is a name's place of publication split into its parts, read
+// from the IPNI-style citation WCVP records in Name.PublishedIn:
+//
+//	"Sp. Pl.: 73 (1753)"                     Title "Sp. Pl.", Pages "73", Year 1753
+//	"Acta Bot. Acad. Sci. Hung. 17: 121 (1971 publ. 1972)"
+//	                                         Volume "17", Pages "121", Year 1972
+//	"Fl. Carniol., ed. 2, 1: 77 (1771)"      Title "Fl. Carniol., ed. 2", Volume "1"
+//
+// Every part is empty (Year 0) when the citation does not carry it.
+type Publication struct {
+	Title  string
+	Volume string
+	Pages  string
+	Year   int
+}
+
+// IsZero reports whether nothing could be read from the citation.
+func (p Publication) IsZero() bool {
+	return p == Publication{}
+}
+
+// ParsePublication splits an IPNI-style citation "Title Volume: Pages (Year)"
+// into a Publication. The year is the last four-digit year in the trailing
+// parenthesis: for "(1971 publ. 1972)" the year the work actually appeared,
+// for a range "(1816-1818)" its end — never earlier than the evidence allows,
+// which is what a "published before" filter needs. A citation that has
+// neither a year nor a ": pages" part ("Unknown", free text) parses to the
+// zero Publication rather than a Title guessed from it.
+func ParsePublication(s string) Publication {
+	s = strings.TrimSpace(s)
+	var p Publication
+	if strings.HasSuffix(s, ")") {
+		if open := strings.LastIndex(s, "("); open >= 0 {
+			if year := lastYear(s[open+1 : len(s)-1]); year > 0 {
+				p.Year = year
+				s = strings.TrimSpace(s[:open])
+			}
+		}
+	}
+	head := s
+	if colon := strings.LastIndex(s, ":"); colon >= 0 {
+		p.Pages = strings.TrimSpace(s[colon+1:])
+		head = strings.TrimSpace(s[:colon])
+	}
+	if p.Year == 0 && p.Pages == "" {
+		return Publication{}
+	}
+	p.Title, p.Volume = splitVolume(head)
+	return p
+}
+
+// lastYear returns the last run of exactly four digits in s, or 0.
+func lastYear(s string) int {
+	year := 0
+	for i := 0; i < len(s); {
+		j := i
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j-i == 4 {
+			year, _ = strconv.Atoi(s[i:j])
+		}
+		if j == i {
+			j++
+		}
+		i = j
+	}
+	return year
+}
+
+// splitVolume splits the volume off the end of "Title Volume": the last
+// space- or comma-separated token when it starts with a digit and carries no
+// period ("17", "2(3)", "45B"). A token ending an edition ("ed. 2") is part
+// of the title, and "ed. 4." — period and all — never looks like a volume.
+func splitVolume(head string) (title, volume string) {
+	head = strings.TrimRight(head, " ,")
+	cut := strings.LastIndexAny(head, " ,")
+	if cut < 0 {
+		return head, ""
+	}
+	token := head[cut+1:]
+	rest := strings.TrimRight(head[:cut], " ,")
+	if token == "" || !unicode.IsDigit(rune(token[0])) || strings.Contains(token, ".") || rest == "" || strings.HasSuffix(rest, "ed.") {
+		return head, ""
+	}
+	return rest, token
+}
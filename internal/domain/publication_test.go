@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+// TestParsePublication runs the citation shapes of the WCVP sample.
+func TestParsePublication(t *testing.T) {
+	tests := []struct {
+		in   string
+		want domain.Publication
+	}{
+		{"Sp. Pl.: 73 (1753)", domain.Publication{Title: "Sp. Pl.", Pages: "73", Year: 1753}},
+		{"Fruct. Sem. Pl. 2: 445 (1791)", domain.Publication{Title: "Fruct. Sem. Pl.", Volume: "2", Pages: "445", Year: 1791}},
+		{"Acta Bot. Acad. Sci. Hung. 17: 121 (1971 publ. 1972)", domain.Publication{Title: "Acta Bot. Acad. Sci. Hung.", Volume: "17", Pages: "121", Year: 1972}},
+		{"Gard. Dict. Abr., ed. 4.: [667] (1754)", domain.Publication{Title: "Gard. Dict. Abr., ed. 4.", Pages: "[667]", Year: 1754}},
+		{"Fl. Carniol., ed. 2, 1: 77 (1771)", domain.Publication{Title: "Fl. Carniol., ed. 2", Volume: "1", Pages: "77", Year: 1771}},
+		{"Fl. Carniol., ed. 2: 77 (1771)", domain.Publication{Title: "Fl. Carniol., ed. 2", Pages: "77", Year: 1771}},
+		{"K.C.T.Goebel, Reise Steppen Russl. 2: 283 (1838)", domain.Publication{Title: "K.C.T.Goebel, Reise Steppen Russl.", Volume: "2", Pages: "283", Year: 1838}},
+		{"Pl. Syst. Evol. 301(6): 1538 (2014)", domain.Publication{Title: "Pl. Syst. Evol.", Volume: "301(6)", Pages: "1538", Year: 2014}},
+		{"Hort. Kew. 1: 102 (1816-1818)", domain.Publication{Title: "Hort. Kew.", Volume: "1", Pages: "102", Year: 1818}},
+		{"Fl. Germ. (1806)", domain.Publication{Title: "Fl. Germ.", Year: 1806}},
+		{"Unknown", domain.Publication{}},
+		{"", domain.Publication{}},
+	}
+	for _, tc := range tests {
+		if got := domain.ParsePublication(tc.in); got != tc.want {
+			t.Errorf("ParsePublication(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "sort"
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+)
 
 // SuggestItem is a single autosuggest candidate, combining the taxon
 // identity (ConceptID/Canonical/Display/VernacularDE/Rank/Status) with the
@@ -40,6 +44,93 @@ type SuggestItem struct {
 	// It answers "can I use this concept downstream in that space?" while
 	// choosing, rather than one concept at a time afterwards.
 	TargetSpaceName string
+	// CorrectedFrom is the query as typed when this item was NOT found by it
+	// but by a near-miss spelling of it (SuggestCorrections) — "Festuka"
+	// finding Festuca. RankSuggestions places such an item after every hit
+	// for the query as typed, prefix or epithet; the marker
+	// lets a client say "did you mean" instead of presenting a guess as a
+	// match. Empty for an ordinary prefix hit.
+	CorrectedFrom string
+	// Frequency is how often the concept is recorded according to an
+	// ingested frequency source (relevés, occurrences), 0 when no source
+	// counts it. It is a popularity signal only: see Relevance.
+	Frequency int64
+	// Match is the name the query matched — the accepted name, a synonym or
+	// an alias — and the runes of it the query prefixed, so a client can
+	// highlight without re-implementing the fold (BestSuggestMatch). It is
+	// the zero SuggestMatch for an index built before matched names were
+	// recorded.
+	Match SuggestMatch
+	// HigherTaxa is the concept's family and genus, so a suggestion list can
+	// read "Festuca ovina (Poaceae)". Set on the final, trimmed page only.
+	HigherTaxa HigherTaxa
+}
+
+// DefaultPopularityWeight is the popularity term's weight when none is
+// configured: a concept counted 1,000 times gains 3 bm25 units over one
+// never counted — enough to lift Carex acuta over an obscure sedge of equal
+// string relevance, not enough to outrank a clearly better string match.
+const DefaultPopularityWeight = 1.0
+
+// Relevance returns the item's final ranking key: its bm25 Score lowered by
+// popularityWeight * log10(1+Frequency). Lower is more relevant, like Score.
+// The logarithm keeps a taxon counted a million times from burying every
+// other candidate; a weight of 0, or an item no source counts, leaves the
+// plain Score.
+func (s SuggestItem) Relevance(popularityWeight float64) float64 {
+	if popularityWeight == 0 || s.Frequency <= 0 {
+		return s.Score
+	}
+	return s.Score - popularityWeight*math.Log10(1+float64(s.Frequency))
+}
+
+// SuggestCorrectionThreshold is the minimum Similarity between a query and a
+// same-length name prefix for the prefix to count as a correction of the
+// query. It is lower than FuzzyThreshold because it compares a fragment, not
+// a whole name: a fragment is short, and one edit costs a short string more.
+// 0.8 admits one edit from five runes on and two from ten; below five runes
+// no correction is offered at all, which is right — "Pao" is as likely the
+// start of something else as a misspelled "Poa".
+const SuggestCorrectionThreshold = 0.8
+
+// SuggestCorrections returns the prefixes that are near-miss spellings of
+// canon (an already Canonicalize'd query), best first: Similarity at or above
+// SuggestCorrectionThreshold, canon itself excluded (it is no correction),
+// ties broken by the prefix for a deterministic order. At most limit are
+// returned; limit <= 0 means no cap.
+//
+// prefixes are expected to be of canon's rune length, as the repository
+// returns them (output.Repository.SuggestPrefixes); a longer one would be
+// judged on letters the user has not typed yet.
+func SuggestCorrections(canon string, prefixes []string, limit int) []string {
+	type scored struct {
+		prefix string
+		sim    float64
+	}
+	n := utf8.RuneCountInString(canon)
+	var hits []scored
+	for _, p := range prefixes {
+		if p == canon || utf8.RuneCountInString(p) > n {
+			continue
+		}
+		if sim := Similarity(canon, p); sim >= SuggestCorrectionThreshold {
+			hits = append(hits, scored{p, sim})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].sim != hits[j].sim {
+			return hits[i].sim > hits[j].sim
+		}
+		return hits[i].prefix < hits[j].prefix
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	out := make([]string, len(hits))
+	for i, h := range hits {
+		out[i] = h.prefix
+	}
+	return out
 }
 
 // rankOrder assigns the ordinal used by RankOrder/RankSuggestions priority
@@ -83,17 +174,33 @@ func RankOrder(r Rank) int {
 // RankSuggestions returns a new, stably-sorted copy of items ordered by the
 // §B.1 autosuggest priority, highest priority first:
 //
-//  1. PrefixHit true before false
-//  2. InArea true before false
-//  3. Status == StatusAccepted before any other status
-//  4. lower RankOrder first (broader/simpler ranks before finer ones)
-//  5. Score ascending (bm25: lower Score means more relevant — see
+//  1. PrefixHit true before false: the name starts with the query, rather
+//     than only containing a word it prefixes (an epithet-only query)
+//  2. a hit for the query as typed before a near-miss correction of it
+//     (SuggestItem.CorrectedFrom, never a prefix hit either) — an epithet
+//     match is a match, a correction a guess
+//  3. InArea true before false
+//  4. Status == StatusAccepted before any other status
+//  5. lower RankOrder first (broader/simpler ranks before finer ones)
+//  6. Score ascending (bm25: lower Score means more relevant — see
 //     SuggestItem's doc comment on the sign convention)
 //
+// Key 2 is hostus' own between §B.1's first and second step, so §B.1's steps
+// 2-5 are 3-6 here.
+//
 // Items that compare equal on every key above keep their relative input
 // order (sort.SliceStable). RankSuggestions is pure: it does not mutate
-// its input slice.
+// its input slice. It is RankSuggestionsWeighted with no popularity term.
 func RankSuggestions(items []SuggestItem) []SuggestItem {
+	return RankSuggestionsWeighted(items, 0)
+}
+
+// RankSuggestionsWeighted is RankSuggestions with key 6 replaced by
+// Relevance(popularityWeight) ascending: among candidates equal on every §B.1
+// priority, a commonly recorded taxon moves ahead of a rare one of similar
+// string relevance. Keys 1-5 are untouched, so popularity never lifts a
+// synonym over an accepted name or a correction over a hit.
+func RankSuggestionsWeighted(items []SuggestItem, popularityWeight float64) []SuggestItem {
 	out := make([]SuggestItem, len(items))
 	copy(out, items)
 
@@ -103,6 +210,9 @@ func RankSuggestions(items []SuggestItem) []SuggestItem {
 		if a.PrefixHit != b.PrefixHit {
 			return a.PrefixHit
 		}
+		if aTyped, bTyped := a.CorrectedFrom == "", b.CorrectedFrom == ""; aTyped != bTyped {
+			return aTyped
+		}
 		if a.InArea != b.InArea {
 			return a.InArea
 		}
@@ -114,7 +224,7 @@ func RankSuggestions(items []SuggestItem) []SuggestItem {
 		if ao, bo := RankOrder(a.Rank), RankOrder(b.Rank); ao != bo {
 			return ao < bo
 		}
-		return a.Score < b.Score
+		return a.Relevance(popularityWeight) < b.Relevance(popularityWeight)
 	})
 
 	return out
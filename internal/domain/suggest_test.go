@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jobrunner/hostus/internal/domain"
@@ -169,3 +170,65 @@ func TestRankOrder_UnknownRankIsWorstOrder(t *testing.T) {
 		t.Fatalf("RankOrder of unknown rank must exceed RankOrder(FORM), got %d", got)
 	}
 }
+
+// TestSuggestCorrections pins the threshold and the order: one edit in a
+// seven-rune fragment is a correction, a different word is not, the query
+// itself and a longer prefix are never offered, and equal scores fall back to
+// the prefix.
+func TestSuggestCorrections(t *testing.T) {
+	got := domain.SuggestCorrections("festuka", []string{"fertile", "festuka", "festucae", "festuca", "fastuka", "festuko"}, 0)
+	want := []string{"fastuka", "festuca", "festuko"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("SuggestCorrections = %v, want %v", got, want)
+	}
+	if got := domain.SuggestCorrections("festuka", []string{"festuca", "festuko"}, 1); len(got) != 1 {
+		t.Errorf("limit 1 returned %v", got)
+	}
+	if got := domain.SuggestCorrections("pao", []string{"poa"}, 0); len(got) != 0 {
+		t.Errorf("short fragment corrected to %v, want nothing", got)
+	}
+}
+
+// TestRankSuggestions_EpithetHitBeatsCorrection isolates key 2: neither item
+// is a prefix hit, and the correction is in area and accepted — yet the hit
+// for the query as typed must still win.
+func TestRankSuggestions_EpithetHitBeatsCorrection(t *testing.T) {
+	items := []domain.SuggestItem{
+		{ConceptID: "corrected", CorrectedFrom: "festuka", InArea: true, Status: domain.StatusAccepted, Score: 0.1},
+		{ConceptID: "epithet", InArea: false, Status: domain.StatusSynonym, Score: 0.9},
+	}
+	got := domain.RankSuggestions(items)
+	if got[0].ConceptID != "epithet" {
+		t.Fatalf("a hit for the query as typed must outrank a correction: %v", got)
+	}
+}
+
+// TestRankSuggestionsWeighted_PopularityBreaksNearTies pins the popularity
+// term: among items equal on keys 1-5, a commonly recorded concept overtakes
+// a rare one of slightly better string relevance, and weight 0 restores the
+// plain bm25 order.
+func TestRankSuggestionsWeighted_PopularityBreaksNearTies(t *testing.T) {
+	items := []domain.SuggestItem{
+		{ConceptID: "obscure", PrefixHit: true, Status: domain.StatusAccepted, Rank: domain.RankSpecies, Score: -5.2},
+		{ConceptID: "common", PrefixHit: true, Status: domain.StatusAccepted, Rank: domain.RankSpecies, Score: -5.0, Frequency: 1000},
+	}
+	if got := domain.RankSuggestionsWeighted(items, domain.DefaultPopularityWeight); got[0].ConceptID != "common" {
+		t.Errorf("weighted: got %v, want common first", got)
+	}
+	if got := domain.RankSuggestionsWeighted(items, 0); got[0].ConceptID != "obscure" {
+		t.Errorf("weight 0: got %v, want obscure first (plain bm25)", got)
+	}
+}
+
+// TestRankSuggestionsWeighted_PopularityNeverBeatsPriorities pins that the
+// term only replaces key 6: a very common synonym still follows an accepted
+// name no source counts.
+func TestRankSuggestionsWeighted_PopularityNeverBeatsPriorities(t *testing.T) {
+	items := []domain.SuggestItem{
+		{ConceptID: "synonym", PrefixHit: true, Status: domain.StatusSynonym, Score: -5, Frequency: 1_000_000},
+		{ConceptID: "accepted", PrefixHit: true, Status: domain.StatusAccepted, Score: -1},
+	}
+	if got := domain.RankSuggestionsWeighted(items, 10); got[0].ConceptID != "accepted" {
+		t.Errorf("got %v, want the accepted name first", got)
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 // Rank is a taxonomic rank, spelled per the WCVP "taxonrank" column.
@@ -153,8 +154,12 @@ type Name struct {
 	Rank        Rank
 	IPNIID      string
 	PublishedIn string
-	NomStatus   string
-	BasionymID  string
+	// PublishedYear is ParsePublication(PublishedIn).Year, read once at
+	// ingest and stored so a year filter need not parse; 0 when the
+	// citation names no year.
+	PublishedYear int
+	NomStatus     string
+	BasionymID    string
 	// RankVerbatim holds the original source "taxonrank" spelling when Rank
 	// is RankOther — the one case where Rank alone has thrown information
 	// away by collapsing an exotic spelling ("proles", "lusus", ...) into a
@@ -180,6 +185,11 @@ type Concept struct {
 	// tracks its accepted name's Rank, but the two are separate structs/
 	// rows, so this is carried independently rather than assumed equal).
 	RankVerbatim string
+	// Family is the family the source files the concept under (WCVP's
+	// `family` column), or "" when the source names none. It is a label, not
+	// a placement: WCVP has no family concepts for ParentID to reach, so a
+	// checklist grouping by family reads it here.
+	Family string
 }
 
 // Xref is a cross-reference to a name or concept in an external authority
@@ -199,21 +209,70 @@ type ClassificationEntry struct {
 	Rank      Rank
 }
 
+// HigherTaxa names the family and genus a concept belongs to, so a result
+// can be labelled "Festuca ovina (Poaceae)" without reading the whole
+// classification. Either is "" when neither the source nor the concept's
+// lineage names one.
+type HigherTaxa struct {
+	Family string
+	Genus  string
+}
+
+// TaxonNode is one concept met walking the classification DOWNWARD (see
+// output.Repository's Children): its identity, rank and status, and how many
+// direct children it has in turn, so a tree view knows whether a node can be
+// expanded without asking for its children first.
+type TaxonNode struct {
+	ConceptID  string
+	Canonical  string
+	Rank       Rank
+	Status     Status
+	ChildCount int
+}
+
 // Distribution is a single area assignment for a Concept, keyed by the
-// area-coding scheme in use (e.g. WGSRPD level 3).
+// area-coding scheme in use (e.g. WGSRPD level 3). Status is the regional
+// source's verdict (see DistributionStatus).
+//
+// Derived marks an area no source asserted for this concept: it was rolled
+// up from one of the species' infraspecific taxa. A derived row never carries
+// a Status — the verdict belongs to the child's row.
 type Distribution struct {
 	AreaScheme string
 	AreaCode   string
+	Status     DistributionStatus
+	Derived    bool
 }
 
 // Area is the human-readable identity of one distribution area: its scheme
 // (e.g. "wgsrpd_l3"), its code (e.g. "GER") and its name (e.g. "Germany").
 // The name is self-sourced from the backbone's distribution data at ingest so
 // a client can offer "Germany (GER)" instead of a bare WGSRPD code.
+//
+// Parent is the code of the WGSRPD unit one level up ("11" for "GER", "1"
+// for "11"), empty for a level-1 continent and for every area outside the
+// WGSRPD hierarchy. It names a code only; the parent's scheme is implied by
+// the child's (see WGSRPDParentScheme).
 type Area struct {
 	Scheme string
 	Code   string
 	Name   string
+	Parent string
+}
+
+// Level is the WGSRPD level of a's scheme, 1 to 4, or 0 for an area outside
+// the hierarchy (euromed, bayern).
+func (a Area) Level() int {
+	return WGSRPDLevel(a.Scheme)
+}
+
+// AreaAlias maps a code a caller is likelier to know — an ISO 3166 country
+// ("DE", "POL") or subdivision ("DE-BY") — onto the WGSRPD unit codes it
+// stands for. Codes may be of any level; an area filter expands them like a
+// code given directly.
+type AreaAlias struct {
+	Alias string
+	Codes []string
 }
 
 // Canonicalize normalizes a scientific name (or name fragment) into a
@@ -230,15 +289,23 @@ func Canonicalize(name string) string {
 	var b strings.Builder
 	b.Grow(len(joined))
 	for _, r := range joined {
-		if plain, ok := diacriticFold[r]; ok {
-			b.WriteRune(plain)
-			continue
-		}
-		b.WriteRune(r)
+		b.WriteRune(foldRune(r))
 	}
 	return b.String()
 }
 
+// foldRune is Canonicalize's per-rune fold: lower-case, then strip the
+// diacritic. It is factored out so HighlightPrefixes folds a name
+// exactly as Canonicalize folds the query, rune for rune, and can map a match
+// in the folded key back onto the name as written.
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	if plain, ok := diacriticFold[r]; ok {
+		return plain
+	}
+	return r
+}
+
 // diacriticFold maps common Latin letters carrying diacritics (as used in
 // botanical author names and place names) to their base ASCII letter. This
 // is a fixed table rather than a full Unicode-normalization dependency,
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// auctMarkers say "the name as authors have USED it", as opposed to the
+// sense its nomenclatural type fixes: "Festuca ovina auct.", "auct. germ.",
+// "auct. mult.". Whatever descriptor follows the marker ("germ.", "plur.")
+// narrows whose use is meant but names no reference hostus could resolve, so
+// it is read past and dropped.
+var auctMarkers = map[string]bool{
+	"auct.":    true,
+	"auct":     true,
+	"auctt.":   true,
+	"auctorum": true,
+}
+
+// sensuMarkers introduce the reference whose usage is meant: "Festuca ovina
+// sensu Wisskirchen", and the CDM house style "… sec. Wisskirchen".
+var sensuMarkers = map[string]bool{
+	"sensu": true,
+	"sec.":  true,
+}
+
+// nonMarkers introduce an author whose homonym is explicitly NOT meant:
+// "Festuca ovina Hack. non L.", "… auct. non L.".
+var nonMarkers = map[string]bool{
+	"non": true,
+	"nec": true,
+}
+
+// sensuLatoWords are what follows "sensu" when it is the aggregate/narrowing
+// qualifier rather than a citation: "sensu lato" widens (an aggregate, see
+// AggregateBases), "sensu stricto" narrows. Neither names a reference.
+var sensuLatoWords = map[string]bool{
+	"lato":       true,
+	"latiore":    true,
+	"stricto":    true,
+	"strictiore": true,
+	"l.":         true,
+	"lat.":       true,
+	"str.":       true,
+}
+
+// UsageQualifier is what a verbatim says about WHOSE use of a name it
+// means, beyond the name itself — the part canonical matching ignores, and
+// the reason "Festuca ovina auct. non L." otherwise resolves straight onto the
+// Linnaean concept the writer explicitly excluded.
+//
+// Name is the verbatim with the qualifier removed (author included, if one
+// preceded it). Auct is set by "auct." in any position, including "sensu
+// auct."; Sensu is the citation after "sensu"/"sec."; Non is the author after
+// "non"/"nec". Any combination can occur.
+type UsageQualifier struct {
+	Name  string
+	Auct  bool
+	Sensu string
+	Non   string
+}
+
+// Misapplication reports whether the qualifier points away from the
+// nomenclatural sense — "auct." or an explicit "sensu" citation — as opposed
+// to a bare "non", which only excludes one author's homonym and leaves the
+// name's own sense in force.
+func (q UsageQualifier) Misapplication() bool {
+	return q.Auct || q.Sensu != ""
+}
+
+// ParseUsageQualifier reports whether verbatim carries a usage qualifier and,
+// if so, splits it off. It works on the raw verbatim because the ordinary
+// split would treat the lower-case "auct."/"sensu"/"non" as further epithets.
+//
+// The qualifier starts at the first marker token after the genus ("non"/"nec"
+// only after the epithet, so a one-word "Non" can never start one). "sensu
+// lato"/"sensu stricto" are NOT usage qualifiers and are left alone. A
+// qualifier that carries nothing ("Festuca ovina sensu" and nothing after) is
+// not reported either: there is nothing to act on, and the ordinary ladder
+// treats the verbatim exactly as before.
+func ParseUsageQualifier(verbatim string) (UsageQualifier, bool) {
+	fields := strings.Fields(verbatim)
+	start := usageQualifierStart(fields)
+	if start < 0 {
+		return UsageQualifier{}, false
+	}
+
+	q := UsageQualifier{Name: strings.Join(fields[:start], " ")}
+	var sensu, non []string
+	var slot *[]string
+	for _, f := range fields[start:] {
+		t := markerToken(f)
+		// Guarded early-continue rather than a tagless switch — see
+		// AggregateBases.
+		if auctMarkers[t] {
+			q.Auct = true
+			slot = nil
+			continue
+		}
+		if sensuMarkers[t] {
+			slot = &sensu
+			continue
+		}
+		if nonMarkers[t] {
+			slot = &non
+			continue
+		}
+		if slot != nil {
+			*slot = append(*slot, f)
+		}
+	}
+	q.Sensu = strings.Join(sensu, " ")
+	q.Non = strings.Join(non, " ")
+	if !q.Auct && q.Sensu == "" && q.Non == "" {
+		return UsageQualifier{}, false
+	}
+	return q, true
+}
+
+// usageQualifierStart returns the index of the first qualifier token in
+// fields, or -1.
+func usageQualifierStart(fields []string) int {
+	for i := 1; i < len(fields); i++ {
+		t := markerToken(fields[i])
+		if auctMarkers[t] {
+			return i
+		}
+		if sensuMarkers[t] && (i+1 >= len(fields) || !sensuLatoWords[markerToken(fields[i+1])]) {
+			return i
+		}
+		if i >= 2 && nonMarkers[t] {
+			return i
+		}
+	}
+	return -1
+}
+
+// markerToken folds a field for marker lookup: lower-cased, with the comma or
+// semicolon a list of qualifiers leaves behind ("auct., non L.") trimmed.
+func markerToken(f string) string {
+	return strings.TrimRight(strings.ToLower(f), ",;")
+}
+
+// citationStopWords carry no identity in a reference citation: connectives
+// between author names ("Wisskirchen & Haeupler", "Greuter et al.").
+var citationStopWords = map[string]bool{
+	"et":  true,
+	"al":  true,
+	"ex":  true,
+	"in":  true,
+	"and": true,
+	"und": true,
+}
+
+// SecReferencesCiting returns the sec. reference spaces a sensu citation can
+// name: a ref whose ID equals the citation outright, or every ref whose title
+// contains each word of it — "Wisskirchen" and "Wisskirchen & Haeupler 1998"
+// both find "Wisskirchen & Haeupler 1998: Standardliste …". Words are compared
+// Canonicalize'd, split on anything that is not a letter or digit, so
+// punctuation and diacritics never decide.
+//
+// The caller decides what several hits mean; this function does not pick. A
+// citation of only stop words (or nothing) matches nothing.
+func SecReferencesCiting(refs []SecReference, citation string) []SecReference {
+	citation = strings.TrimSpace(citation)
+	for _, r := range refs {
+		if r.ID != "" && r.ID == citation {
+			return []SecReference{r}
+		}
+	}
+	words := citationWords(citation)
+	if len(words) == 0 {
+		return nil
+	}
+	var out []SecReference
+	for _, r := range refs {
+		title := make(map[string]bool)
+		for _, w := range citationWords(r.Title) {
+			title[w] = true
+		}
+		if containsAll(title, words) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func citationWords(s string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(Canonicalize(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !citationStopWords[w] {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func containsAll(set map[string]bool, words []string) bool {
+	for _, w := range words {
+		if !set[w] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/domain"
+)
+
+func TestParseUsageQualifier(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		want   domain.UsageQualifier
+		wantOK bool
+	}{
+		{name: "bare auct.", in: "Pinus abies auct.", want: domain.UsageQualifier{Name: "Pinus abies", Auct: true}, wantOK: true},
+		{
+			// the excluded author is kept: it says which homonym was misapplied.
+			name: "auct. non", in: "Festuca ovina auct. non L.",
+			want: domain.UsageQualifier{Name: "Festuca ovina", Auct: true, Non: "L."}, wantOK: true,
+		},
+		{
+			name: "auct. with a descriptor and a comma", in: "Festuca ovina auct. germ., non L.",
+			want: domain.UsageQualifier{Name: "Festuca ovina", Auct: true, Non: "L."}, wantOK: true,
+		},
+		{
+			name: "sensu citation after an author", in: "Abies alba Mill. sensu Oberdorfer",
+			want: domain.UsageQualifier{Name: "Abies alba Mill.", Sensu: "Oberdorfer"}, wantOK: true,
+		},
+		{
+			name: "CDM sec. spelling", in: "Abies alba sec. Wisskirchen & Haeupler 1998",
+			want: domain.UsageQualifier{Name: "Abies alba", Sensu: "Wisskirchen & Haeupler 1998"}, wantOK: true,
+		},
+		{
+			name: "sensu auct.", in: "Festuca ovina sensu auct.",
+			want: domain.UsageQualifier{Name: "Festuca ovina", Auct: true}, wantOK: true,
+		},
+		{
+			name: "later homonym", in: "Festuca ovina Hack. non L.",
+			want: domain.UsageQualifier{Name: "Festuca ovina Hack.", Non: "L."}, wantOK: true,
+		},
+		{name: "sensu lato is an aggregate, not a usage", in: "Festuca ovina sensu lato", wantOK: false},
+		{name: "sensu str. is a narrowing, not a usage", in: "Festuca ovina sensu str.", wantOK: false},
+		{name: "marker with nothing after it", in: "Festuca ovina sensu", wantOK: false},
+		{name: "non right after the genus is not a marker", in: "Festuca non", wantOK: false},
+		{name: "plain binomial", in: "Festuca ovina L.", wantOK: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := domain.ParseUsageQualifier(tc.in)
+			if ok != tc.wantOK || got != tc.want {
+				t.Errorf("ParseUsageQualifier(%q) = (%+v, %v), want (%+v, %v)", tc.in, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestSecReferencesCiting(t *testing.T) {
+	refs := []domain.SecReference{
+		{ID: "sec:wisskirchen", Title: "Wisskirchen & Haeupler 1998: Standardliste der Farn- und Blütenpflanzen Deutschlands"},
+		{ID: "sec:oberdorfer", Title: "Oberdorfer 2001: Pflanzensoziologische Exkursionsflora"},
+		{ID: "sec:oberdorfer-1990", Title: "Oberdorfer 1990: Pflanzensoziologische Exkursionsflora"},
+	}
+	tests := []struct {
+		citation string
+		want     []string
+	}{
+		{citation: "sec:oberdorfer", want: []string{"sec:oberdorfer"}},
+		{citation: "Wisskirchen", want: []string{"sec:wisskirchen"}},
+		{citation: "Wisskirchen et Häupler, 1998", want: nil}, // "Häupler" folds to "haupler", not "haeupler"
+		{citation: "wisskirchen & haeupler 1998", want: []string{"sec:wisskirchen"}},
+		{citation: "Oberdorfer", want: []string{"sec:oberdorfer", "sec:oberdorfer-1990"}},
+		{citation: "Oberdorfer 1990", want: []string{"sec:oberdorfer-1990"}},
+		{citation: "Hegi", want: nil},
+		{citation: "et al.", want: nil},
+	}
+	for _, tc := range tests {
+		var got []string
+		for _, r := range domain.SecReferencesCiting(refs, tc.citation) {
+			got = append(got, r.ID)
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("SecReferencesCiting(%q) = %v, want %v", tc.citation, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("SecReferencesCiting(%q) = %v, want %v", tc.citation, got, tc.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Package explain is the one wire shape of application.MatchTrace, shared by
+// the HTTP adapter (POST /v1/match?explain=true) and the MCP adapter
+// (explain_match), so a curator reading both sees one vocabulary and the two
+// cannot drift apart field by field.
+package explain
+
+import "github.com/jobrunner/hostus/internal/application"
+
+// Trace is application.MatchTrace on the wire. Path, lookups and filter are
+// always present so a curator can tell "not tried" from "tried and found
+// nothing". Fuzzy keeps the same distinction MatchTrace.Fuzzy draws: absent
+// when fuzzy matching was never reached, [] when it was reached but the pool
+// came back empty.
+type Trace struct {
+	Verbatim  string   `json:"verbatim"`
+	Canonical string   `json:"canonical"`
+	Author    string   `json:"author,omitempty"`
+	Path      string   `json:"path"`
+	Filter    Filter   `json:"filter"`
+	Lookups   []Lookup `json:"lookups"`
+	Fuzzy     *[]Fuzzy `json:"fuzzy,omitempty"`
+}
+
+// Filter echoes the resolution filter in POST /v1/match's own field names.
+type Filter struct {
+	EntryBackbone   string `json:"entry_backbone,omitempty"`
+	EntrySec        string `json:"entry_sec,omitempty"`
+	Area            string `json:"area,omitempty"`
+	PublishedBefore int    `json:"published_before,omitempty"`
+}
+
+// Lookup is one repository lookup (application.MatchLookup).
+type Lookup struct {
+	Key         string      `json:"key"`
+	Rule        string      `json:"rule"`
+	Candidates  []Candidate `json:"candidates"`
+	FilteredOut int         `json:"filtered_out"`
+	Decision    string      `json:"decision"`
+}
+
+// Candidate is one lookup candidate as the tie-break saw it.
+type Candidate struct {
+	ConceptID  string `json:"concept_id"`
+	Backbone   string `json:"backbone"`
+	Name       string `json:"name"`
+	Authorship string `json:"authorship,omitempty"`
+	Role       string `json:"role"`
+	Homotypic  *bool  `json:"homotypic,omitempty"`
+}
+
+// Fuzzy is one scored fuzzy-pool candidate.
+type Fuzzy struct {
+	ConceptID  string  `json:"concept_id"`
+	Name       string  `json:"name"`
+	Similarity float64 `json:"similarity"`
+}
+
+// FromTrace renders tr. Lookups and each lookup's candidates are always
+// non-nil arrays, never null, so a client can iterate them without a guard.
+func FromTrace(tr *application.MatchTrace) Trace {
+	out := Trace{
+		Verbatim:  tr.Verbatim,
+		Canonical: tr.Canonical,
+		Author:    tr.Author,
+		Path:      string(tr.Path),
+		Filter:    Filter{EntryBackbone: tr.Filter.Backbone, EntrySec: tr.Filter.Sec, Area: tr.Filter.Area, PublishedBefore: tr.Filter.PublishedBefore},
+		Lookups:   make([]Lookup, 0, len(tr.Lookups)),
+	}
+	for _, l := range tr.Lookups {
+		lookup := Lookup{
+			Key:         l.Key,
+			Rule:        string(l.Rule),
+			Candidates:  make([]Candidate, 0, len(l.Candidates)),
+			FilteredOut: l.FilteredOut,
+			Decision:    string(l.Decision),
+		}
+		for _, c := range l.Candidates {
+			lookup.Candidates = append(lookup.Candidates, Candidate{
+				ConceptID:  c.ConceptID,
+				Backbone:   c.BackboneID,
+				Name:       c.Name,
+				Authorship: c.Authorship,
+				Role:       c.Role,
+				Homotypic:  c.Homotypic,
+			})
+		}
+		out.Lookups = append(out.Lookups, lookup)
+	}
+	if tr.Fuzzy != nil {
+		fuzzy := make([]Fuzzy, 0, len(tr.Fuzzy))
+		for _, f := range tr.Fuzzy {
+			fuzzy = append(fuzzy, Fuzzy{ConceptID: f.ConceptID, Name: f.Name, Similarity: f.Similarity})
+		}
+		out.Fuzzy = &fuzzy
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package explain_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jobrunner/hostus/internal/application"
+	"github.com/jobrunner/hostus/internal/explain"
+)
+
+// TestFromTrace_FuzzyKeepsNotReachedApartFromEmpty pins the distinction
+// application.MatchTrace.Fuzzy draws on the wire: a nil pool (fuzzy never
+// reached) omits the key, an empty one (reached, nothing in the pool) is
+// emitted as [].
+func TestFromTrace_FuzzyKeepsNotReachedApartFromEmpty(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fuzzy []application.FuzzyScore
+		want  string
+	}{
+		{"never reached", nil, ""},
+		{"reached, empty", []application.FuzzyScore{}, `"fuzzy":[]`},
+		{"reached", []application.FuzzyScore{{ConceptID: "c", Name: "Festuca", Similarity: 0.9}}, `"fuzzy":[{"concept_id":"c","name":"Festuca","similarity":0.9}]`},
+	} {
+		body, err := json.Marshal(explain.FromTrace(&application.MatchTrace{Fuzzy: tc.fuzzy}))
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", tc.name, err)
+		}
+		got := string(body)
+		if tc.want == "" {
+			if strings.Contains(got, `"fuzzy"`) {
+				t.Errorf("%s: %s, want no fuzzy key", tc.name, got)
+			}
+			continue
+		}
+		if !strings.Contains(got, tc.want) {
+			t.Errorf("%s: %s, want it to contain %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestFromTrace_ArraysNeverNull pins that lookups and each lookup's
+// candidates are arrays even when empty, so a client iterates without a
+// guard.
+func TestFromTrace_ArraysNeverNull(t *testing.T) {
+	tr := &application.MatchTrace{Lookups: []application.MatchLookup{{Key: "festuca", Rule: application.LookupExact}}}
+	body, err := json.Marshal(explain.FromTrace(tr))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if got := string(body); !strings.Contains(got, `"candidates":[]`) || strings.Contains(got, "null") {
+		t.Errorf("trace = %s, want empty arrays, never null", got)
+	}
+	body, err = json.Marshal(explain.FromTrace(&application.MatchTrace{}))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if got := string(body); !strings.Contains(got, `"lookups":[]`) {
+		t.Errorf("trace = %s, want lookups as []", got)
+	}
+}
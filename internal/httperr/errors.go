@@ -8,14 +8,15 @@ import (
 type Code string
 
 const (
-	InvalidQuery       Code = "INVALID_QUERY"
-	RateLimitExceeded  Code = "RATE_LIMIT_EXCEEDED"
-	UpstreamOverloaded Code = "UPSTREAM_OVERLOADED"
-	GBIFTimeout        Code = "GBIF_TIMEOUT"
-	GBIFUnavailable    Code = "GBIF_UNAVAILABLE"
-	Internal           Code = "INTERNAL_ERROR"
-	NotFound           Code = "NOT_FOUND"
-	Unresolvable       Code = "UNRESOLVABLE"
+	InvalidQuery        Code = "INVALID_QUERY"
+	RateLimitExceeded   Code = "RATE_LIMIT_EXCEEDED"
+	UpstreamOverloaded  Code = "UPSTREAM_OVERLOADED"
+	GBIFTimeout         Code = "GBIF_TIMEOUT"
+	GBIFUnavailable     Code = "GBIF_UNAVAILABLE"
+	Internal            Code = "INTERNAL_ERROR"
+	NotFound            Code = "NOT_FOUND"
+	Unresolvable        Code = "UNRESOLVABLE"
+	GeometryUnavailable Code = "GEOMETRY_UNAVAILABLE"
 )
 
 type Response struct {
@@ -50,6 +51,10 @@ func UpstreamOverloadedError(w http.ResponseWriter) {
 	Write(w, http.StatusServiceUnavailable, UpstreamOverloaded, "Upstream service is overloaded")
 }
 
+func GeometryUnavailableError(w http.ResponseWriter) {
+	Write(w, http.StatusServiceUnavailable, GeometryUnavailable, "Area geometries are not available on this server")
+}
+
 func GBIFTimeoutError(w http.ResponseWriter) {
 	Write(w, http.StatusGatewayTimeout, GBIFTimeout, "GBIF request timed out")
 }
@@ -52,6 +52,12 @@ func (mrw *metricsResponseWriter) WriteHeader(status int) {
 	mrw.ResponseWriter.WriteHeader(status)
 }
 
+// Unwrap exposes the wrapped writer to http.ResponseController, so a
+// streaming handler can still flush through this middleware.
+func (mrw *metricsResponseWriter) Unwrap() http.ResponseWriter {
+	return mrw.ResponseWriter
+}
+
 func Metrics(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -0,0 +1,16 @@
+package output
+
+import "github.com/jobrunner/hostus/internal/domain"
+
+// AreaLocator is the driven port that turns a position into the WGSRPD areas
+// containing it. It is separate from Repository on purpose: the geometries
+// are static reference data embedded in the binary, not part of an ingested
+// index, so a server without a database can still locate, and a database
+// never has to carry polygons.
+type AreaLocator interface {
+	// Locate returns the areas containing p, one per WGSRPD level, broadest
+	// first: level 1 (domain.AreaSchemeWGSRPDL1), level 2, level 3. A level
+	// the geometry data carries no code for is left out. A position in no
+	// area — open sea — returns nil.
+	Locate(p domain.Point) []domain.Area
+}
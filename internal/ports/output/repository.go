@@ -16,6 +16,13 @@ type Repository interface {
 	// see SynonymName), its cross-references, and its distribution. Returns
 	// domain.ErrNotFound (wrapped) if id is unknown.
 	Concept(ctx context.Context, id string) (*domain.Concept, []SynonymName, []domain.Xref, []domain.Distribution, error)
+	// Concepts resolves many concepts at once into ConceptDocuments: each
+	// with what Concept returns plus its sec. reference, and the parts
+	// include asks for. It runs a fixed number of queries however many ids
+	// it is given — one per part, never one per concept. Documents follow
+	// ids' order, a repeated id once; an unknown id is simply absent, so the
+	// caller reads "not found" off the difference.
+	Concepts(ctx context.Context, ids []string, include ConceptInclude) ([]ConceptDocument, error)
 	// SynonymCandidates returns conceptID's synonyms in the shape the UC5
 	// relevance model consumes (domain.SynonymCandidate): the name itself
 	// plus the three fields the decision needs and Concept()'s SynonymName
@@ -42,16 +49,69 @@ type Repository interface {
 	// with no synonyms returns an empty, non-error slice — callers must not
 	// conflate the two.
 	SynonymCandidates(ctx context.Context, conceptID string) ([]domain.SynonymCandidate, error)
-	// Classification walks conceptID's taxon_concept.parent_id chain
-	// upward, bounded to a small fixed depth (see the sqlite adapter's
-	// maxClassificationDepth) so a cyclic or corrupt parent_id chain can
-	// never hang. Returns the ancestor chain ROOT-FIRST — index 0 is the
+	// NameRecord resolves one name by id together with its nomenclatural
+	// neighbourhood: the basionym_id chain above it, the homotypic group
+	// rooted at that chain's end, and every concept_name link using it (see
+	// NameRecord). Both walks are bounded, so a cyclic basionym_id chain
+	// ends the walk instead of hanging it. Returns domain.ErrNotFound
+	// (wrapped) if nameID is unknown.
+	NameRecord(ctx context.Context, nameID string) (NameRecord, error)
+	// NameUsages answers "where is this name used": it resolves key to the
+	// name with that id if there is one, otherwise to every name whose
+	// folded canonical (domain.Canonicalize) equals key's — several, when
+	// authorships or backbones differ — and returns those names by id with
+	// every concept_name link of any of them, ordered like
+	// NameRecord.Usages. Returns domain.ErrNotFound (wrapped) if key
+	// resolves to no name; a name no concept uses returns it with no usages.
+	NameUsages(ctx context.Context, key string) (names []domain.Name, usages []NameUsage, err error)
+	// TreatmentLinks finds the concepts that treat the same taxon as
+	// conceptID in another backbone or sec. reference space, one hop out:
+	// those sharing an IPNI identity with it (TreatmentViaXref), those whose
+	// accepted name folds to the same canonical (TreatmentViaName), and its
+	// congruent concept_relation partners in either stored direction
+	// (TreatmentViaCongruent). Each linked concept is listed once, with every
+	// link that found it, ordered by concept id; conceptID itself never is.
+	// Returns domain.ErrNotFound (wrapped) if conceptID is unknown; a concept
+	// nothing links to returns an empty, non-error slice.
+	TreatmentLinks(ctx context.Context, conceptID string) ([]TreatmentLink, error)
+	// Classification reads conceptID's ancestors along
+	// taxon_concept.parent_id from the materialised concept_lineage
+	// (written by IngestTx.Finalize and BuildLineageClosure), bounded to a
+	// small fixed depth (see the sqlite adapter's maxClassificationDepth) so
+	// a cyclic or corrupt parent_id chain never runs away; a cycle lists each
+	// ancestor once. Returns the ancestor chain ROOT-FIRST — index 0 is the
 	// topmost ancestor reached, and the last element is conceptID's
 	// immediate parent; conceptID itself is never included. A concept with
-	// no parent_id (or one whose chain terminates within the depth bound)
-	// returns an empty, non-error slice. Returns domain.ErrNotFound
-	// (wrapped) if conceptID is unknown.
+	// no parent_id returns an empty, non-error slice, as does every concept
+	// of a database whose lineage was never built. Returns
+	// domain.ErrNotFound (wrapped) if conceptID is unknown.
 	Classification(ctx context.Context, conceptID string) ([]domain.ClassificationEntry, error)
+	// HigherTaxa reports, for each of conceptIDs, the family and genus it
+	// belongs to: the family the source files it under (Concept.Family),
+	// else its nearest FAMILY concept in concept_lineage, and its nearest
+	// GENUS concept — the concept itself counts, so a genus is its own
+	// genus. One query for the whole list; an unknown id is absent from the
+	// result, and an empty list returns an empty map.
+	HigherTaxa(ctx context.Context, conceptIDs []string) (map[string]domain.HigherTaxa, error)
+	// Children lists conceptID's direct children in the classification —
+	// the concepts whose parent_id it is, read from the materialised
+	// concept_lineage (BuildLineageClosure) — ordered by canonical name, then
+	// id, and paged by opts.Limit/Offset. total is the number of children
+	// matching opts before paging. Returns domain.ErrNotFound (wrapped) if
+	// conceptID is unknown; a known leaf returns an empty, non-error page.
+	Children(ctx context.Context, conceptID string, opts TreeOpts) (nodes []domain.TaxonNode, total int, err error)
+	// DescendantCounts counts conceptID's descendants at every depth (not
+	// conceptID itself) per rank, filtered by opts.Ranks and opts.Area like
+	// Children; Limit and Offset are ignored. A rank with no descendants is
+	// absent. Returns domain.ErrNotFound (wrapped) if conceptID is unknown.
+	DescendantCounts(ctx context.Context, conceptID string, opts TreeOpts) (map[domain.Rank]int, error)
+	// Checklist lists the ACCEPTED concepts occurring in opts.Area (read
+	// from distribution_effective, so derived and name-fallback presence
+	// counts), ordered by ChecklistKey — family, with the family-less last,
+	// then canonical name, then id — and starting after opts.After. It
+	// returns at most opts.Limit entries; the caller pages by passing the
+	// last entry's key back as After.
+	Checklist(ctx context.Context, opts ChecklistOpts) ([]domain.ChecklistEntry, error)
 	// ConceptByXref resolves a taxon_concept via a cross-reference to an
 	// external authority (e.g. authority="powo", extID="396681-1").
 	ConceptByXref(ctx context.Context, authority, extID string) (*domain.Concept, error)
@@ -74,10 +134,20 @@ type Repository interface {
 	// ordered by id.
 	SecReferences(ctx context.Context) ([]domain.SecReference, error)
 	// Areas lists every distribution area that actually carries data (a
-	// DISTINCT area_scheme/area_code from the distribution table), each with
-	// its human-readable name where one was ingested (empty otherwise),
-	// ordered by (scheme, code). Backs GET /v1/areas.
+	// DISTINCT area_scheme/area_code from the distribution table) plus every
+	// WGSRPD hierarchy unit above one of them, each with its human-readable
+	// name where one was ingested (empty otherwise) and its Parent code,
+	// ordered by (scheme, code). Every Parent named is itself in the result.
+	// Backs GET /v1/areas.
 	Areas(ctx context.Context) ([]domain.Area, error)
+	// AreaPresence reports, for each of conceptIDs, whether its effective
+	// distribution (the precomputed distribution_effective closure: own
+	// areas, or its WCVP name twin's for a concept with none) reaches area,
+	// which is resolved exactly like SuggestOpts.Area. A concept with no
+	// effective distribution at all is absent from the result — "no range
+	// known" — so a caller can tell it from "known range, not in the area".
+	// An empty area or id list returns an empty map.
+	AreaPresence(ctx context.Context, area string, conceptIDs []string) (map[string]AreaPresence, error)
 	// SecReferenceByID resolves one sec. reference space by its id.
 	// Returns domain.ErrNotFound (wrapped) if the id is unknown — which is
 	// what lets /v1/translate tell a MISTYPED target space (404) apart from
@@ -104,6 +174,20 @@ type Repository interface {
 	// concept with no relation into targetSec returns a populated Source
 	// and an empty Edges slice — callers must not conflate the two.
 	ConceptRelationsInSec(ctx context.Context, conceptID, targetSec string) (ConceptRelations, error)
+	// UsageTargets returns the name-usage edges leaving any of fromIDs:
+	// every stored misapplication (IngestTx.AddMisapplication) and every
+	// pro-parte concept_relation row, each with the concept it points to.
+	// Only the stated direction is followed — neither relation has an
+	// inverse (domain.Relation.Inverse). Rows are ordered by (from, relation,
+	// to) so a caller's tie report is stable; an id with no such edge is
+	// simply absent.
+	UsageTargets(ctx context.Context, fromIDs []string) ([]UsageTarget, error)
+	// MatchHybridParents returns every concept whose stored hybrid parents
+	// (IngestTx.AddHybridParent) are exactly parents — compared folded
+	// (domain.Canonicalize), in any order, none missing and none extra — as
+	// accepted-role candidates carrying the concept's accepted name, ordered
+	// by concept id.
+	MatchHybridParents(ctx context.Context, parents []string) ([]MatchCandidate, error)
 	// MatchExact returns every name (accepted or synonym) whose canonical
 	// form equals canon, leaving classification (exact vs. exact_author,
 	// etc.) to the application layer.
@@ -134,6 +218,11 @@ type Repository interface {
 	// resolves CDM concepts' in_area name fallback against WCVP twins, which
 	// must already be present.
 	BuildDistributionClosure(ctx context.Context) error
+	// BuildLineageClosure (re)builds the derived concept_lineage table — every
+	// concept's ancestors along parent_id, materialised. Call once after ALL
+	// backbones are ingested, like BuildDistributionClosure; SuggestOpts.Within
+	// reads it.
+	BuildLineageClosure(ctx context.Context) error
 
 	// Traits returns every domain.TraitSet hostus holds for conceptID,
 	// grouped PER VOCABULARY — TraitSets are never merged across
@@ -178,13 +267,24 @@ type Repository interface {
 	// caller of candidates that would otherwise have made the cut; the
 	// returned slice's length is therefore not bounded by opts.Limit.
 	//
-	// q is matched by its domain.Canonicalize'd form as a left-anchored
-	// FTS5 prefix query; a canonicalized q shorter than two runes
-	// (including empty) returns an empty, non-error result — too short a
-	// prefix is both a meaningless autosuggest signal and a pathologically
-	// broad FTS5 MATCH. opts.Area == "" means "no area filter": InArea is
+	// q is matched by its domain.Canonicalize'd form, each word as a prefix
+	// of some word of the name ("fe ov", "F. ov", "ovina"); an item's
+	// PrefixHit says whether the name STARTS with q's first word. A q whose
+	// words are shorter than two runes together (including empty) returns an
+	// empty, non-error result — too short a prefix is both a meaningless
+	// autosuggest signal and a pathologically broad FTS5 MATCH. opts.Area == "" means "no area filter": InArea is
 	// false on every returned item (an unknown area cannot be "in").
 	Suggest(ctx context.Context, q string, opts SuggestOpts) ([]domain.SuggestItem, error)
+	// SuggestPrefixes returns up to limit distinct name prefixes of canon's
+	// rune length (an already domain.Canonicalize'd query) that are
+	// CHEAP-TO-FIND candidates for correcting it, for the application layer to
+	// score with domain.SuggestCorrections — it does not itself compute or
+	// filter by similarity. It is MatchFuzzyCandidates' counterpart for a
+	// fragment: the same first-letter prefilter (see the sqlite adapter), but
+	// over prefixes rather than whole names, since "Festuka" is the start of
+	// "Festuca ovina", not a near-miss of the whole name. A canon shorter than
+	// two runes returns nothing. limit <= 0 uses the adapter's default cap.
+	SuggestPrefixes(ctx context.Context, canon string, limit int) ([]string, error)
 
 	// BeginIngest starts an ingest transaction for the given backbone
 	// version. Callers must Commit or Rollback the returned IngestTx.
@@ -204,10 +304,55 @@ type Repository interface {
 	BeginTraitIngest(ctx context.Context) (IngestTx, error)
 }
 
+// TreeOpts narrows a downward classification walk (Repository.Children,
+// Repository.DescendantCounts).
+type TreeOpts struct {
+	// Ranks restricts the result to concepts of these ranks; empty means
+	// every rank.
+	Ranks []domain.Rank
+	// Area keeps only concepts with positive distribution evidence in the
+	// area for themselves OR any of their descendants — a genus is in an
+	// area when one of its species is. Resolved exactly like
+	// SuggestOpts.Area; empty means no area filter.
+	Area string
+	// Limit and Offset page Children's result. Limit <= 0 means no limit.
+	Limit  int
+	Offset int
+}
+
+// ChecklistKey is a position in Repository.Checklist's order: the entry it
+// names and every entry before it are skipped. The zero key starts at the
+// beginning.
+type ChecklistKey struct {
+	Family    string
+	Canonical string
+	ConceptID string
+}
+
+// ChecklistOpts configures Repository.Checklist.
+type ChecklistOpts struct {
+	// Area is resolved exactly like SuggestOpts.Area. It is required: an
+	// empty Area returns no entries.
+	Area string
+	// Ranks restricts the checklist to these ranks; empty means every rank.
+	Ranks []domain.Rank
+	// Establishment keeps only entries with this
+	// domain.ChecklistEntry.Establishment; empty means no filter.
+	Establishment domain.DistributionStatus
+	// Backbone restricts the checklist to one backbone's concepts; empty
+	// means every backbone.
+	Backbone string
+	After    ChecklistKey
+	// Limit caps the entries returned; <= 0 means no cap.
+	Limit int
+}
+
 // SuggestOpts configures Repository.Suggest.
 type SuggestOpts struct {
-	// Area is a WGSRPD level-3 area code (e.g. "GER"), or one of a small
-	// set of documented convenience aliases (e.g. "DE"); see
+	// Area is a WGSRPD area code of any level — level 3 (e.g. "GER"), a
+	// level-1/2 unit expanded to the level-3 areas it contains (e.g. "11"
+	// Middle Europe), a level-4 unit narrowed to its level-3 area — or an
+	// ingested ISO 3166 alias (e.g. "DE", "POL", "DE-BY"); see
 	// internal/adapters/sqlite's areaCodes. Empty means no area filter.
 	Area string
 	// Ranks restricts results to the given domain.Rank values. Empty means
@@ -223,12 +368,31 @@ type SuggestOpts struct {
 	// since one name can occur once per CDM sec. reference and crowd the
 	// single WCVP concept out of the page.
 	Backbone string
+	// Within restricts results to a concept and its descendants (e.g. the
+	// Poaceae family concept), read from the materialised concept_lineage
+	// rather than walked per row. Empty means no restriction. Applied ahead
+	// of the limit, like Backbone.
+	Within string
+	// PublishedBefore, when > 0, keeps only concepts with at least one name
+	// (accepted or synonym) published before that year or of unknown year
+	// (name.published_year 0): a concept is dropped only when every name it
+	// carries is known to be younger. 0 means no filter. Applied ahead of
+	// the limit, like Backbone.
+	PublishedBefore int
 	// Limit is the caller's target result count; Suggest may return more
 	// than Limit candidates (see the Suggest doc comment's fetch-budget
 	// note). A value <= 0 uses the adapter's default budget.
 	Limit int
 }
 
+// AreaPresence is one concept's entry in Repository.AreaPresence' result. A
+// returned entry always has a known range; InArea says whether any of it lies
+// in the requested area. InArea false is therefore "known range elsewhere",
+// never "no data".
+type AreaPresence struct {
+	InArea bool
+}
+
 // MatchCandidate is one row returned by Repository.MatchExact: a concept
 // together with the specific name that matched and the role that name
 // plays for that concept.
@@ -256,6 +420,111 @@ type ConceptRelations struct {
 	Edges  []ConceptRelationEdge
 }
 
+// ConceptInclude selects the optional parts of a Repository.Concepts
+// document. The zero value reads Concept's parts and the sec. reference
+// only.
+type ConceptInclude struct {
+	Classification    bool
+	Traits            bool
+	SynonymCandidates bool
+	NameSpaceEntries  bool
+}
+
+// ConceptDocument is one concept as Repository.Concepts returns it. The
+// first six fields are always read, Synonyms, Xrefs and Distribution
+// ordered as Concept orders them; Sec is the concept's sec. reference row,
+// zero for a concept without one or whose id has no sec_reference row;
+// HybridParents is the nothotaxon's parents in formula order, each resolved
+// to the one concept of the hybrid's backbone holding that name (accepted or
+// synonym), or to none when no or several concepts do — nil for a concept
+// without a stored formula. The
+// remaining fields are read only when ConceptInclude asks for them, ordered
+// as Classification, Traits (all vocabularies), SynonymCandidates and
+// NameSpaceEntries (all spaces) order theirs. An included part the concept
+// has nothing of is empty and non-nil; a part not asked for stays nil.
+type ConceptDocument struct {
+	Concept           domain.Concept
+	Sec               domain.SecReference
+	Synonyms          []SynonymName
+	Xrefs             []domain.Xref
+	Distribution      []domain.Distribution
+	HybridParents     []domain.HybridParent
+	Classification    []domain.ClassificationEntry
+	Traits            []domain.TraitSet
+	SynonymCandidates []domain.SynonymCandidate
+	NameSpaceEntries  []domain.NameSpaceEntry
+}
+
+// TreatmentVia names one way Repository.TreatmentLinks links two concepts.
+type TreatmentVia string
+
+const (
+	// TreatmentViaXref: the two concepts share an IPNI identity — the IPNI
+	// id of a concept's accepted name, or the ext_id of one of its powo or
+	// ipni xrefs (POWO mints its taxon ids in IPNI's id space). A literal
+	// shared xref row cannot exist: xref's key is (authority, ext_id), so
+	// each external record names one concept.
+	TreatmentViaXref TreatmentVia = "xref"
+	// TreatmentViaName: the accepted names fold to the same canonical
+	// (domain.Canonicalize), authorship disregarded.
+	TreatmentViaName TreatmentVia = "name"
+	// TreatmentViaCongruent: a congruent concept_relation joins the two.
+	TreatmentViaCongruent TreatmentVia = "congruent"
+)
+
+// TreatmentLink is one concept Repository.TreatmentLinks found, with every
+// link that found it in the order xref, name, congruent.
+type TreatmentLink struct {
+	ConceptID string
+	Via       []TreatmentVia
+}
+
+// NameRecord is Repository.NameRecord's result.
+type NameRecord struct {
+	Name domain.Name
+	// BasionymChain follows basionym_id upward: index 0 is Name's basionym,
+	// the last element the oldest name reached. Empty when Name has no
+	// basionym_id.
+	BasionymChain []domain.Name
+	// HomotypicGroup is every name reaching the chain's root (Name itself
+	// when the chain is empty) through basionym_id, the root included: the
+	// basionym and all its recombinations. Root first, then by name id. It
+	// is computed from basionym_id alone, so it holds for names no concept
+	// links as homotypic.
+	HomotypicGroup []domain.Name
+	// Usages is every concept_name row of Name, ordered by backbone, sec.
+	// reference id, then concept id.
+	Usages []NameUsage
+}
+
+// NameUsage is one concept that uses the name NameID, in Role "accepted"
+// or "synonym". SecReference is the concept's sec. reference space, zero
+// for a backbone without one; its Title is empty when the id has no
+// sec_reference row. Homotypic is the link's concept_name.homotypic, nil
+// when unknown as for SynonymName; Accepted is the concept's accepted
+// name, which is NameID itself for an accepted usage.
+type NameUsage struct {
+	NameID        string
+	ConceptID     string
+	BackboneID    string
+	SecReference  domain.SecReference
+	ConceptStatus domain.Status
+	Role          string
+	Homotypic     *bool
+	Accepted      domain.Name
+}
+
+// UsageTarget is one edge Repository.UsageTargets returns: the name of
+// FromID, as used by some author, means ToID (Relation misapplied), or
+// applies to ToID only in part (Relation pro_parte). ToName is ToID's
+// accepted canonical, for a reviewer reading a tie.
+type UsageTarget struct {
+	FromID   string
+	ToID     string
+	ToName   string
+	Relation domain.Relation
+}
+
 // ConceptRelationEdge is one stored concept_relation row seen from one of
 // its two ends (the "source" end a Repository.ConceptRelationsInSec query
 // started at).
@@ -304,11 +573,22 @@ type IngestTx interface {
 	// xref-source ingest must name its source, since that attribution is
 	// what ExportBundle's redistribution gate joins against.
 	AddXref(conceptID string, x domain.Xref, source string) error
-	AddDistribution(conceptID string, d domain.Distribution) error
+	// AddDistribution writes one distribution row for conceptID, attributed
+	// to the distribution_source id given by source — "" for the ranges a
+	// backbone ingest carries itself, exactly like AddXref's source. d.Status
+	// "" stores no verdict; a domain.DistributionAbsent row must never be
+	// written (see application.IngestDistributions).
+	AddDistribution(conceptID string, d domain.Distribution, source string) error
 	// UpsertArea records one (scheme, code) area's human-readable name, keyed
-	// by (scheme, code) — first non-empty name wins (INSERT OR IGNORE), so it
-	// is safe to call once per distinct area. Backs Repository.Areas.
+	// by (scheme, code) — first non-empty name wins, so it is safe to call
+	// once per distinct area. A non-empty a.Parent is recorded even when the
+	// area already exists (the WGSRPD hierarchy links areas a backbone
+	// named first). Backs Repository.Areas and the area filter's roll-up.
 	UpsertArea(a domain.Area) error
+	// AddAreaAlias records which WGSRPD codes a.Alias stands for in an area
+	// filter, replacing whatever an earlier ingest recorded for the same
+	// alias. Backs the alias step of the area filter (SuggestOpts.Area).
+	AddAreaAlias(a domain.AreaAlias) error
 	// AddTraitValue writes one trait_value row for conceptID. A nil
 	// tv.NicheWidth/tv.NSystems must be persisted as SQL NULL, not as a
 	// 0/0.0 literal — see domain.TraitValue's doc comment on why nil and
@@ -328,6 +608,19 @@ type IngestTx interface {
 	// IT; the inverse row is never synthesized (domain.Relation.Inverse
 	// exists for query-time traversal instead).
 	AddConceptRelation(fromID, toID string, rel domain.Relation, source string) error
+	// AddMisapplication writes one misapplied-name assertion: the name of
+	// fromID was wrongly used for what toID circumscribes. It is kept apart
+	// from AddConceptRelation on purpose — a misapplication is a statement
+	// about name usage, not about circumscriptions (see
+	// domain.Relation.IsConceptRelation) — and is read back only by
+	// Repository.UsageTargets. Same FK contract as AddConceptRelation.
+	AddMisapplication(fromID, toID, source string) error
+	// AddHybridParent writes one parent of the nothotaxon conceptID as its
+	// hybrid formula names it (domain.ParseHybridFormula), position its
+	// 0-based place in the formula. The parent is stored by canonical, not
+	// as a concept: Repository.Concepts resolves it on read. Re-adding a
+	// position replaces it.
+	AddHybridParent(conceptID string, position int, canonical string) error
 	// UpsertXrefSource records one xref-source provenance row (id, version,
 	// license, manifest_sha, redistribution), which AddXref's source
 	// attribution references and ExportBundle's redistribution gate reads.
@@ -338,6 +631,17 @@ type IngestTx interface {
 	// it — FloraVeg's redistribution is "unknown", so a bundle carrying its
 	// entries is refused unless --force-include-restricted is set.
 	UpsertNameSpace(meta domain.NameSpaceMeta) error
+	// UpsertDistributionSource records one regional distribution source's
+	// provenance row, which AddDistribution's source attribution references
+	// and ExportBundle's redistribution gate reads.
+	UpsertDistributionSource(meta domain.DistributionSourceMeta) error
+	// UpsertFrequencySource records one taxon-frequency source's provenance
+	// row, which SetFrequency's source references and ExportBundle's
+	// redistribution gate reads.
+	UpsertFrequencySource(meta domain.FrequencySourceMeta) error
+	// SetFrequency records source's count for conceptID (the source's total
+	// over every row resolved to that concept), replacing an earlier one.
+	SetFrequency(conceptID, source string, count int64) error
 	// AddNameSpaceEntry attaches one name-space spelling to conceptID. Both
 	// e.Space and conceptID are foreign keys, so the caller must have
 	// upserted the space and resolved the concept first — see
@@ -345,7 +649,9 @@ type IngestTx interface {
 	AddNameSpaceEntry(conceptID string, e domain.NameSpaceEntry) error
 	// Finalize (re)builds the FTS5 autosuggest index (fts_name/fts_name_map)
 	// for every name this transaction has linked to a concept (both the
-	// accepted name and its synonyms), so Suggest can find them. Callers
+	// accepted name and its synonyms), so Suggest can find them, and the
+	// concept_lineage rows of this backbone's concepts, so Classification
+	// and HigherTaxa see its hierarchy. Callers
 	// must call Finalize after all UpsertName/UpsertConcept/LinkName calls
 	// for this ingest and before Commit — it is not implicit in Commit,
 	// since it needs to see the transaction's own uncommitted writes.
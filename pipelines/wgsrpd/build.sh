@@ -0,0 +1,45 @@
+#!/usr/bin/env bash
+# TDWG WGSRPD level-3 geometries -> simplified GeoJSON embedded in hostus.
+#
+# Source: https://github.com/tdwg/wgsrpd, file geojson/level3.geojson (the
+# level-3 "botanical countries" with LEVEL3_COD/LEVEL3_NAM/LEVEL2_COD/
+# LEVEL1_COD properties). The repository has no release tags, so the pin is a
+# commit: WGSRPD_REF is REQUIRED and must be a full commit SHA — never a
+# branch, never "latest". Record it in docs/research/quellenregister.md
+# together with the printed sha256 when the asset is regenerated.
+#
+# Unlike the other pipelines the output is not a gitignored CSV but the
+# checked-in asset internal/adapters/wgsrpd/level3.geojson, which
+# //go:embed compiles into the binary: hostus never fetches geometries at
+# runtime. Rebuild hostus after running this.
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+REPO_ROOT="$(cd "${SCRIPT_DIR}/../.." && pwd)"
+
+WGSRPD_REF="${WGSRPD_REF:?set WGSRPD_REF to the tdwg/wgsrpd commit SHA to pin}"
+SOURCE_URL="https://raw.githubusercontent.com/tdwg/wgsrpd/${WGSRPD_REF}/geojson/level3.geojson"
+SOURCE_FILE="level3-${WGSRPD_REF}.geojson"
+
+CACHE_DIR="${SCRIPT_DIR}/.cache"
+mkdir -p "${CACHE_DIR}"
+
+SRC_PATH="${CACHE_DIR}/${SOURCE_FILE}"
+OUT_PATH="${REPO_ROOT}/internal/adapters/wgsrpd/level3.geojson"
+SUMMARY_PATH="${SCRIPT_DIR}/wgsrpd.summary.txt"
+
+if [[ -f "${SRC_PATH}" ]]; then
+  echo "WGSRPD: using cached ${SRC_PATH}"
+else
+  echo "WGSRPD: downloading ${SOURCE_URL}"
+  curl -sSfL -A "hostus-pipeline-wgsrpd/0.1 (research)" "${SOURCE_URL}" -o "${SRC_PATH}"
+fi
+
+{
+  echo "source=${SOURCE_URL}"
+  echo "sha256=$(sha256sum "${SRC_PATH}" | cut -d' ' -f1)"
+  python3 "${SCRIPT_DIR}/convert.py" "${SRC_PATH}" "${OUT_PATH}"
+} | tee "${SUMMARY_PATH}"
+
+echo "WGSRPD: embedded asset written to ${OUT_PATH}"
+echo "WGSRPD: summary written to ${SUMMARY_PATH}"
@@ -0,0 +1,119 @@
+#!/usr/bin/env python3
+"""TDWG WGSRPD level3.geojson -> simplified GeoJSON for internal/adapters/wgsrpd.
+
+The source carries one feature per level-3 area with the properties
+LEVEL3_COD ("GER"), LEVEL3_NAM ("Germany"), LEVEL2_COD (11) and LEVEL1_COD
+(1) — the level-2/level-1 codes are numbers. Geometries are Polygon or
+MultiPolygon at full coastline resolution, several MB in total; hostus only
+needs to place a phone's position in a botanical country, so each ring is
+simplified with Douglas-Peucker at TOLERANCE degrees (about 1 km) and the
+coordinates are rounded to DIGITS decimals.
+
+TOLERANCE must stay equal to SnapDegrees in internal/adapters/wgsrpd: the
+resolver snaps positions up to that far outside a simplified coastline back
+onto land, which is exactly the error simplification introduced.
+
+A ring that would collapse below 4 vertices (a small island) keeps its
+original vertices instead of vanishing — an island dropped here is an area
+no position on it can ever resolve to.
+
+Canonical mapping (feature properties, all strings):
+  code   = LEVEL3_COD
+  name   = LEVEL3_NAM
+  level2 = str(LEVEL2_COD)
+  level1 = str(LEVEL1_COD)
+"""
+import json
+import sys
+
+TOLERANCE = 0.01
+DIGITS = 4
+
+
+def perpendicular(p, a, b):
+    """Distance from p to segment a-b, in degrees."""
+    (px, py), (ax, ay), (bx, by) = p, a, b
+    dx, dy = bx - ax, by - ay
+    if dx == 0 and dy == 0:
+        return ((px - ax) ** 2 + (py - ay) ** 2) ** 0.5
+    t = max(0.0, min(1.0, ((px - ax) * dx + (py - ay) * dy) / (dx * dx + dy * dy)))
+    return ((px - (ax + t * dx)) ** 2 + (py - (ay + t * dy)) ** 2) ** 0.5
+
+
+def douglas_peucker(points):
+    """Iterative Douglas-Peucker; keeps both endpoints."""
+    keep = [False] * len(points)
+    keep[0] = keep[-1] = True
+    stack = [(0, len(points) - 1)]
+    while stack:
+        lo, hi = stack.pop()
+        best, idx = 0.0, -1
+        for i in range(lo + 1, hi):
+            d = perpendicular(points[i], points[lo], points[hi])
+            if d > best:
+                best, idx = d, i
+        if idx >= 0 and best > TOLERANCE:
+            keep[idx] = True
+            stack.append((lo, idx))
+            stack.append((idx, hi))
+    return [p for p, k in zip(points, keep) if k]
+
+
+def simplify_ring(ring):
+    out = douglas_peucker(ring)
+    if len(out) < 4:  # closed ring: 3 distinct vertices + the closing one
+        out = ring
+    return [[round(x, DIGITS), round(y, DIGITS)] for x, y in out]
+
+
+def simplify(geometry):
+    typ, coords = geometry["type"], geometry["coordinates"]
+    if typ == "Polygon":
+        polys = [coords]
+    elif typ == "MultiPolygon":
+        polys = coords
+    else:
+        raise SystemExit(f"unsupported geometry type {typ!r}")
+    out = [[simplify_ring(r) for r in poly] for poly in polys]
+    return {"type": "MultiPolygon", "coordinates": out}
+
+
+def main():
+    in_path, out_path = sys.argv[1:3]
+    with open(in_path, encoding="utf-8") as f:
+        src = json.load(f)
+
+    features = []
+    vertices_in = vertices_out = 0
+    level1, level2 = set(), set()
+    for feat in src["features"]:
+        p = feat["properties"]
+        geom = feat.get("geometry")
+        if not geom:
+            print(f"skipped {p.get('LEVEL3_COD')}: no geometry")
+            continue
+        simplified = simplify(geom)
+        for poly in (geom["coordinates"] if geom["type"] == "MultiPolygon" else [geom["coordinates"]]):
+            vertices_in += sum(len(r) for r in poly)
+        for poly in simplified["coordinates"]:
+            vertices_out += sum(len(r) for r in poly)
+        l2, l1 = str(p["LEVEL2_COD"]), str(p["LEVEL1_COD"])
+        level1.add(l1)
+        level2.add(l2)
+        features.append({
+            "type": "Feature",
+            "properties": {"code": p["LEVEL3_COD"], "name": p["LEVEL3_NAM"], "level2": l2, "level1": l1},
+            "geometry": simplified,
+        })
+
+    features.sort(key=lambda f: f["properties"]["code"])
+    with open(out_path, "w", encoding="utf-8") as f:
+        json.dump({"type": "FeatureCollection", "features": features}, f, separators=(",", ":"))
+        f.write("\n")
+
+    print(f"areas={len(features)} level2={len(level2)} level1={len(level1)}")
+    print(f"vertices: {vertices_in} -> {vertices_out} (tolerance={TOLERANCE} digits={DIGITS})")
+
+
+if __name__ == "__main__":
+    main()